@@ -3,10 +3,12 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/spf13/cobra"
 
@@ -19,6 +21,8 @@ var (
 	listOutdated  bool
 	listCurrent   bool
 	listJSON      bool
+	infoCurrent   bool
+	infoJSON      bool
 )
 
 // agentsCmd represents the agents command
@@ -29,6 +33,7 @@ var agentsCmd = &cobra.Command{
 
 Examples:
   agentpipe agents list              # List all supported agents
+  agentpipe agents info claude       # Show details for one agent
   agentpipe agents install claude    # Install Claude CLI
   agentpipe agents install --all     # Install all agents`,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -50,6 +55,21 @@ Examples:
 	Run: runAgentsList,
 }
 
+// agentsInfoCmd shows the details of a single agent
+var agentsInfoCmd = &cobra.Command{
+	Use:   "info <name>",
+	Short: "Show details for one AI agent CLI",
+	Long: `Show a single agent's registry definition: description, command, install and
+upgrade commands, docs, package manager, and installed version.
+
+Examples:
+  agentpipe agents info claude            # Show Claude CLI details
+  agentpipe agents info claude --current  # Also check the latest version
+  agentpipe agents info claude --json     # Output in JSON format`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAgentsInfo,
+}
+
 // agentsInstallCmd installs one or more agents
 var agentsInstallCmd = &cobra.Command{
 	Use:   "install [agent...]",
@@ -79,6 +99,7 @@ Examples:
 func init() {
 	rootCmd.AddCommand(agentsCmd)
 	agentsCmd.AddCommand(agentsListCmd)
+	agentsCmd.AddCommand(agentsInfoCmd)
 	agentsCmd.AddCommand(agentsInstallCmd)
 	agentsCmd.AddCommand(agentsUpgradeCmd)
 
@@ -86,6 +107,8 @@ func init() {
 	agentsListCmd.Flags().BoolVar(&listOutdated, "outdated", false, "List outdated agents with version comparison table")
 	agentsListCmd.Flags().BoolVar(&listCurrent, "current", false, "Check and display latest versions from the web")
 	agentsListCmd.Flags().BoolVar(&listJSON, "json", false, "Output in JSON format")
+	agentsInfoCmd.Flags().BoolVar(&infoCurrent, "current", false, "Check and display the latest version from the web")
+	agentsInfoCmd.Flags().BoolVar(&infoJSON, "json", false, "Output in JSON format")
 	agentsInstallCmd.Flags().BoolVar(&installAll, "all", false, "Install all agents")
 	agentsUpgradeCmd.Flags().BoolVar(&installAll, "all", false, "Upgrade all agents")
 }
@@ -104,6 +127,154 @@ type AgentListJSON struct {
 	InstallCmd    string `json:"install_cmd,omitempty"`
 }
 
+// AgentInfoJSON represents a single agent's details in JSON output
+type AgentInfoJSON struct {
+	Name           string `json:"name"`
+	Command        string `json:"command"`
+	Description    string `json:"description"`
+	Docs           string `json:"docs"`
+	PackageManager string `json:"package_manager,omitempty"`
+	PackageName    string `json:"package_name,omitempty"`
+	InstallCmd     string `json:"install_cmd,omitempty"`
+	UpgradeCmd     string `json:"upgrade_cmd,omitempty"`
+	RequiresAuth   bool   `json:"requires_auth"`
+	Installed      bool   `json:"installed"`
+	Path           string `json:"path,omitempty"`
+	Version        string `json:"version,omitempty"`
+	LatestVersion  string `json:"latest_version,omitempty"`
+	LatestError    string `json:"latest_error,omitempty"`
+	HasUpdate      bool   `json:"has_update,omitempty"`
+	UpdateStatus   string `json:"update_status,omitempty"`
+}
+
+// Update statuses reported in AgentInfoJSON.UpdateStatus.
+const (
+	updateAvailable = "update_available"
+	upToDate        = "up_to_date"
+	updateUnknown   = "unknown"
+)
+
+// compareVersions compares an installed and a latest version. Tests replace
+// it to exercise versions that cannot be compared.
+var compareVersions = registry.CompareVersions
+
+// updateStatus reports whether latest is newer than the installed version,
+// or updateUnknown when the two cannot be compared.
+func updateStatus(installed, latest string) string {
+	cmp, err := compareVersions(installed, latest)
+	switch {
+	case err != nil:
+		return updateUnknown
+	case cmp < 0:
+		return updateAvailable
+	default:
+		return upToDate
+	}
+}
+
+func runAgentsInfo(cmd *cobra.Command, args []string) error {
+	def, err := registry.GetByName(args[0])
+	if err != nil {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%w - run 'agentpipe agents list' to see available agents", err)
+	}
+	return writeAgentInfo(os.Stdout, buildAgentInfo(def, infoCurrent), infoJSON)
+}
+
+// buildAgentInfo collects an agent's registry definition and installation
+// status. With current, the latest version is looked up from its package
+// manager.
+func buildAgentInfo(def *registry.AgentDefinition, current bool) AgentInfoJSON {
+	info := AgentInfoJSON{
+		Name:           def.Name,
+		Command:        def.Command,
+		Description:    def.Description,
+		Docs:           def.Docs,
+		PackageManager: def.PackageManager,
+		PackageName:    def.PackageName,
+		RequiresAuth:   def.RequiresAuth,
+		Installed:      isAgentInstalled(def.Command),
+	}
+	info.InstallCmd, _ = def.GetInstallCommand()
+	info.UpgradeCmd, _ = def.GetUpgradeCommand()
+
+	if info.Installed {
+		if path, err := exec.LookPath(def.Command); err == nil {
+			info.Path = path
+		}
+		info.Version = registry.GetInstalledVersion(def.Command)
+	}
+
+	if current && def.PackageManager != "" {
+		latest, err := fetchLatestVersion(def)
+		if err != nil {
+			info.LatestError = err.Error()
+		} else {
+			info.LatestVersion = latest
+			if info.Version != "" {
+				info.UpdateStatus = updateStatus(info.Version, latest)
+				info.HasUpdate = info.UpdateStatus == updateAvailable
+			}
+		}
+	}
+
+	return info
+}
+
+// writeAgentInfo renders an agent's details as text or JSON.
+func writeAgentInfo(w io.Writer, info AgentInfoJSON, asJSON bool) error {
+	if asJSON {
+		output, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal agent info to JSON: %w", err)
+		}
+		fmt.Fprintln(w, string(output))
+		return nil
+	}
+
+	statusIcon := "✅"
+	if !info.Installed {
+		statusIcon = "❌"
+	}
+	fmt.Fprintf(w, "\n%s %s (%s)\n", statusIcon, info.Name, info.Command)
+	fmt.Fprintln(w, strings.Repeat("=", 70))
+	fmt.Fprintf(w, "   %s\n\n", info.Description)
+
+	field := func(label, value string) {
+		if value != "" {
+			fmt.Fprintf(w, "   %-16s %s\n", label+":", value)
+		}
+	}
+	if info.Installed {
+		field("Installed", info.Path)
+		field("Version", info.Version)
+	} else {
+		field("Installed", "no")
+	}
+	switch {
+	case info.LatestVersion != "" && info.UpdateStatus == updateAvailable:
+		field("Latest", info.LatestVersion+" ⚠️  (update available)")
+	case info.LatestVersion != "" && info.UpdateStatus == upToDate:
+		field("Latest", info.LatestVersion+" ✅ (up to date)")
+	case info.LatestVersion != "" && info.UpdateStatus == updateUnknown:
+		field("Latest", info.LatestVersion+" (update status unknown)")
+	case info.LatestVersion != "":
+		field("Latest", info.LatestVersion)
+	case info.LatestError != "":
+		field("Latest", "(unable to fetch: "+info.LatestError+")")
+	}
+	field("Package manager", info.PackageManager)
+	field("Package", info.PackageName)
+	field("Install", info.InstallCmd)
+	field("Upgrade", info.UpgradeCmd)
+	if info.RequiresAuth {
+		field("Authentication", "required")
+	}
+	field("Docs", info.Docs)
+	fmt.Fprintln(w)
+	return nil
+}
+
 func runAgentsList(cmd *cobra.Command, args []string) {
 	agents := registry.GetAll()
 
@@ -158,6 +329,12 @@ func runAgentsList(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	// Fetch latest versions up front, concurrently, if --current is set
+	var latestVersions []latestVersionResult
+	if showVersionInfo {
+		latestVersions = fetchLatestVersions(filteredAgents)
+	}
+
 	for i, agent := range filteredAgents {
 		// Add spacing between agents
 		if i > 0 {
@@ -188,15 +365,17 @@ func runAgentsList(cmd *cobra.Command, args []string) {
 
 			// Check for updates if --current is set
 			if showVersionInfo && agent.PackageManager != "" {
-				latest, err := agent.GetLatestVersion()
+				latest, err := latestVersions[i].version, latestVersions[i].err
 				if err == nil {
 					fmt.Printf("   Latest:  %s", latest)
 					if version != "" {
-						cmp, _ := registry.CompareVersions(version, latest)
-						if cmp < 0 {
+						switch updateStatus(version, latest) {
+						case updateAvailable:
 							fmt.Printf(" ⚠️  (update available)")
-						} else if cmp == 0 {
+						case upToDate:
 							fmt.Printf(" ✅ (up to date)")
+						default:
+							fmt.Printf(" (update status unknown)")
 						}
 					}
 					fmt.Println()
@@ -217,7 +396,7 @@ func runAgentsList(cmd *cobra.Command, args []string) {
 
 			// Show latest version if --current is set and agent has package manager
 			if showVersionInfo && agent.PackageManager != "" {
-				latest, err := agent.GetLatestVersion()
+				latest, err := latestVersions[i].version, latestVersions[i].err
 				if err == nil {
 					fmt.Printf("   Latest:  %s\n", latest)
 				}
@@ -240,84 +419,110 @@ type agentVersionRow struct {
 	canCheck  bool
 }
 
-// showOutdatedTable displays a table of agents with version comparison
-func showOutdatedTable(agents []*registry.AgentDefinition) {
-
-	// Fetch version info in parallel
-	type versionResult struct {
-		index   int
-		current string
-		latest  string
-		err     error
-	}
+// latestVersionWorkers bounds how many version lookups run at once.
+const latestVersionWorkers = 4
 
-	resultChan := make(chan versionResult, len(agents))
-	rows := make([]agentVersionRow, len(agents))
-	outdatedCount := 0
+// fetchLatestVersion looks up an agent's latest version. Tests replace it to
+// avoid network calls.
+var fetchLatestVersion = func(a *registry.AgentDefinition) (string, error) {
+	return a.GetLatestVersion()
+}
 
-	// Launch parallel version checks
-	for i, agent := range agents {
-		go func(index int, ag *registry.AgentDefinition) {
-			result := versionResult{index: index}
-
-			// Check if installed and get current version
-			installed := isAgentInstalled(ag.Command)
-			if installed {
-				result.current = registry.GetInstalledVersion(ag.Command)
-				if result.current == "" {
-					result.current = "unknown"
-				}
-			} else {
-				result.current = "not installed"
-			}
+// latestVersionResult is the outcome of one agent's latest-version lookup.
+type latestVersionResult struct {
+	version string
+	err     error
+}
 
-			// Fetch latest version if package manager is configured
-			if ag.PackageManager != "" {
-				latest, err := ag.GetLatestVersion()
-				if err == nil {
-					result.latest = latest
-				} else {
-					result.err = err
-				}
-			} else {
-				result.latest = "manual install"
+// forEachParallel calls fn for each index in [0, n) on a pool of at most
+// latestVersionWorkers goroutines and waits for all of them to finish.
+func forEachParallel(n int, fn func(i int)) {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(latestVersionWorkers, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
 			}
-
-			resultChan <- result
-		}(i, agent)
+		}()
 	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
 
-	// Collect results
-	for i := 0; i < len(agents); i++ {
-		result := <-resultChan
-		agent := agents[result.index]
-		installed := result.current != "not installed"
-
-		r := agentVersionRow{
-			name:      agent.Name,
-			installed: installed,
-			current:   result.current,
-			canCheck:  agent.PackageManager != "",
+// fetchLatestVersions looks up the latest version of each agent concurrently.
+// Results are in the same order as agents, and each holds that agent's own
+// error, so one failed lookup doesn't affect the others. Agents without a
+// package manager get an empty result.
+func fetchLatestVersions(agents []*registry.AgentDefinition) []latestVersionResult {
+	results := make([]latestVersionResult, len(agents))
+	forEachParallel(len(agents), func(i int) {
+		if agents[i].PackageManager != "" {
+			results[i].version, results[i].err = fetchLatestVersion(agents[i])
 		}
+	})
+	return results
+}
 
-		if result.err != nil {
-			r.latest = fmt.Sprintf("(error: %v)", result.err)
+// buildVersionRows checks the installed and latest version of each agent
+// concurrently and returns one row per agent, in the same order as agents,
+// along with the number of agents that have an update available.
+func buildVersionRows(agents []*registry.AgentDefinition) ([]agentVersionRow, int) {
+	rows := make([]agentVersionRow, len(agents))
+	forEachParallel(len(agents), func(i int) {
+		ag := agents[i]
+		r := agentVersionRow{name: ag.Name, canCheck: ag.PackageManager != ""}
+
+		// Check if installed and get current version
+		r.installed = isAgentInstalled(ag.Command)
+		if r.installed {
+			r.current = registry.GetInstalledVersion(ag.Command)
+			if r.current == "" {
+				r.current = "unknown"
+			}
 		} else {
-			r.latest = result.latest
+			r.current = "not installed"
 		}
 
+		// Fetch latest version if package manager is configured
+		if !r.canCheck {
+			r.latest = "manual install"
+			rows[i] = r
+			return
+		}
+		latest, err := fetchLatestVersion(ag)
+		if err != nil {
+			r.latest = fmt.Sprintf("(error: %v)", err)
+			rows[i] = r
+			return
+		}
+		r.latest = latest
+
 		// Check for updates
-		if installed && r.current != "unknown" && result.latest != "" && result.latest != "manual install" && result.err == nil {
-			cmp, err := registry.CompareVersions(r.current, result.latest)
-			if err == nil && cmp < 0 {
-				r.hasUpdate = true
-				outdatedCount++
-			}
+		if r.installed && r.current != "unknown" && latest != "" {
+			cmp, err := registry.CompareVersions(r.current, latest)
+			r.hasUpdate = err == nil && cmp < 0
 		}
+		rows[i] = r
+	})
 
-		rows[result.index] = r
+	outdatedCount := 0
+	for _, r := range rows {
+		if r.hasUpdate {
+			outdatedCount++
+		}
 	}
-	close(resultChan)
+	return rows, outdatedCount
+}
+
+// showOutdatedTable displays a table of agents with version comparison
+func showOutdatedTable(agents []*registry.AgentDefinition) {
+	rows, outdatedCount := buildVersionRows(agents)
 
 	// Output JSON format if requested
 	if listJSON {
@@ -556,9 +761,33 @@ func runAgentsUpgrade(cmd *cobra.Command, args []string) {
 
 // outputAgentsJSON outputs agent list in JSON format
 func outputAgentsJSON(agents []*registry.AgentDefinition, showVersionInfo bool) {
+	// Wrap array in object for consistent API structure
+	wrapper := struct {
+		Agents []AgentListJSON `json:"agents"`
+	}{
+		Agents: buildAgentsListJSON(agents, showVersionInfo),
+	}
+
+	output, err := json.MarshalIndent(wrapper, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating JSON output: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println(string(output))
+}
+
+// buildAgentsListJSON returns the JSON entry of each agent, in the same order
+// as agents. With showVersionInfo, latest versions are fetched concurrently.
+func buildAgentsListJSON(agents []*registry.AgentDefinition, showVersionInfo bool) []AgentListJSON {
 	jsonAgents := make([]AgentListJSON, 0, len(agents))
 
-	for _, agent := range agents {
+	var latestVersions []latestVersionResult
+	if showVersionInfo {
+		latestVersions = fetchLatestVersions(agents)
+	}
+
+	for i, agent := range agents {
 		installed := isAgentInstalled(agent.Command)
 
 		agentJSON := AgentListJSON{
@@ -581,7 +810,7 @@ func outputAgentsJSON(agents []*registry.AgentDefinition, showVersionInfo bool)
 
 			// Check for updates if showVersionInfo is true
 			if showVersionInfo && agent.PackageManager != "" {
-				latest, err := agent.GetLatestVersion()
+				latest, err := latestVersions[i].version, latestVersions[i].err
 				if err == nil {
 					agentJSON.LatestVersion = latest
 					if version != "" {
@@ -603,7 +832,7 @@ func outputAgentsJSON(agents []*registry.AgentDefinition, showVersionInfo bool)
 
 			// Show latest version if showVersionInfo is set
 			if showVersionInfo && agent.PackageManager != "" {
-				latest, err := agent.GetLatestVersion()
+				latest, err := latestVersions[i].version, latestVersions[i].err
 				if err == nil {
 					agentJSON.LatestVersion = latest
 				}
@@ -613,20 +842,7 @@ func outputAgentsJSON(agents []*registry.AgentDefinition, showVersionInfo bool)
 		jsonAgents = append(jsonAgents, agentJSON)
 	}
 
-	// Wrap array in object for consistent API structure
-	wrapper := struct {
-		Agents []AgentListJSON `json:"agents"`
-	}{
-		Agents: jsonAgents,
-	}
-
-	output, err := json.MarshalIndent(wrapper, "", "  ")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error generating JSON output: %v\n", err)
-		os.Exit(1)
-	}
-
-	fmt.Println(string(output))
+	return jsonAgents
 }
 
 // outputOutdatedJSON outputs agent version information in JSON format
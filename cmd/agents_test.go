@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/internal/registry"
+)
+
+func TestAgentInfo(t *testing.T) {
+	def, err := registry.GetByName("CLAUDE")
+	if err != nil {
+		t.Fatalf("expected Claude in the registry: %v", err)
+	}
+
+	info := buildAgentInfo(def, false)
+	if info.Name != def.Name || info.Command != def.Command || info.LatestVersion != "" {
+		t.Errorf("unexpected info: %+v", info)
+	}
+
+	var buf bytes.Buffer
+	if err := writeAgentInfo(&buf, info, false); err != nil {
+		t.Fatalf("writeAgentInfo failed: %v", err)
+	}
+	for _, want := range []string{def.Name + " (" + def.Command + ")", def.Description, "Docs:", def.Docs} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, buf.String())
+		}
+	}
+	if info.InstallCmd != "" && !strings.Contains(buf.String(), info.InstallCmd) {
+		t.Errorf("expected the install command in the output, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := writeAgentInfo(&buf, info, true); err != nil {
+		t.Fatalf("writeAgentInfo failed: %v", err)
+	}
+	var decoded AgentInfoJSON
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, buf.String())
+	}
+	if decoded != info {
+		t.Errorf("JSON output = %+v, want %+v", decoded, info)
+	}
+}
+
+func TestAgentInfoUpdateStatus(t *testing.T) {
+	if got := updateStatus("1.2.0", "1.3.0"); got != updateAvailable {
+		t.Errorf("updateStatus(1.2.0, 1.3.0) = %q, want %q", got, updateAvailable)
+	}
+	if got := updateStatus("1.3.0", "1.3.0"); got != upToDate {
+		t.Errorf("updateStatus(1.3.0, 1.3.0) = %q, want %q", got, upToDate)
+	}
+
+	orig := compareVersions
+	compareVersions = func(v1, v2 string) (int, error) {
+		return 0, errors.New("unparseable version")
+	}
+	t.Cleanup(func() { compareVersions = orig })
+
+	status := updateStatus("nightly", "1.3.0")
+	if status != updateUnknown {
+		t.Fatalf("expected %q when versions can't be compared, got %q", updateUnknown, status)
+	}
+
+	info := AgentInfoJSON{Name: "Stub", Command: "stub", Installed: true, Version: "nightly", LatestVersion: "1.3.0", UpdateStatus: status}
+	var buf bytes.Buffer
+	if err := writeAgentInfo(&buf, info, false); err != nil {
+		t.Fatalf("writeAgentInfo failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "1.3.0 (update status unknown)") || strings.Contains(buf.String(), "up to date") {
+		t.Errorf("expected an unknown update status, got:\n%s", buf.String())
+	}
+}
+
+func TestAgentInfoUnknownAgent(t *testing.T) {
+	err := runAgentsInfo(agentsInfoCmd, []string{"no-such-agent"})
+	if err == nil || !strings.Contains(err.Error(), "no-such-agent") || !strings.Contains(err.Error(), "agents list") {
+		t.Errorf("expected a not-found error pointing to agents list, got %v", err)
+	}
+}
+
+// fakeVersionAgents returns n agents that aren't installed, with a fake
+// latest-version lookup that fails for "agent-3", takes longer for earlier
+// agents so lookups finish out of order, and records the peak number of
+// concurrent lookups.
+func fakeVersionAgents(t *testing.T, n int) ([]*registry.AgentDefinition, *int32) {
+	t.Helper()
+
+	agents := make([]*registry.AgentDefinition, n)
+	for i := range agents {
+		agents[i] = &registry.AgentDefinition{
+			Name:           fmt.Sprintf("agent-%d", i),
+			Command:        fmt.Sprintf("agentpipe-no-such-command-%d", i),
+			PackageManager: "npm",
+		}
+	}
+	agents[n-1].PackageManager = "" // manual install
+
+	var running, peak int32
+	orig := fetchLatestVersion
+	fetchLatestVersion = func(a *registry.AgentDefinition) (string, error) {
+		now := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if now <= p || atomic.CompareAndSwapInt32(&peak, p, now) {
+				break
+			}
+		}
+
+		var i int
+		fmt.Sscanf(a.Name, "agent-%d", &i)
+		time.Sleep(time.Duration(n-i) * time.Millisecond)
+		if a.Name == "agent-3" {
+			return "", errors.New("registry unavailable")
+		}
+		return fmt.Sprintf("1.0.%d", i), nil
+	}
+	t.Cleanup(func() { fetchLatestVersion = orig })
+
+	return agents, &peak
+}
+
+func TestFetchLatestVersions(t *testing.T) {
+	agents, peak := fakeVersionAgents(t, 10)
+
+	results := fetchLatestVersions(agents)
+	if len(results) != len(agents) {
+		t.Fatalf("expected %d results, got %d", len(agents), len(results))
+	}
+	for i, r := range results {
+		switch {
+		case i == 3:
+			if r.err == nil || r.version != "" {
+				t.Errorf("expected agent-3's lookup to fail, got %+v", r)
+			}
+		case i == len(agents)-1:
+			if r.err != nil || r.version != "" {
+				t.Errorf("expected no lookup for an agent without a package manager, got %+v", r)
+			}
+		default:
+			if want := fmt.Sprintf("1.0.%d", i); r.err != nil || r.version != want {
+				t.Errorf("result %d = %+v, want version %s", i, r, want)
+			}
+		}
+	}
+	if *peak > latestVersionWorkers {
+		t.Errorf("expected at most %d concurrent lookups, got %d", latestVersionWorkers, *peak)
+	}
+}
+
+func TestBuildVersionRowsOrderIsStable(t *testing.T) {
+	agents, _ := fakeVersionAgents(t, 10)
+
+	for run := 0; run < 3; run++ {
+		rows, outdated := buildVersionRows(agents)
+		if outdated != 0 {
+			t.Errorf("expected no updates for agents that aren't installed, got %d", outdated)
+		}
+		for i, r := range rows {
+			if r.name != agents[i].Name || r.current != "not installed" {
+				t.Errorf("run %d: row %d = %+v, want %s not installed", run, i, r, agents[i].Name)
+			}
+			var want string
+			switch {
+			case i == 3:
+				want = "(error: registry unavailable)"
+			case i == len(agents)-1:
+				want = "manual install"
+			default:
+				want = fmt.Sprintf("1.0.%d", i)
+			}
+			if r.latest != want {
+				t.Errorf("run %d: row %d latest = %q, want %q", run, i, r.latest, want)
+			}
+		}
+	}
+}
+
+func TestBuildAgentsListJSONWithLatestVersions(t *testing.T) {
+	agents, _ := fakeVersionAgents(t, 6)
+
+	entries := buildAgentsListJSON(agents, true)
+	if len(entries) != len(agents) {
+		t.Fatalf("expected %d entries, got %d", len(agents), len(entries))
+	}
+	for i, e := range entries {
+		want := fmt.Sprintf("1.0.%d", i)
+		if i == 3 || i == len(agents)-1 {
+			want = ""
+		}
+		if e.Name != agents[i].Name || e.LatestVersion != want {
+			t.Errorf("entry %d = %s latest %q, want %s latest %q", i, e.Name, e.LatestVersion, agents[i].Name, want)
+		}
+	}
+}
@@ -260,6 +260,10 @@ func runBridgeStatus() {
 	fmt.Printf("Timeout:        %dms\n", config.TimeoutMs)
 	fmt.Printf("Retry Attempts: %d\n", config.RetryAttempts)
 	fmt.Printf("Log Level:      %s\n", config.LogLevel)
+	fmt.Printf("Sink:           %s\n", config.Sink)
+	if config.Sink == bridge.SinkFile {
+		fmt.Printf("File:           %s\n", config.File)
+	}
 	fmt.Println()
 
 	// Show configuration source
@@ -380,6 +384,8 @@ type BridgeStatusJSON struct {
 	TimeoutMs     int    `json:"timeout_ms"`
 	RetryAttempts int    `json:"retry_attempts"`
 	LogLevel      string `json:"log_level"`
+	Sink          string `json:"sink"`
+	File          string `json:"file,omitempty"`
 	ConfigFile    string `json:"config_file,omitempty"`
 }
 
@@ -391,6 +397,8 @@ func outputStatusJSON(config *bridge.Config) {
 		TimeoutMs:     config.TimeoutMs,
 		RetryAttempts: config.RetryAttempts,
 		LogLevel:      config.LogLevel,
+		Sink:          config.Sink,
+		File:          config.File,
 		ConfigFile:    viper.ConfigFileUsed(),
 	}
 
@@ -0,0 +1,342 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/config"
+	"github.com/kevinelliott/agentpipe/pkg/log"
+	"github.com/kevinelliott/agentpipe/pkg/orchestrator"
+)
+
+var (
+	compareGroupA   []string
+	compareGroupB   []string
+	comparePrompt   string
+	compareMode     string
+	compareMaxTurns int
+	compareWidth    int
+	compareJSON     bool
+)
+
+var compareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "Run the same prompt against two groups of agents and compare them",
+	Long: `Run the same initial prompt against two independent groups of agents and
+print the transcripts side by side, followed by message, token, cost, and
+duration totals for each group.
+
+Agents use the same format as 'agentpipe run --agents' (type, type:name, or
+type:model:name). Both groups run at the same time.
+
+Examples:
+  agentpipe compare --group-a claude:Claude --group-b gemini:Gemini -p "Design a URL shortener"
+  agentpipe compare --group-a claude,gemini --group-b codex,qwen -p "Debate tabs vs spaces" --max-turns 2
+  agentpipe compare --group-a claude --group-b gemini -p "Explain monads" --json`,
+	RunE: runCompare,
+}
+
+func init() {
+	rootCmd.AddCommand(compareCmd)
+
+	compareCmd.Flags().StringSliceVar(&compareGroupA, "group-a", nil, "Agents in the first group")
+	compareCmd.Flags().StringSliceVar(&compareGroupB, "group-b", nil, "Agents in the second group")
+	compareCmd.Flags().StringVarP(&comparePrompt, "prompt", "p", "", "Initial prompt sent to both groups")
+	compareCmd.Flags().StringVarP(&compareMode, "mode", "m", "round-robin", "Conversation mode for both groups")
+	compareCmd.Flags().IntVar(&compareMaxTurns, "max-turns", 3, "Maximum turns per group")
+	compareCmd.Flags().IntVar(&compareWidth, "width", 60, "Column width of the side-by-side transcript")
+	compareCmd.Flags().BoolVar(&compareJSON, "json", false, "Output results as JSON")
+	compareCmd.Flags().Bool("skip-health-check", false, "Skip agent health checks")
+	_ = compareCmd.MarkFlagRequired("group-a")
+	_ = compareCmd.MarkFlagRequired("group-b")
+	_ = compareCmd.MarkFlagRequired("prompt")
+}
+
+// comparisonGroup is one lineup of agents in a comparison.
+type comparisonGroup struct {
+	Label  string
+	Config *config.Config
+}
+
+// groupResult is the outcome of running one comparison group.
+type groupResult struct {
+	Label           string          `json:"label"`
+	Agents          []string        `json:"agents"`
+	Messages        []agent.Message `json:"messages"`
+	AgentMessages   int             `json:"agent_messages"`
+	TotalTokens     int             `json:"total_tokens"`
+	TotalCost       float64         `json:"total_cost"`
+	DurationSeconds float64         `json:"duration_seconds"`
+	Error           string          `json:"error,omitempty"`
+}
+
+func runCompare(cmd *cobra.Command, args []string) error {
+	prompt := strings.TrimSpace(comparePrompt)
+	if prompt == "" {
+		return fmt.Errorf("--prompt is required")
+	}
+
+	groups := make([]comparisonGroup, 0, 2)
+	for _, g := range []struct {
+		label string
+		specs []string
+	}{{"A", compareGroupA}, {"B", compareGroupB}} {
+		cfg, err := buildGroupConfig(g.specs, prompt)
+		if err != nil {
+			return fmt.Errorf("group %s: %w", g.label, err)
+		}
+		groups = append(groups, comparisonGroup{Label: g.label, Config: cfg})
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var progress io.Writer = os.Stderr
+	if compareJSON {
+		progress = io.Discard
+	}
+
+	results, err := runComparison(ctx, cmd, groups, progress)
+	if err != nil {
+		return err
+	}
+
+	if compareJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	}
+
+	writeComparison(os.Stdout, results, compareWidth)
+	return nil
+}
+
+// buildGroupConfig creates the config for one group from agent specs.
+func buildGroupConfig(specs []string, prompt string) (*config.Config, error) {
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("at least one agent is required")
+	}
+
+	cfg := config.NewDefaultConfig()
+	for i, spec := range specs {
+		agentCfg, err := parseAgentSpec(spec, i)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Agents = append(cfg.Agents, agentCfg)
+	}
+	cfg.Orchestrator.Mode = compareMode
+	cfg.Orchestrator.MaxTurns = compareMaxTurns
+	cfg.Orchestrator.InitialPrompt = prompt
+	cfg.Orchestrator.Summary.Enabled = false
+	cfg.Logging.Enabled = false
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// runComparison initializes every group's agents, failing fast if any group
+// cannot start, then runs the groups concurrently with separate orchestrators.
+func runComparison(ctx context.Context, cmd *cobra.Command, groups []comparisonGroup, progress io.Writer) ([]groupResult, error) {
+	agentsByGroup := make([][]agent.Agent, len(groups))
+	for i, g := range groups {
+		fmt.Fprintf(progress, "Group %s:\n", g.Label)
+		agentsList, err := initializeAgents(cmd, g.Config, progress)
+		if err != nil {
+			return nil, fmt.Errorf("group %s: %w", g.Label, err)
+		}
+		agentsByGroup[i] = agentsList
+	}
+
+	fmt.Fprintf(progress, "🚀 Running %d groups...\n", len(groups))
+
+	results := make([]groupResult, len(groups))
+	var wg sync.WaitGroup
+	for i, g := range groups {
+		wg.Add(1)
+		go func(i int, g comparisonGroup) {
+			defer wg.Done()
+			results[i] = runGroup(ctx, g, agentsByGroup[i])
+		}(i, g)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// runGroup runs one group's conversation and totals its results.
+func runGroup(ctx context.Context, g comparisonGroup, agentsList []agent.Agent) groupResult {
+	orch := orchestrator.NewOrchestrator(orchestrator.ConfigFrom(g.Config), nil)
+
+	result := groupResult{Label: g.Label}
+	for _, a := range agentsList {
+		orch.AddAgent(a)
+		result.Agents = append(result.Agents, a.GetName())
+	}
+
+	start := time.Now()
+	err := orch.Start(ctx)
+	result.DurationSeconds = time.Since(start).Seconds()
+	if err != nil {
+		log.WithError(err).WithField("group", g.Label).Warn("comparison group ended with error")
+		result.Error = err.Error()
+	}
+
+	result.Messages = orch.GetMessages()
+	stats := orch.GetStats()
+	result.AgentMessages = stats.AgentMessages
+	result.TotalTokens = stats.TotalTokens
+	result.TotalCost = stats.TotalCost
+
+	return result
+}
+
+// writeComparison prints the transcripts side by side, then the totals.
+func writeComparison(w io.Writer, results []groupResult, width int) {
+	if width < 20 {
+		width = 20
+	}
+
+	columns := make([][]string, len(results))
+	header := make([]string, len(results))
+	for i, r := range results {
+		header[i] = fmt.Sprintf("Group %s: %s", r.Label, strings.Join(r.Agents, ", "))
+		for _, msg := range r.Messages {
+			if msg.Role == agent.RoleAgent || msg.AgentID == "host" {
+				columns[i] = append(columns[i], wrapColumn(fmt.Sprintf("%s: %s", msg.AgentName, msg.Content), width)...)
+				columns[i] = append(columns[i], "")
+			}
+		}
+	}
+
+	writeRow := func(cells []string) {
+		for i, cell := range cells {
+			if i > 0 {
+				fmt.Fprint(w, " │ ")
+			}
+			if i < len(cells)-1 {
+				fmt.Fprint(w, padRight(cell, width))
+			} else {
+				fmt.Fprint(w, cell)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+
+	rule := strings.Repeat("─", width)
+	rules := make([]string, len(results))
+	for i := range rules {
+		rules[i] = rule
+	}
+
+	headerLines := make([][]string, len(results))
+	rows := 0
+	for i, h := range header {
+		headerLines[i] = wrapColumn(h, width)
+		if len(headerLines[i]) > rows {
+			rows = len(headerLines[i])
+		}
+	}
+	writeColumns(writeRow, headerLines, rows)
+	writeRow(rules)
+
+	rows = 0
+	for _, col := range columns {
+		if len(col) > rows {
+			rows = len(col)
+		}
+	}
+	writeColumns(writeRow, columns, rows)
+
+	fmt.Fprintln(w)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprint(tw, "METRIC")
+	for _, r := range results {
+		fmt.Fprintf(tw, "\tGROUP %s", r.Label)
+	}
+	fmt.Fprintln(tw)
+	writeMetric := func(name string, value func(groupResult) string) {
+		fmt.Fprint(tw, name)
+		for _, r := range results {
+			fmt.Fprintf(tw, "\t%s", value(r))
+		}
+		fmt.Fprintln(tw)
+	}
+	writeMetric("Agent messages", func(r groupResult) string { return fmt.Sprintf("%d", r.AgentMessages) })
+	writeMetric("Tokens", func(r groupResult) string { return fmt.Sprintf("%d", r.TotalTokens) })
+	writeMetric("Cost", func(r groupResult) string { return fmt.Sprintf("$%.4f", r.TotalCost) })
+	writeMetric("Duration", func(r groupResult) string { return fmt.Sprintf("%.1fs", r.DurationSeconds) })
+	writeMetric("Status", func(r groupResult) string {
+		if r.Error != "" {
+			return "error: " + r.Error
+		}
+		return "completed"
+	})
+	tw.Flush()
+}
+
+// writeColumns writes rows of cells taken from each column, padding short columns.
+func writeColumns(writeRow func([]string), columns [][]string, rows int) {
+	for row := 0; row < rows; row++ {
+		cells := make([]string, len(columns))
+		for i, col := range columns {
+			if row < len(col) {
+				cells[i] = col[row]
+			}
+		}
+		writeRow(cells)
+	}
+}
+
+// wrapColumn wraps text to lines of at most width runes, breaking at spaces
+// where possible and keeping existing line breaks.
+func wrapColumn(text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := []rune{}
+		for _, word := range strings.Fields(paragraph) {
+			runes := []rune(word)
+			for len(runes) > width {
+				if len(line) > 0 {
+					lines = append(lines, string(line))
+					line = line[:0]
+				}
+				lines = append(lines, string(runes[:width]))
+				runes = runes[width:]
+			}
+			switch {
+			case len(line) == 0:
+				line = append(line, runes...)
+			case len(line)+1+len(runes) <= width:
+				line = append(append(line, ' '), runes...)
+			default:
+				lines = append(lines, string(line))
+				line = append([]rune{}, runes...)
+			}
+		}
+		lines = append(lines, string(line))
+	}
+	return lines
+}
+
+// padRight pads s with spaces to width runes.
+func padRight(s string, width int) string {
+	if n := len([]rune(s)); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
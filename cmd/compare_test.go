@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/config"
+)
+
+// promptOf returns the host's initial prompt from the messages an agent received.
+func promptOf(messages []agent.Message) string {
+	for _, msg := range messages {
+		if msg.AgentID == "host" {
+			return msg.Content
+		}
+	}
+	return ""
+}
+
+func TestRunComparisonSendsSamePromptToBothGroups(t *testing.T) {
+	var created []*runTestAgent
+	agent.RegisterFactory("compare-test", func() agent.Agent {
+		a := &runTestAgent{response: "Use a hash map."}
+		created = append(created, a)
+		return a
+	})
+
+	const prompt = "Design a URL shortener"
+	var groups []comparisonGroup
+	for _, g := range []struct {
+		label string
+		names []string
+	}{{"A", []string{"Alice"}}, {"B", []string{"Bob", "Carol"}}} {
+		cfg := config.NewDefaultConfig()
+		for i, name := range g.names {
+			cfg.Agents = append(cfg.Agents, agent.AgentConfig{ID: fmt.Sprintf("%s-%d", g.label, i), Type: "compare-test", Name: name})
+		}
+		cfg.Logging.Enabled = false
+		cfg.Orchestrator.Summary.Enabled = false
+		cfg.Orchestrator.MaxTurns = 1
+		cfg.Orchestrator.ResponseDelay = time.Millisecond
+		cfg.Orchestrator.InitialPrompt = prompt
+		groups = append(groups, comparisonGroup{Label: g.label, Config: cfg})
+	}
+
+	results, err := runComparison(context.Background(), compareCmd, groups, io.Discard)
+	if err != nil {
+		t.Fatalf("runComparison failed: %v", err)
+	}
+
+	if len(created) != 3 {
+		t.Fatalf("expected 3 agents, got %d", len(created))
+	}
+	for _, a := range created {
+		if a.sent != 1 {
+			t.Errorf("agent %s: expected 1 turn, got %d", a.GetName(), a.sent)
+		}
+		if got := promptOf(a.received); got != prompt {
+			t.Errorf("agent %s received prompt %q, want %q", a.GetName(), got, prompt)
+		}
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for i, want := range []struct {
+		label    string
+		agents   string
+		messages int
+	}{{"A", "Alice", 1}, {"B", "Bob,Carol", 2}} {
+		r := results[i]
+		if r.Label != want.label || strings.Join(r.Agents, ",") != want.agents {
+			t.Errorf("result %d: unexpected group %s with %v", i, r.Label, r.Agents)
+		}
+		if r.AgentMessages != want.messages {
+			t.Errorf("group %s: expected %d agent messages, got %d", r.Label, want.messages, r.AgentMessages)
+		}
+		if r.Error != "" {
+			t.Errorf("group %s: unexpected error %q", r.Label, r.Error)
+		}
+	}
+
+	var out strings.Builder
+	writeComparison(&out, results, 40)
+	output := out.String()
+	for _, want := range []string{"Group A: Alice", "Group B: Bob, Carol", "Alice: Use a hash map.", "Carol: Use a hash map.", "Agent messages", "GROUP A", "GROUP B"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestBuildGroupConfig(t *testing.T) {
+	origMode, origMaxTurns := compareMode, compareMaxTurns
+	compareMode, compareMaxTurns = "reactive", 2
+	defer func() { compareMode, compareMaxTurns = origMode, origMaxTurns }()
+
+	cfg, err := buildGroupConfig([]string{"claude:Alice", "gemini:gemini-2.5-pro:Bob"}, "Hello")
+	if err != nil {
+		t.Fatalf("buildGroupConfig failed: %v", err)
+	}
+	if len(cfg.Agents) != 2 || cfg.Agents[1].Model != "gemini-2.5-pro" {
+		t.Errorf("unexpected agents: %+v", cfg.Agents)
+	}
+	if cfg.Orchestrator.Mode != "reactive" || cfg.Orchestrator.MaxTurns != 2 || cfg.Orchestrator.InitialPrompt != "Hello" {
+		t.Errorf("unexpected orchestrator config: %+v", cfg.Orchestrator)
+	}
+
+	if _, err := buildGroupConfig(nil, "Hello"); err == nil {
+		t.Error("expected an error for an empty group")
+	}
+}
+
+func TestWrapColumn(t *testing.T) {
+	got := wrapColumn("the quick brown fox\nabcdefghijkl", 10)
+	want := []string{"the quick", "brown fox", "abcdefghij", "kl"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("wrapColumn = %q, want %q", got, want)
+	}
+}
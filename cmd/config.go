@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/config"
+)
+
+var configLintJSON bool
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Work with agentpipe config files",
+}
+
+var configLintCmd = &cobra.Command{
+	Use:   "lint <file>",
+	Short: "Check a config file without running it",
+	Long: `Load a config file, validate it, and check that each of its agents can be
+created and is available on this machine, without starting a conversation.
+
+Unlike 'agentpipe doctor', which checks the whole system, lint checks only the
+agents the config uses. Exits with status 1 if any problem is found.
+
+Examples:
+  agentpipe config lint team.yaml
+  agentpipe config lint team.yaml --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigLint,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configLintCmd)
+
+	configLintCmd.Flags().BoolVar(&configLintJSON, "json", false, "Output in JSON format")
+}
+
+// LintProblem is one problem found in a config file.
+type LintProblem struct {
+	// Field is the YAML path of the problem, e.g. "agents[1].type" (empty if
+	// the problem is with the whole file)
+	Field string `json:"field,omitempty"`
+	// Line is the line of the field in the file (0 if unknown)
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+// LintReport is the result of linting a config file.
+type LintReport struct {
+	File     string        `json:"file"`
+	Valid    bool          `json:"valid"`
+	Agents   []string      `json:"agents,omitempty"`
+	Problems []LintProblem `json:"problems"`
+}
+
+func runConfigLint(cmd *cobra.Command, args []string) error {
+	report := lintConfig(args[0])
+	if err := writeLintReport(os.Stdout, report, configLintJSON); err != nil {
+		return err
+	}
+	if !report.Valid {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%s has %d problem(s)", report.File, len(report.Problems))
+	}
+	return nil
+}
+
+// lintConfig loads and validates the config at path, then creates each of its
+// agents to check that it is available. Every problem found is listed in the
+// report; agents are only checked when the config itself is valid.
+func lintConfig(path string) LintReport {
+	report := LintReport{File: path, Problems: []LintProblem{}}
+
+	cfg, err := config.LoadConfig(path)
+	if err != nil {
+		var errs config.ValidationErrors
+		if errors.As(err, &errs) {
+			for _, fe := range errs {
+				report.Problems = append(report.Problems, LintProblem{Field: fe.Field, Line: fe.Line, Message: fe.Message})
+			}
+		} else {
+			report.Problems = append(report.Problems, LintProblem{Message: err.Error()})
+		}
+		return report
+	}
+
+	for i, agentCfg := range cfg.Agents {
+		report.Agents = append(report.Agents, agentCfg.Name)
+		field := fmt.Sprintf("agents[%d]", i)
+
+		a, err := agent.CreateAgent(agentCfg)
+		if err != nil {
+			report.Problems = append(report.Problems, LintProblem{
+				Field:   field,
+				Message: fmt.Sprintf("agent %s (type: %s) cannot be created: %v", agentCfg.Name, agentCfg.Type, err),
+			})
+			continue
+		}
+		if !a.IsAvailable() {
+			report.Problems = append(report.Problems, LintProblem{
+				Field:   field,
+				Message: fmt.Sprintf("agent %s (type: %s) is not available - please run 'agentpipe doctor'", agentCfg.Name, agentCfg.Type),
+			})
+		}
+	}
+
+	report.Valid = len(report.Problems) == 0
+	return report
+}
+
+// writeLintReport renders the report as readable text or JSON.
+func writeLintReport(w io.Writer, report LintReport, asJSON bool) error {
+	if asJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal lint report to JSON: %w", err)
+		}
+		fmt.Fprintln(w, string(data))
+		return nil
+	}
+
+	if report.Valid {
+		fmt.Fprintf(w, "✅ %s is valid (%d agent(s): %s)\n", report.File, len(report.Agents), strings.Join(report.Agents, ", "))
+		return nil
+	}
+
+	fmt.Fprintf(w, "❌ %s has %d problem(s):\n", report.File, len(report.Problems))
+	for _, p := range report.Problems {
+		switch {
+		case p.Line > 0:
+			fmt.Fprintf(w, "  - line %d: %s: %s\n", p.Line, p.Field, p.Message)
+		case p.Field != "":
+			fmt.Fprintf(w, "  - %s: %s\n", p.Field, p.Message)
+		default:
+			fmt.Fprintf(w, "  - %s\n", p.Message)
+		}
+	}
+	return nil
+}
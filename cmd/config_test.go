@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+func writeLintFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestLintConfigValid(t *testing.T) {
+	agent.RegisterFactory("lint-test", func() agent.Agent { return &runTestAgent{} })
+
+	path := writeLintFile(t, `agents:
+  - id: a
+    type: lint-test
+    name: Alice
+  - id: b
+    type: lint-test
+    name: Bob
+orchestrator:
+  mode: round-robin
+`)
+
+	report := lintConfig(path)
+	if !report.Valid || len(report.Problems) != 0 {
+		t.Fatalf("expected a valid config, got %+v", report)
+	}
+
+	var buf bytes.Buffer
+	if err := writeLintReport(&buf, report, false); err != nil {
+		t.Fatalf("writeLintReport failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "is valid (2 agent(s): Alice, Bob)") {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestLintConfigInvalid(t *testing.T) {
+	agent.RegisterFactory("lint-test", func() agent.Agent { return &runTestAgent{} })
+
+	path := writeLintFile(t, `agents:
+  - id: a
+    type: lint-test
+    name: Alice
+  - id: a
+    type: lint-test
+orchestrator:
+  mode: sideways
+`)
+
+	report := lintConfig(path)
+	if report.Valid {
+		t.Fatal("expected an invalid config")
+	}
+	fields := map[string]int{}
+	for _, p := range report.Problems {
+		fields[p.Field] = p.Line
+	}
+	if fields["orchestrator.mode"] != 8 {
+		t.Errorf("expected the invalid mode on line 8, got problems %+v", report.Problems)
+	}
+	if _, ok := fields["agents[1].name"]; !ok {
+		t.Errorf("expected the missing name to be reported, got problems %+v", report.Problems)
+	}
+
+	var buf bytes.Buffer
+	if err := writeLintReport(&buf, report, false); err != nil {
+		t.Fatalf("writeLintReport failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "line 8: orchestrator.mode:") {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestLintConfigUnavailableAgent(t *testing.T) {
+	path := writeLintFile(t, `agents:
+  - id: tool
+    type: exec
+    name: Tool
+    command: agentpipe-lint-test-missing-command
+`)
+
+	report := lintConfig(path)
+	if report.Valid || len(report.Problems) != 1 || report.Problems[0].Field != "agents[0]" {
+		t.Fatalf("expected the missing command to be reported, got %+v", report)
+	}
+
+	var buf bytes.Buffer
+	if err := writeLintReport(&buf, report, true); err != nil {
+		t.Fatalf("writeLintReport failed: %v", err)
+	}
+	var decoded LintReport
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, buf.String())
+	}
+	if decoded.Valid || len(decoded.Problems) != 1 || !strings.Contains(decoded.Problems[0].Message, "Tool") {
+		t.Errorf("unexpected JSON report: %+v", decoded)
+	}
+}
+
+func TestLintConfigMissingFile(t *testing.T) {
+	report := lintConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	if report.Valid || len(report.Problems) != 1 || report.Problems[0].Field != "" {
+		t.Errorf("expected one file-level problem, got %+v", report)
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -39,6 +40,7 @@ type DoctorOutput struct {
 	SystemEnvironment []SystemCheck `json:"system_environment"`
 	SupportedAgents   []AgentCheck  `json:"supported_agents"`
 	AvailableAgents   []AgentCheck  `json:"available_agents"`
+	APIAgents         []SystemCheck `json:"api_agents"`
 	Configuration     []SystemCheck `json:"configuration"`
 	Summary           DoctorSummary `json:"summary"`
 }
@@ -47,26 +49,69 @@ type DoctorSummary struct {
 	TotalAgents    int      `json:"total_agents"`
 	AvailableCount int      `json:"available_count"`
 	MissingAgents  []string `json:"missing_agents,omitempty"`
-	Ready          bool     `json:"ready"`
+	// MissingRequired lists the --require agents that are not ready
+	MissingRequired []string `json:"missing_required,omitempty"`
+	Ready           bool     `json:"ready"`
 }
 
 var (
-	doctorJSON bool
+	doctorJSON    bool
+	doctorRequire []string
 )
 
+// apiAgent describes an agent type that calls a provider API instead of a CLI.
+type apiAgent struct {
+	Name   string
+	Type   string
+	EnvVar string
+	Docs   string
+}
+
+// apiAgents lists the API-based agent types checked by doctor.
+var apiAgents = []apiAgent{
+	{Name: "OpenRouter", Type: "openrouter", EnvVar: "OPENROUTER_API_KEY", Docs: "https://openrouter.ai/docs"},
+}
+
 var doctorCmd = &cobra.Command{
 	Use:   "doctor",
 	Short: "Check if AI agent CLIs are installed and available",
-	Long:  `Doctor command checks your system for installed AI agent CLIs, versions, and configuration.`,
-	Run:   runDoctor,
+	Long: `Doctor command checks your system for installed AI agent CLIs, versions, and configuration.
+
+Exits with status 1 when no agent is available, or, with --require, when any
+required agent is not installed or not authenticated.`,
+	Run: runDoctor,
 }
 
 func init() {
 	rootCmd.AddCommand(doctorCmd)
 	doctorCmd.Flags().BoolVar(&doctorJSON, "json", false, "Output results in JSON format")
+	doctorCmd.Flags().StringSliceVar(&doctorRequire, "require", nil, "Agents that must be installed and authenticated (comma-separated names or commands); exit 1 otherwise")
 }
 
 func runDoctor(cmd *cobra.Command, args []string) {
+	output := collectDoctorOutput(doctorRequire)
+
+	// Output in requested format
+	if doctorJSON {
+		if err := writeDoctorJSON(cmd.OutOrStdout(), output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating JSON output: %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		printHumanReadableOutput(output)
+	}
+
+	if code := doctorExitCode(output, doctorRequire); code != 0 {
+		if len(output.Summary.MissingRequired) > 0 {
+			fmt.Fprintf(os.Stderr, "Required agents not ready: %s\n", strings.Join(output.Summary.MissingRequired, ", "))
+		}
+		os.Exit(code)
+	}
+}
+
+// collectDoctorOutput runs every doctor check and reports the agents in
+// required that are not ready.
+func collectDoctorOutput(required []string) DoctorOutput {
 	// Get all agents from registry
 	registryAgents := registry.GetAll()
 
@@ -100,6 +145,13 @@ func runDoctor(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	// API-based agents need an API key instead of a CLI
+	apiChecks := checkAPIAgents()
+	apiReady := false
+	for _, check := range apiChecks {
+		apiReady = apiReady || check.Status
+	}
+
 	// Configuration checks
 	configChecks := performConfigChecks()
 
@@ -108,7 +160,7 @@ func runDoctor(cmd *cobra.Command, args []string) {
 		TotalAgents:    len(registryAgents),
 		AvailableCount: len(availableAgents),
 		MissingAgents:  unavailableAgents,
-		Ready:          len(availableAgents) > 0,
+		Ready:          len(availableAgents) > 0 || apiReady,
 	}
 
 	// Build complete output
@@ -116,21 +168,85 @@ func runDoctor(cmd *cobra.Command, args []string) {
 		SystemEnvironment: systemChecks,
 		SupportedAgents:   supportedAgents,
 		AvailableAgents:   availableAgents,
+		APIAgents:         apiChecks,
 		Configuration:     configChecks,
 		Summary:           summary,
 	}
+	output.Summary.MissingRequired = missingRequiredAgents(output, required)
 
-	// Output in requested format
-	if doctorJSON {
-		jsonOutput, err := json.MarshalIndent(output, "", "  ")
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error generating JSON output: %v\n", err)
-			os.Exit(1)
+	return output
+}
+
+// writeDoctorJSON writes the doctor results as indented JSON.
+func writeDoctorJSON(w io.Writer, output DoctorOutput) error {
+	jsonOutput, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(jsonOutput))
+	return err
+}
+
+// doctorExitCode returns the exit status for a doctor run: 1 when any required
+// agent is not ready, or, with no requirements, when no agent is usable at all;
+// 0 otherwise.
+func doctorExitCode(output DoctorOutput, required []string) int {
+	if len(missingRequiredAgents(output, required)) > 0 {
+		return 1
+	}
+	if !output.Summary.Ready {
+		return 1
+	}
+	return 0
+}
+
+// missingRequiredAgents describes each required agent that is not ready to use:
+// a CLI that is not installed or not authenticated, an API agent without its
+// API key, or a name that matches no agent. Agents are matched by name or
+// command, ignoring case.
+func missingRequiredAgents(output DoctorOutput, required []string) []string {
+	var missing []string
+	for _, name := range required {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		if problem, found := requiredAgentProblem(output, name); !found {
+			missing = append(missing, fmt.Sprintf("%s (unknown agent)", name))
+		} else if problem != "" {
+			missing = append(missing, fmt.Sprintf("%s (%s)", name, problem))
 		}
-		fmt.Println(string(jsonOutput))
-	} else {
-		printHumanReadableOutput(output)
 	}
+	return missing
+}
+
+// requiredAgentProblem returns why the named agent is not ready ("" if it is),
+// and whether doctor checked an agent by that name at all.
+func requiredAgentProblem(output DoctorOutput, name string) (string, bool) {
+	for _, check := range output.SupportedAgents {
+		if !strings.EqualFold(check.Name, name) && !strings.EqualFold(check.Command, name) {
+			continue
+		}
+		switch {
+		case !check.Available:
+			return "not installed", true
+		case !check.Authenticated:
+			return "not authenticated", true
+		}
+		return "", true
+	}
+
+	for _, check := range output.APIAgents {
+		if strings.EqualFold(check.Name, name) {
+			if !check.Status {
+				return "API key not set", true
+			}
+			return "", true
+		}
+	}
+
+	return "", false
 }
 
 func printHumanReadableOutput(output DoctorOutput) {
@@ -187,6 +303,14 @@ func printHumanReadableOutput(output DoctorOutput) {
 	}
 	fmt.Println()
 
+	// API agent checks
+	fmt.Println("\n🌐 API AGENTS")
+	fmt.Println(strings.Repeat("-", 61))
+	for _, check := range output.APIAgents {
+		fmt.Printf("  %s %s: %s\n", check.Icon, check.Name, check.Message)
+	}
+	fmt.Println()
+
 	// Configuration checks
 	fmt.Println("\n⚙️  CONFIGURATION")
 	fmt.Println(strings.Repeat("-", 61))
@@ -204,19 +328,46 @@ func printHumanReadableOutput(output DoctorOutput) {
 		fmt.Printf("   Missing Agents:   %s\n", strings.Join(output.Summary.MissingAgents, ", "))
 	}
 
-	if output.Summary.AvailableCount == 0 {
+	if !output.Summary.Ready {
 		fmt.Println()
-		fmt.Println("⚠️  No AI agents found. Please install at least one agent CLI to use AgentPipe.")
+		fmt.Println("⚠️  No AI agents found. Please install at least one agent CLI or set an API key to use AgentPipe.")
 		fmt.Println("   Visit the respective documentation pages above for installation instructions.")
 	} else {
 		fmt.Println()
-		fmt.Printf("✨ AgentPipe is ready! You can use %d agent(s).\n", output.Summary.AvailableCount)
+		if output.Summary.AvailableCount > 0 {
+			fmt.Printf("✨ AgentPipe is ready! You can use %d agent(s).\n", output.Summary.AvailableCount)
+		} else {
+			fmt.Println("✨ AgentPipe is ready! You can use API-based agents.")
+		}
 		fmt.Println("   Run 'agentpipe run --help' to start a conversation.")
 	}
 
 	fmt.Println()
 }
 
+// checkAPIAgents reports whether each API-based agent has its API key set.
+func checkAPIAgents() []SystemCheck {
+	checks := make([]SystemCheck, 0, len(apiAgents))
+	for _, a := range apiAgents {
+		if os.Getenv(a.EnvVar) != "" {
+			checks = append(checks, SystemCheck{
+				Name:    a.Name,
+				Status:  true,
+				Message: fmt.Sprintf("%s is set (type: %s; set model per agent)", a.EnvVar, a.Type),
+				Icon:    "✅",
+			})
+		} else {
+			checks = append(checks, SystemCheck{
+				Name:    a.Name,
+				Status:  false,
+				Message: fmt.Sprintf("Set %s to use type '%s' (%s)", a.EnvVar, a.Type, a.Docs),
+				Icon:    "ℹ️",
+			})
+		}
+	}
+	return checks
+}
+
 func performSystemChecks() []SystemCheck {
 	checks := []SystemCheck{}
 
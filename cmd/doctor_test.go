@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/kevinelliott/agentpipe/internal/registry"
+)
+
+func TestCheckAPIAgents(t *testing.T) {
+	t.Setenv("OPENROUTER_API_KEY", "")
+	checks := checkAPIAgents()
+	if len(checks) != len(apiAgents) {
+		t.Fatalf("expected %d checks, got %d", len(apiAgents), len(checks))
+	}
+	if checks[0].Name != "OpenRouter" || checks[0].Status {
+		t.Errorf("expected OpenRouter to be unconfigured, got %+v", checks[0])
+	}
+	if !strings.Contains(checks[0].Message, "OPENROUTER_API_KEY") {
+		t.Errorf("expected the check to name the API key variable, got %q", checks[0].Message)
+	}
+
+	t.Setenv("OPENROUTER_API_KEY", "sk-or-test")
+	if checks := checkAPIAgents(); !checks[0].Status {
+		t.Errorf("expected OpenRouter to be configured, got %+v", checks[0])
+	}
+}
+
+func TestDoctorJSONOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as a fake agent CLI")
+	}
+
+	// Only a fake claude CLI is on the PATH
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho 'claude 1.2.3'\n"
+	if err := os.WriteFile(filepath.Join(dir, "claude"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+
+	var buf bytes.Buffer
+	if err := writeDoctorJSON(&buf, collectDoctorOutput([]string{"claude", "gemini"})); err != nil {
+		t.Fatalf("writeDoctorJSON failed: %v", err)
+	}
+
+	var output DoctorOutput
+	if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+		t.Fatalf("expected JSON output, got %v:\n%s", err, buf.String())
+	}
+
+	if len(output.SystemEnvironment) == 0 {
+		t.Error("expected system checks")
+	}
+	if len(output.Configuration) == 0 {
+		t.Error("expected config checks")
+	}
+	if len(output.SupportedAgents) != len(registry.GetAll()) || output.Summary.TotalAgents != len(output.SupportedAgents) {
+		t.Errorf("expected a check for each of the %d registry agents, got %d", len(registry.GetAll()), len(output.SupportedAgents))
+	}
+
+	var claude *AgentCheck
+	for i, check := range output.SupportedAgents {
+		if check.Name == "" || check.Command == "" {
+			t.Errorf("expected every agent check to have a name and command, got %+v", check)
+		}
+		if check.Command == "claude" {
+			claude = &output.SupportedAgents[i]
+		} else if check.Available {
+			t.Errorf("expected %s to be unavailable, got %+v", check.Name, check)
+		}
+	}
+	if claude == nil {
+		t.Fatal("expected a check for the claude CLI")
+	}
+	if !claude.Available || claude.Path != filepath.Join(dir, "claude") || claude.Version != "claude 1.2.3" || !claude.Authenticated {
+		t.Errorf("unexpected claude check: %+v", *claude)
+	}
+	if output.Summary.AvailableCount != 1 || len(output.AvailableAgents) != 1 || !output.Summary.Ready {
+		t.Errorf("expected one available agent and a ready summary, got %+v", output.Summary)
+	}
+	if len(output.Summary.MissingRequired) != 1 || output.Summary.MissingRequired[0] != "gemini (not installed)" {
+		t.Errorf("expected only gemini to be missing, got %v", output.Summary.MissingRequired)
+	}
+}
+
+func TestDoctorExitCode(t *testing.T) {
+	output := DoctorOutput{
+		SupportedAgents: []AgentCheck{
+			{Name: "Claude", Command: "claude", Available: true, Authenticated: true},
+			{Name: "Cursor", Command: "cursor-agent", Available: true, Authenticated: false},
+			{Name: "Gemini", Command: "gemini", Available: false, Authenticated: true},
+		},
+		APIAgents: []SystemCheck{{Name: "OpenRouter", Status: false}},
+		Summary:   DoctorSummary{AvailableCount: 2, Ready: true},
+	}
+
+	tests := []struct {
+		name        string
+		output      DoctorOutput
+		required    []string
+		wantCode    int
+		wantMissing []string
+	}{
+		{"no requirements", output, nil, 0, nil},
+		{"nothing available", DoctorOutput{Summary: DoctorSummary{Ready: false}}, nil, 1, nil},
+		{"required by name and command", output, []string{"claude", "Claude"}, 0, nil},
+		{"not installed", output, []string{"claude", "gemini"}, 1, []string{"gemini (not installed)"}},
+		{"not authenticated", output, []string{"cursor-agent"}, 1, []string{"cursor-agent (not authenticated)"}},
+		{"API key not set", output, []string{"openrouter"}, 1, []string{"openrouter (API key not set)"}},
+		{"unknown agent", output, []string{"nope", " "}, 1, []string{"nope (unknown agent)"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := doctorExitCode(tt.output, tt.required); code != tt.wantCode {
+				t.Errorf("expected exit code %d, got %d", tt.wantCode, code)
+			}
+			missing := missingRequiredAgents(tt.output, tt.required)
+			if strings.Join(missing, "; ") != strings.Join(tt.wantMissing, "; ") {
+				t.Errorf("expected missing %v, got %v", tt.wantMissing, missing)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kevinelliott/agentpipe/pkg/config"
+	"github.com/kevinelliott/agentpipe/pkg/utils"
+)
+
+var estimateCmd = &cobra.Command{
+	Use:   "estimate",
+	Short: "Forecast the token usage and cost of a conversation before running it",
+	Long: `Forecast a rough token usage and cost range for a conversation without
+calling any agents.
+
+The projection is based on the number of turns, the number of agents, the
+conversation mode, assumed message sizes, and model pricing (including any
+overrides in ~/.agentpipe/pricing.yaml). It is a planning tool, not an exact bill:
+the low and high bounds assume every message is --min-message-tokens and
+--max-message-tokens long respectively.
+
+Examples:
+  agentpipe estimate --config examples/debate.yaml
+  agentpipe estimate --agents claude,gemini --max-turns 20
+  agentpipe estimate -c config.yaml --max-message-tokens 1000 --json`,
+	RunE: runEstimate,
+}
+
+var (
+	estimateConfigPath string
+	estimateAgents     []string
+	estimateMode       string
+	estimateMaxTurns   int
+	estimateMinTokens  int
+	estimateMaxTokens  int
+	estimateJSON       bool
+)
+
+func init() {
+	rootCmd.AddCommand(estimateCmd)
+
+	estimateCmd.Flags().StringVarP(&estimateConfigPath, "config", "c", "", "Path to YAML configuration file")
+	estimateCmd.Flags().StringSliceVarP(&estimateAgents, "agents", "a", []string{}, "Agents to use (e.g., claude:Assistant1,gemini:Assistant2)")
+	estimateCmd.Flags().StringVarP(&estimateMode, "mode", "m", "", "Conversation mode (overrides config)")
+	estimateCmd.Flags().IntVar(&estimateMaxTurns, "max-turns", 0, "Maximum number of conversation turns (overrides config)")
+	estimateCmd.Flags().IntVar(&estimateMinTokens, "min-message-tokens", utils.DefaultForecastMinMessageTokens, "Assumed tokens per message (low estimate)")
+	estimateCmd.Flags().IntVar(&estimateMaxTokens, "max-message-tokens", utils.DefaultForecastMaxMessageTokens, "Assumed tokens per message (high estimate)")
+	estimateCmd.Flags().BoolVar(&estimateJSON, "json", false, "Output in JSON format")
+}
+
+func runEstimate(cmd *cobra.Command, args []string) error {
+	var cfg *config.Config
+	if estimateConfigPath != "" {
+		var err error
+		cfg, err = config.LoadConfig(estimateConfigPath)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+	} else if len(estimateAgents) > 0 {
+		cfg = config.NewDefaultConfig()
+		for i, spec := range estimateAgents {
+			agentCfg, err := parseAgentSpec(spec, i)
+			if err != nil {
+				return err
+			}
+			cfg.Agents = append(cfg.Agents, agentCfg)
+		}
+	} else {
+		return fmt.Errorf("either --config or --agents must be specified")
+	}
+
+	if estimateMode != "" {
+		cfg.Orchestrator.Mode = estimateMode
+	}
+	if estimateMaxTurns > 0 {
+		cfg.Orchestrator.MaxTurns = estimateMaxTurns
+	}
+
+	forecast := forecastForConfig(cfg, estimateMinTokens, estimateMaxTokens)
+
+	if estimateJSON {
+		data, err := json.MarshalIndent(forecast, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal forecast: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	printForecast(os.Stdout, cfg, forecast)
+	return nil
+}
+
+// forecastForConfig builds forecast inputs from a configuration and runs the projection.
+func forecastForConfig(cfg *config.Config, minTokens, maxTokens int) utils.CostForecast {
+	agents := make([]utils.ForecastAgent, 0, len(cfg.Agents))
+	for _, a := range cfg.Agents {
+		model := a.Model
+		if model == "" {
+			model = a.Type
+		}
+		agents = append(agents, utils.ForecastAgent{
+			Name:         a.Name,
+			Model:        model,
+			PromptTokens: utils.CountTokens(model, a.Prompt),
+		})
+	}
+
+	return utils.ForecastCost(agents, utils.ForecastAssumptions{
+		Responses:           expectedResponses(cfg.Orchestrator.Mode, cfg.Orchestrator.MaxTurns, len(cfg.Agents)),
+		InitialPromptTokens: utils.EstimateTokens(cfg.Orchestrator.InitialPrompt),
+		MinMessageTokens:    minTokens,
+		MaxMessageTokens:    maxTokens,
+	})
+}
+
+// expectedResponses returns how many agent responses a conversation produces.
+// In round-robin mode a turn is one full rotation through all agents; in the
+// other modes every response counts as a turn.
+func expectedResponses(mode string, maxTurns, agentCount int) int {
+	if mode == "" || mode == "round-robin" {
+		return maxTurns * agentCount
+	}
+	return maxTurns
+}
+
+// printForecast writes a human-readable forecast table.
+func printForecast(w io.Writer, cfg *config.Config, f utils.CostForecast) {
+	fmt.Fprintln(w, "💰 Conversation Cost Forecast")
+	fmt.Fprintf(w, "Mode: %s | Max turns: %d | Agents: %d | Responses: %d\n",
+		cfg.Orchestrator.Mode, cfg.Orchestrator.MaxTurns, len(cfg.Agents), f.Assumptions.Responses)
+	fmt.Fprintf(w, "Assumed message size: %d-%d tokens\n\n", f.Assumptions.MinMessageTokens, f.Assumptions.MaxMessageTokens)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "AGENT\tMODEL\tRESPONSES\tTOKENS\tCOST")
+	fmt.Fprintln(tw, "-----\t-----\t---------\t------\t----")
+	for _, a := range f.Agents {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d - %d\t$%.4f - $%.4f\n",
+			a.Name,
+			a.Model,
+			a.Responses,
+			a.InputTokens.Low+a.OutputTokens.Low,
+			a.InputTokens.High+a.OutputTokens.High,
+			a.Cost.Low,
+			a.Cost.High,
+		)
+	}
+	tw.Flush()
+
+	fmt.Fprintf(w, "\nTotal tokens: %d - %d\n", f.TotalTokens.Low, f.TotalTokens.High)
+	fmt.Fprintf(w, "Total cost:   $%.4f - $%.4f\n", f.TotalCost.Low, f.TotalCost.High)
+	fmt.Fprintln(w, "\nNote: models without known pricing are counted as $0.00")
+}
@@ -9,29 +9,37 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/kevinelliott/agentpipe/pkg/agent"
-	"github.com/kevinelliott/agentpipe/pkg/export"
+	"github.com/kevinelliott/agentpipe/pkg/conversation"
+	"github.com/kevinelliott/agentpipe/pkg/logger"
 )
 
 var exportCmd = &cobra.Command{
 	Use:   "export [log-file]",
 	Short: "Export a conversation to different formats",
-	Long: `Export a conversation log file to JSON, Markdown, or HTML format.
+	Long: `Export a conversation log file to text, JSON, Markdown, HTML, or CSV format.
 
-The export command reads a conversation log file and converts it to the specified
-format with optional metrics and timestamps.
+The export command parses a chat log (text or JSON log format), or a state file
+saved with --save-state, and re-renders it in the specified format with
+optional metrics and timestamps.
 
 Examples:
-  # Export to JSON
-  agentpipe export ~/.agentpipe/chats/conversation_20231015.txt --format json
+  # Convert a text log to JSON
+  agentpipe export ~/.agentpipe/chats/chat_2025-01-15_10-30-00.log --to json -o chat.json
 
   # Export to Markdown with metrics
-  agentpipe export chat.txt --format markdown --metrics
+  agentpipe export chat.log --format markdown --metrics
 
   # Export to HTML with custom title
-  agentpipe export chat.txt --format html --title "Team Brainstorm"
+  agentpipe export chat.log --format html --title "Team Brainstorm"
 
   # Export latest conversation
   agentpipe export --latest --format markdown
+
+  # Export a saved conversation state as a standalone HTML page
+  agentpipe export --state ~/.agentpipe/states/conversation-20250115-103000.json --format html -o demo.html
+
+  # Export per-message metrics for a spreadsheet
+  agentpipe export --state ~/.agentpipe/states/conversation-20250115-103000.json --format csv -o metrics.csv
 `,
 	RunE: runExport,
 }
@@ -43,23 +51,32 @@ var (
 	exportTimestamps bool
 	exportTitle      string
 	exportLatest     bool
+	exportTo         string
+	exportState      string
 )
 
 func init() {
 	rootCmd.AddCommand(exportCmd)
 
-	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "markdown", "Export format (json, markdown, html)")
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "markdown", "Export format (text, json, markdown, html, csv)")
+	exportCmd.Flags().StringVar(&exportTo, "to", "", "Alias for --format")
 	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output file (default: stdout)")
 	exportCmd.Flags().BoolVar(&exportMetrics, "metrics", true, "Include metrics (tokens, cost)")
 	exportCmd.Flags().BoolVar(&exportTimestamps, "timestamps", true, "Include timestamps")
 	exportCmd.Flags().StringVar(&exportTitle, "title", "", "Conversation title")
 	exportCmd.Flags().BoolVar(&exportLatest, "latest", false, "Export the latest conversation")
+	exportCmd.Flags().StringVar(&exportState, "state", "", "Export a conversation state file saved with --save-state instead of a log")
 }
 
 func runExport(cmd *cobra.Command, args []string) error {
 	// Determine input file
 	var inputFile string
-	if exportLatest {
+	if exportState != "" {
+		if exportLatest || len(args) > 0 {
+			return fmt.Errorf("--state cannot be combined with a log file or --latest")
+		}
+		inputFile = exportState
+	} else if exportLatest {
 		// Find latest conversation in default log directory
 		homeDir, err := os.UserHomeDir()
 		if err != nil {
@@ -80,23 +97,33 @@ func runExport(cmd *cobra.Command, args []string) error {
 		inputFile = args[0]
 	}
 
-	// Read messages from log file
-	messages, err := readLogFile(inputFile)
+	// Determine export format before reading, so a typo fails fast
+	formatName := exportFormat
+	if exportTo != "" {
+		formatName = exportTo
+	}
+	format, err := conversation.ParseFormat(formatName)
 	if err != nil {
-		return fmt.Errorf("failed to read log file: %w", err)
+		return fmt.Errorf("invalid format: %w", err)
 	}
 
-	if len(messages) == 0 {
-		return fmt.Errorf("no messages found in log file")
+	// Read messages from the state or log file
+	var messages []agent.Message
+	if exportState != "" {
+		state, err := conversation.LoadState(exportState)
+		if err != nil {
+			return fmt.Errorf("failed to load state: %w", err)
+		}
+		messages = state.Messages
+	} else {
+		messages, err = readLogFile(inputFile)
+		if err != nil {
+			return fmt.Errorf("failed to read log file: %w", err)
+		}
 	}
 
-	// Determine export format
-	format := export.Format(strings.ToLower(exportFormat))
-	switch format {
-	case export.FormatJSON, export.FormatMarkdown, export.FormatHTML:
-		// Valid format
-	default:
-		return fmt.Errorf("invalid format: %s (use json, markdown, or html)", exportFormat)
+	if len(messages) == 0 {
+		return fmt.Errorf("no messages found in %s", inputFile)
 	}
 
 	// Set default title if not provided
@@ -105,13 +132,11 @@ func runExport(cmd *cobra.Command, args []string) error {
 		title = fmt.Sprintf("Conversation - %s", filepath.Base(inputFile))
 	}
 
-	// Create exporter
-	exporter := export.NewExporter(export.ExportOptions{
-		Format:            format,
+	opts := conversation.RenderOptions{
+		Title:             title,
 		IncludeMetrics:    exportMetrics,
 		IncludeTimestamps: exportTimestamps,
-		Title:             title,
-	})
+	}
 
 	// Determine output writer
 	var writer *os.File
@@ -131,7 +156,7 @@ func runExport(cmd *cobra.Command, args []string) error {
 	}
 
 	// Export
-	if err := exporter.Export(messages, writer); err != nil {
+	if err := conversation.RenderWithOptions(writer, messages, format, opts); err != nil {
 		return fmt.Errorf("export failed: %w", err)
 	}
 
@@ -143,12 +168,15 @@ func runExport(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// readLogFile reads and parses a conversation log file.
-// This is a simplified implementation - in production, you'd want more robust parsing.
+// readLogFile reads and parses a conversation log file in text or JSON format.
 func readLogFile(path string) ([]agent.Message, error) {
-	// For now, return an error indicating this needs the logger package support
-	// In a real implementation, this would parse the log file format
-	return nil, fmt.Errorf("log file parsing not yet implemented - this feature requires log file format support")
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return logger.ParseLog(f)
 }
 
 // findLatestLog finds the most recent log file in the given directory.
@@ -166,9 +194,8 @@ func findLatestLog(dir string) (string, error) {
 			continue
 		}
 
-		// Look for text or JSON log files
 		name := entry.Name()
-		if !strings.HasSuffix(name, ".txt") && !strings.HasSuffix(name, ".json") {
+		if !isLogFile(name) {
 			continue
 		}
 
@@ -189,3 +216,8 @@ func findLatestLog(dir string) (string, error) {
 
 	return latestFile, nil
 }
+
+// isLogFile reports whether name looks like a text or JSON chat log.
+func isLogFile(name string) bool {
+	return strings.HasSuffix(name, ".log") || strings.HasSuffix(name, ".txt") || strings.HasSuffix(name, ".json")
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/config"
+	"github.com/kevinelliott/agentpipe/pkg/conversation"
+)
+
+const exportTestLog = `=== AgentPipe Chat Log ===
+Started: 2025-01-15 10:30:00
+=====================================
+
+[10:30:00] HOST (system): Name a good test framework
+
+[10:30:04] Claude (agent): The standard library's testing package.
+
+It needs no dependencies.
+
+[10:30:09] Gemini (agent): Agreed, with table-driven tests.
+
+`
+
+func runExportForTest(t *testing.T, to string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "chat.log")
+	if err := os.WriteFile(logPath, []byte(exportTestLog), 0644); err != nil {
+		t.Fatal(err)
+	}
+	outPath := filepath.Join(dir, "out."+to)
+
+	origTo, origOutput, origLatest := exportTo, exportOutput, exportLatest
+	exportTo, exportOutput, exportLatest = to, outPath, false
+	defer func() { exportTo, exportOutput, exportLatest = origTo, origOutput, origLatest }()
+
+	if err := runExport(exportCmd, []string{logPath}); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("output not written: %v", err)
+	}
+	return string(data)
+}
+
+func TestExportTextLogToJSON(t *testing.T) {
+	out := runExportForTest(t, "json")
+
+	var messages []agent.Message
+	if err := json.Unmarshal([]byte(out), &messages); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, out)
+	}
+	if len(messages) != 3 {
+		t.Fatalf("expected 3 messages, got %d", len(messages))
+	}
+
+	want := []struct{ name, role, content string }{
+		{"HOST", "system", "Name a good test framework"},
+		{"Claude", "agent", "The standard library's testing package.\n\nIt needs no dependencies."},
+		{"Gemini", "agent", "Agreed, with table-driven tests."},
+	}
+	for i, w := range want {
+		if messages[i].AgentName != w.name || messages[i].Role != w.role || messages[i].Content != w.content {
+			t.Errorf("message %d = %+v, want %+v", i, messages[i], w)
+		}
+	}
+}
+
+func TestExportTextLogToMarkdown(t *testing.T) {
+	out := runExportForTest(t, "markdown")
+
+	for _, want := range []string{
+		"# Conversation - chat.log",
+		"### [SYSTEM]",
+		"Name a good test framework",
+		"### Claude",
+		"The standard library's testing package.\n\nIt needs no dependencies.",
+		"### Gemini",
+		"Agreed, with table-driven tests.",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestExportRejectsUnknownFormat(t *testing.T) {
+	origTo := exportTo
+	exportTo = "pdf"
+	defer func() { exportTo = origTo }()
+
+	if err := runExport(exportCmd, []string{"unused.log"}); err == nil || !strings.Contains(err.Error(), "invalid format") {
+		t.Errorf("expected invalid format error, got %v", err)
+	}
+}
+
+func TestExportStateToHTML(t *testing.T) {
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, "state.json")
+	messages := []agent.Message{
+		{AgentID: "host", AgentName: "HOST", Content: "Name a good test framework", Timestamp: time.Now().Unix(), Role: "system"},
+		{AgentID: "claude", AgentName: "Claude", Content: "Use testing:\n```go\nfunc TestX(t *testing.T) {}\n```", Timestamp: time.Now().Unix(), Role: "agent"},
+	}
+	if err := conversation.NewState(messages, config.NewDefaultConfig(), time.Now()).Save(statePath); err != nil {
+		t.Fatal(err)
+	}
+	outPath := filepath.Join(dir, "out.html")
+
+	origTo, origOutput, origState := exportTo, exportOutput, exportState
+	exportTo, exportOutput, exportState = "html", outPath, statePath
+	defer func() { exportTo, exportOutput, exportState = origTo, origOutput, origState }()
+
+	if err := runExport(exportCmd, nil); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("output not written: %v", err)
+	}
+	if !strings.Contains(string(data), `<pre><code class="language-go">func TestX(t *testing.T) {}</code></pre>`) {
+		t.Errorf("expected the code block in the HTML export, got:\n%s", data)
+	}
+
+	if err := runExport(exportCmd, []string{"chat.log"}); err == nil || !strings.Contains(err.Error(), "--state cannot be combined") {
+		t.Errorf("expected an error for --state with a log file, got %v", err)
+	}
+}
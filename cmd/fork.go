@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kevinelliott/agentpipe/pkg/conversation"
+)
+
+var forkCmd = &cobra.Command{
+	Use:   "fork <state-file>",
+	Short: "Fork a saved conversation at a message",
+	Long: `Write a copy of a conversation saved with --save-state that keeps only its
+first N messages.
+
+Resume each fork with 'agentpipe run --resume' to continue the same
+conversation in different ways, for example to compare two prompts or models
+from the same starting point.
+
+Examples:
+  agentpipe fork conversation.json --at 6 --out variant-a.json
+  agentpipe fork conversation.json --at 6 --out variant-b.json
+  agentpipe run -c variant-b.yaml --resume variant-b.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFork,
+}
+
+var (
+	forkAt  int
+	forkOut string
+)
+
+func init() {
+	rootCmd.AddCommand(forkCmd)
+
+	forkCmd.Flags().IntVar(&forkAt, "at", 0, "Number of messages to keep from the start of the conversation")
+	forkCmd.Flags().StringVar(&forkOut, "out", "", "Path to write the forked state to")
+	_ = forkCmd.MarkFlagRequired("at")
+	_ = forkCmd.MarkFlagRequired("out")
+}
+
+func runFork(cmd *cobra.Command, args []string) error {
+	state, err := conversation.LoadState(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	fork, err := forkState(state, forkAt, args[0])
+	if err != nil {
+		return err
+	}
+	if err := fork.Save(forkOut); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "🍴 Forked %s at message %d of %d: %s\n", args[0], forkAt, len(state.Messages), forkOut)
+	fmt.Fprintf(cmd.OutOrStdout(), "Continue it with: agentpipe run --resume %s\n", forkOut)
+	return nil
+}
+
+// forkState truncates state to its first at messages, noting the source file
+// in the fork's description.
+func forkState(state *conversation.State, at int, source string) (*conversation.State, error) {
+	if at < 0 || at > len(state.Messages) {
+		return nil, fmt.Errorf("--at must be between 0 and %d, the number of messages in %s", len(state.Messages), source)
+	}
+
+	fork := state.Fork(at)
+	note := fmt.Sprintf("forked from %s at message %d", filepath.Base(source), at)
+	if fork.Metadata.Description != "" {
+		fork.Metadata.Description += " (" + note + ")"
+	} else {
+		fork.Metadata.Description = "Conversation " + note
+	}
+	return &fork, nil
+}
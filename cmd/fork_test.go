@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kevinelliott/agentpipe/pkg/conversation"
+)
+
+func TestForkState(t *testing.T) {
+	source := saveReplayTestState(t)
+	state, err := conversation.LoadState(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fork, err := forkState(state, 2, source)
+	if err != nil {
+		t.Fatalf("forkState() error = %v", err)
+	}
+	out := filepath.Join(t.TempDir(), "fork.json")
+	if err := fork.Save(out); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := conversation.LoadState(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Messages) != 2 || loaded.Messages[1].AgentName != "Claude" {
+		t.Errorf("expected the first 2 messages, got %+v", loaded.Messages)
+	}
+	if want := "forked from state.json at message 2"; !strings.Contains(loaded.Metadata.Description, want) {
+		t.Errorf("expected %q in the description, got %q", want, loaded.Metadata.Description)
+	}
+	if len(state.Messages) != 3 {
+		t.Errorf("expected the source state to keep its messages, got %d", len(state.Messages))
+	}
+
+	for _, at := range []int{-1, 4} {
+		if _, err := forkState(state, at, source); err == nil || !strings.Contains(err.Error(), "between 0 and 3") {
+			t.Errorf("--at %d: expected a range error, got %v", at, err)
+		}
+	}
+}
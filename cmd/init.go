@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -12,25 +13,182 @@ import (
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 
+	"github.com/kevinelliott/agentpipe/internal/registry"
 	"github.com/kevinelliott/agentpipe/pkg/agent"
 	"github.com/kevinelliott/agentpipe/pkg/config"
+	"github.com/kevinelliott/agentpipe/pkg/orchestrator"
 )
 
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize a new AgentPipe configuration",
 	Long: `Create a new AgentPipe configuration file interactively.
-This command will guide you through setting up agents and orchestration options.`,
+This command detects the agent CLIs installed on your system and guides you
+through choosing participants, a conversation mode, and orchestration options.
+The configuration is written to ~/.agentpipe/config.yaml unless --output is given.`,
 	RunE: runInit,
 }
 
 func init() {
 	rootCmd.AddCommand(initCmd)
-	initCmd.Flags().StringP("output", "o", "agentpipe.yaml", "Output configuration file path")
+	initCmd.Flags().StringP("output", "o", "", "Output configuration file path (default: ~/.agentpipe/config.yaml)")
+}
+
+// initAgentAnswer holds the wizard answers for one participant.
+// Empty fields are filled with defaults derived from Type.
+type initAgentAnswer struct {
+	Type           string
+	ID             string
+	Name           string
+	Prompt         string
+	Announcement   string
+	Model          string
+	RateLimit      float64
+	RateLimitBurst int
+}
+
+// initAnswers holds everything the init wizard asks for, so the configuration
+// can be assembled without any interactive prompting.
+type initAnswers struct {
+	Agents        []initAgentAnswer
+	Mode          string
+	MaxTurns      int
+	TurnTimeout   time.Duration
+	ResponseDelay time.Duration
+	InitialPrompt string
+	Logging       bool
+	LogDir        string
+	LogFormat     string
+	ShowMetrics   bool
+}
+
+// defaultInitConfigPath returns ~/.agentpipe/config.yaml.
+func defaultInitConfigPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".agentpipe", "config.yaml"), nil
+}
+
+// detectAgentTypes returns the agent types that have an adapter and whose CLI
+// is found by installed, sorted by name.
+func detectAgentTypes(installed func(command string) bool) []string {
+	var types []string
+	for _, def := range registry.GetAll() {
+		agentType := strings.ToLower(def.Name)
+		if agent.HasFactory(agentType) && installed(def.Command) {
+			types = append(types, agentType)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// allAgentTypes returns every registry agent type that has an adapter.
+func allAgentTypes() []string {
+	return detectAgentTypes(func(string) bool { return true })
+}
+
+// defaultAgentName capitalizes an agent type for display (e.g., "claude" -> "Claude").
+func defaultAgentName(agentType string) string {
+	if agentType == "" {
+		return ""
+	}
+	return strings.ToUpper(agentType[:1]) + agentType[1:]
+}
+
+// buildInitConfig assembles and validates a configuration from wizard answers.
+func buildInitConfig(answers initAnswers) (*config.Config, error) {
+	if len(answers.Agents) == 0 {
+		return nil, fmt.Errorf("at least one agent must be selected")
+	}
+
+	cfg := &config.Config{
+		Version: "1.0",
+		Agents:  make([]agent.AgentConfig, 0, len(answers.Agents)),
+	}
+
+	usedIDs := make(map[string]bool)
+	typeCounts := make(map[string]int)
+	for _, a := range answers.Agents {
+		if a.Type == "" {
+			return nil, fmt.Errorf("agent type is required")
+		}
+		typeCounts[a.Type]++
+
+		id := a.ID
+		if id == "" {
+			n := typeCounts[a.Type]
+			id = fmt.Sprintf("%s-%d", a.Type, n)
+			for usedIDs[id] {
+				n++
+				id = fmt.Sprintf("%s-%d", a.Type, n)
+			}
+		}
+		if usedIDs[id] {
+			return nil, fmt.Errorf("duplicate agent ID: %s", id)
+		}
+		usedIDs[id] = true
+
+		name := a.Name
+		if name == "" {
+			name = defaultAgentName(a.Type)
+		}
+		prompt := a.Prompt
+		if prompt == "" {
+			prompt = fmt.Sprintf("You are a helpful AI assistant powered by %s.", defaultAgentName(a.Type))
+		}
+
+		agentCfg := agent.AgentConfig{
+			ID:           id,
+			Type:         a.Type,
+			Name:         name,
+			Prompt:       prompt,
+			Announcement: a.Announcement,
+			Model:        a.Model,
+			RateLimit:    a.RateLimit,
+		}
+		if a.RateLimit > 0 {
+			agentCfg.RateLimitBurst = a.RateLimitBurst
+		}
+		cfg.Agents = append(cfg.Agents, agentCfg)
+	}
+
+	if answers.Mode != "" && !orchestrator.HasMode(answers.Mode) {
+		return nil, fmt.Errorf("unknown conversation mode %q (available: %s)",
+			answers.Mode, strings.Join(orchestrator.RegisteredModes(), ", "))
+	}
+	cfg.Orchestrator.Mode = answers.Mode
+	cfg.Orchestrator.MaxTurns = answers.MaxTurns
+	cfg.Orchestrator.TurnTimeout = answers.TurnTimeout
+	cfg.Orchestrator.ResponseDelay = answers.ResponseDelay
+	cfg.Orchestrator.InitialPrompt = answers.InitialPrompt
+
+	cfg.Logging.Enabled = answers.Logging
+	if answers.Logging {
+		cfg.Logging.ChatLogDir = answers.LogDir
+		cfg.Logging.LogFormat = answers.LogFormat
+		cfg.Logging.ShowMetrics = answers.ShowMetrics
+	}
+
+	// Apply defaults
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	return cfg, nil
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
 	outputPath, _ := cmd.Flags().GetString("output")
+	if outputPath == "" {
+		path, err := defaultInitConfigPath()
+		if err != nil {
+			return err
+		}
+		outputPath = path
+	}
 
 	reader := bufio.NewReader(os.Stdin)
 
@@ -49,10 +207,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 		fmt.Println()
 	}
 
-	cfg := &config.Config{
-		Version: "1.0",
-		Agents:  []agent.AgentConfig{},
-	}
+	answers := initAnswers{}
 
 	// Configure agents
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
@@ -60,84 +215,55 @@ func runInit(cmd *cobra.Command, args []string) error {
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Println()
 
-	availableAgents := []string{"claude", "gemini", "copilot", "cursor", "qwen", "codex"}
-	fmt.Println("Available agent types:")
+	availableAgents := detectAgentTypes(isAgentInstalled)
+	if len(availableAgents) == 0 {
+		fmt.Println("⚠️  No agent CLIs detected in PATH (run 'agentpipe agents list' to see how to install them).")
+		fmt.Println("   Showing all supported agent types instead.")
+		availableAgents = allAgentTypes()
+	} else {
+		fmt.Println("Installed agents:")
+	}
 	for i, agentType := range availableAgents {
 		fmt.Printf("  %d. %s\n", i+1, agentType)
 	}
 	fmt.Println()
 
-	for {
+	var selectedAgents []string
+	for len(selectedAgents) == 0 {
 		fmt.Printf("Select agents to configure (e.g., 1,2,4 or 'all'): ")
 		selection, _ := reader.ReadString('\n')
-		selection = strings.TrimSpace(selection)
-
-		if selection == "" {
-			fmt.Println("❌ Please select at least one agent.")
-			continue
-		}
-
-		selectedAgents := []string{}
-		if selection == "all" {
-			selectedAgents = availableAgents
-		} else {
-			indices := strings.Split(selection, ",")
-			for _, idx := range indices {
-				idx = strings.TrimSpace(idx)
-				i, err := strconv.Atoi(idx)
-				if err != nil || i < 1 || i > len(availableAgents) {
-					fmt.Printf("❌ Invalid selection: %s\n", idx)
-					continue
-				}
-				selectedAgents = append(selectedAgents, availableAgents[i-1])
-			}
-		}
-
+		selectedAgents = parseAgentSelection(strings.TrimSpace(selection), availableAgents)
 		if len(selectedAgents) == 0 {
 			fmt.Println("❌ Please select at least one agent.")
-			continue
 		}
+	}
 
-		// Configure each selected agent
-		for _, agentType := range selectedAgents {
-			fmt.Println()
-			fmt.Printf("Configuring %s agent:\n", agentType)
-			fmt.Println("  " + strings.Repeat("─", 40))
-
-			agentCfg := agent.AgentConfig{
-				Type: agentType,
-			}
-
-			// Agent ID
-			agentCfg.ID = promptString(reader, fmt.Sprintf("  Agent ID (default: %s-1)", agentType), fmt.Sprintf("%s-1", agentType))
-
-			// Agent Name
-			defaultName := strings.ToUpper(agentType[:1]) + agentType[1:]
-			agentCfg.Name = promptString(reader, fmt.Sprintf("  Agent Name (default: %s)", defaultName), defaultName)
-
-			// System Prompt
-			defaultPrompt := fmt.Sprintf("You are a helpful AI assistant powered by %s.", defaultName)
-			agentCfg.Prompt = promptString(reader, "  System Prompt (default: helpful assistant)", defaultPrompt)
-
-			// Announcement
-			agentCfg.Announcement = promptString(reader, "  Announcement (optional)", "")
-
-			// Model (optional)
-			agentCfg.Model = promptString(reader, "  Model (optional, e.g., claude-sonnet-4.5)", "")
-
-			// Rate limiting (optional)
-			if promptYesNo(reader, "  Configure rate limiting for this agent?", false) {
-				agentCfg.RateLimit = promptFloat(reader, "  Rate limit (requests per second, 0 for unlimited)", 0.0)
-				if agentCfg.RateLimit > 0 {
-					agentCfg.RateLimitBurst = promptInt(reader, "  Rate limit burst size", 1)
-				}
+	// Configure each selected agent
+	for _, agentType := range selectedAgents {
+		fmt.Println()
+		fmt.Printf("Configuring %s agent:\n", agentType)
+		fmt.Println("  " + strings.Repeat("─", 40))
+
+		a := initAgentAnswer{Type: agentType}
+		defaultID := fmt.Sprintf("%s-%d", agentType, countType(answers.Agents, agentType)+1)
+		a.ID = promptString(reader, fmt.Sprintf("  Agent ID (default: %s)", defaultID), defaultID)
+
+		defaultName := defaultAgentName(agentType)
+		a.Name = promptString(reader, fmt.Sprintf("  Agent Name (default: %s)", defaultName), defaultName)
+		a.Prompt = promptString(reader, "  System Prompt (default: helpful assistant)", "")
+		a.Announcement = promptString(reader, "  Announcement (optional)", "")
+		a.Model = promptString(reader, "  Model (optional, e.g., claude-sonnet-4.5)", "")
+
+		// Rate limiting (optional)
+		if promptYesNo(reader, "  Configure rate limiting for this agent?", false) {
+			a.RateLimit = promptFloat(reader, "  Rate limit (requests per second, 0 for unlimited)", 0.0)
+			if a.RateLimit > 0 {
+				a.RateLimitBurst = promptInt(reader, "  Rate limit burst size", 1)
 			}
-
-			cfg.Agents = append(cfg.Agents, agentCfg)
-			fmt.Printf("  ✅ Added %s\n", agentCfg.Name)
 		}
 
-		break
+		answers.Agents = append(answers.Agents, a)
+		fmt.Printf("  ✅ Added %s\n", a.Name)
 	}
 
 	// Configure orchestrator
@@ -147,21 +273,24 @@ func runInit(cmd *cobra.Command, args []string) error {
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Println()
 
+	modes := orchestrator.RegisteredModes()
 	fmt.Println("Conversation modes:")
-	fmt.Println("  1. round-robin - Agents take turns in order")
-	fmt.Println("  2. reactive    - Random agent selection (no repeats)")
-	fmt.Println("  3. free-form   - All agents can participate freely")
+	defaultMode := 1
+	for i, mode := range modes {
+		fmt.Printf("  %d. %-12s - %s\n", i+1, mode, orchestrator.ModeDescription(mode))
+		if mode == string(orchestrator.ModeRoundRobin) {
+			defaultMode = i + 1
+		}
+	}
 	fmt.Println()
 
-	modeChoice := promptChoice(reader, "Select mode", []string{"round-robin", "reactive", "free-form"}, 1)
-	cfg.Orchestrator.Mode = modeChoice
-
-	cfg.Orchestrator.MaxTurns = promptInt(reader, "Maximum turns (0 for unlimited)", 10)
-	cfg.Orchestrator.TurnTimeout = time.Duration(promptInt(reader, "Turn timeout (seconds)", 30)) * time.Second
-	cfg.Orchestrator.ResponseDelay = time.Duration(promptInt(reader, "Delay between responses (seconds)", 2)) * time.Second
+	answers.Mode = promptChoice(reader, "Select mode", modes, defaultMode)
+	answers.MaxTurns = promptInt(reader, "Maximum turns (0 for unlimited)", 10)
+	answers.TurnTimeout = time.Duration(promptInt(reader, "Turn timeout (seconds)", 30)) * time.Second
+	answers.ResponseDelay = time.Duration(promptInt(reader, "Delay between responses (seconds)", 2)) * time.Second
 
-	if promptYesNo(reader, "Add an initial prompt to start the conversation?", false) {
-		cfg.Orchestrator.InitialPrompt = promptString(reader, "Initial prompt", "")
+	if promptYesNo(reader, "Add an initial prompt to start the conversation?", true) {
+		answers.InitialPrompt = promptString(reader, "Initial prompt", "")
 	}
 
 	// Configure logging
@@ -171,22 +300,19 @@ func runInit(cmd *cobra.Command, args []string) error {
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Println()
 
-	cfg.Logging.Enabled = promptYesNo(reader, "Enable conversation logging?", true)
+	answers.Logging = promptYesNo(reader, "Enable conversation logging?", true)
 
-	if cfg.Logging.Enabled {
+	if answers.Logging {
 		homeDir, _ := os.UserHomeDir()
 		defaultLogDir := filepath.Join(homeDir, ".agentpipe", "chats")
-		cfg.Logging.ChatLogDir = promptString(reader, fmt.Sprintf("Log directory (default: %s)", defaultLogDir), defaultLogDir)
-
-		formatChoice := promptChoice(reader, "Log format", []string{"text", "json"}, 1)
-		cfg.Logging.LogFormat = formatChoice
-
-		cfg.Logging.ShowMetrics = promptYesNo(reader, "Show token/cost metrics in logs?", false)
+		answers.LogDir = promptString(reader, fmt.Sprintf("Log directory (default: %s)", defaultLogDir), defaultLogDir)
+		answers.LogFormat = promptChoice(reader, "Log format", []string{"text", "json", "markdown"}, 1)
+		answers.ShowMetrics = promptYesNo(reader, "Show token/cost metrics in logs?", false)
 	}
 
-	// Apply defaults
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("configuration validation failed: %w", err)
+	cfg, err := buildInitConfig(answers)
+	if err != nil {
+		return err
 	}
 
 	// Save configuration
@@ -224,6 +350,40 @@ func runInit(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// parseAgentSelection resolves a selection like "1,3" or "all" against the
+// listed agent types. Invalid entries are reported and skipped.
+func parseAgentSelection(selection string, availableAgents []string) []string {
+	if selection == "" {
+		return nil
+	}
+	if selection == "all" {
+		return availableAgents
+	}
+
+	var selected []string
+	for _, idx := range strings.Split(selection, ",") {
+		idx = strings.TrimSpace(idx)
+		i, err := strconv.Atoi(idx)
+		if err != nil || i < 1 || i > len(availableAgents) {
+			fmt.Printf("❌ Invalid selection: %s\n", idx)
+			continue
+		}
+		selected = append(selected, availableAgents[i-1])
+	}
+	return selected
+}
+
+// countType returns how many answers already use agentType.
+func countType(agents []initAgentAnswer, agentType string) int {
+	n := 0
+	for _, a := range agents {
+		if a.Type == agentType {
+			n++
+		}
+	}
+	return n
+}
+
 func promptString(reader *bufio.Reader, prompt, defaultValue string) string {
 	if defaultValue != "" {
 		fmt.Printf("%s: ", prompt)
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kevinelliott/agentpipe/pkg/config"
+)
+
+func TestBuildInitConfig(t *testing.T) {
+	answers := initAnswers{
+		Agents: []initAgentAnswer{
+			{Type: "claude", ID: "architect", Name: "Architect", Prompt: "You design systems.", Model: "claude-sonnet-4.5"},
+			{Type: "gemini", RateLimit: 2, RateLimitBurst: 3},
+			{Type: "gemini", Announcement: "Second Gemini here."},
+		},
+		Mode:          "reactive",
+		MaxTurns:      6,
+		TurnTimeout:   45 * time.Second,
+		ResponseDelay: time.Second,
+		InitialPrompt: "Design a URL shortener",
+		Logging:       true,
+		LogDir:        "/tmp/chats",
+		LogFormat:     "json",
+		ShowMetrics:   true,
+	}
+
+	cfg, err := buildInitConfig(answers)
+	if err != nil {
+		t.Fatalf("buildInitConfig failed: %v", err)
+	}
+
+	if len(cfg.Agents) != 3 {
+		t.Fatalf("expected 3 agents, got %d", len(cfg.Agents))
+	}
+	architect := cfg.Agents[0]
+	if architect.ID != "architect" || architect.Name != "Architect" || architect.Prompt != "You design systems." || architect.Model != "claude-sonnet-4.5" {
+		t.Errorf("expected explicit answers to be kept, got %+v", architect)
+	}
+
+	gemini := cfg.Agents[1]
+	if gemini.ID != "gemini-1" || gemini.Name != "Gemini" {
+		t.Errorf("expected default ID and name, got %q and %q", gemini.ID, gemini.Name)
+	}
+	if gemini.Prompt != "You are a helpful AI assistant powered by Gemini." {
+		t.Errorf("expected default prompt, got %q", gemini.Prompt)
+	}
+	if gemini.RateLimit != 2 || gemini.RateLimitBurst != 3 {
+		t.Errorf("expected rate limit 2/3, got %v/%d", gemini.RateLimit, gemini.RateLimitBurst)
+	}
+	if cfg.Agents[2].ID != "gemini-2" || cfg.Agents[2].Announcement != "Second Gemini here." {
+		t.Errorf("expected a second gemini agent, got %+v", cfg.Agents[2])
+	}
+
+	o := cfg.Orchestrator
+	if o.Mode != "reactive" || o.MaxTurns != 6 || o.TurnTimeout != 45*time.Second || o.ResponseDelay != time.Second || o.InitialPrompt != "Design a URL shortener" {
+		t.Errorf("unexpected orchestrator config: %+v", o)
+	}
+	if !cfg.Logging.Enabled || cfg.Logging.ChatLogDir != "/tmp/chats" || cfg.Logging.LogFormat != "json" || !cfg.Logging.ShowMetrics {
+		t.Errorf("unexpected logging config: %+v", cfg.Logging)
+	}
+}
+
+func TestBuildInitConfigRoundTripsThroughYAML(t *testing.T) {
+	cfg, err := buildInitConfig(initAnswers{
+		Agents:        []initAgentAnswer{{Type: "claude"}, {Type: "codex"}},
+		Mode:          "round-robin",
+		MaxTurns:      4,
+		InitialPrompt: "Review this design",
+	})
+	if err != nil {
+		t.Fatalf("buildInitConfig failed: %v", err)
+	}
+
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("failed to marshal config: %v", err)
+	}
+	loaded, err := config.ParseConfig(data)
+	if err != nil {
+		t.Fatalf("generated config does not load: %v\n%s", err, data)
+	}
+	if len(loaded.Agents) != 2 || loaded.Agents[1].ID != "codex-1" {
+		t.Errorf("unexpected agents after reload: %+v", loaded.Agents)
+	}
+	if loaded.Orchestrator.MaxTurns != 4 || loaded.Orchestrator.InitialPrompt != "Review this design" {
+		t.Errorf("unexpected orchestrator after reload: %+v", loaded.Orchestrator)
+	}
+}
+
+func TestBuildInitConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		answers initAnswers
+		want    string
+	}{
+		{name: "no agents", answers: initAnswers{}, want: "at least one agent"},
+		{name: "unknown mode", answers: initAnswers{Agents: []initAgentAnswer{{Type: "claude"}}, Mode: "shouting"}, want: "unknown conversation mode"},
+		{name: "duplicate IDs", answers: initAnswers{Agents: []initAgentAnswer{{Type: "claude", ID: "a"}, {Type: "gemini", ID: "a"}}}, want: "duplicate agent ID"},
+		{name: "missing type", answers: initAnswers{Agents: []initAgentAnswer{{ID: "a"}}}, want: "agent type is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildInitConfig(tt.answers)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestDetectAgentTypes(t *testing.T) {
+	installed := map[string]bool{"claude": true, "cursor-agent": true, "ollama": true}
+	got := detectAgentTypes(func(command string) bool { return installed[command] })
+
+	// Commands are mapped to adapter types; CLIs without an adapter are skipped
+	if strings.Join(got, ",") != "claude,cursor" {
+		t.Errorf("expected [claude cursor], got %v", got)
+	}
+
+	all := allAgentTypes()
+	for _, want := range []string{"claude", "gemini", "factory", "qoder", "aider"} {
+		if !strings.Contains(","+strings.Join(all, ",")+",", ","+want+",") {
+			t.Errorf("expected %q in all agent types %v", want, all)
+		}
+	}
+	if strings.Contains(strings.Join(all, ","), "ollama") {
+		t.Errorf("expected types without an adapter to be excluded, got %v", all)
+	}
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/log"
+)
+
+// jsonlOutputFormat is the --output-format value that writes every message as a
+// JSON line while the conversation runs, instead of a transcript at the end.
+const jsonlOutputFormat = "jsonl"
+
+// liveJSONL reports whether messages are written as JSON lines during the run.
+func liveJSONL() bool {
+	return outputFormat == jsonlOutputFormat
+}
+
+// quietConsole reports whether stdout is reserved for machine-readable output
+// (--json, or --output-format jsonl without --output), so the human-readable
+// progress, conversation, and summary output must be suppressed.
+func quietConsole() bool {
+	return jsonOutput || (liveJSONL() && outputFile == "")
+}
+
+// jsonlWriter writes messages to w as compact JSON objects, one per line, in
+// the same shape as the json transcript format. Metrics are included for agent
+// responses. It is safe for concurrent use.
+type jsonlWriter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func newJSONLWriter(w io.Writer) *jsonlWriter {
+	return &jsonlWriter{enc: json.NewEncoder(w)}
+}
+
+// WriteMessage writes msg as a single JSON line. It matches
+// orchestrator.MessageCallback; write errors are logged, not returned, so a
+// closed pipe never stops the conversation.
+func (j *jsonlWriter) WriteMessage(msg agent.Message) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := j.enc.Encode(msg); err != nil {
+		log.WithError(err).Warn("failed to write JSONL message")
+	}
+}
+
+// openJSONLOutput returns the destination for live JSONL output: the --output
+// file when one is given, stdout otherwise. The returned close function must be
+// called when the run ends.
+func openJSONLOutput(path string) (io.Writer, func(), error) {
+	if path == "" {
+		return os.Stdout, func() {}, nil
+	}
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, nil, fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	return f, func() { f.Close() }, nil
+}
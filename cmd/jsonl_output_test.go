@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/config"
+)
+
+// readJSONLMessages decodes one agent.Message per line.
+func readJSONLMessages(t *testing.T, data []byte) []agent.Message {
+	t.Helper()
+
+	var messages []agent.Message
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var msg agent.Message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			t.Fatalf("line %q is not a JSON message: %v", scanner.Text(), err)
+		}
+		messages = append(messages, msg)
+	}
+	return messages
+}
+
+func TestJSONLWriterWritesOneMessagePerLine(t *testing.T) {
+	var buf bytes.Buffer
+	w := newJSONLWriter(&buf)
+
+	w.WriteMessage(agent.Message{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Plan a trip\nto Lisbon"})
+	w.WriteMessage(agent.Message{
+		AgentID:   "claude",
+		AgentName: "Claude",
+		Role:      "agent",
+		Content:   "Start in Alfama",
+		Metrics:   &agent.ResponseMetrics{Duration: 1500 * time.Millisecond, TotalTokens: 42, Model: "claude-sonnet-4", Cost: 0.0012},
+	})
+
+	if lines := strings.Count(buf.String(), "\n"); lines != 2 {
+		t.Fatalf("expected 2 lines, got %d:\n%s", lines, buf.String())
+	}
+
+	messages := readJSONLMessages(t, buf.Bytes())
+	if messages[0].Content != "Plan a trip\nto Lisbon" || messages[0].Metrics != nil {
+		t.Errorf("unexpected first message: %+v", messages[0])
+	}
+	m := messages[1].Metrics
+	if m == nil || m.TotalTokens != 42 || m.Cost != 0.0012 || m.Duration != 1500*time.Millisecond || m.Model != "claude-sonnet-4" {
+		t.Errorf("expected the response metrics to round-trip, got %+v", m)
+	}
+}
+
+func TestStartConversationWritesJSONLLive(t *testing.T) {
+	agent.RegisterFactory("jsonl-test", func() agent.Agent { return &runTestAgent{response: "Start in Alfama"} })
+
+	path := filepath.Join(t.TempDir(), "live.jsonl")
+	origFile, origFormat := outputFile, outputFormat
+	outputFile, outputFormat = path, jsonlOutputFormat
+	defer func() { outputFile, outputFormat = origFile, origFormat }()
+
+	cfg := config.NewDefaultConfig()
+	cfg.Logging.Enabled = false
+	cfg.Orchestrator.Summary.Enabled = false
+	cfg.Orchestrator.MaxTurns = 1
+	cfg.Orchestrator.ResponseDelay = time.Millisecond
+	cfg.Orchestrator.InitialPrompt = "Plan a trip to Lisbon"
+	cfg.Agents = []agent.AgentConfig{{ID: "a1", Type: "jsonl-test", Name: "Alice"}}
+
+	if _, err := startConversation(runCmd, cfg, nil); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected the JSONL output file: %v", err)
+	}
+	messages := readJSONLMessages(t, data)
+
+	var prompt, response *agent.Message
+	for i := range messages {
+		switch {
+		case messages[i].Content == "Plan a trip to Lisbon":
+			prompt = &messages[i]
+		case messages[i].Role == "agent":
+			response = &messages[i]
+		}
+	}
+	if prompt == nil {
+		t.Errorf("expected the initial prompt in the output, got %+v", messages)
+	}
+	if response == nil || response.Content != "Start in Alfama" {
+		t.Fatalf("expected the agent response in the output, got %+v", messages)
+	}
+	if response.Metrics == nil {
+		t.Error("expected the response to include metrics")
+	}
+}
+
+func TestStartConversationRejectsJSONLWithJSONOnStdout(t *testing.T) {
+	origFile, origFormat, origJSON := outputFile, outputFormat, jsonOutput
+	outputFile, outputFormat, jsonOutput = "", jsonlOutputFormat, true
+	defer func() { outputFile, outputFormat, jsonOutput = origFile, origFormat, origJSON }()
+
+	_, err := startConversation(runCmd, config.NewDefaultConfig(), nil)
+	if err == nil || !strings.Contains(err.Error(), "--json") {
+		t.Errorf("expected a conflict error, got %v", err)
+	}
+}
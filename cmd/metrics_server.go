@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/log"
+	"github.com/kevinelliott/agentpipe/pkg/metrics"
+)
+
+// startMetricsServer serves Prometheus metrics on addr while a conversation
+// runs. It returns the metrics the orchestrator should record into and a
+// function that shuts the server down, or an error if addr can't be listened
+// on.
+func startMetricsServer(addr string) (*metrics.Metrics, func(), error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to serve metrics on %s: %w", addr, err)
+	}
+
+	srv := metrics.NewServer(metrics.ServerConfig{Addr: addr})
+	go func() {
+		if err := srv.Serve(l); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}()
+
+	stop := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Stop(ctx); err != nil {
+			log.WithError(err).WithField("addr", addr).Warn("failed to stop metrics server")
+		}
+	}
+
+	return srv.GetMetrics(), stop, nil
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestStartMetricsServer(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+
+	m, stop, err := startMetricsServer(addr)
+	if err != nil {
+		t.Fatalf("startMetricsServer failed: %v", err)
+	}
+	m.RecordConversationTurn("round-robin")
+
+	// The address is bound before startMetricsServer returns
+	resp, err := http.Get("http://" + addr + "/metrics")
+	if err != nil {
+		t.Fatalf("metrics server is not listening: %v", err)
+	}
+	data, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	body := string(data)
+
+	if !strings.Contains(body, `agentpipe_conversation_turns_total{mode="round-robin"} 1`) {
+		t.Errorf("expected the recorded turn in /metrics, got:\n%s", body)
+	}
+
+	stop()
+	if _, err := http.Get("http://" + addr + "/metrics"); err == nil {
+		t.Error("expected the metrics server to be stopped")
+	}
+}
+
+func TestStartMetricsServerAddressInUse(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if _, _, err := startMetricsServer(l.Addr().String()); err == nil {
+		t.Fatal("expected an error for an address that is already in use")
+	}
+}
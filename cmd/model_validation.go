@@ -45,10 +45,18 @@ var agentModelSupport = map[string]ModelSupport{
 		Supported: true,
 		Required:  false,
 	},
+	"grok": {
+		Supported: true,
+		Required:  false,
+	},
 	"groq": {
 		Supported: true,
 		Required:  false,
 	},
+	"mistral": {
+		Supported: true,
+		Required:  false,
+	},
 	"crush": {
 		Supported: true,
 		Required:  false,
@@ -73,11 +81,21 @@ var agentModelSupport = map[string]ModelSupport{
 		Supported: false,
 		Required:  false,
 	},
+	"rovodev": {
+		Supported: false,
+		Required:  false,
+	},
 	"opencode": {
 		Supported: false,
 		Required:  false,
 	},
 
+	// Exec agents run the command set in their config; there is no model flag
+	"exec": {
+		Supported: false,
+		Required:  false,
+	},
+
 	// Ollama is special - it requires model but uses different mechanism
 	"ollama": {
 		Supported: true,
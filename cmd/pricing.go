@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kevinelliott/agentpipe/pkg/utils"
+)
+
+var (
+	pricingJSONOutput bool
+	pricingFilter     string
+)
+
+var pricingCmd = &cobra.Command{
+	Use:   "pricing",
+	Short: "Show the model pricing used for cost estimates",
+	Long: `Show the model pricing AgentPipe uses to estimate costs.
+
+Built-in prices come from the provider registry (see 'agentpipe providers').
+Prices in ~/.agentpipe/pricing.yaml override or extend them.`,
+}
+
+var pricingListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the effective model-to-rate table",
+	Long: `List every model with its input and output price in USD per 1K tokens.
+
+Built-in prices are merged with overrides from ~/.agentpipe/pricing.yaml; the
+SOURCE column shows which one applies.
+
+Examples:
+  agentpipe pricing list
+  agentpipe pricing list --filter claude
+  agentpipe pricing list --json`,
+	RunE: runPricingList,
+}
+
+func init() {
+	rootCmd.AddCommand(pricingCmd)
+	pricingCmd.AddCommand(pricingListCmd)
+
+	pricingListCmd.Flags().BoolVar(&pricingJSONOutput, "json", false, "Output in JSON format")
+	pricingListCmd.Flags().StringVar(&pricingFilter, "filter", "", "Only show models whose ID contains this text")
+}
+
+func runPricingList(cmd *cobra.Command, args []string) error {
+	return writePricingList(os.Stdout, filterPricing(utils.ListPricing(), pricingFilter), pricingJSONOutput)
+}
+
+// filterPricing keeps entries whose model ID contains filter (case-insensitive).
+func filterPricing(entries []utils.PricingEntry, filter string) []utils.PricingEntry {
+	if filter == "" {
+		return entries
+	}
+
+	filter = strings.ToLower(filter)
+	filtered := make([]utils.PricingEntry, 0, len(entries))
+	for _, e := range entries {
+		if strings.Contains(strings.ToLower(e.Model), filter) {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// writePricingList renders the pricing table as a formatted table or JSON.
+func writePricingList(w io.Writer, entries []utils.PricingEntry, asJSON bool) error {
+	if asJSON {
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal pricing to JSON: %w", err)
+		}
+		fmt.Fprintln(w, string(data))
+		return nil
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No models found")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MODEL\tPROVIDER\tINPUT $/1K\tOUTPUT $/1K\tSOURCE")
+	fmt.Fprintln(tw, "-----\t--------\t----------\t-----------\t------")
+	for _, e := range entries {
+		provider := e.Provider
+		if provider == "" {
+			provider = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t$%.6f\t$%.6f\t%s\n",
+			truncate(e.Model, 45),
+			provider,
+			e.InputPer1K,
+			e.OutputPer1K,
+			e.Source,
+		)
+	}
+	tw.Flush()
+
+	fmt.Fprintf(w, "\n%d models. Override prices in ~/.agentpipe/pricing.yaml\n", len(entries))
+	return nil
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kevinelliott/agentpipe/pkg/utils"
+)
+
+func TestWritePricingListIncludesKnownModels(t *testing.T) {
+	utils.SetPricing(&utils.PricingConfig{Models: map[string]utils.ModelRate{
+		"my-custom-model": {InputPer1K: 0.5, OutputPer1K: 1.5},
+	}})
+	defer utils.SetPricing(nil)
+
+	entries := utils.ListPricing()
+
+	var buf bytes.Buffer
+	if err := writePricingList(&buf, entries, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+
+	// Claude Sonnet 4.5 is $3/$15 per 1M tokens in the embedded registry
+	for _, want := range []string{
+		"claude-sonnet-4-5-20250929", "$0.003000", "$0.015000", "built-in",
+		"my-custom-model", "$0.500000", "$1.500000", "override",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestWritePricingListJSON(t *testing.T) {
+	utils.SetPricing(nil)
+
+	var buf bytes.Buffer
+	if err := writePricingList(&buf, filterPricing(utils.ListPricing(), "CLAUDE-SONNET-4-5"), true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var entries []utils.PricingEntry
+	if err := json.Unmarshal(buf.Bytes(), &entries); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	if len(entries) == 0 {
+		t.Fatal("expected filtered entries")
+	}
+	for _, e := range entries {
+		if !strings.Contains(e.Model, "claude-sonnet-4-5") {
+			t.Errorf("unexpected model in filtered output: %s", e.Model)
+		}
+		if e.Model == "claude-sonnet-4-5-20250929" && (e.InputPer1K != 0.003 || e.OutputPer1K != 0.015) {
+			t.Errorf("unexpected rate for %s: %+v", e.Model, e.ModelRate)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/conversation"
+	"github.com/kevinelliott/agentpipe/pkg/logger"
+	"github.com/kevinelliott/agentpipe/pkg/theme"
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay <state-file>",
+	Short: "Replay a saved conversation",
+	Long: `Replay a conversation saved with --save-state without re-running it.
+
+Messages are rendered with the same formatting as a live run. Use --log-format
+to print the entries a json or markdown chat log would contain instead.
+
+By default the whole conversation is printed at once. --speed replays it with
+its original timing between messages: 1 is real time, 2 is twice as fast.
+
+Colors follow the theme saved with the conversation unless --theme is given.
+
+Examples:
+  agentpipe replay ~/.agentpipe/states/conversation-20231215-143022.json
+  agentpipe replay conversation.json --speed 4 --metrics
+  agentpipe replay conversation.json --log-format markdown > transcript.md`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReplay,
+}
+
+var (
+	replayMetrics   bool
+	replayLogFormat string
+	replaySpeed     float64
+	replayTheme     string
+)
+
+func init() {
+	rootCmd.AddCommand(replayCmd)
+
+	replayCmd.Flags().BoolVar(&replayMetrics, "metrics", false, "Show response metrics (time, tokens, cost)")
+	replayCmd.Flags().StringVar(&replayLogFormat, "log-format", logger.LogFormatText, "Output format (text, json, markdown)")
+	replayCmd.Flags().Float64Var(&replaySpeed, "speed", 0, "Replay with the original timing sped up by this factor (0: instantly)")
+	replayCmd.Flags().StringVar(&replayTheme, "theme", "", "Color theme: auto, dark or light (overrides the saved config)")
+}
+
+func runReplay(cmd *cobra.Command, args []string) error {
+	switch replayLogFormat {
+	case logger.LogFormatText, logger.LogFormatJSON, logger.LogFormatMarkdown:
+	default:
+		return fmt.Errorf("invalid log format %q (must be text, json, or markdown)", replayLogFormat)
+	}
+	if replaySpeed < 0 {
+		return fmt.Errorf("--speed must not be negative")
+	}
+
+	state, err := conversation.LoadState(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+	if len(state.Messages) == 0 {
+		return fmt.Errorf("no messages found in state file")
+	}
+
+	// Apply the color theme before anything is printed
+	palette, err := replayPalette(state, replayTheme)
+	if err != nil {
+		return err
+	}
+	logger.SetTheme(palette)
+
+	return replayConversation(cmd.Context(), os.Stdout, state, replayLogFormat, replayMetrics, replaySpeed, time.Sleep)
+}
+
+// replayPalette resolves the theme to replay state with: name if given, else
+// the theme saved in the state's config.
+func replayPalette(state *conversation.State, name string) (theme.Theme, error) {
+	if name == "" && state.Config != nil {
+		name = state.Config.Theme
+	}
+	return theme.Resolve(name)
+}
+
+// replayConversation renders the state's messages to w through a ChatLogger.
+// With a positive speed, it waits between messages for the time that originally
+// passed between them, divided by speed.
+func replayConversation(ctx context.Context, w io.Writer, state *conversation.State, logFormat string, showMetrics bool, speed float64, sleep func(time.Duration)) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	started := state.Metadata.StartedAt
+	if started.IsZero() && len(state.Messages) > 0 {
+		started = time.Unix(state.Messages[0].Timestamp, 0)
+	}
+	chatLogger := logger.NewTranscriptLogger(w, logFormat, showMetrics, started)
+
+	for i, msg := range state.Messages {
+		if i > 0 && speed > 0 {
+			if delay := replayDelay(state.Messages[i-1], msg, speed); delay > 0 {
+				sleep(delay)
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		chatLogger.LogMessage(msg)
+	}
+	return nil
+}
+
+// replayDelay returns how long to wait between prev and next at the given speed.
+func replayDelay(prev, next agent.Message, speed float64) time.Duration {
+	gap := time.Duration(next.Timestamp-prev.Timestamp) * time.Second
+	if gap <= 0 {
+		return 0
+	}
+	return time.Duration(float64(gap) / speed)
+}
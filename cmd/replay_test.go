@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/config"
+	"github.com/kevinelliott/agentpipe/pkg/conversation"
+	"github.com/kevinelliott/agentpipe/pkg/theme"
+)
+
+func saveReplayTestState(t *testing.T) string {
+	t.Helper()
+
+	start := time.Date(2025, 1, 15, 10, 30, 0, 0, time.Local)
+	messages := []agent.Message{
+		{AgentID: "host", AgentName: "HOST", Content: "Name a good test framework", Timestamp: start.Unix(), Role: "system"},
+		{AgentID: "claude", AgentName: "Claude", AgentType: "claude", Content: "The standard library's testing package.", Timestamp: start.Add(4 * time.Second).Unix(), Role: "agent",
+			Metrics: &agent.ResponseMetrics{Duration: 4 * time.Second, TotalTokens: 30, Cost: 0.0004}},
+		{AgentID: "gemini", AgentName: "Gemini", AgentType: "gemini", Content: "Agreed, with table-driven tests.", Timestamp: start.Add(10 * time.Second).Unix(), Role: "agent"},
+	}
+
+	state := conversation.NewState(messages, config.NewDefaultConfig(), start)
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := state.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func replayForTest(t *testing.T, logFormat string, showMetrics bool, speed float64) (string, []time.Duration) {
+	t.Helper()
+
+	state, err := conversation.LoadState(saveReplayTestState(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	var delays []time.Duration
+	sleep := func(d time.Duration) { delays = append(delays, d) }
+	if err := replayConversation(context.Background(), &out, state, logFormat, showMetrics, speed, sleep); err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+	return out.String(), delays
+}
+
+func TestReplayText(t *testing.T) {
+	out, delays := replayForTest(t, "text", true, 0)
+
+	for _, want := range []string{"Name a good test framework", "Claude (claude)", "(4.00s, 30 tokens, $0.000400)", "Agreed, with table-driven tests."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected replay to contain %q, got:\n%s", want, out)
+		}
+	}
+	if len(delays) != 0 {
+		t.Errorf("expected an instant replay, got delays %v", delays)
+	}
+}
+
+func TestReplayMarkdown(t *testing.T) {
+	out, _ := replayForTest(t, "markdown", false, 0)
+
+	for _, want := range []string{"# AgentPipe Chat Log", "**Started:** 2025-01-15 10:30:00", "] Claude (claude)\n\n> The standard library's testing package."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected replay to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "tokens") {
+		t.Errorf("expected no metrics without --metrics, got:\n%s", out)
+	}
+}
+
+func TestReplayJSON(t *testing.T) {
+	out, _ := replayForTest(t, "json", false, 0)
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 JSON lines, got %d:\n%s", len(lines), out)
+	}
+	var msg agent.Message
+	if err := json.Unmarshal([]byte(lines[2]), &msg); err != nil {
+		t.Fatalf("invalid JSON line: %v", err)
+	}
+	if msg.AgentName != "Gemini" {
+		t.Errorf("expected the last message from Gemini, got %s", msg.AgentName)
+	}
+}
+
+func TestReplaySpeed(t *testing.T) {
+	_, delays := replayForTest(t, "text", false, 2)
+
+	want := []time.Duration{2 * time.Second, 3 * time.Second}
+	if len(delays) != len(want) || delays[0] != want[0] || delays[1] != want[1] {
+		t.Errorf("expected delays %v at 2x speed, got %v", want, delays)
+	}
+}
+
+func TestReplayPalette(t *testing.T) {
+	t.Setenv("COLORFGBG", "")
+
+	state, err := conversation.LoadState(saveReplayTestState(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.Config.Theme = theme.LightName
+
+	tests := []struct {
+		name     string
+		flag     string
+		config   *config.Config
+		wantName string
+		wantErr  bool
+	}{
+		{"saved theme", "", state.Config, theme.LightName, false},
+		{"flag overrides saved theme", theme.DarkName, state.Config, theme.DarkName, false},
+		{"no saved config", "", nil, theme.DarkName, false},
+		{"invalid flag", "solarized", state.Config, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := *state
+			s.Config = tt.config
+
+			got, err := replayPalette(&s, tt.flag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("replayPalette(%q) error = %v, wantErr %v", tt.flag, err, tt.wantErr)
+			}
+			if got.Name != tt.wantName {
+				t.Errorf("replayPalette(%q) = %q theme, want %q", tt.flag, got.Name, tt.wantName)
+			}
+		})
+	}
+}
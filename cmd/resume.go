@@ -17,7 +17,7 @@ var resumeCmd = &cobra.Command{
 	Long: `Resume a conversation from a previously saved state file.
 
 The state file contains the conversation history, configuration, and metadata.
-You can resume the conversation and continue where you left off.
+To continue the conversation where you left off, use 'agentpipe run --resume'.
 
 Example:
   agentpipe resume ~/.agentpipe/states/conversation-20231215-143022.json
@@ -35,7 +35,7 @@ func init() {
 	rootCmd.AddCommand(resumeCmd)
 
 	resumeCmd.Flags().BoolVar(&listStates, "list", false, "List all saved conversation states")
-	resumeCmd.Flags().BoolVar(&continueConversation, "continue", false, "Continue the conversation (default: just load and display)")
+	resumeCmd.Flags().BoolVar(&continueConversation, "continue", false, "Show how to continue the conversation with 'agentpipe run --resume'")
 }
 
 func runResume(cmd *cobra.Command, args []string) {
@@ -108,19 +108,8 @@ func runResume(cmd *cobra.Command, args []string) {
 	}
 
 	if continueConversation {
-		fmt.Println("\n🚀 Continuing conversation...")
-
-		// TODO: Implement conversation continuation
-		// This would require:
-		// 1. Reinitializing agents from config
-		// 2. Loading message history into orchestrator
-		// 3. Resuming the conversation loop
-
-		fmt.Println("Note: Conversation continuation is not yet implemented.")
-		fmt.Println("For now, you can:")
-		fmt.Println("  1. View the saved state with: agentpipe resume <state-file>")
-		fmt.Println("  2. Export to different formats with: agentpipe export <state-file>")
-		fmt.Println("  3. Start a new conversation with the same config")
+		fmt.Println("\nTo continue this conversation with the same agents, run:")
+		fmt.Printf("  agentpipe run --resume %s\n", statePath)
 	}
 }
 
@@ -10,6 +10,8 @@ import (
 
 	"github.com/kevinelliott/agentpipe/internal/bridge"
 	"github.com/kevinelliott/agentpipe/internal/version"
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/config"
 	"github.com/kevinelliott/agentpipe/pkg/log"
 )
 
@@ -67,6 +69,9 @@ func Execute() {
 func init() {
 	cobra.OnInitialize(initConfig)
 
+	// Config validation accepts the agent types of the linked-in adapters
+	config.AgentTypes = agent.RegisteredTypes
+
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.agentpipe.yaml)")
 	rootCmd.PersistentFlags().Bool("verbose", false, "Enable verbose output")
 	rootCmd.Flags().BoolVarP(&showVersion, "version", "V", false, "Show version information")
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -9,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 	"syscall"
+	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -16,6 +18,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/kevinelliott/agentpipe/internal/bridge"
+	"github.com/kevinelliott/agentpipe/internal/templates"
 	"github.com/kevinelliott/agentpipe/internal/version"
 	_ "github.com/kevinelliott/agentpipe/pkg/adapters"
 	"github.com/kevinelliott/agentpipe/pkg/agent"
@@ -23,7 +26,9 @@ import (
 	"github.com/kevinelliott/agentpipe/pkg/conversation"
 	"github.com/kevinelliott/agentpipe/pkg/log"
 	"github.com/kevinelliott/agentpipe/pkg/logger"
+	"github.com/kevinelliott/agentpipe/pkg/metrics"
 	"github.com/kevinelliott/agentpipe/pkg/orchestrator"
+	"github.com/kevinelliott/agentpipe/pkg/theme"
 	"github.com/kevinelliott/agentpipe/pkg/tui"
 )
 
@@ -48,8 +53,35 @@ var (
 	noSummary          bool
 	summaryAgent       string
 	jsonOutput         bool
+	dryRun             bool
+	listModes          bool
+	outputFile         string
+	outputFormat       string
+	oneshot            bool
+	maxCost            float64
+	maxTokens          int
+	maxDuration        time.Duration
+	seed               int64
+	strictMode         bool
+	scriptFile         string
+	completionWebhook  string
+	sharedMemory       bool
+	finalSummary       bool
+	useCache           bool
+	cacheTTL           time.Duration
+	templateName       string
+	resumeFile         string
+	metricsAddr        string
+	stepMode           bool
+	themeName          string
 )
 
+// runOrchestrator starts the conversation. It is a variable so tests can
+// verify when a run reaches the orchestrator.
+var runOrchestrator = func(ctx context.Context, orch *orchestrator.Orchestrator) error {
+	return orch.Start(ctx)
+}
+
 var runCmd = &cobra.Command{
 	Use:   "run",
 	Short: "Start a conversation between AI agents",
@@ -62,12 +94,13 @@ func init() {
 	rootCmd.AddCommand(runCmd)
 
 	runCmd.Flags().StringVarP(&configPath, "config", "c", "", "Path to YAML configuration file")
+	runCmd.Flags().StringVar(&templateName, "template", "", "Start from a built-in conversation template (see 'agentpipe templates list')")
 	runCmd.Flags().StringSliceVarP(&agents, "agents", "a", []string{}, "Agents to use (e.g., claude:Assistant1,gemini:Assistant2)")
-	runCmd.Flags().StringVarP(&mode, "mode", "m", "round-robin", "Conversation mode (round-robin, reactive, free-form)")
+	runCmd.Flags().StringVarP(&mode, "mode", "m", "round-robin", "Conversation mode (round-robin, reactive, free-form, moderator; see --list-modes)")
 	runCmd.Flags().IntVar(&maxTurns, "max-turns", 10, "Maximum number of conversation turns")
 	runCmd.Flags().IntVar(&turnTimeout, "timeout", 30, "Turn timeout in seconds")
 	runCmd.Flags().IntVar(&responseDelay, "delay", 1, "Delay between responses in seconds")
-	runCmd.Flags().StringVarP(&initialPrompt, "prompt", "p", "", "Initial prompt to start the conversation")
+	runCmd.Flags().StringVarP(&initialPrompt, "prompt", "p", "", "Initial prompt to start the conversation (use - to read from stdin)")
 	runCmd.Flags().BoolVarP(&useTUI, "tui", "t", false, "Use TUI interface")
 	runCmd.Flags().Bool("skip-health-check", false, "Skip agent health checks (not recommended)")
 	runCmd.Flags().IntVar(&healthCheckTimeout, "health-check-timeout", 5, "Health check timeout in seconds")
@@ -82,6 +115,26 @@ func init() {
 	runCmd.Flags().BoolVar(&noSummary, "no-summary", false, "Disable conversation summary generation (overrides config)")
 	runCmd.Flags().StringVar(&summaryAgent, "summary-agent", "", "Agent to use for summary generation (default: gemini, overrides config)")
 	runCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output events in JSON format (JSONL)")
+	runCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Write the final transcript to this file after the run")
+	runCmd.Flags().StringVar(&outputFormat, "output-format", "text", "Transcript format for --output (text, json, markdown), or jsonl to stream messages as JSON lines to --output or stdout")
+	runCmd.Flags().Int64Var(&seed, "seed", 0, "Seed for agent selection to make conversations reproducible (0 = random)")
+	runCmd.Flags().Float64Var(&maxCost, "max-cost", 0, "Stop the conversation once agent responses have cost this much in USD (0 = no budget)")
+	runCmd.Flags().IntVar(&maxTokens, "max-tokens", 0, "Stop the conversation once agent responses have used this many tokens in total (0 = no budget)")
+	runCmd.Flags().DurationVar(&maxDuration, "max-duration", 0, "Stop the conversation once it has run this long, e.g. 10m (0 = no limit)")
+	runCmd.Flags().BoolVar(&strictMode, "strict", false, "Stop the conversation with an error if any agent fails after retries")
+	runCmd.Flags().BoolVar(&finalSummary, "final-summary", false, "Have the first agent (or orchestrator.final_summary_agent) summarize the conversation at the end")
+	runCmd.Flags().BoolVar(&sharedMemory, "shared-memory", false, "Let agents keep shared notes by writing MEMORY[key]=value lines")
+	runCmd.Flags().BoolVar(&useCache, "cache", false, "Reuse agent responses from earlier runs with the same context (stored in ~/.agentpipe/cache)")
+	runCmd.Flags().DurationVar(&cacheTTL, "cache-ttl", 0, "How long cached responses are reused with --cache (default 24h)")
+	runCmd.Flags().StringVar(&scriptFile, "script", "", "File of prompts (one per line) injected at the start of successive rounds")
+	runCmd.Flags().StringVar(&completionWebhook, "completion-webhook", "", "URL to POST a JSON run summary to when the conversation ends")
+	runCmd.Flags().BoolVar(&oneshot, "oneshot", false, "Run a single turn and print only the agent's response to stdout")
+	runCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Validate config and initialize agents without starting the conversation")
+	runCmd.Flags().BoolVar(&listModes, "list-modes", false, "List the available conversation modes and exit")
+	runCmd.Flags().StringVar(&metricsAddr, "metrics-addr", "", "Serve Prometheus metrics on this address during the run (e.g., :9090)")
+	runCmd.Flags().BoolVar(&stepMode, "step", false, "Wait for Enter before each turn after the first (q to quit)")
+	runCmd.Flags().StringVar(&themeName, "theme", "", "Color theme for the TUI and console output: auto, dark or light (overrides config)")
+	runCmd.Flags().StringVar(&resumeFile, "resume", "", "Continue a conversation saved with --save-state (uses its config unless --config, --template or --agents is given)")
 }
 
 func runConversation(cobraCmd *cobra.Command, args []string) {
@@ -94,7 +147,28 @@ func runConversation(cobraCmd *cobra.Command, args []string) {
 		stdoutEmitter = globalJSONEmitter
 	}
 
-	if configPath != "" {
+	if listModes {
+		if err := writeModes(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if templateName != "" && (configPath != "" || len(agents) > 0) {
+		fmt.Fprintf(os.Stderr, "Error: --template cannot be combined with --config or --agents\n")
+		os.Exit(1)
+	}
+
+	if templateName != "" {
+		log.WithField("template", templateName).Debug("loading configuration from template")
+		cfg, err = templates.Load(templateName)
+		if err != nil {
+			log.WithError(err).WithField("template", templateName).Error("failed to load template")
+			fmt.Fprintf(os.Stderr, "Error loading template: %v\n", err)
+			os.Exit(1)
+		}
+	} else if configPath != "" {
 		log.WithField("config_path", configPath).Debug("loading configuration from file")
 		cfg, err = config.LoadConfig(configPath)
 		if err != nil {
@@ -119,26 +193,85 @@ func runConversation(cobraCmd *cobra.Command, args []string) {
 			}
 			cfg.Agents = append(cfg.Agents, agentCfg)
 		}
+	} else if resumeFile != "" {
+		log.WithField("state_path", resumeFile).Debug("loading configuration from state file")
+		cfg, err = loadResumeConfig(resumeFile)
+		if err != nil {
+			log.WithError(err).WithField("state_path", resumeFile).Error("failed to load configuration from state file")
+			fmt.Fprintf(os.Stderr, "Error loading state: %v\n", err)
+			os.Exit(1)
+		}
 	} else {
-		log.Error("no configuration source specified (need --config or --agents)")
-		fmt.Fprintf(os.Stderr, "Error: Either --config or --agents must be specified\n")
+		log.Error("no configuration source specified (need --config, --template or --agents)")
+		fmt.Fprintf(os.Stderr, "Error: One of --config, --template, --agents or --resume must be specified\n")
 		os.Exit(1)
 	}
 
-	if mode != "" {
+	// Only explicit flags override a loaded config; the flag defaults match NewDefaultConfig
+	flags := cobraCmd.Flags()
+	if flags.Changed("mode") {
 		cfg.Orchestrator.Mode = mode
 	}
-	if maxTurns > 0 {
+	if flags.Changed("max-turns") {
 		cfg.Orchestrator.MaxTurns = maxTurns
 	}
-	if turnTimeout > 0 {
+	if flags.Changed("timeout") {
 		cfg.Orchestrator.TurnTimeout = time.Duration(turnTimeout) * time.Second
 	}
-	if responseDelay > 0 {
+	if flags.Changed("delay") {
 		cfg.Orchestrator.ResponseDelay = time.Duration(responseDelay) * time.Second
 	}
-	if initialPrompt != "" {
+	if initialPrompt == "-" {
+		if useTUI {
+			fmt.Fprintf(os.Stderr, "Error: --prompt - cannot be used with --tui (the TUI reads from stdin)\n")
+			os.Exit(1)
+		}
+		if stepMode {
+			fmt.Fprintf(os.Stderr, "Error: --prompt - cannot be used with --step (stepping reads from stdin)\n")
+			os.Exit(1)
+		}
+		prompt, err := readPrompt(os.Stdin)
+		if err != nil {
+			log.WithError(err).Error("failed to read prompt from stdin")
+			fmt.Fprintf(os.Stderr, "Error reading prompt from stdin: %v\n", err)
+			os.Exit(1)
+		}
+		cfg.Orchestrator.InitialPrompt = prompt
+	} else if initialPrompt != "" {
 		cfg.Orchestrator.InitialPrompt = initialPrompt
+	} else if cfg.Orchestrator.InitialPrompt == "" && !useTUI && !stepMode && stdinIsPiped() {
+		// No prompt anywhere else: use piped stdin if it has content
+		if prompt, err := readPrompt(os.Stdin); err == nil {
+			cfg.Orchestrator.InitialPrompt = prompt
+		}
+	}
+
+	if seed != 0 {
+		cfg.Orchestrator.Seed = seed
+	}
+	if strictMode {
+		cfg.Orchestrator.StopOnError = true
+	}
+	if sharedMemory {
+		cfg.Orchestrator.SharedMemory = true
+	}
+	if finalSummary {
+		cfg.Orchestrator.FinalSummary = true
+	}
+	if useCache {
+		cfg.Orchestrator.Cache = true
+	}
+	if cacheTTL > 0 {
+		cfg.Orchestrator.CacheTTL = cacheTTL
+	}
+	if maxCost > 0 {
+		cfg.Orchestrator.MaxCost = maxCost
+	}
+	if maxTokens > 0 {
+		cfg.Orchestrator.MaxTokens = maxTokens
+	}
+	if maxDuration > 0 {
+		cfg.Orchestrator.MaxDuration = maxDuration
 	}
 
 	// Apply CLI overrides for logging
@@ -161,10 +294,25 @@ func runConversation(cobraCmd *cobra.Command, args []string) {
 		cfg.Orchestrator.Summary.Agent = summaryAgent
 	}
 
-	if err := startConversation(cobraCmd, cfg, stdoutEmitter); err != nil {
+	// Apply the color theme before anything is drawn
+	if themeName != "" {
+		cfg.Theme = themeName
+	}
+	palette, err := theme.Resolve(cfg.Theme)
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	tui.SetTheme(palette)
+	logger.SetTheme(palette)
+
+	outcome, err := startConversation(cobraCmd, cfg, stdoutEmitter)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+	if code := outcome.exitCode(); code != exitCodeOK {
+		os.Exit(code)
+	}
 }
 
 func parseAgentSpec(spec string, index int) (agent.AgentConfig, error) {
@@ -187,10 +335,79 @@ func parseAgentSpec(spec string, index int) (agent.AgentConfig, error) {
 	}, nil
 }
 
-func startConversation(cmd *cobra.Command, cfg *config.Config, stdoutEmitter *bridge.StdoutEmitter) error {
+// validateMode checks that mode is a registered conversation mode.
+func validateMode(mode string) error {
+	if orchestrator.HasMode(mode) {
+		return nil
+	}
+	return fmt.Errorf("unknown conversation mode %q (valid modes: %s)", mode, strings.Join(orchestrator.RegisteredModes(), ", "))
+}
+
+// writeModes lists the registered conversation modes with their descriptions.
+func writeModes(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MODE\tDESCRIPTION")
+	for _, name := range orchestrator.RegisteredModes() {
+		fmt.Fprintf(tw, "%s\t%s\n", name, orchestrator.ModeDescription(name))
+	}
+	return tw.Flush()
+}
+
+func startConversation(cmd *cobra.Command, cfg *config.Config, stdoutEmitter *bridge.StdoutEmitter) (conversationOutcome, error) {
+	// Validate the mode before any agent is initialized
+	if err := validateMode(cfg.Orchestrator.Mode); err != nil {
+		return outcomeFailed, err
+	}
+
+	// Validate the transcript format up front so a typo doesn't waste a whole run
+	var transcriptFormat conversation.Format
+	if outputFile != "" && !liveJSONL() {
+		var err error
+		transcriptFormat, err = conversation.ParseFormat(outputFormat)
+		if err != nil {
+			return outcomeFailed, fmt.Errorf("invalid --output-format: %w", err)
+		}
+	}
+
+	if liveJSONL() && outputFile == "" && jsonOutput {
+		return outcomeFailed, fmt.Errorf("--output-format jsonl writes to stdout and cannot be combined with --json; use --output to write it to a file")
+	}
+
+	// Load the conversation to resume and check it was held by these agents
+	var resumeState *conversation.State
+	if resumeFile != "" {
+		if useTUI || oneshot {
+			return outcomeFailed, fmt.Errorf("--resume cannot be combined with --tui or --oneshot")
+		}
+		var err error
+		resumeState, err = conversation.LoadState(resumeFile)
+		if err != nil {
+			return outcomeFailed, err
+		}
+		if err := resumeState.CheckAgents(cfg.Agents); err != nil {
+			return outcomeFailed, err
+		}
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	var script []string
+	if scriptFile != "" {
+		var err error
+		script, err = loadScript(scriptFile)
+		if err != nil {
+			return outcomeFailed, err
+		}
+	}
+
+	if oneshot && !dryRun {
+		if err := runOneshot(cmd, cfg, os.Stdout); err != nil {
+			return outcomeFailed, err
+		}
+		return outcomeCompleted, nil
+	}
+
 	// Set up config watcher if requested
 	var configWatcher *config.ConfigWatcher
 	if watchConfig && configPath != "" {
@@ -237,118 +454,87 @@ func startConversation(cmd *cobra.Command, cfg *config.Config, stdoutEmitter *br
 		cancel()
 	}()
 
-	if useTUI {
+	if useTUI && !dryRun {
 		// Use enhanced TUI - agent initialization will happen inside TUI
 		skipHealthCheck, err := cmd.Flags().GetBool("skip-health-check")
 		if err != nil {
 			skipHealthCheck = false
 		}
-		return tui.RunEnhanced(ctx, cfg, nil, skipHealthCheck, healthCheckTimeout, configPath)
+		if metricsAddr != "" {
+			fmt.Fprintln(os.Stderr, "Warning: --metrics-addr is not supported with --tui and will be ignored")
+		}
+		if stepMode {
+			fmt.Fprintln(os.Stderr, "Warning: --step is not supported with --tui and will be ignored")
+		}
+		runStart := time.Now()
+		orch, err := tui.RunEnhanced(ctx, cfg, nil, skipHealthCheck, healthCheckTimeout, configPath)
+		outcome := outcomeCompleted
+		if err != nil {
+			outcome = outcomeFailed
+		}
+		notifyCompletionWebhook(orch, cfg, outcome, err, runStart, time.Since(runStart))
+		return outcome, err
 	}
 
 	// Non-TUI mode: initialize agents here
-	agentsList := make([]agent.Agent, 0)
-
-	verbose := viper.GetBool("verbose")
-
-	if !jsonOutput {
-		fmt.Println("🔍 Initializing agents...")
+	var progressOut io.Writer = os.Stdout
+	if quietConsole() {
+		progressOut = io.Discard
+	}
+	agentsList, err := initializeAgents(cmd, cfg, progressOut)
+	if err != nil {
+		return outcomeFailed, err
 	}
 
-	for _, agentCfg := range cfg.Agents {
-		if verbose {
-			fmt.Printf("  Creating agent %s (type: %s)...\n", agentCfg.Name, agentCfg.Type)
-		}
-
-		log.WithFields(map[string]interface{}{
-			"agent_name": agentCfg.Name,
-			"agent_type": agentCfg.Type,
-			"agent_id":   agentCfg.ID,
-		}).Debug("creating agent")
+	if len(agentsList) == 0 {
+		return outcomeFailed, fmt.Errorf("no agents configured")
+	}
 
-		a, err := agent.CreateAgent(agentCfg)
-		if err != nil {
-			log.WithError(err).WithFields(map[string]interface{}{
-				"agent_name": agentCfg.Name,
-				"agent_type": agentCfg.Type,
-			}).Error("failed to create agent")
-			return fmt.Errorf("failed to create agent %s: %w", agentCfg.Name, err)
-		}
+	if !quietConsole() {
+		fmt.Printf("✅ All %d agents initialized successfully\n\n", len(agentsList))
+	}
 
-		if !a.IsAvailable() {
-			log.WithFields(map[string]interface{}{
-				"agent_name": agentCfg.Name,
-				"agent_type": agentCfg.Type,
-			}).Error("agent CLI not available")
-			return fmt.Errorf("agent %s (type: %s) is not available - please run 'agentpipe doctor'", agentCfg.Name, agentCfg.Type)
+	if dryRun {
+		opening := orchestrator.OpeningMessages(agentsList, cfg.Orchestrator.InitialPrompt)
+		if resumeState != nil {
+			opening = resumeState.Messages
 		}
+		printDryRunReport(os.Stdout, cmd, cfg, agentsList, opening)
+		return outcomeCompleted, nil
+	}
 
-		// Perform health check unless skipped
-		skipHealthCheck, err := cmd.Flags().GetBool("skip-health-check")
+	// Expose Prometheus metrics for the duration of the run
+	var runMetrics *metrics.Metrics
+	if metricsAddr != "" {
+		var stopMetrics func()
+		runMetrics, stopMetrics, err = startMetricsServer(metricsAddr)
 		if err != nil {
-			skipHealthCheck = false
+			return outcomeFailed, err
 		}
-		if !skipHealthCheck {
-			if verbose {
-				fmt.Printf("  Checking health of %s...\n", agentCfg.Name)
-			}
-
-			timeout := time.Duration(healthCheckTimeout) * time.Second
-			if timeout == 0 {
-				timeout = 5 * time.Second
-			}
+		defer stopMetrics()
 
-			healthCtx, cancel := context.WithTimeout(context.Background(), timeout)
-			err = a.HealthCheck(healthCtx)
-			cancel()
-
-			if err != nil {
-				fmt.Printf("  ⚠️  Health check failed for %s: %v\n", agentCfg.Name, err)
-				fmt.Printf("  Troubleshooting tips:\n")
-				fmt.Printf("    - Make sure the %s CLI is properly installed and configured\n", agentCfg.Type)
-				fmt.Printf("    - Try running the CLI manually to check if it works\n")
-				fmt.Printf("    - Check if API keys or authentication is required\n")
-				fmt.Printf("    - Use --skip-health-check to bypass this check (not recommended)\n")
-				if verbose {
-					fmt.Printf("    - Full error: %v\n", err)
-				}
-				return fmt.Errorf("agent %s failed health check", agentCfg.Name)
-			}
-
-			if verbose {
-				fmt.Printf("  ✅ Agent %s is ready\n", agentCfg.Name)
-			}
-		} else if verbose {
-			fmt.Printf("  ⚠️  Skipping health check for %s\n", agentCfg.Name)
+		if !quietConsole() {
+			fmt.Printf("📈 Serving metrics on %s/metrics\n", metricsAddr)
 		}
-
-		agentsList = append(agentsList, a)
 	}
 
-	if len(agentsList) == 0 {
-		return fmt.Errorf("no agents configured")
-	}
+	verbose := viper.GetBool("verbose")
 
-	if !jsonOutput {
-		fmt.Printf("✅ All %d agents initialized successfully\n\n", len(agentsList))
-	}
+	orchConfig := orchestrator.ConfigFrom(cfg)
+	orchConfig.Script = script
 
-	orchConfig := orchestrator.OrchestratorConfig{
-		Mode:          orchestrator.ConversationMode(cfg.Orchestrator.Mode),
-		TurnTimeout:   cfg.Orchestrator.TurnTimeout,
-		MaxTurns:      cfg.Orchestrator.MaxTurns,
-		ResponseDelay: cfg.Orchestrator.ResponseDelay,
-		InitialPrompt: cfg.Orchestrator.InitialPrompt,
-		Summary:       cfg.Orchestrator.Summary,
+	// The script decides the conversation length unless --max-turns was given
+	if len(script) > 0 && !cmd.Flags().Changed("max-turns") {
+		orchConfig.MaxTurns = 0
 	}
 
 	// Create logger if enabled
 	var chatLogger *logger.ChatLogger
 	if cfg.Logging.Enabled {
 		var err error
-		// Suppress console output when --json is set
+		// Suppress console output when stdout carries JSON
 		var consoleWriter io.Writer = os.Stdout
-		if jsonOutput {
+		if quietConsole() {
 			consoleWriter = nil
 		}
 		chatLogger, err = logger.NewChatLogger(cfg.Logging.ChatLogDir, cfg.Logging.LogFormat, consoleWriter, cfg.Logging.ShowMetrics)
@@ -362,7 +548,7 @@ func startConversation(cmd *cobra.Command, cfg *config.Config, stdoutEmitter *br
 
 	// Create orchestrator with appropriate writer
 	var writer io.Writer = os.Stdout
-	if chatLogger != nil || jsonOutput {
+	if chatLogger != nil || quietConsole() {
 		writer = nil // Logger will handle console output, or suppress for JSON mode
 	}
 
@@ -370,6 +556,19 @@ func startConversation(cmd *cobra.Command, cfg *config.Config, stdoutEmitter *br
 	if chatLogger != nil {
 		orch.SetLogger(chatLogger)
 	}
+	if runMetrics != nil {
+		orch.SetMetrics(runMetrics)
+	}
+
+	// Write every message as a JSON line as soon as it is stored
+	if liveJSONL() {
+		out, closeOut, err := openJSONLOutput(outputFile)
+		if err != nil {
+			return outcomeFailed, err
+		}
+		defer closeOut()
+		orch.OnMessage(newJSONLWriter(out).WriteMessage)
+	}
 
 	// Capture command information for event tracking
 	commandInfo := buildCommandInfo(cmd, cfg)
@@ -396,18 +595,26 @@ func startConversation(cmd *cobra.Command, cfg *config.Config, stdoutEmitter *br
 					bridgeConfig.Enabled = true
 				}
 
-				emitter := bridge.NewEmitter(bridgeConfig, version.GetShortVersion())
+				sink, err := bridge.NewSink(bridgeConfig)
+				if err != nil {
+					return outcomeFailed, fmt.Errorf("failed to set up bridge: %w", err)
+				}
+				emitter := bridge.NewEmitterWithSink(bridgeConfig, sink, version.GetShortVersion())
 				orch.SetBridgeEmitter(emitter)
 
 				if verbose {
-					fmt.Printf("🌐 Streaming enabled (conversation ID: %s)\n", emitter.GetConversationID())
+					if fileSink, ok := sink.(*bridge.FileSink); ok {
+						fmt.Printf("🌐 Streaming enabled to %s (conversation ID: %s)\n", fileSink.Path(), emitter.GetConversationID())
+					} else {
+						fmt.Printf("🌐 Streaming enabled (conversation ID: %s)\n", emitter.GetConversationID())
+					}
 				}
 			}
 		}
 	}
 
 	// Only show UI elements when not in JSON output mode
-	if !jsonOutput {
+	if !quietConsole() {
 		fmt.Println("🚀 Starting AgentPipe conversation...")
 		fmt.Printf("Mode: %s | Max turns: %d | Agents: %d\n", cfg.Orchestrator.Mode, cfg.Orchestrator.MaxTurns, len(agentsList))
 		if !cfg.Logging.Enabled {
@@ -427,8 +634,33 @@ func startConversation(cmd *cobra.Command, cfg *config.Config, stdoutEmitter *br
 	for _, a := range agentsList {
 		orch.AddAgent(a)
 	}
+	if resumeState != nil {
+		orch.LoadMessages(resumeState.Messages)
+	}
+	if stepMode {
+		orch.SetBeforeTurn(newStepper(ctx, os.Stdin, os.Stderr))
+	}
+
+	// Print a progress summary on SIGUSR1 without interrupting the conversation
+	if !quietConsole() {
+		summaryChan := make(chan os.Signal, 1)
+		notifySummarySignal(summaryChan)
+		defer signal.Stop(summaryChan)
+		go func() {
+			for {
+				select {
+				case <-summaryChan:
+					printProgressSummary(os.Stdout, orch)
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
 
-	err := orch.Start(ctx)
+	runStart := time.Now()
+	err = runOrchestrator(ctx, orch)
+	runDuration := time.Since(runStart)
 
 	if err != nil {
 		log.WithError(err).Error("orchestrator error during conversation")
@@ -436,8 +668,15 @@ func startConversation(cmd *cobra.Command, cfg *config.Config, stdoutEmitter *br
 		log.Info("conversation completed successfully")
 	}
 
+	// A run that returned cleanly but produced no agent responses made no progress
+	runErr := err
+	if runErr == nil {
+		runErr = orch.CheckProgress()
+	}
+	outcome := classifyOutcome(gracefulShutdown, runErr, orch.FailedResponses())
+
 	// Only print UI summary when not in JSON mode
-	if !jsonOutput {
+	if !quietConsole() {
 		fmt.Println("\n" + strings.Repeat("=", 60))
 	}
 
@@ -449,25 +688,398 @@ func startConversation(cmd *cobra.Command, cfg *config.Config, stdoutEmitter *br
 		}
 	}
 
+	// Write the transcript if requested (live JSONL output was written during the run)
+	if outputFile != "" && !liveJSONL() {
+		if writeErr := writeTranscript(outputFile, orch.GetMessages(), transcriptFormat); writeErr != nil {
+			log.WithError(writeErr).WithField("path", outputFile).Error("failed to write transcript")
+			fmt.Fprintf(os.Stderr, "Warning: Failed to write transcript: %v\n", writeErr)
+		} else if !quietConsole() {
+			fmt.Printf("\n📄 Transcript written to: %s\n", outputFile)
+		}
+	}
+
 	// Only print session summary when not in JSON output mode
-	if !jsonOutput {
+	if !quietConsole() {
 		// Always print session summary (whether interrupted or completed normally)
-		if gracefulShutdown {
+		switch outcome {
+		case outcomeInterrupted:
 			fmt.Println("📊 Session Summary (Interrupted)")
-		} else if err != nil {
+		case outcomeNoProgress:
+			fmt.Println("📊 Session Summary (No Progress)")
+		case outcomeCompletedWithErrors:
 			fmt.Println("📊 Session Summary (Ended with Error)")
-		} else {
+		default:
 			fmt.Println("📊 Session Summary (Completed)")
 		}
 		fmt.Println(strings.Repeat("=", 60))
 		printSessionSummary(orch, cfg)
 	}
 
+	// Notify the completion webhook; failures never change the exit code
+	notifyCompletionWebhook(orch, cfg, outcome, runErr, runStart, runDuration)
+
+	switch {
+	case outcome == outcomeInterrupted:
+		return outcome, nil
+	case err != nil:
+		return outcome, fmt.Errorf("orchestrator error: %w", err)
+	case runErr != nil:
+		return outcome, runErr
+	}
+
+	return outcome, nil
+}
+
+// conversationOutcome describes how a run ended. It determines the exit code.
+type conversationOutcome int
+
+const (
+	// outcomeFailed means the run could not be set up (bad config, agent init failure)
+	outcomeFailed conversationOutcome = iota
+	// outcomeCompleted means every turn succeeded
+	outcomeCompleted
+	// outcomeCompletedWithErrors means the conversation ran but some turns failed,
+	// or the orchestrator stopped with an error
+	outcomeCompletedWithErrors
+	// outcomeNoProgress means no agent produced a response
+	outcomeNoProgress
+	// outcomeInterrupted means the user stopped the run (Ctrl+C / SIGTERM)
+	outcomeInterrupted
+)
+
+// Process exit codes for agentpipe run.
+const (
+	exitCodeOK                  = 0
+	exitCodeFailed              = 1
+	exitCodeCompletedWithErrors = 2
+	exitCodeNoProgress          = 3
+	exitCodeInterrupted         = 130
+)
+
+// exitCode maps an outcome to the process exit code.
+func (o conversationOutcome) exitCode() int {
+	switch o {
+	case outcomeCompleted:
+		return exitCodeOK
+	case outcomeCompletedWithErrors:
+		return exitCodeCompletedWithErrors
+	case outcomeNoProgress:
+		return exitCodeNoProgress
+	case outcomeInterrupted:
+		return exitCodeInterrupted
+	default:
+		return exitCodeFailed
+	}
+}
+
+// String returns the outcome name used in completion webhooks.
+func (o conversationOutcome) String() string {
+	switch o {
+	case outcomeCompleted:
+		return "completed"
+	case outcomeCompletedWithErrors:
+		return "completed_with_errors"
+	case outcomeNoProgress:
+		return "no_progress"
+	case outcomeInterrupted:
+		return "interrupted"
+	default:
+		return "failed"
+	}
+}
+
+// classifyOutcome determines how a conversation ended from the orchestrator's
+// result. Interruption wins over errors, since canceling surfaces as an error too.
+func classifyOutcome(interrupted bool, runErr error, failedResponses int) conversationOutcome {
+	switch {
+	case interrupted || errors.Is(runErr, context.Canceled):
+		return outcomeInterrupted
+	case errors.Is(runErr, orchestrator.ErrNoProgress):
+		return outcomeNoProgress
+	case runErr != nil || failedResponses > 0:
+		return outcomeCompletedWithErrors
+	default:
+		return outcomeCompleted
+	}
+}
+
+// loadScript reads a prompt script: every non-empty line is one prompt.
+func loadScript(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return fmt.Errorf("orchestrator error: %w", err)
+		return nil, fmt.Errorf("failed to read script: %w", err)
 	}
 
-	return nil
+	var prompts []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			prompts = append(prompts, line)
+		}
+	}
+
+	if len(prompts) == 0 {
+		return nil, fmt.Errorf("script %s contains no prompts", path)
+	}
+	return prompts, nil
+}
+
+// stdinIsPiped reports whether stdin is a pipe or a regular file, which is
+// when reading it to EOF is safe. Terminals, sockets and devices may never
+// reach EOF, so they are not read.
+func stdinIsPiped() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return isPipeOrFile(info.Mode())
+}
+
+// isPipeOrFile reports whether mode is a named pipe or a regular file.
+func isPipeOrFile(mode os.FileMode) bool {
+	return mode&os.ModeNamedPipe != 0 || mode.IsRegular()
+}
+
+// readPrompt reads an initial prompt from r until EOF.
+// Surrounding whitespace is trimmed; an empty prompt is an error.
+func readPrompt(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to read prompt: %w", err)
+	}
+
+	prompt := strings.TrimSpace(string(data))
+	if prompt == "" {
+		return "", fmt.Errorf("prompt is empty")
+	}
+	return prompt, nil
+}
+
+// runOneshot sends the initial prompt to a single agent and writes only its
+// response to w. Progress and diagnostics go to stderr so w stays clean for piping.
+func runOneshot(cmd *cobra.Command, cfg *config.Config, w io.Writer) error {
+	if useTUI {
+		return fmt.Errorf("--oneshot cannot be used with --tui")
+	}
+	if len(cfg.Agents) != 1 {
+		return fmt.Errorf("--oneshot requires exactly one agent (got %d)", len(cfg.Agents))
+	}
+	prompt := strings.TrimSpace(cfg.Orchestrator.InitialPrompt)
+	if prompt == "" {
+		return fmt.Errorf("--oneshot requires an initial prompt (use --prompt)")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	agentsList, err := initializeAgents(cmd, cfg, os.Stderr)
+	if err != nil {
+		return err
+	}
+	a := agentsList[0]
+
+	timeout := cfg.Orchestrator.TurnTimeout
+	if cfg.Agents[0].Timeout > 0 {
+		timeout = cfg.Agents[0].Timeout
+	}
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	turnCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	messages := []agent.Message{
+		{
+			AgentID:   "host",
+			AgentName: "HOST",
+			Content:   prompt,
+			Timestamp: time.Now().Unix(),
+			Role:      agent.RoleSystem,
+		},
+	}
+
+	log.WithFields(map[string]interface{}{
+		"agent_name": a.GetName(),
+		"agent_type": a.GetType(),
+	}).Info("running oneshot turn")
+
+	response, err := a.SendMessage(turnCtx, messages)
+	if err != nil {
+		return fmt.Errorf("agent %s failed: %w", a.GetName(), err)
+	}
+	response = strings.TrimSpace(response)
+	if response == "" {
+		return fmt.Errorf("agent %s returned an empty response", a.GetName())
+	}
+
+	_, err = fmt.Fprintln(w, response)
+	return err
+}
+
+// initializeAgents creates every configured agent, verifies its CLI is available,
+// and runs health checks unless --skip-health-check is set. Progress is written to out.
+func initializeAgents(cmd *cobra.Command, cfg *config.Config, out io.Writer) ([]agent.Agent, error) {
+	agentsList := make([]agent.Agent, 0)
+
+	verbose := viper.GetBool("verbose")
+
+	if !jsonOutput {
+		fmt.Fprintln(out, "🔍 Initializing agents...")
+	}
+
+	for _, agentCfg := range cfg.Agents {
+		if verbose {
+			fmt.Fprintf(out, "  Creating agent %s (type: %s)...\n", agentCfg.Name, agentCfg.Type)
+		}
+
+		log.WithFields(map[string]interface{}{
+			"agent_name": agentCfg.Name,
+			"agent_type": agentCfg.Type,
+			"agent_id":   agentCfg.ID,
+		}).Debug("creating agent")
+
+		a, err := agent.CreateAgent(agentCfg)
+		if err != nil {
+			log.WithError(err).WithFields(map[string]interface{}{
+				"agent_name": agentCfg.Name,
+				"agent_type": agentCfg.Type,
+			}).Error("failed to create agent")
+			return nil, fmt.Errorf("failed to create agent %s: %w", agentCfg.Name, err)
+		}
+
+		if !a.IsAvailable() {
+			log.WithFields(map[string]interface{}{
+				"agent_name": agentCfg.Name,
+				"agent_type": agentCfg.Type,
+			}).Error("agent CLI not available")
+			return nil, fmt.Errorf("agent %s (type: %s) is not available - please run 'agentpipe doctor'", agentCfg.Name, agentCfg.Type)
+		}
+
+		// Perform health check unless skipped
+		skipHealthCheck, err := cmd.Flags().GetBool("skip-health-check")
+		if err != nil {
+			skipHealthCheck = false
+		}
+		if !skipHealthCheck {
+			if verbose {
+				fmt.Fprintf(out, "  Checking health of %s...\n", agentCfg.Name)
+			}
+
+			timeout := time.Duration(healthCheckTimeout) * time.Second
+			if timeout == 0 {
+				timeout = 5 * time.Second
+			}
+
+			healthCtx, cancel := context.WithTimeout(context.Background(), timeout)
+			err = a.HealthCheck(healthCtx)
+			cancel()
+
+			if err != nil {
+				fmt.Fprintf(out, "  ⚠️  Health check failed for %s: %v\n", agentCfg.Name, err)
+				fmt.Fprintf(out, "  Troubleshooting tips:\n")
+				fmt.Fprintf(out, "    - Make sure the %s CLI is properly installed and configured\n", agentCfg.Type)
+				fmt.Fprintf(out, "    - Try running the CLI manually to check if it works\n")
+				fmt.Fprintf(out, "    - Check if API keys or authentication is required\n")
+				fmt.Fprintf(out, "    - Use --skip-health-check to bypass this check (not recommended)\n")
+				if verbose {
+					fmt.Fprintf(out, "    - Full error: %v\n", err)
+				}
+				return nil, fmt.Errorf("agent %s failed health check", agentCfg.Name)
+			}
+
+			if verbose {
+				fmt.Fprintf(out, "  ✅ Agent %s is ready\n", agentCfg.Name)
+			}
+		} else if verbose {
+			fmt.Fprintf(out, "  ⚠️  Skipping health check for %s\n", agentCfg.Name)
+		}
+
+		// A dry run must not send the agent any messages
+		if agentCfg.Warmup && !dryRun {
+			warmUpAgent(a, out)
+		}
+
+		agentsList = append(agentsList, a)
+	}
+
+	return agentsList, nil
+}
+
+// printDryRunReport describes the agents a run would use without starting it,
+// followed by the prompt each agent would be sent for the opening messages.
+func printDryRunReport(w io.Writer, cmd *cobra.Command, cfg *config.Config, agentsList []agent.Agent, opening []agent.Message) {
+	skipHealthCheck, err := cmd.Flags().GetBool("skip-health-check")
+	if err != nil {
+		skipHealthCheck = false
+	}
+	health := "passed"
+	if skipHealthCheck {
+		health = "skipped"
+	}
+
+	fmt.Fprintln(w, "🧪 Dry run: configuration is valid and all agents are ready")
+	fmt.Fprintf(w, "Mode: %s | Max turns: %d | Agents: %d\n\n", cfg.Orchestrator.Mode, cfg.Orchestrator.MaxTurns, len(agentsList))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "AGENT\tTYPE\tMODEL\tCLI VERSION\tHEALTH")
+	fmt.Fprintln(tw, "-----\t----\t-----\t-----------\t------")
+	for _, a := range agentsList {
+		model := a.GetModel()
+		if model == "" {
+			model = "-"
+		}
+		cliVersion := a.GetCLIVersion()
+		if cliVersion == "" {
+			cliVersion = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", a.GetName(), a.GetType(), model, cliVersion, health)
+	}
+	tw.Flush()
+
+	for _, a := range agentsList {
+		fmt.Fprintf(w, "\n📝 First prompt for %s (%s)\n", a.GetName(), a.GetType())
+		fmt.Fprintln(w, strings.Repeat("-", 60))
+		if p, ok := a.(agent.PromptPreviewer); ok {
+			fmt.Fprintln(w, strings.TrimRight(p.PreviewPrompt(opening), "\n"))
+		} else {
+			fmt.Fprintf(w, "(prompt preview is not available for %s agents)\n", a.GetType())
+		}
+		fmt.Fprintln(w, strings.Repeat("-", 60))
+	}
+
+	fmt.Fprintln(w, "\nNo conversation was started (--dry-run)")
+}
+
+// writeTranscript renders messages in the given format and writes them to path.
+func writeTranscript(path string, messages []agent.Message, format conversation.Format) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	if err := conversation.Render(f, messages, format); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to render transcript: %w", err)
+	}
+
+	return f.Close()
+}
+
+// loadResumeConfig returns the config a saved conversation was run with.
+func loadResumeConfig(path string) (*config.Config, error) {
+	state, err := conversation.LoadState(path)
+	if err != nil {
+		return nil, err
+	}
+	if state.Config == nil {
+		return nil, fmt.Errorf("state file %s has no saved config; use --config or --agents", path)
+	}
+	return state.Config, nil
 }
 
 // saveConversationState saves the current conversation state to a file.
@@ -511,65 +1123,70 @@ func saveConversationState(orch *orchestrator.Orchestrator, cfg *config.Config,
 
 // printSessionSummary prints a summary of the conversation session
 func printSessionSummary(orch *orchestrator.Orchestrator, cfg *config.Config) {
-	messages := orch.GetMessages()
+	writeSessionStats(os.Stdout, orch)
 
-	// Calculate statistics
-	totalMessages := 0
-	agentMessages := 0
-	systemMessages := 0
-	totalCost := 0.0
-	totalTime := time.Duration(0)
-	totalTokens := 0
-
-	for _, msg := range messages {
-		totalMessages++
-
-		if msg.Role == "agent" {
-			agentMessages++
-			if msg.Metrics != nil {
-				if msg.Metrics.Cost > 0 {
-					totalCost += msg.Metrics.Cost
-				}
-				if msg.Metrics.Duration > 0 {
-					totalTime += msg.Metrics.Duration
-				}
-				if msg.Metrics.TotalTokens > 0 {
-					totalTokens += msg.Metrics.TotalTokens
-				}
-			}
-		} else if msg.Role == "system" {
-			systemMessages++
-		}
-	}
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Println("Session ended. All messages logged.")
+}
+
+// printProgressSummary writes a summary of a conversation that is still running.
+func printProgressSummary(w io.Writer, orch *orchestrator.Orchestrator) {
+	fmt.Fprintln(w, "\n"+strings.Repeat("=", 60))
+	fmt.Fprintln(w, "📊 Session Summary (In Progress)")
+	fmt.Fprintln(w, strings.Repeat("=", 60))
+	writeSessionStats(w, orch)
+	fmt.Fprintln(w, strings.Repeat("=", 60))
+}
+
+// writeSessionStats writes message, token, time, and cost totals plus the
+// per-agent breakdown. It only reads a snapshot of the orchestrator's messages,
+// so it is safe to call while a conversation is running.
+func writeSessionStats(w io.Writer, orch *orchestrator.Orchestrator) {
+	stats := orch.GetStats()
 
 	// Display summary
-	fmt.Printf("Total Messages:      %d\n", totalMessages)
-	fmt.Printf("  Agent Messages:    %d\n", agentMessages)
-	fmt.Printf("  System Messages:   %d\n", systemMessages)
+	fmt.Fprintf(w, "Total Messages:      %d\n", stats.TotalMessages)
+	fmt.Fprintf(w, "  Agent Messages:    %d\n", stats.AgentMessages)
+	fmt.Fprintf(w, "  System Messages:   %d\n", stats.SystemMessages)
 
-	if totalTokens > 0 {
-		fmt.Printf("Total Tokens:        %d\n", totalTokens)
+	if stats.TotalTokens > 0 {
+		fmt.Fprintf(w, "Total Tokens:        %d\n", stats.TotalTokens)
 	}
 
-	// Format time
-	if totalTime > 0 {
-		if totalTime < time.Second {
-			fmt.Printf("Total Time:          %dms\n", totalTime.Milliseconds())
-		} else if totalTime < time.Minute {
-			fmt.Printf("Total Time:          %.1fs\n", totalTime.Seconds())
-		} else {
-			minutes := int(totalTime.Minutes())
-			seconds := int(totalTime.Seconds()) % 60
-			fmt.Printf("Total Time:          %dm%ds\n", minutes, seconds)
-		}
+	if stats.TotalDuration > 0 {
+		fmt.Fprintf(w, "Total Time:          %s\n", formatSessionDuration(stats.TotalDuration))
 	}
 
-	if totalCost > 0 {
-		fmt.Printf("Total Cost:          $%.4f\n", totalCost)
+	if stats.TotalCost > 0 {
+		fmt.Fprintf(w, "Total Cost:          $%.4f\n", stats.TotalCost)
 	}
 
-	fmt.Println(strings.Repeat("=", 60))
-	fmt.Println("Session ended. All messages logged.")
+	if len(stats.PerAgent) > 0 {
+		fmt.Fprintln(w, "\nPer-Agent Breakdown:")
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "  AGENT\tMESSAGES\tTOKENS\tCOST\tAVG TIME")
+		for _, s := range stats.PerAgent {
+			avg := "-"
+			if s.AvgDuration > 0 {
+				avg = formatSessionDuration(s.AvgDuration)
+			}
+			fmt.Fprintf(tw, "  %s\t%d\t%d\t$%.4f\t%s\n", s.AgentName, s.Messages, s.Tokens, s.Cost, avg)
+		}
+		tw.Flush()
+	}
+}
+
+// formatSessionDuration formats d as milliseconds, seconds, or minutes and
+// seconds, whichever reads best.
+func formatSessionDuration(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	case d < time.Minute:
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	default:
+		return fmt.Sprintf("%dm%ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
 }
 
 // determineShouldStream determines if streaming should be enabled based on CLI flags.
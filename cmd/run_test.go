@@ -1,9 +1,22 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/config"
+	"github.com/kevinelliott/agentpipe/pkg/conversation"
+	"github.com/kevinelliott/agentpipe/pkg/orchestrator"
 )
 
 func TestParseAgentSpec(t *testing.T) {
@@ -494,6 +507,644 @@ func TestValidateModelForAgent(t *testing.T) {
 	}
 }
 
+func TestWriteSessionStats(t *testing.T) {
+	orch := orchestrator.NewOrchestrator(orchestrator.OrchestratorConfig{}, nil)
+	orch.LoadMessages([]agent.Message{
+		{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Topic"},
+		{AgentID: "a", AgentName: "Alice", Role: "agent", Metrics: &agent.ResponseMetrics{TotalTokens: 100, Cost: 0.01, Duration: 400 * time.Millisecond}},
+		{AgentID: "b", AgentName: "Bob", Role: "agent", Metrics: &agent.ResponseMetrics{TotalTokens: 300, Cost: 0.05, Duration: time.Second}},
+		{AgentID: "a", AgentName: "Alice", Role: "agent", Metrics: &agent.ResponseMetrics{TotalTokens: 150, Cost: 0.02, Duration: 600 * time.Millisecond}},
+	})
+
+	var buf strings.Builder
+	writeSessionStats(&buf, orch)
+	out := buf.String()
+
+	for _, want := range []string{
+		"Total Messages:      4",
+		"Agent Messages:    3",
+		"System Messages:   1",
+		"Total Tokens:        550",
+		"Total Time:          2.0s",
+		"Total Cost:          $0.0800",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in summary, got:\n%s", want, out)
+		}
+	}
+
+	// The breakdown lists the most expensive agent first
+	if bob, alice := strings.Index(out, "Bob"), strings.Index(out, "Alice"); bob < 0 || alice < 0 || bob > alice {
+		t.Errorf("expected Bob before Alice in the per-agent breakdown, got:\n%s", out)
+	}
+}
+
+func TestWriteSessionStatsPerAgentTable(t *testing.T) {
+	orch := orchestrator.NewOrchestrator(orchestrator.OrchestratorConfig{}, nil)
+	orch.LoadMessages([]agent.Message{
+		{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Topic"},
+		{AgentID: "claude", AgentName: "Claude", Role: "agent", Metrics: &agent.ResponseMetrics{TotalTokens: 120, Cost: 0.0030, Duration: 2 * time.Second}},
+		{AgentID: "gemini", AgentName: "Gemini", Role: "agent", Metrics: &agent.ResponseMetrics{TotalTokens: 80, Cost: 0.0010, Duration: 500 * time.Millisecond}},
+		{AgentID: "claude", AgentName: "Claude", Role: "agent", Metrics: &agent.ResponseMetrics{TotalTokens: 180, Cost: 0.0050, Duration: 4 * time.Second}},
+		{AgentID: "gemini", AgentName: "Gemini", Role: "agent", Metrics: &agent.ResponseMetrics{TotalTokens: 40, Cost: 0.0005, Duration: 700 * time.Millisecond}},
+	})
+
+	var buf strings.Builder
+	writeSessionStats(&buf, orch)
+	out := buf.String()
+
+	rows := make(map[string][]string)
+	var order []string
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 5 && (fields[0] == "Claude" || fields[0] == "Gemini") {
+			rows[fields[0]] = fields[1:]
+			order = append(order, fields[0])
+		}
+	}
+
+	want := map[string][]string{
+		"Claude": {"2", "300", "$0.0080", "3.0s"},
+		"Gemini": {"2", "120", "$0.0015", "600ms"},
+	}
+	for name, cells := range want {
+		if got := strings.Join(rows[name], " "); got != strings.Join(cells, " ") {
+			t.Errorf("%s row: expected %v, got %v\n%s", name, cells, rows[name], out)
+		}
+	}
+	if strings.Join(order, ",") != "Claude,Gemini" {
+		t.Errorf("expected agents sorted by cost, got %v", order)
+	}
+	if !strings.Contains(out, "AVG TIME") || !strings.Contains(out, "Total Cost:          $0.0095") {
+		t.Errorf("expected the average time column and the totals, got:\n%s", out)
+	}
+}
+
+// runTestAgent is a minimal available agent for exercising startConversation.
+type runTestAgent struct {
+	agent.BaseAgent
+	sent     int
+	received []agent.Message
+	response string
+	err      error
+}
+
+func (a *runTestAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	a.sent++
+	a.received = messages
+	if a.err != nil {
+		return "", a.err
+	}
+	return a.response, nil
+}
+
+func (a *runTestAgent) StreamMessage(ctx context.Context, messages []agent.Message, writer io.Writer) error {
+	a.sent++
+	return nil
+}
+
+func (a *runTestAgent) IsAvailable() bool                     { return true }
+func (a *runTestAgent) HealthCheck(ctx context.Context) error { return nil }
+func (a *runTestAgent) GetCLIVersion() string                 { return "1.0.0" }
+
+func TestStartConversationDryRunDoesNotStart(t *testing.T) {
+	var created []*runTestAgent
+	agent.RegisterFactory("dry-run-test", func() agent.Agent {
+		a := &runTestAgent{}
+		created = append(created, a)
+		return a
+	})
+
+	started := false
+	origRun := runOrchestrator
+	runOrchestrator = func(ctx context.Context, orch *orchestrator.Orchestrator) error {
+		started = true
+		return nil
+	}
+	origDryRun := dryRun
+	dryRun = true
+	defer func() {
+		runOrchestrator = origRun
+		dryRun = origDryRun
+	}()
+
+	cfg := config.NewDefaultConfig()
+	cfg.Logging.Enabled = false
+	cfg.Agents = []agent.AgentConfig{
+		{ID: "a1", Type: "dry-run-test", Name: "Alice"},
+		{ID: "a2", Type: "dry-run-test", Name: "Bob"},
+	}
+
+	outcome, err := startConversation(runCmd, cfg, nil)
+	if err != nil {
+		t.Fatalf("dry run returned error: %v", err)
+	}
+	if started {
+		t.Error("dry run should not start the orchestrator")
+	}
+	if outcome.exitCode() != exitCodeOK {
+		t.Errorf("expected exit code %d for a successful dry run, got %d", exitCodeOK, outcome.exitCode())
+	}
+	if len(created) != 2 {
+		t.Errorf("expected 2 agents to be initialized, got %d", len(created))
+	}
+	for _, a := range created {
+		if a.sent != 0 {
+			t.Errorf("agent %s should not receive messages during a dry run", a.GetName())
+		}
+	}
+}
+
+// previewTestAgent is a runTestAgent that can preview its prompt.
+type previewTestAgent struct {
+	runTestAgent
+}
+
+func (a *previewTestAgent) PreviewPrompt(messages []agent.Message) string {
+	contents := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		contents = append(contents, msg.Content)
+	}
+	return a.Name + " sees: " + strings.Join(contents, " | ")
+}
+
+func TestPrintDryRunReportShowsFirstPrompts(t *testing.T) {
+	previewer := &previewTestAgent{}
+	plain := &runTestAgent{}
+	if err := previewer.Initialize(agent.AgentConfig{ID: "a1", Type: "preview-test", Name: "Alice", Announcement: "Alice is here"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := plain.Initialize(agent.AgentConfig{ID: "a2", Type: "plain-test", Name: "Bob", Announcement: "Bob is here"}); err != nil {
+		t.Fatal(err)
+	}
+	agentsList := []agent.Agent{previewer, plain}
+
+	cfg := config.NewDefaultConfig()
+	cfg.Orchestrator.InitialPrompt = "Plan a trip to Lisbon"
+
+	var buf bytes.Buffer
+	printDryRunReport(&buf, runCmd, cfg, agentsList, orchestrator.OpeningMessages(agentsList, cfg.Orchestrator.InitialPrompt))
+	out := buf.String()
+
+	for _, want := range []string{
+		"First prompt for Alice (preview-test)",
+		"Alice sees: Alice is here | Bob is here | Plan a trip to Lisbon",
+		"(prompt preview is not available for plain-test agents)",
+		"No conversation was started (--dry-run)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the report, got:\n%s", want, out)
+		}
+	}
+	if previewer.sent != 0 || plain.sent != 0 {
+		t.Error("a dry run should never send messages")
+	}
+}
+
+func TestStartConversationDryRunReportsInitErrors(t *testing.T) {
+	origDryRun := dryRun
+	dryRun = true
+	defer func() { dryRun = origDryRun }()
+
+	cfg := config.NewDefaultConfig()
+	cfg.Agents = []agent.AgentConfig{{ID: "x", Type: "no-such-agent", Name: "Ghost"}}
+
+	outcome, err := startConversation(runCmd, cfg, nil)
+	if err == nil {
+		t.Error("expected dry run to fail for an unknown agent type")
+	}
+	if outcome.exitCode() != exitCodeFailed {
+		t.Errorf("expected exit code %d, got %d", exitCodeFailed, outcome.exitCode())
+	}
+}
+
+func TestStartConversationRejectsInvalidModeBeforeInit(t *testing.T) {
+	created := 0
+	agent.RegisterFactory("mode-test", func() agent.Agent {
+		created++
+		return &runTestAgent{}
+	})
+
+	cfg := config.NewDefaultConfig()
+	cfg.Logging.Enabled = false
+	cfg.Orchestrator.Mode = "round-robbin"
+	cfg.Agents = []agent.AgentConfig{{ID: "a1", Type: "mode-test", Name: "Alice"}}
+
+	outcome, err := startConversation(runCmd, cfg, nil)
+	if err == nil || !strings.Contains(err.Error(), `unknown conversation mode "round-robbin"`) {
+		t.Fatalf("expected unknown mode error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "round-robin, ") && !strings.Contains(err.Error(), ", round-robin") {
+		t.Errorf("expected the valid modes to be listed, got %v", err)
+	}
+	if created != 0 {
+		t.Errorf("expected no agents to be initialized, got %d", created)
+	}
+	if outcome.exitCode() != exitCodeFailed {
+		t.Errorf("expected exit code %d, got %d", exitCodeFailed, outcome.exitCode())
+	}
+}
+
+func TestStartConversationResume(t *testing.T) {
+	agent.RegisterFactory("resume-test", func() agent.Agent { return &runTestAgent{} })
+
+	cfg := config.NewDefaultConfig()
+	cfg.Logging.Enabled = false
+	cfg.Agents = []agent.AgentConfig{
+		{ID: "a1", Type: "resume-test", Name: "Alice"},
+		{ID: "a2", Type: "resume-test", Name: "Bob"},
+	}
+	messages := []agent.Message{
+		{AgentID: "host", AgentName: "HOST", Content: "Name the product", Role: "system", Timestamp: time.Now().Unix()},
+		{AgentID: "a1", AgentName: "Alice", AgentType: "resume-test", Content: "Pipeline", Role: "agent", Timestamp: time.Now().Unix()},
+	}
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	if err := conversation.NewState(messages, cfg, time.Now()).Save(statePath); err != nil {
+		t.Fatal(err)
+	}
+
+	var resumed *orchestrator.Orchestrator
+	origRun, origResume := runOrchestrator, resumeFile
+	runOrchestrator = func(ctx context.Context, orch *orchestrator.Orchestrator) error {
+		resumed = orch
+		return nil
+	}
+	resumeFile = statePath
+	defer func() { runOrchestrator, resumeFile = origRun, origResume }()
+
+	if _, err := startConversation(runCmd, cfg, nil); err != nil {
+		t.Fatalf("resume failed: %v", err)
+	}
+	if resumed == nil {
+		t.Fatal("expected the resumed conversation to run")
+	}
+	if resumed.ResumedTurns() != 1 {
+		t.Errorf("expected 1 resumed turn, got %d", resumed.ResumedTurns())
+	}
+	if got := resumed.GetMessages(); len(got) != 2 || got[1].Content != "Pipeline" {
+		t.Errorf("expected the saved history to be loaded, got %v", got)
+	}
+
+	// A different set of agents cannot continue the conversation
+	other := config.NewDefaultConfig()
+	other.Logging.Enabled = false
+	other.Agents = []agent.AgentConfig{{ID: "a1", Type: "resume-test", Name: "Alice"}}
+	resumed = nil
+	_, err := startConversation(runCmd, other, nil)
+	if err == nil || !strings.Contains(err.Error(), "do not match the saved conversation") {
+		t.Errorf("expected an agent mismatch error, got %v", err)
+	}
+	if resumed != nil {
+		t.Error("a mismatched resume should not start the conversation")
+	}
+}
+
+func TestWriteModes(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeModes(&buf); err != nil {
+		t.Fatalf("writeModes failed: %v", err)
+	}
+
+	out := buf.String()
+	for _, mode := range []string{"round-robin", "reactive", "free-form"} {
+		if !strings.Contains(out, mode) {
+			t.Errorf("expected %s in mode list:\n%s", mode, out)
+		}
+	}
+	if !strings.Contains(out, "fixed circular order") {
+		t.Errorf("expected mode descriptions in mode list:\n%s", out)
+	}
+}
+
+func TestWriteTranscript(t *testing.T) {
+	messages := []agent.Message{
+		{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Pick a name"},
+		{AgentID: "a", AgentName: "Alice", Role: "agent", Content: "How about Orion?"},
+	}
+
+	tests := []struct {
+		format conversation.Format
+		check  func(t *testing.T, data []byte)
+	}{
+		{
+			format: conversation.FormatText,
+			check: func(t *testing.T, data []byte) {
+				if !strings.Contains(string(data), "Alice (agent): How about Orion?") {
+					t.Errorf("text transcript missing agent line:\n%s", data)
+				}
+			},
+		},
+		{
+			format: conversation.FormatJSON,
+			check: func(t *testing.T, data []byte) {
+				var got []agent.Message
+				if err := json.Unmarshal(data, &got); err != nil {
+					t.Fatalf("json transcript is invalid: %v", err)
+				}
+				if len(got) != 2 || got[1].Content != "How about Orion?" {
+					t.Errorf("unexpected json transcript: %+v", got)
+				}
+			},
+		},
+		{
+			format: conversation.FormatMarkdown,
+			check: func(t *testing.T, data []byte) {
+				if !strings.Contains(string(data), "### Alice") || !strings.Contains(string(data), "How about Orion?") {
+					t.Errorf("markdown transcript missing agent section:\n%s", data)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "out", "transcript."+string(tt.format))
+			if err := writeTranscript(path, messages, tt.format); err != nil {
+				t.Fatalf("writeTranscript failed: %v", err)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("output file was not created: %v", err)
+			}
+			tt.check(t, data)
+		})
+	}
+}
+
+func TestStartConversationRejectsInvalidOutputFormat(t *testing.T) {
+	origFile, origFormat := outputFile, outputFormat
+	outputFile, outputFormat = filepath.Join(t.TempDir(), "out.txt"), "yaml"
+	defer func() { outputFile, outputFormat = origFile, origFormat }()
+
+	_, err := startConversation(runCmd, config.NewDefaultConfig(), nil)
+	if err == nil || !strings.Contains(err.Error(), "output-format") {
+		t.Errorf("expected output format error, got %v", err)
+	}
+}
+
+func TestRunOneshotPrintsOnlyResponse(t *testing.T) {
+	var created *runTestAgent
+	agent.RegisterFactory("oneshot-test", func() agent.Agent {
+		created = &runTestAgent{response: "  The answer is 42.\n\n"}
+		return created
+	})
+
+	cfg := config.NewDefaultConfig()
+	cfg.Orchestrator.InitialPrompt = "What is the answer?"
+	cfg.Agents = []agent.AgentConfig{{ID: "o1", Type: "oneshot-test", Name: "Oracle"}}
+
+	var out strings.Builder
+	if err := runOneshot(runCmd, cfg, &out); err != nil {
+		t.Fatalf("runOneshot failed: %v", err)
+	}
+
+	if out.String() != "The answer is 42.\n" {
+		t.Errorf("expected only the response on stdout, got %q", out.String())
+	}
+	if created.sent != 1 {
+		t.Errorf("expected exactly one message to the agent, got %d", created.sent)
+	}
+	if len(created.received) != 1 || created.received[0].Content != "What is the answer?" {
+		t.Errorf("agent did not receive the prompt: %+v", created.received)
+	}
+}
+
+func TestRunOneshotErrors(t *testing.T) {
+	agent.RegisterFactory("oneshot-fail", func() agent.Agent {
+		return &runTestAgent{err: errors.New("boom")}
+	})
+
+	tests := []struct {
+		name   string
+		agents []agent.AgentConfig
+		prompt string
+	}{
+		{"no prompt", []agent.AgentConfig{{ID: "f1", Type: "oneshot-fail", Name: "F"}}, ""},
+		{"multiple agents", []agent.AgentConfig{{ID: "f1", Type: "oneshot-fail", Name: "F"}, {ID: "f2", Type: "oneshot-fail", Name: "G"}}, "hi"},
+		{"agent failure", []agent.AgentConfig{{ID: "f1", Type: "oneshot-fail", Name: "F"}}, "hi"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.NewDefaultConfig()
+			cfg.Orchestrator.InitialPrompt = tt.prompt
+			cfg.Agents = tt.agents
+
+			var out strings.Builder
+			if err := runOneshot(runCmd, cfg, &out); err == nil {
+				t.Error("expected an error")
+			}
+			if out.Len() != 0 {
+				t.Errorf("expected no stdout output on failure, got %q", out.String())
+			}
+		})
+	}
+}
+
+func TestLoadScript(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "interview.txt")
+	if err := os.WriteFile(path, []byte("What is your name?\n\n  What is your quest?  \n\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadScript(path)
+	if err != nil {
+		t.Fatalf("loadScript failed: %v", err)
+	}
+	want := []string{"What is your name?", "What is your quest?"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("loadScript() = %q, want %q", got, want)
+	}
+
+	empty := filepath.Join(dir, "empty.txt")
+	if err := os.WriteFile(empty, []byte("\n  \n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadScript(empty); err == nil {
+		t.Error("expected error for a script with no prompts")
+	}
+
+	if _, err := loadScript(filepath.Join(dir, "missing.txt")); err == nil {
+		t.Error("expected error for a missing script")
+	}
+}
+
+func TestReadPrompt(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"single line", "Discuss Go generics\n", "Discuss Go generics", false},
+		{"multi line", "Line one\n\nLine two\n", "Line one\n\nLine two", false},
+		{"surrounding whitespace", "\n  padded  \n\n", "padded", false},
+		{"empty", "", "", true},
+		{"whitespace only", " \n\t\n", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readPrompt(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readPrompt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("readPrompt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsPipeOrFile(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	pipeInfo, err := r.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(t.TempDir(), "prompt.txt")
+	if err := os.WriteFile(file, []byte("Discuss Go generics"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	fileInfo, err := os.Stat(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		mode os.FileMode
+		want bool
+	}{
+		{"pipe", pipeInfo.Mode(), true},
+		{"regular file", fileInfo.Mode(), true},
+		{"terminal", os.ModeDevice | os.ModeCharDevice, false},
+		{"socket", os.ModeSocket, false},
+		{"directory", os.ModeDir, false},
+	}
+	for _, tt := range tests {
+		if got := isPipeOrFile(tt.mode); got != tt.want {
+			t.Errorf("isPipeOrFile(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// blockingTestAgent answers its first message and then blocks on the second
+// until released, leaving the conversation paused mid-run.
+type blockingTestAgent struct {
+	runTestAgent
+	calls   int
+	reached chan struct{}
+	release chan struct{}
+}
+
+func (a *blockingTestAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	a.calls++
+	if a.calls == 2 {
+		close(a.reached)
+		select {
+		case <-a.release:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+	return "Still thinking", nil
+}
+
+func TestPrintProgressSummaryMidRun(t *testing.T) {
+	a := &blockingTestAgent{reached: make(chan struct{}), release: make(chan struct{})}
+	if err := a.Initialize(agent.AgentConfig{ID: "alice", Type: "mock", Name: "Alice"}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	orch := orchestrator.NewOrchestrator(orchestrator.OrchestratorConfig{
+		Mode:              orchestrator.ModeRoundRobin,
+		MaxTurns:          3,
+		TurnTimeout:       5 * time.Second,
+		ResponseDelay:     time.Millisecond,
+		MaxRetries:        0,
+		RetryInitialDelay: time.Millisecond,
+	}, nil)
+	orch.AddAgent(a)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- orch.Start(ctx) }()
+
+	select {
+	case <-a.reached:
+	case <-time.After(5 * time.Second):
+		t.Fatal("conversation did not reach the second turn")
+	}
+
+	before := len(orch.GetMessages())
+
+	var first, second strings.Builder
+	printProgressSummary(&first, orch)
+	printProgressSummary(&second, orch)
+
+	if !strings.Contains(first.String(), "In Progress") {
+		t.Errorf("expected in-progress header, got:\n%s", first.String())
+	}
+	if !strings.Contains(first.String(), "Agent Messages:    1") {
+		t.Errorf("expected one agent message so far, got:\n%s", first.String())
+	}
+	if first.String() != second.String() {
+		t.Error("printing the summary twice should produce identical output")
+	}
+	if after := len(orch.GetMessages()); after != before {
+		t.Errorf("printing the summary changed the message history: %d -> %d", before, after)
+	}
+
+	close(a.release)
+	cancel()
+	<-done
+}
+
+func TestClassifyOutcomeExitCodes(t *testing.T) {
+	tests := []struct {
+		name            string
+		interrupted     bool
+		runErr          error
+		failedResponses int
+		wantOutcome     conversationOutcome
+		wantCode        int
+	}{
+		{"clean completion", false, nil, 0, outcomeCompleted, 0},
+		{"failed turns", false, nil, 2, outcomeCompletedWithErrors, 2},
+		{"orchestrator error", false, errors.New("agent failed"), 1, outcomeCompletedWithErrors, 2},
+		{"no progress", false, orchestrator.ErrNoProgress, 3, outcomeNoProgress, 3},
+		{"wrapped no progress", false, fmt.Errorf("run: %w", orchestrator.ErrNoProgress), 0, outcomeNoProgress, 3},
+		{"interrupted by signal", true, nil, 0, outcomeInterrupted, 130},
+		{"interrupted with cancel error", false, context.Canceled, 0, outcomeInterrupted, 130},
+		{"interrupt wins over errors", true, errors.New("agent failed"), 1, outcomeInterrupted, 130},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyOutcome(tt.interrupted, tt.runErr, tt.failedResponses)
+			if got != tt.wantOutcome {
+				t.Errorf("classifyOutcome() = %v, want %v", got, tt.wantOutcome)
+			}
+			if code := got.exitCode(); code != tt.wantCode {
+				t.Errorf("exitCode() = %d, want %d", code, tt.wantCode)
+			}
+		})
+	}
+
+	if code := outcomeFailed.exitCode(); code != exitCodeFailed {
+		t.Errorf("setup failure exit code = %d, want %d", code, exitCodeFailed)
+	}
+}
+
 // contains checks if a string contains a substring
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
@@ -508,3 +1159,71 @@ func stringContains(s, substr string) bool {
 	}
 	return false
 }
+
+func TestInitializeAgentsWarmsUpWarmAgents(t *testing.T) {
+	agent.RegisterFactory("warmup-test", func() agent.Agent { return &runTestAgent{response: "hi"} })
+	agent.RegisterFactory("warmup-fail-test", func() agent.Agent { return &runTestAgent{err: errors.New("model not loaded")} })
+
+	cfg := config.NewDefaultConfig()
+	cfg.Agents = []agent.AgentConfig{
+		{ID: "a1", Type: "warmup-test", Name: "Cold", Warmup: true},
+		{ID: "a2", Type: "warmup-test", Name: "Ready"},
+		{ID: "a3", Type: "warmup-fail-test", Name: "Failing", Warmup: true},
+	}
+
+	var out bytes.Buffer
+	agentsList, err := initializeAgents(runCmd, cfg, &out)
+	if err != nil {
+		t.Fatalf("a failed warm-up should not fail initialization: %v", err)
+	}
+	if len(agentsList) != 3 {
+		t.Fatalf("expected 3 agents, got %d", len(agentsList))
+	}
+
+	for i, want := range []int{1, 0, 1} {
+		if got := agentsList[i].(*runTestAgent).sent; got != want {
+			t.Errorf("agent %s: expected %d warm-up message(s), got %d", agentsList[i].GetName(), want, got)
+		}
+	}
+	for _, want := range []string{"Warming up Cold", "Cold warmed up in", "Warm-up of Failing failed", "model not loaded"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "Warming up Ready") {
+		t.Errorf("expected no warm-up for an agent without warmup, got:\n%s", out.String())
+	}
+}
+
+// statefulTestAgent sends only the messages it hasn't sent before, like Amp's
+// threads.
+type statefulTestAgent struct {
+	runTestAgent
+	lastMessageIdx int
+}
+
+func (a *statefulTestAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	a.sent++
+	a.received = messages[a.lastMessageIdx:]
+	a.lastMessageIdx = len(messages)
+	return a.response, nil
+}
+
+func (a *statefulTestAgent) ResetSession() {
+	a.lastMessageIdx = 0
+}
+
+func TestWarmUpResetsStatefulAgents(t *testing.T) {
+	a := &statefulTestAgent{runTestAgent: runTestAgent{response: "hi"}}
+	a.Name = "Amp"
+
+	warmUpAgent(a, io.Discard)
+
+	prompt := agent.Message{AgentID: "host", AgentName: "HOST", Content: "Pick a database", Role: agent.RoleSystem}
+	if _, err := a.SendMessage(context.Background(), []agent.Message{prompt}); err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+	if len(a.received) != 1 || a.received[0].Content != prompt.Content {
+		t.Errorf("expected the first prompt after warm-up to be sent, got %+v", a.received)
+	}
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kevinelliott/agentpipe/pkg/server"
+)
+
+var (
+	serveAddr      string
+	serveToken     string
+	serveRetention time.Duration
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run conversations over an HTTP API",
+	Long: `Start an HTTP server that runs conversations on request.
+
+Endpoints:
+  POST /conversations       Start a conversation. The body is a config in JSON
+                            (or YAML) using the same keys as the config file.
+                            The response streams Server-Sent Events with the
+                            same payloads as the streaming bridge; the
+                            conversation ID is in the X-Conversation-ID header.
+  GET  /conversations/{id}  Conversation status and messages as JSON,
+                            until --retention after the conversation ends.
+
+The server only accepts local connections unless --addr says otherwise. With
+--token (or $AGENTPIPE_SERVE_TOKEN), every request must send the token as
+"Authorization: Bearer <token>".
+
+Examples:
+  agentpipe serve
+  agentpipe serve --addr :9000 --token "$(openssl rand -hex 32)"`,
+	RunE: runServe,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().StringVar(&serveAddr, "addr", server.DefaultAddr, "Address to listen on")
+	serveCmd.Flags().StringVar(&serveToken, "token", "", "Bearer token required on every request (default: $AGENTPIPE_SERVE_TOKEN)")
+	serveCmd.Flags().DurationVar(&serveRetention, "retention", server.DefaultRetention, "How long finished conversations stay available")
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	if serveToken == "" {
+		serveToken = os.Getenv("AGENTPIPE_SERVE_TOKEN")
+	}
+	srv := server.NewServer(server.ServerConfig{Addr: serveAddr, Token: serveToken, Retention: serveRetention})
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- srv.Start()
+	}()
+
+	fmt.Printf("🌐 Serving conversations on %s (Ctrl+C to stop)\n", serveAddr)
+	if serveToken == "" {
+		fmt.Println("⚠️  No --token set: anyone who can connect to this address can start conversations")
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-sigChan:
+		fmt.Println("\n⏸️  Shutting down...")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return srv.Stop(ctx)
+}
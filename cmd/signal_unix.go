@@ -0,0 +1,15 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifySummarySignal relays SIGUSR1 to c so a running conversation can print
+// a progress summary on demand (kill -USR1 <pid>).
+func notifySummarySignal(c chan<- os.Signal) {
+	signal.Notify(c, syscall.SIGUSR1)
+}
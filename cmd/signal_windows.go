@@ -0,0 +1,8 @@
+//go:build windows
+
+package cmd
+
+import "os"
+
+// notifySummarySignal is a no-op on Windows, which has no SIGUSR1.
+func notifySummarySignal(c chan<- os.Signal) {}
@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+var statsJSONOutput bool
+
+var statsCmd = &cobra.Command{
+	Use:   "stats [log-dir]",
+	Short: "Show aggregate statistics for past chat logs",
+	Long: `Parse every chat log in a directory and print aggregate statistics:
+cost and tokens per day, per-agent usage, and average turn duration.
+
+The directory defaults to ~/.agentpipe/chats. Token counts and costs are only
+recorded in JSON-format logs; text logs contribute message counts and turn
+durations estimated from message timestamps.
+
+Examples:
+  agentpipe stats
+  agentpipe stats ./logs --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runStats,
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().BoolVar(&statsJSONOutput, "json", false, "Output in JSON format")
+}
+
+// logStats is the aggregate over a set of chat logs.
+type logStats struct {
+	Logs               int             `json:"logs"`
+	Messages           int             `json:"messages"`
+	AgentMessages      int             `json:"agent_messages"`
+	TotalTokens        int             `json:"total_tokens"`
+	TotalCost          float64         `json:"total_cost"`
+	AvgTurnDurationSec float64         `json:"avg_turn_duration_seconds"`
+	Days               []dayStats      `json:"days"`
+	Agents             []agentLogStats `json:"agents"`
+}
+
+// dayStats totals the conversations started on one day.
+type dayStats struct {
+	Date          string  `json:"date"`
+	Conversations int     `json:"conversations"`
+	Messages      int     `json:"messages"`
+	Tokens        int     `json:"tokens"`
+	Cost          float64 `json:"cost"`
+}
+
+// agentLogStats totals one agent's responses across all logs.
+type agentLogStats struct {
+	Name               string  `json:"name"`
+	Messages           int     `json:"messages"`
+	InputTokens        int     `json:"input_tokens"`
+	OutputTokens       int     `json:"output_tokens"`
+	TotalTokens        int     `json:"total_tokens"`
+	Cost               float64 `json:"cost"`
+	AvgTurnDurationSec float64 `json:"avg_turn_duration_seconds"`
+
+	turnTime  time.Duration
+	turnCount int
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	dir := ""
+	if len(args) > 0 {
+		dir = args[0]
+	} else {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to get home directory: %w", err)
+		}
+		dir = filepath.Join(homeDir, ".agentpipe", "chats")
+	}
+
+	transcripts, err := readLogDir(dir)
+	if err != nil {
+		return err
+	}
+	if len(transcripts) == 0 {
+		return fmt.Errorf("no chat logs found in %s", dir)
+	}
+
+	return writeLogStats(os.Stdout, aggregateLogStats(transcripts), statsJSONOutput)
+}
+
+// readLogDir parses every chat log in dir. Unparseable files are skipped with a warning.
+func readLogDir(dir string) ([][]agent.Message, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	var transcripts [][]agent.Message
+	for _, entry := range entries {
+		if entry.IsDir() || !isLogFile(entry.Name()) {
+			continue
+		}
+
+		messages, err := readLogFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping %s: %v\n", entry.Name(), err)
+			continue
+		}
+		if len(messages) > 0 {
+			transcripts = append(transcripts, messages)
+		}
+	}
+
+	return transcripts, nil
+}
+
+// aggregateLogStats totals messages, tokens, and cost across transcripts, per
+// day (by the first message's local date) and per agent (by name, since text
+// logs do not record agent IDs).
+//
+// A turn's duration is its recorded metric when present, otherwise the time
+// since the previous message in the same transcript.
+func aggregateLogStats(transcripts [][]agent.Message) logStats {
+	stats := logStats{Logs: len(transcripts)}
+	days := make(map[string]*dayStats)
+	agents := make(map[string]*agentLogStats)
+	var turnTime time.Duration
+	turnCount := 0
+
+	for _, messages := range transcripts {
+		if len(messages) == 0 {
+			continue
+		}
+
+		date := time.Unix(messages[0].Timestamp, 0).Format("2006-01-02")
+		day, ok := days[date]
+		if !ok {
+			day = &dayStats{Date: date}
+			days[date] = day
+		}
+		day.Conversations++
+
+		for i, msg := range messages {
+			stats.Messages++
+			day.Messages++
+
+			if msg.Role != agent.RoleAgent {
+				continue
+			}
+			stats.AgentMessages++
+
+			a, ok := agents[msg.AgentName]
+			if !ok {
+				a = &agentLogStats{Name: msg.AgentName}
+				agents[msg.AgentName] = a
+			}
+			a.Messages++
+
+			var duration time.Duration
+			if msg.Metrics != nil {
+				a.InputTokens += msg.Metrics.InputTokens
+				a.OutputTokens += msg.Metrics.OutputTokens
+				a.TotalTokens += msg.Metrics.TotalTokens
+				a.Cost += msg.Metrics.Cost
+				stats.TotalTokens += msg.Metrics.TotalTokens
+				stats.TotalCost += msg.Metrics.Cost
+				day.Tokens += msg.Metrics.TotalTokens
+				day.Cost += msg.Metrics.Cost
+				duration = msg.Metrics.Duration
+			}
+			if duration == 0 && i > 0 {
+				duration = time.Duration(msg.Timestamp-messages[i-1].Timestamp) * time.Second
+			}
+			if duration > 0 {
+				a.turnTime += duration
+				a.turnCount++
+				turnTime += duration
+				turnCount++
+			}
+		}
+	}
+
+	if turnCount > 0 {
+		stats.AvgTurnDurationSec = (turnTime / time.Duration(turnCount)).Seconds()
+	}
+
+	stats.Days = make([]dayStats, 0, len(days))
+	for _, day := range days {
+		stats.Days = append(stats.Days, *day)
+	}
+	sort.Slice(stats.Days, func(i, j int) bool {
+		return stats.Days[i].Date < stats.Days[j].Date
+	})
+
+	stats.Agents = make([]agentLogStats, 0, len(agents))
+	for _, a := range agents {
+		if a.turnCount > 0 {
+			a.AvgTurnDurationSec = (a.turnTime / time.Duration(a.turnCount)).Seconds()
+		}
+		stats.Agents = append(stats.Agents, *a)
+	}
+	sort.Slice(stats.Agents, func(i, j int) bool {
+		if stats.Agents[i].Cost != stats.Agents[j].Cost {
+			return stats.Agents[i].Cost > stats.Agents[j].Cost
+		}
+		return stats.Agents[i].Name < stats.Agents[j].Name
+	})
+
+	return stats
+}
+
+// writeLogStats prints stats as tables, or as indented JSON.
+func writeLogStats(w io.Writer, stats logStats, asJSON bool) error {
+	if asJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(stats)
+	}
+
+	fmt.Fprintf(w, "Logs: %d\n", stats.Logs)
+	fmt.Fprintf(w, "Messages: %d (%d from agents)\n", stats.Messages, stats.AgentMessages)
+	fmt.Fprintf(w, "Total Tokens: %d\n", stats.TotalTokens)
+	fmt.Fprintf(w, "Total Cost: $%.4f\n", stats.TotalCost)
+	fmt.Fprintf(w, "Average Turn Duration: %.1fs\n", stats.AvgTurnDurationSec)
+
+	fmt.Fprintln(w, "\nBy Day:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  DATE\tCONVERSATIONS\tMESSAGES\tTOKENS\tCOST")
+	for _, d := range stats.Days {
+		fmt.Fprintf(tw, "  %s\t%d\t%d\t%d\t$%.4f\n", d.Date, d.Conversations, d.Messages, d.Tokens, d.Cost)
+	}
+	tw.Flush()
+
+	if len(stats.Agents) > 0 {
+		fmt.Fprintln(w, "\nBy Agent:")
+		tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "  AGENT\tMESSAGES\tINPUT\tOUTPUT\tCOST\tAVG TURN")
+		for _, a := range stats.Agents {
+			fmt.Fprintf(tw, "  %s\t%d\t%d\t%d\t$%.4f\t%.1fs\n",
+				a.Name, a.Messages, a.InputTokens, a.OutputTokens, a.Cost, a.AvgTurnDurationSec)
+		}
+		tw.Flush()
+	}
+
+	return nil
+}
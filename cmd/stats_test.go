@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+const statsTextLog = `=== AgentPipe Chat Log ===
+Started: 2025-01-15 10:00:00
+=====================================
+
+[10:00:00] HOST (system): Plan the launch
+
+[10:00:04] Claude (agent): Start with a beta.
+
+[10:00:10] Gemini (agent): Agreed.
+
+`
+
+// writeStatsJSONLog writes messages in the JSON chat log format.
+func writeStatsJSONLog(t *testing.T, path string, messages []agent.Message) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	for _, msg := range messages {
+		data, err := json.Marshal(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAggregateLogStats(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "chat_2025-01-15_10-00-00.log"), []byte(statsTextLog), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2025, 1, 16, 9, 0, 0, 0, time.Local).Unix()
+	writeStatsJSONLog(t, filepath.Join(dir, "chat_2025-01-16_09-00-00.log"), []agent.Message{
+		{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Review the PR", Timestamp: start},
+		{AgentID: "claude-0", AgentName: "Claude", Role: "agent", Content: "LGTM", Timestamp: start + 5,
+			Metrics: &agent.ResponseMetrics{Duration: 2 * time.Second, InputTokens: 100, OutputTokens: 20, TotalTokens: 120, Cost: 0.5}},
+		{AgentID: "claude-0", AgentName: "Claude", Role: "agent", Content: "Ship it", Timestamp: start + 9,
+			Metrics: &agent.ResponseMetrics{Duration: 4 * time.Second, InputTokens: 150, OutputTokens: 30, TotalTokens: 180, Cost: 0.25}},
+	})
+
+	// Not a chat log
+	if err := os.WriteFile(filepath.Join(dir, "notes.md"), []byte("ignore me"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	transcripts, err := readLogDir(dir)
+	if err != nil {
+		t.Fatalf("readLogDir failed: %v", err)
+	}
+	stats := aggregateLogStats(transcripts)
+
+	if stats.Logs != 2 {
+		t.Errorf("expected 2 logs, got %d", stats.Logs)
+	}
+	if stats.Messages != 6 || stats.AgentMessages != 4 {
+		t.Errorf("expected 6 messages (4 from agents), got %d (%d)", stats.Messages, stats.AgentMessages)
+	}
+	if stats.TotalTokens != 300 {
+		t.Errorf("expected 300 tokens, got %d", stats.TotalTokens)
+	}
+	if stats.TotalCost != 0.75 {
+		t.Errorf("expected cost 0.75, got %f", stats.TotalCost)
+	}
+	// Text log turns: 4s and 6s from timestamps; JSON log turns: 2s and 4s from metrics
+	if stats.AvgTurnDurationSec != 4 {
+		t.Errorf("expected average turn duration 4s, got %.2fs", stats.AvgTurnDurationSec)
+	}
+
+	if len(stats.Days) != 2 {
+		t.Fatalf("expected 2 days, got %d", len(stats.Days))
+	}
+	if stats.Days[0].Date != "2025-01-15" || stats.Days[0].Cost != 0 || stats.Days[0].Messages != 3 {
+		t.Errorf("unexpected first day: %+v", stats.Days[0])
+	}
+	if stats.Days[1].Date != "2025-01-16" || stats.Days[1].Cost != 0.75 || stats.Days[1].Tokens != 300 {
+		t.Errorf("unexpected second day: %+v", stats.Days[1])
+	}
+
+	if len(stats.Agents) != 2 {
+		t.Fatalf("expected 2 agents, got %d", len(stats.Agents))
+	}
+	claude := stats.Agents[0]
+	if claude.Name != "Claude" || claude.Messages != 3 || claude.InputTokens != 250 || claude.OutputTokens != 50 {
+		t.Errorf("unexpected Claude stats: %+v", claude)
+	}
+	// (4s + 2s + 4s) / 3
+	if diff := claude.AvgTurnDurationSec - 10.0/3; diff > 0.001 || diff < -0.001 {
+		t.Errorf("expected Claude average turn 3.33s, got %.2fs", claude.AvgTurnDurationSec)
+	}
+	if gemini := stats.Agents[1]; gemini.Name != "Gemini" || gemini.Messages != 1 || gemini.AvgTurnDurationSec != 6 {
+		t.Errorf("unexpected Gemini stats: %+v", gemini)
+	}
+}
+
+func TestWriteLogStats(t *testing.T) {
+	stats := aggregateLogStats([][]agent.Message{{
+		{AgentName: "Claude", Role: "agent", Content: "Hi", Timestamp: time.Now().Unix(),
+			Metrics: &agent.ResponseMetrics{Duration: time.Second, TotalTokens: 10, Cost: 0.01}},
+	}})
+
+	var text bytes.Buffer
+	if err := writeLogStats(&text, stats, false); err != nil {
+		t.Fatalf("writeLogStats failed: %v", err)
+	}
+	for _, want := range []string{"Logs: 1", "Total Cost: $0.0100", "By Day:", "By Agent:", "Claude"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("text output missing %q:\n%s", want, text.String())
+		}
+	}
+
+	var out bytes.Buffer
+	if err := writeLogStats(&out, stats, true); err != nil {
+		t.Fatalf("writeLogStats failed: %v", err)
+	}
+	var decoded logStats
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	if decoded.TotalTokens != 10 || len(decoded.Agents) != 1 {
+		t.Errorf("unexpected JSON stats: %+v", decoded)
+	}
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// newStepper returns the orchestrator's BeforeTurn callback for --step. Before
+// every turn after the first, it prompts on out and waits for a line from in:
+// Enter continues, q (or quit) ends the conversation, as do the end of input
+// and ctx being canceled.
+func newStepper(ctx context.Context, in io.Reader, out io.Writer) func() bool {
+	var lines chan string
+	first := true
+
+	return func() bool {
+		if first {
+			first = false
+			return true
+		}
+
+		// in is read by one goroutine for the whole run, so an interrupted
+		// prompt doesn't leave a second reader behind
+		if lines == nil {
+			lines = make(chan string)
+			go func() {
+				defer close(lines)
+				scanner := bufio.NewScanner(in)
+				for scanner.Scan() {
+					lines <- scanner.Text()
+				}
+			}()
+		}
+
+		fmt.Fprint(out, "\n⏯️  Press Enter for the next turn, or q to quit: ")
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				fmt.Fprintln(out)
+				return false
+			}
+			answer := strings.ToLower(strings.TrimSpace(line))
+			return answer != "q" && answer != "quit"
+		case <-ctx.Done():
+			fmt.Fprintln(out)
+			return false
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestStepper(t *testing.T) {
+	var out bytes.Buffer
+	step := newStepper(context.Background(), strings.NewReader("\n  \nq\n"), &out)
+
+	// The first turn starts without waiting
+	if !step() || out.Len() != 0 {
+		t.Fatalf("expected the first turn to start without a prompt, got %q", out.String())
+	}
+	for i, want := range []bool{true, true, false} {
+		if got := step(); got != want {
+			t.Errorf("step %d: got %v, want %v", i+2, got, want)
+		}
+	}
+	if n := strings.Count(out.String(), "Press Enter for the next turn"); n != 3 {
+		t.Errorf("expected 3 prompts, got %d: %q", n, out.String())
+	}
+}
+
+func TestStepperStopsAtEndOfInput(t *testing.T) {
+	step := newStepper(context.Background(), strings.NewReader("\n"), io.Discard)
+	step()
+	if !step() {
+		t.Error("expected Enter to continue")
+	}
+	if step() {
+		t.Error("expected the end of input to end the conversation")
+	}
+}
+
+func TestStepperStopsWhenCanceled(t *testing.T) {
+	in, w := io.Pipe()
+	defer w.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	step := newStepper(ctx, in, io.Discard)
+	step()
+	cancel()
+	if step() {
+		t.Error("expected an interrupted prompt to end the conversation")
+	}
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/config"
+	"github.com/kevinelliott/agentpipe/pkg/conversation"
+	"github.com/kevinelliott/agentpipe/pkg/orchestrator"
+)
+
+var (
+	summarizeAgent   string
+	summarizeShort   bool
+	summarizeJSON    bool
+	summarizeTimeout time.Duration
+)
+
+var summarizeCmd = &cobra.Command{
+	Use:   "summarize <state-file>",
+	Short: "Summarize a saved conversation",
+	Long: `Ask an agent to summarize a conversation saved with --save-state.
+
+The agent uses the same format as 'agentpipe run --agents' (type, type:name,
+or type:model:name). It defaults to the summary agent of the saved config
+(orchestrator.summary.agent), or gemini.
+
+By default the full summary is printed. --short prints the 1-2 sentence version
+instead, and --json prints both with the token, cost and duration details in
+the format of the conversation.completed event's summary.
+
+Examples:
+  agentpipe summarize ~/.agentpipe/states/conversation-20231215-143022.json
+  agentpipe summarize conversation.json --agent claude --short
+  agentpipe summarize conversation.json --agent claude:claude-sonnet-4.5:Claude --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSummarize,
+}
+
+func init() {
+	rootCmd.AddCommand(summarizeCmd)
+
+	summarizeCmd.Flags().StringVar(&summarizeAgent, "agent", "", "Agent that writes the summary (default: the saved summary agent, or gemini)")
+	summarizeCmd.Flags().BoolVar(&summarizeShort, "short", false, "Print the one-line summary instead of the full one")
+	summarizeCmd.Flags().BoolVar(&summarizeJSON, "json", false, "Print the summary and its metadata as JSON")
+	summarizeCmd.Flags().DurationVar(&summarizeTimeout, "timeout", 2*time.Minute, "Maximum time the agent may take")
+	summarizeCmd.Flags().Bool("skip-health-check", false, "Skip the agent health check")
+}
+
+func runSummarize(cmd *cobra.Command, args []string) error {
+	state, err := conversation.LoadState(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+	if len(state.Messages) == 0 {
+		return fmt.Errorf("no messages found in state file")
+	}
+
+	agentCfg, err := parseAgentSpec(summaryAgentSpec(summarizeAgent, state), 0)
+	if err != nil {
+		return err
+	}
+	agentsList, err := initializeAgents(cmd, &config.Config{Agents: []agent.AgentConfig{agentCfg}}, os.Stderr)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, summarizeTimeout)
+	defer cancel()
+
+	return summarizeConversation(ctx, os.Stdout, agentsList[0], state.Messages, summarizeShort, summarizeJSON)
+}
+
+// summaryAgentSpec returns the agent spec to summarize with: spec if given,
+// otherwise the summary agent of the saved config, or gemini.
+func summaryAgentSpec(spec string, state *conversation.State) string {
+	if spec != "" {
+		return spec
+	}
+	if state.Config != nil && state.Config.Orchestrator.Summary.Agent != "" {
+		return state.Config.Orchestrator.Summary.Agent
+	}
+	return "gemini"
+}
+
+// summarizeConversation has a summarize messages and writes the summary to w.
+func summarizeConversation(ctx context.Context, w io.Writer, a agent.Agent, messages []agent.Message, short, asJSON bool) error {
+	summary, err := orchestrator.Summarize(ctx, a, messages)
+	if err != nil {
+		return fmt.Errorf("failed to summarize conversation: %w", err)
+	}
+
+	if asJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(summary)
+	}
+
+	if short {
+		_, err = fmt.Fprintln(w, summary.ShortText)
+	} else {
+		_, err = fmt.Fprintln(w, summary.Text)
+	}
+	return err
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kevinelliott/agentpipe/internal/bridge"
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/config"
+	"github.com/kevinelliott/agentpipe/pkg/conversation"
+)
+
+var summarizeTestMessages = []agent.Message{
+	{AgentID: "host", AgentName: "HOST", Content: "Pick a database", Role: "system"},
+	{AgentID: "a", AgentName: "Alice", Content: "Postgres, for its reliability.", Role: "agent"},
+	{AgentID: "b", AgentName: "Bob", Content: "Agreed, Postgres.", Role: "agent"},
+}
+
+func newSummarizeTestAgent() *runTestAgent {
+	a := &runTestAgent{response: "SHORT: The agents chose Postgres.\nFULL: Alice proposed Postgres for its reliability and Bob agreed."}
+	_ = a.Initialize(agent.AgentConfig{ID: "summary", Type: "claude", Name: "Summary"})
+	return a
+}
+
+func TestSummarizeConversation(t *testing.T) {
+	a := newSummarizeTestAgent()
+
+	var out bytes.Buffer
+	if err := summarizeConversation(context.Background(), &out, a, summarizeTestMessages, false, false); err != nil {
+		t.Fatalf("summarize failed: %v", err)
+	}
+	if got := strings.TrimSpace(out.String()); got != "Alice proposed Postgres for its reliability and Bob agreed." {
+		t.Errorf("expected the full summary, got %q", got)
+	}
+
+	prompt := a.received[0].Content
+	if !strings.Contains(prompt, "Alice: Postgres, for its reliability.") || strings.Contains(prompt, "Pick a database") {
+		t.Errorf("expected the prompt to contain the agent messages only, got:\n%s", prompt)
+	}
+
+	out.Reset()
+	if err := summarizeConversation(context.Background(), &out, a, summarizeTestMessages, true, false); err != nil {
+		t.Fatalf("summarize failed: %v", err)
+	}
+	if got := strings.TrimSpace(out.String()); got != "The agents chose Postgres." {
+		t.Errorf("expected the short summary, got %q", got)
+	}
+}
+
+func TestSummarizeConversationJSON(t *testing.T) {
+	var out bytes.Buffer
+	if err := summarizeConversation(context.Background(), &out, newSummarizeTestAgent(), summarizeTestMessages, false, true); err != nil {
+		t.Fatalf("summarize failed: %v", err)
+	}
+
+	var summary bridge.SummaryMetadata
+	if err := json.Unmarshal(out.Bytes(), &summary); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	if summary.ShortText != "The agents chose Postgres." || summary.AgentType != "claude" || summary.TotalTokens == 0 {
+		t.Errorf("unexpected summary metadata: %+v", summary)
+	}
+}
+
+func TestSummarizeConversationWithoutAgentMessages(t *testing.T) {
+	err := summarizeConversation(context.Background(), &bytes.Buffer{}, newSummarizeTestAgent(), summarizeTestMessages[:1], false, false)
+	if err == nil {
+		t.Error("expected an error for a conversation without agent messages")
+	}
+}
+
+func TestSummaryAgentSpec(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Orchestrator.Summary.Agent = "qwen"
+
+	tests := []struct {
+		name  string
+		spec  string
+		state *conversation.State
+		want  string
+	}{
+		{"explicit agent", "claude:Claude", &conversation.State{Config: cfg}, "claude:Claude"},
+		{"saved summary agent", "", &conversation.State{Config: cfg}, "qwen"},
+		{"no saved config", "", &conversation.State{}, "gemini"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := summaryAgentSpec(tt.spec, tt.state); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kevinelliott/agentpipe/internal/templates"
+)
+
+var templatesJSONOutput bool
+
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "Show the built-in conversation templates",
+	Long: `Show the built-in conversation templates usable with 'agentpipe run --template'.
+
+Templates are complete configs; flags passed to 'run' override their settings.`,
+}
+
+var templatesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the built-in templates",
+	Long: `List the built-in conversation templates.
+
+Examples:
+  agentpipe templates list
+  agentpipe run --template debate -p "Motion: Tabs are better than spaces"`,
+	RunE: runTemplatesList,
+}
+
+var templatesShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Print a template's YAML config",
+	Long: `Print the YAML config of a built-in template, e.g. as a starting point for
+your own config file.
+
+Examples:
+  agentpipe templates show code-review > review.yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplatesShow,
+}
+
+func init() {
+	rootCmd.AddCommand(templatesCmd)
+	templatesCmd.AddCommand(templatesListCmd)
+	templatesCmd.AddCommand(templatesShowCmd)
+
+	templatesListCmd.Flags().BoolVar(&templatesJSONOutput, "json", false, "Output in JSON format")
+}
+
+func runTemplatesList(cmd *cobra.Command, args []string) error {
+	return writeTemplatesList(os.Stdout, templates.List(), templatesJSONOutput)
+}
+
+func runTemplatesShow(cmd *cobra.Command, args []string) error {
+	data, err := templates.Source(args[0])
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
+
+// writeTemplatesList renders the templates as a formatted table or JSON.
+func writeTemplatesList(w io.Writer, list []templates.Template, asJSON bool) error {
+	if asJSON {
+		data, err := json.MarshalIndent(list, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal templates to JSON: %w", err)
+		}
+		fmt.Fprintln(w, string(data))
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tDESCRIPTION")
+	for _, t := range list {
+		fmt.Fprintf(tw, "%s\t%s\n", t.Name, t.Description)
+	}
+	return tw.Flush()
+}
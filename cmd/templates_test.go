@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kevinelliott/agentpipe/internal/templates"
+)
+
+func TestWriteTemplatesList(t *testing.T) {
+	var out strings.Builder
+	if err := writeTemplatesList(&out, templates.List(), false); err != nil {
+		t.Fatalf("writeTemplatesList failed: %v", err)
+	}
+	for _, want := range []string{"NAME", "brainstorm", "code-review", "debate", "interview"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in output:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := writeTemplatesList(&out, templates.List(), true); err != nil {
+		t.Fatalf("writeTemplatesList failed: %v", err)
+	}
+	var decoded []templates.Template
+	if err := json.Unmarshal([]byte(out.String()), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(decoded) != len(templates.List()) {
+		t.Errorf("expected %d templates, got %d", len(templates.List()), len(decoded))
+	}
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/log"
+)
+
+// warmupTimeout bounds an agent's warm-up, so a stuck CLI delays the
+// conversation by at most this long.
+var warmupTimeout = 30 * time.Second
+
+// warmupPrompt is the trivial message sent to preload a warm agent's model.
+const warmupPrompt = "hello"
+
+// warmUpAgent sends a trivial prompt to an agent with Warmup set so its model
+// is loaded before the first turn. The response is discarded, and a failed
+// warm-up is reported but doesn't stop the run; the first turn will just be
+// slower. Stateful agents have their session reset afterwards, so the warm-up
+// prompt doesn't count as part of the conversation.
+func warmUpAgent(a agent.Agent, out io.Writer) {
+	fmt.Fprintf(out, "  🔥 Warming up %s...\n", a.GetName())
+
+	if s, ok := a.(agent.Stateful); ok {
+		defer s.ResetSession()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), warmupTimeout)
+	defer cancel()
+
+	start := time.Now()
+	_, err := a.SendMessage(ctx, []agent.Message{{
+		AgentID:   "host",
+		AgentName: "HOST",
+		Content:   warmupPrompt,
+		Timestamp: start.Unix(),
+		Role:      agent.RoleSystem,
+	}})
+	elapsed := time.Since(start)
+
+	if err != nil {
+		log.WithFields(map[string]interface{}{
+			"agent_name": a.GetName(),
+			"duration":   elapsed.String(),
+		}).WithError(err).Warn("agent warm-up failed")
+		fmt.Fprintf(out, "  ⚠️  Warm-up of %s failed after %s: %v (continuing)\n", a.GetName(), elapsed.Round(time.Millisecond), err)
+		return
+	}
+
+	log.WithFields(map[string]interface{}{
+		"agent_name": a.GetName(),
+		"duration":   elapsed.String(),
+	}).Info("agent warmed up")
+	fmt.Fprintf(out, "  ✅ %s warmed up in %s\n", a.GetName(), elapsed.Round(time.Millisecond))
+}
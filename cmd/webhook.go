@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/internal/bridge"
+	"github.com/kevinelliott/agentpipe/pkg/config"
+	"github.com/kevinelliott/agentpipe/pkg/log"
+	"github.com/kevinelliott/agentpipe/pkg/orchestrator"
+)
+
+// completionWebhookPayload is the JSON body POSTed to --completion-webhook.
+type completionWebhookPayload struct {
+	Status          string    `json:"status"`
+	ExitCode        int       `json:"exit_code"`
+	Error           string    `json:"error,omitempty"`
+	Mode            string    `json:"mode"`
+	Agents          []string  `json:"agents"`
+	TotalMessages   int       `json:"total_messages"`
+	AgentMessages   int       `json:"agent_messages"`
+	FailedResponses int       `json:"failed_responses"`
+	TotalTokens     int       `json:"total_tokens"`
+	TotalCost       float64   `json:"total_cost"`
+	DurationSeconds float64   `json:"duration_seconds"`
+	StartedAt       time.Time `json:"started_at"`
+	CompletedAt     time.Time `json:"completed_at"`
+}
+
+// buildCompletionPayload summarizes a finished run for the completion webhook.
+func buildCompletionPayload(stats orchestrator.ConversationStats, cfg *config.Config, outcome conversationOutcome, runErr error, failedResponses int, started time.Time, duration time.Duration) completionWebhookPayload {
+	payload := completionWebhookPayload{
+		Status:          outcome.String(),
+		ExitCode:        outcome.exitCode(),
+		Mode:            cfg.Orchestrator.Mode,
+		Agents:          make([]string, 0, len(cfg.Agents)),
+		TotalMessages:   stats.TotalMessages,
+		AgentMessages:   stats.AgentMessages,
+		FailedResponses: failedResponses,
+		TotalTokens:     stats.TotalTokens,
+		TotalCost:       stats.TotalCost,
+		DurationSeconds: duration.Seconds(),
+		StartedAt:       started.UTC(),
+		CompletedAt:     started.Add(duration).UTC(),
+	}
+	if runErr != nil && outcome != outcomeInterrupted {
+		payload.Error = runErr.Error()
+	}
+
+	for _, agentCfg := range cfg.Agents {
+		payload.Agents = append(payload.Agents, agentCfg.Name)
+	}
+
+	return payload
+}
+
+// sendCompletionWebhook POSTs payload to url using the bridge client's retry
+// and backoff.
+func sendCompletionWebhook(url string, payload completionWebhookPayload) error {
+	client := bridge.NewClient(&bridge.Config{
+		TimeoutMs:     10000,
+		RetryAttempts: 2,
+	})
+	return client.PostJSON(url, payload)
+}
+
+// notifyCompletionWebhook sends the run summary to --completion-webhook, if
+// set. Failures are only reported: they never change the exit code.
+func notifyCompletionWebhook(orch *orchestrator.Orchestrator, cfg *config.Config, outcome conversationOutcome, runErr error, started time.Time, duration time.Duration) {
+	if completionWebhook == "" {
+		return
+	}
+
+	var stats orchestrator.ConversationStats
+	failedResponses := 0
+	if orch != nil {
+		stats = orch.GetStats()
+		failedResponses = orch.FailedResponses()
+	}
+
+	payload := buildCompletionPayload(stats, cfg, outcome, runErr, failedResponses, started, duration)
+	if err := sendCompletionWebhook(completionWebhook, payload); err != nil {
+		log.WithError(err).WithField("url", completionWebhook).Warn("completion webhook failed")
+		fmt.Fprintf(os.Stderr, "Warning: Failed to send completion webhook: %v\n", err)
+	}
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/config"
+	"github.com/kevinelliott/agentpipe/pkg/orchestrator"
+)
+
+func TestSendCompletionWebhook(t *testing.T) {
+	var received completionWebhookPayload
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("expected application/json, got %s", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("failed to decode payload: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cfg := config.NewDefaultConfig()
+	cfg.Agents = []agent.AgentConfig{
+		{ID: "a1", Type: "claude", Name: "Alice"},
+		{ID: "a2", Type: "gemini", Name: "Bob"},
+	}
+	messages := []agent.Message{
+		{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Topic"},
+		{AgentID: "a1", AgentName: "Alice", Role: "agent", Content: "One", Metrics: &agent.ResponseMetrics{TotalTokens: 100, Cost: 0.25}},
+		{AgentID: "a2", AgentName: "Bob", Role: "agent", Content: "Two", Metrics: &agent.ResponseMetrics{TotalTokens: 50, Cost: 0.5}},
+	}
+	started := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	payload := buildCompletionPayload(orchestrator.CalculateStats(messages), cfg, outcomeCompletedWithErrors, errors.New("agent Bob failed"), 1, started, 90*time.Second)
+	if err := sendCompletionWebhook(server.URL, payload); err != nil {
+		t.Fatalf("sendCompletionWebhook failed: %v", err)
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected 1 webhook call, got %d", calls)
+	}
+	if received.Status != "completed_with_errors" || received.ExitCode != exitCodeCompletedWithErrors {
+		t.Errorf("unexpected status %q / exit code %d", received.Status, received.ExitCode)
+	}
+	if received.Error != "agent Bob failed" {
+		t.Errorf("unexpected error %q", received.Error)
+	}
+	if received.Mode != "round-robin" || len(received.Agents) != 2 || received.Agents[0] != "Alice" {
+		t.Errorf("unexpected mode/agents: %s %v", received.Mode, received.Agents)
+	}
+	if received.TotalMessages != 3 || received.AgentMessages != 2 || received.FailedResponses != 1 {
+		t.Errorf("unexpected message counts: %+v", received)
+	}
+	if received.TotalTokens != 150 || received.TotalCost != 0.75 {
+		t.Errorf("unexpected totals: %d tokens, $%f", received.TotalTokens, received.TotalCost)
+	}
+	if received.DurationSeconds != 90 {
+		t.Errorf("expected 90s duration, got %f", received.DurationSeconds)
+	}
+	if !received.StartedAt.Equal(started) || !received.CompletedAt.Equal(started.Add(90*time.Second)) {
+		t.Errorf("unexpected timestamps: %v - %v", received.StartedAt, received.CompletedAt)
+	}
+}
+
+func TestSendCompletionWebhookReportsFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	payload := buildCompletionPayload(orchestrator.ConversationStats{}, config.NewDefaultConfig(), outcomeCompleted, nil, 0, time.Now(), time.Second)
+	if err := sendCompletionWebhook(server.URL, payload); err == nil {
+		t.Error("expected an error for a rejected webhook")
+	}
+}
@@ -7,14 +7,36 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
-// Client is an HTTP client for sending streaming events to AgentPipe Web
+// defaultFlushTimeout bounds how long Close waits for async sends in flight
+const defaultFlushTimeout = 10 * time.Second
+
+// Client is an HTTP client for sending streaming events to AgentPipe Web.
+// It is the default Sink.
 type Client struct {
 	config           *Config
 	httpClient       *http.Client
 	suppressWarnings bool // Set to true after first failure to avoid spamming warnings
+
+	// Delivery counters, updated concurrently by async sends
+	delivered atomic.Int64
+	failed    atomic.Int64
+	retried   atomic.Int64
+
+	// pending tracks async sends still in flight; Close waits up to flushTimeout for them
+	pending      sync.WaitGroup
+	flushTimeout time.Duration
+}
+
+// DeliveryStats counts the outcome of the events a client tried to send.
+type DeliveryStats struct {
+	Delivered int64 // Events the server accepted
+	Failed    int64 // Events dropped after all attempts failed
+	Retried   int64 // Retry attempts made across all events
 }
 
 // NewClient creates a new bridge client with the given configuration
@@ -25,6 +47,7 @@ func NewClient(config *Config) *Client {
 			Timeout: time.Duration(config.TimeoutMs) * time.Millisecond,
 		},
 		suppressWarnings: false,
+		flushTimeout:     defaultFlushTimeout,
 	}
 }
 
@@ -42,6 +65,7 @@ func (c *Client) SendEvent(event *Event) error {
 
 	// Validate that we have an API key
 	if c.config.APIKey == "" {
+		c.failed.Add(1)
 		if c.config.LogLevel == "debug" {
 			fmt.Fprintln(os.Stderr, "Debug: Streaming enabled but no API key configured")
 		}
@@ -51,10 +75,83 @@ func (c *Client) SendEvent(event *Event) error {
 	// Serialize event to JSON
 	body, err := json.Marshal(event)
 	if err != nil {
+		c.failed.Add(1)
 		return fmt.Errorf("failed to marshal event: %w", err)
 	}
 
-	// Retry logic with exponential backoff
+	lastErr := c.postWithRetry(c.getEndpointURL(), body)
+	if lastErr == nil {
+		c.delivered.Add(1)
+		if c.config.LogLevel == "debug" {
+			fmt.Fprintf(os.Stderr, "Debug: Successfully sent %s event\n", event.Type)
+		}
+		return nil // Success
+	}
+
+	c.failed.Add(1)
+
+	// Log error but don't fail the conversation
+	if !c.suppressWarnings {
+		// Show a user-friendly warning only once
+		fmt.Fprintln(os.Stderr, "\n⚠️  Bridge streaming unavailable - conversation will continue normally")
+		fmt.Fprintln(os.Stderr, "   (Events will be saved locally and can be uploaded later)")
+		c.suppressWarnings = true
+	}
+
+	// Log detailed error at debug level only
+	if c.config.LogLevel == "debug" {
+		fmt.Fprintf(os.Stderr, "Debug: Failed to stream event after %d attempts: %v\n",
+			c.config.RetryAttempts+1, lastErr)
+	}
+
+	return lastErr
+}
+
+// Stats returns the delivery counts of the events sent so far. Async sends
+// still in flight are not counted yet.
+func (c *Client) Stats() DeliveryStats {
+	return DeliveryStats{
+		Delivered: c.delivered.Load(),
+		Failed:    c.failed.Load(),
+		Retried:   c.retried.Load(),
+	}
+}
+
+// Close implements Sink. It waits for async sends still in flight, for at
+// most the flush timeout, so Stats counts them; sends that outlast it are left
+// to finish in the background.
+func (c *Client) Close() error {
+	done := make(chan struct{})
+	go func() {
+		c.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(c.flushTimeout):
+		if c.config.LogLevel == "debug" {
+			fmt.Fprintf(os.Stderr, "Debug: Gave up waiting for bridge events in flight after %v\n", c.flushTimeout)
+		}
+	}
+	return nil
+}
+
+// PostJSON sends payload as JSON to an arbitrary URL with the same retry and
+// backoff as SendEvent. Unlike SendEvent it ignores Enabled, and the API key is
+// only sent when configured.
+func (c *Client) PostJSON(url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	return c.postWithRetry(url, body)
+}
+
+// postWithRetry POSTs body to url, retrying network and server errors with
+// exponential backoff
+func (c *Client) postWithRetry(url string, body []byte) error {
 	var lastErr error
 	for attempt := 0; attempt <= c.config.RetryAttempts; attempt++ {
 		if attempt > 0 {
@@ -64,6 +161,7 @@ func (c *Client) SendEvent(event *Event) error {
 			exponent := uint(attempt - 1)
 			backoff := time.Duration(1<<exponent) * time.Second
 			time.Sleep(backoff)
+			c.retried.Add(1)
 
 			if c.config.LogLevel == "debug" {
 				fmt.Fprintf(os.Stderr, "Debug: Retry attempt %d/%d after %v\n",
@@ -71,12 +169,9 @@ func (c *Client) SendEvent(event *Event) error {
 			}
 		}
 
-		err := c.sendRequest(body)
+		err := c.sendRequest(url, body)
 		if err == nil {
-			if c.config.LogLevel == "debug" {
-				fmt.Fprintf(os.Stderr, "Debug: Successfully sent %s event\n", event.Type)
-			}
-			return nil // Success
+			return nil
 		}
 
 		lastErr = err
@@ -87,34 +182,20 @@ func (c *Client) SendEvent(event *Event) error {
 		}
 	}
 
-	// Log error but don't fail the conversation
-	if !c.suppressWarnings {
-		// Show a user-friendly warning only once
-		fmt.Fprintln(os.Stderr, "\n⚠️  Bridge streaming unavailable - conversation will continue normally")
-		fmt.Fprintln(os.Stderr, "   (Events will be saved locally and can be uploaded later)")
-		c.suppressWarnings = true
-	}
-
-	// Log detailed error at debug level only
-	if c.config.LogLevel == "debug" {
-		fmt.Fprintf(os.Stderr, "Debug: Failed to stream event after %d attempts: %v\n",
-			c.config.RetryAttempts+1, lastErr)
-	}
-
 	return lastErr
 }
 
 // sendRequest performs a single HTTP request to send an event
-func (c *Client) sendRequest(body []byte) error {
-	url := c.getEndpointURL()
-
+func (c *Client) sendRequest(url string, body []byte) error {
 	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.config.APIKey)
+	if c.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.APIKey)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -122,8 +203,8 @@ func (c *Client) sendRequest(body []byte) error {
 	}
 	defer resp.Body.Close()
 
-	// Success codes
-	if resp.StatusCode == http.StatusCreated || resp.StatusCode == http.StatusOK {
+	// Success codes (webhook receivers often answer 202 or 204)
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
 		return nil
 	}
 
@@ -138,7 +219,9 @@ func (c *Client) sendRequest(body []byte) error {
 // SendEventAsync sends an event asynchronously in a goroutine (non-blocking)
 // Errors are logged at debug level but do not block or fail the conversation
 func (c *Client) SendEventAsync(event *Event) {
+	c.pending.Add(1)
 	go func() {
+		defer c.pending.Done()
 		if err := c.SendEvent(event); err != nil {
 			// Log at debug level only to avoid cluttering output
 			if c.config.LogLevel == "debug" {
@@ -285,6 +285,10 @@ func TestSendEvent_Retry(t *testing.T) {
 	if attemptCount != 3 {
 		t.Errorf("Expected 3 attempts, got %d", attemptCount)
 	}
+
+	if got, want := client.Stats(), (DeliveryStats{Delivered: 1, Retried: 2}); got != want {
+		t.Errorf("Expected stats %+v, got %+v", want, got)
+	}
 }
 
 func TestSendEvent_NoRetryOn4xx(t *testing.T) {
@@ -393,3 +397,52 @@ func TestIsClientError(t *testing.T) {
 		t.Error("Expected false for non-httpError")
 	}
 }
+
+func TestPostJSON(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/hooks/done" {
+			t.Errorf("Expected path=/hooks/done, got %s", r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("Expected no Authorization header without an API key, got %s", auth)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// PostJSON works even when streaming is disabled
+	client := NewClient(&Config{TimeoutMs: 5000, RetryAttempts: 0})
+	if err := client.PostJSON(server.URL+"/hooks/done", map[string]string{"status": "completed"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if received["status"] != "completed" {
+		t.Errorf("Expected status=completed, got %v", received["status"])
+	}
+}
+
+func TestClientCloseGivesUpAfterFlushTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(&Config{Enabled: true, URL: server.URL, APIKey: "sk_test", TimeoutMs: 5000})
+	client.flushTimeout = 50 * time.Millisecond
+	client.SendEventAsync(&Event{Type: EventMessageCreated, Timestamp: UTCTime{time.Now()}})
+
+	start := time.Now()
+	if err := client.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected Close to give up after the flush timeout, took %v", elapsed)
+	}
+}
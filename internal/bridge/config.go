@@ -7,6 +7,12 @@ import (
 	"github.com/spf13/viper"
 )
 
+// Sink types selectable with bridge.sink
+const (
+	SinkHTTP = "http" // Send events to AgentPipe Web (default)
+	SinkFile = "file" // Append events to a local NDJSON file
+)
+
 // Config holds the configuration for the bridge streaming functionality
 type Config struct {
 	Enabled       bool   `mapstructure:"enabled"`
@@ -15,6 +21,8 @@ type Config struct {
 	TimeoutMs     int    `mapstructure:"timeout_ms"`
 	RetryAttempts int    `mapstructure:"retry_attempts"`
 	LogLevel      string `mapstructure:"log_level"`
+	Sink          string `mapstructure:"sink"` // SinkHTTP or SinkFile
+	File          string `mapstructure:"file"` // Output path for SinkFile
 }
 
 // LoadConfig loads bridge configuration from viper, environment variables, and defaults
@@ -26,6 +34,7 @@ func LoadConfig() *Config {
 		TimeoutMs:     10000,
 		RetryAttempts: 3,
 		LogLevel:      "info",
+		Sink:          SinkHTTP,
 	}
 
 	// Load from viper config file if available
@@ -47,6 +56,12 @@ func LoadConfig() *Config {
 	if viper.IsSet("bridge.log_level") {
 		config.LogLevel = viper.GetString("bridge.log_level")
 	}
+	if viper.IsSet("bridge.sink") {
+		config.Sink = viper.GetString("bridge.sink")
+	}
+	if viper.IsSet("bridge.file") {
+		config.File = viper.GetString("bridge.file")
+	}
 
 	// Override with environment variables (highest priority)
 	if enabled := os.Getenv("AGENTPIPE_STREAM_ENABLED"); enabled == "true" || enabled == "1" {
@@ -36,6 +36,10 @@ func TestLoadConfig_Defaults(t *testing.T) {
 		t.Errorf("Expected LogLevel=info, got %s", config.LogLevel)
 	}
 
+	if config.Sink != SinkHTTP {
+		t.Errorf("Expected Sink=%s, got %s", SinkHTTP, config.Sink)
+	}
+
 	// URL should be the default (depends on build tag)
 	if config.URL == "" {
 		t.Error("Expected URL to be set to default")
@@ -90,6 +94,8 @@ func TestLoadConfig_ViperConfig(t *testing.T) {
 	viper.Set("bridge.timeout_ms", 15000)
 	viper.Set("bridge.retry_attempts", 5)
 	viper.Set("bridge.log_level", "debug")
+	viper.Set("bridge.sink", "file")
+	viper.Set("bridge.file", "/tmp/events.ndjson")
 
 	defer viper.Reset()
 
@@ -118,6 +124,10 @@ func TestLoadConfig_ViperConfig(t *testing.T) {
 	if config.LogLevel != "debug" {
 		t.Errorf("Expected LogLevel=debug, got %s", config.LogLevel)
 	}
+
+	if config.Sink != SinkFile || config.File != "/tmp/events.ndjson" {
+		t.Errorf("Expected Sink=file and File=/tmp/events.ndjson, got %s and %s", config.Sink, config.File)
+	}
 }
 
 func TestLoadConfig_EnvironmentOverridesViper(t *testing.T) {
@@ -11,7 +11,8 @@ import (
 
 // Emitter provides high-level methods for emitting streaming events
 type Emitter struct {
-	client          *Client
+	config          *Config
+	sink            Sink
 	conversationID  string
 	sequenceNumber  int
 	systemInfo      SystemInfo
@@ -19,9 +20,17 @@ type Emitter struct {
 	eventStore      *EventStore
 }
 
-// NewEmitter creates a new event emitter for a conversation
+// NewEmitter creates a new event emitter for a conversation that sends its
+// events to AgentPipe Web
 // Automatically sends a bridge.connected event to announce the connection
 func NewEmitter(config *Config, agentpipeVersion string) *Emitter {
+	return NewEmitterWithSink(config, NewClient(config), agentpipeVersion)
+}
+
+// NewEmitterWithSink creates a new event emitter for a conversation that
+// delivers its events to sink, e.g. one returned by NewSink
+// Automatically sends a bridge.connected event to announce the connection
+func NewEmitterWithSink(config *Config, sink Sink, agentpipeVersion string) *Emitter {
 	conversationID := uuid.New().String()
 
 	// Create event store for local logging
@@ -36,7 +45,8 @@ func NewEmitter(config *Config, agentpipeVersion string) *Emitter {
 	}
 
 	emitter := &Emitter{
-		client:          NewClient(config),
+		config:          config,
+		sink:            sink,
 		conversationID:  conversationID,
 		sequenceNumber:  0,
 		systemInfo:      CollectSystemInfo(agentpipeVersion),
@@ -60,19 +70,53 @@ func (e *Emitter) saveEventLocally(event *Event) {
 	if e.eventStore != nil {
 		if err := e.eventStore.SaveEvent(event); err != nil {
 			// Log error but don't fail
-			if e.client.config.LogLevel == "debug" {
+			if e.config.LogLevel == "debug" {
 				fmt.Fprintf(os.Stderr, "Debug: Failed to save event locally: %v\n", err)
 			}
 		}
 	}
 }
 
+// Stats returns how many of this emitter's events were delivered, failed, or
+// retried so far.
+func (e *Emitter) Stats() DeliveryStats {
+	return e.sink.Stats()
+}
+
 // Close closes the emitter and flushes any buffered events
+// Logs a delivery summary, always when events failed, otherwise at debug level.
+// The summary is logged after the sink is closed, so it counts the events
+// that were still being sent.
 func (e *Emitter) Close() error {
+	sinkErr := e.sink.Close()
+	e.logDeliverySummary()
+
 	if e.eventStore != nil {
-		return e.eventStore.Close()
+		if err := e.eventStore.Close(); err != nil {
+			return err
+		}
+	}
+	return sinkErr
+}
+
+// logDeliverySummary reports how many events reached the sink, so an
+// incomplete web session or file can be explained
+func (e *Emitter) logDeliverySummary() {
+	if !e.config.Enabled {
+		return
+	}
+
+	stats := e.Stats()
+	if stats.Failed > 0 {
+		fmt.Fprintf(os.Stderr, "⚠️  Bridge: %d event(s) delivered, %d failed (%d retries)\n",
+			stats.Delivered, stats.Failed, stats.Retried)
+		if e.eventStore != nil {
+			fmt.Fprintf(os.Stderr, "   All events were saved locally to %s\n", e.eventStore.filePath)
+		}
+	} else if e.config.LogLevel == "debug" {
+		fmt.Fprintf(os.Stderr, "Debug: Bridge delivered %d event(s) (%d retries)\n",
+			stats.Delivered, stats.Retried)
 	}
-	return nil
 }
 
 // EmitConversationStarted emits a conversation.started event
@@ -97,7 +141,7 @@ func (e *Emitter) EmitConversationStarted(
 		},
 	}
 	e.saveEventLocally(event)
-	e.client.SendEventAsync(event)
+	e.sink.SendEventAsync(event)
 }
 
 // EmitMessageCreated emits a message.created event
@@ -136,7 +180,7 @@ func (e *Emitter) EmitMessageCreated(
 		},
 	}
 	e.saveEventLocally(event)
-	e.client.SendEventAsync(event)
+	e.sink.SendEventAsync(event)
 }
 
 // EmitConversationCompleted emits a conversation.completed event
@@ -166,7 +210,7 @@ func (e *Emitter) EmitConversationCompleted(
 	}
 	e.saveEventLocally(event)
 	// Use synchronous send for completion event to ensure it's sent before program exit
-	_ = e.client.SendEvent(event)
+	_ = e.sink.SendEvent(event)
 }
 
 // EmitConversationError emits a conversation.error event
@@ -188,7 +232,7 @@ func (e *Emitter) EmitConversationError(
 	}
 	e.saveEventLocally(event)
 	// Use synchronous send for error event to ensure it's sent before program exit
-	_ = e.client.SendEvent(event)
+	_ = e.sink.SendEvent(event)
 }
 
 // emitBridgeConnected emits a bridge.connected event to announce the connection
@@ -204,5 +248,5 @@ func (e *Emitter) emitBridgeConnected() {
 	}
 	e.saveEventLocally(event)
 	// Use synchronous send to ensure connection is announced before proceeding
-	_ = e.client.SendEvent(event)
+	_ = e.sink.SendEvent(event)
 }
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -26,8 +27,8 @@ func TestNewEmitter(t *testing.T) {
 		t.Fatal("Expected emitter to be created")
 	}
 
-	if emitter.client == nil {
-		t.Error("Expected client to be initialized")
+	if _, ok := emitter.sink.(*Client); !ok {
+		t.Errorf("Expected an HTTP client sink, got %T", emitter.sink)
 	}
 
 	// Conversation ID should be a valid UUID
@@ -504,3 +505,84 @@ func TestBridgeConnectedEvent(t *testing.T) {
 		t.Error("Expected connected_at to be non-empty")
 	}
 }
+
+func TestEmitterStats(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Create a flaky server that fails the first 2 requests
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	config := &Config{
+		Enabled:       true,
+		URL:           server.URL,
+		APIKey:        "sk_test",
+		TimeoutMs:     5000,
+		RetryAttempts: 1,
+		LogLevel:      "info",
+	}
+
+	// bridge.connected fails on both attempts
+	emitter := NewEmitter(config, "0.2.4")
+	if got, want := emitter.Stats(), (DeliveryStats{Failed: 1, Retried: 1}); got != want {
+		t.Errorf("after bridge.connected: stats = %+v, want %+v", got, want)
+	}
+
+	// conversation.completed succeeds on the first attempt
+	emitter.EmitConversationCompleted("completed", 2, 1, 100, 0.01, time.Second, nil)
+	if got, want := emitter.Stats(), (DeliveryStats{Delivered: 1, Failed: 1, Retried: 1}); got != want {
+		t.Errorf("after conversation.completed: stats = %+v, want %+v", got, want)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("expected 3 requests, got %d", got)
+	}
+
+	if err := emitter.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+}
+
+func TestEmitterCloseWaitsForAsyncSends(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// A slow server that fails the first request, so the async send retries
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		if requests.Add(1) == 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	config := &Config{
+		Enabled:       true,
+		URL:           server.URL,
+		APIKey:        "sk_test",
+		TimeoutMs:     5000,
+		RetryAttempts: 1,
+		LogLevel:      "info",
+	}
+
+	emitter := NewEmitter(config, "0.2.4")
+	emitter.EmitMessageCreated("agent-1", "claude", "Claude", "Hello", "", 1, 10, 5, 5, 0.001, time.Second)
+	if got := emitter.Stats().Delivered; got != 1 {
+		t.Fatalf("expected only bridge.connected to be delivered before Close, got %d", got)
+	}
+
+	if err := emitter.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+	if got, want := emitter.Stats(), (DeliveryStats{Delivered: 2, Retried: 1}); got != want {
+		t.Errorf("after Close: stats = %+v, want %+v", got, want)
+	}
+}
@@ -156,7 +156,7 @@ type LogEntryData struct {
 	AgentName      string                 `json:"agent_name,omitempty"`
 	AgentType      string                 `json:"agent_type,omitempty"`
 	Content        string                 `json:"content"`
-	Role           string                 `json:"role,omitempty"` // "assistant", "system", "user"
+	Role           string                 `json:"role,omitempty"` // "agent", "system", "user", "tool", or "moderator"
 	Metrics        *LogEntryMetrics       `json:"metrics,omitempty"`
 	Metadata       map[string]interface{} `json:"metadata,omitempty"` // Additional context
 }
@@ -235,6 +235,37 @@ func TestConversationErrorEvent(t *testing.T) {
 	}
 }
 
+func TestLogEntryEventCarriesRole(t *testing.T) {
+	for _, role := range []string{"agent", "system", "user", "tool", "moderator"} {
+		event := &Event{
+			Type:      EventLogEntry,
+			Timestamp: UTCTime{time.Now()},
+			Data: LogEntryData{
+				ConversationID: "test-conv-123",
+				Level:          "message",
+				AgentName:      "Linter",
+				Content:        "2 warnings",
+				Role:           role,
+			},
+		}
+
+		data, err := json.Marshal(event)
+		if err != nil {
+			t.Fatalf("Failed to marshal log.entry event: %v", err)
+		}
+
+		var parsed struct {
+			Data LogEntryData `json:"data"`
+		}
+		if err := json.Unmarshal(data, &parsed); err != nil {
+			t.Fatalf("Failed to unmarshal JSON: %v", err)
+		}
+		if parsed.Data.Role != role {
+			t.Errorf("Expected role=%s, got %q", role, parsed.Data.Role)
+		}
+	}
+}
+
 func TestBridgeTestEvent(t *testing.T) {
 	sysInfo := SystemInfo{
 		AgentPipeVersion: "0.3.3",
@@ -0,0 +1,98 @@
+package bridge
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileSink appends each event as a JSON line (NDJSON) to a local file, for
+// users without AgentPipe Web or to import a conversation later
+type FileSink struct {
+	path string
+	file *os.File
+	mu   sync.Mutex
+
+	delivered int64
+	failed    int64
+}
+
+// NewFileSink opens path for appending, creating it and its directory if needed
+func NewFileSink(path string) (*FileSink, error) {
+	if path == "" {
+		return nil, fmt.Errorf("bridge.file is required when bridge.sink is %s", SinkFile)
+	}
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create bridge file directory: %w", err)
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bridge file: %w", err)
+	}
+
+	return &FileSink{path: path, file: file}, nil
+}
+
+// Path returns the file events are written to
+func (s *FileSink) Path() string {
+	return s.path
+}
+
+// SendEvent appends event to the file as one JSON line
+func (s *FileSink) SendEvent(event *Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		s.mu.Lock()
+		s.failed++
+		s.mu.Unlock()
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		s.failed++
+		return fmt.Errorf("bridge file %s is closed", s.path)
+	}
+	if _, err := s.file.Write(append(data, '\n')); err != nil {
+		s.failed++
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+	s.delivered++
+	return nil
+}
+
+// SendEventAsync writes the event right away. File writes are fast, and
+// writing in order keeps the file's events in sequence. Failures are counted
+// in Stats.
+func (s *FileSink) SendEventAsync(event *Event) {
+	_ = s.SendEvent(event)
+}
+
+// Stats returns how many events were written or failed. The file sink never
+// retries.
+func (s *FileSink) Stats() DeliveryStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return DeliveryStats{Delivered: s.delivered, Failed: s.failed}
+}
+
+// Close closes the file
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
@@ -0,0 +1,150 @@
+package bridge
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readNDJSON decodes each line of the file at path as an Event
+func readNDJSON(t *testing.T, path string) []Event {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", path, err)
+	}
+	defer f.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("Line %d is not a JSON event: %v\n%s", len(events)+1, err, scanner.Text())
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	return events
+}
+
+func TestFileSinkWritesNDJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "events.ndjson")
+
+	sink, err := NewFileSink(path)
+	if err != nil {
+		t.Fatalf("NewFileSink failed: %v", err)
+	}
+
+	if err := sink.SendEvent(&Event{Type: EventConversationStarted, Timestamp: UTCTime{time.Now()}, Data: ConversationStartedData{ConversationID: "conv-1", Mode: "round-robin"}}); err != nil {
+		t.Fatalf("SendEvent failed: %v", err)
+	}
+	sink.SendEventAsync(&Event{Type: EventMessageCreated, Timestamp: UTCTime{time.Now()}, Data: MessageCreatedData{ConversationID: "conv-1", Content: "line one\nline two"}})
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	events := readNDJSON(t, path)
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if events[0].Type != EventConversationStarted || events[1].Type != EventMessageCreated {
+		t.Errorf("Expected started then message events, got %s and %s", events[0].Type, events[1].Type)
+	}
+	data, ok := events[1].Data.(map[string]interface{})
+	if !ok || data["content"] != "line one\nline two" {
+		t.Errorf("Expected the multi-line content to round-trip, got %v", events[1].Data)
+	}
+
+	if got, want := sink.Stats(), (DeliveryStats{Delivered: 2}); got != want {
+		t.Errorf("Expected stats %+v, got %+v", want, got)
+	}
+
+	// Writing after Close fails and is counted
+	if err := sink.SendEvent(&Event{Type: EventConversationError}); err == nil {
+		t.Error("Expected an error writing to a closed sink")
+	}
+	if sink.Stats().Failed != 1 {
+		t.Errorf("Expected 1 failed event, got %d", sink.Stats().Failed)
+	}
+}
+
+func TestFileSinkAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+
+	for i := 0; i < 2; i++ {
+		sink, err := NewFileSink(path)
+		if err != nil {
+			t.Fatalf("NewFileSink failed: %v", err)
+		}
+		if err := sink.SendEvent(&Event{Type: EventBridgeTest, Timestamp: UTCTime{time.Now()}}); err != nil {
+			t.Fatalf("SendEvent failed: %v", err)
+		}
+		sink.Close()
+	}
+
+	if events := readNDJSON(t, path); len(events) != 2 {
+		t.Errorf("Expected 2 events after reopening the file, got %d", len(events))
+	}
+}
+
+func TestNewSink(t *testing.T) {
+	if sink, err := NewSink(&Config{Sink: SinkHTTP}); err != nil {
+		t.Errorf("Expected an HTTP sink, got error %v", err)
+	} else if _, ok := sink.(*Client); !ok {
+		t.Errorf("Expected *Client, got %T", sink)
+	}
+
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+	sink, err := NewSink(&Config{Sink: SinkFile, File: path})
+	if err != nil {
+		t.Fatalf("Expected a file sink, got error %v", err)
+	}
+	defer sink.Close()
+	if fileSink, ok := sink.(*FileSink); !ok || fileSink.Path() != path {
+		t.Errorf("Expected a *FileSink writing to %s, got %T", path, sink)
+	}
+
+	if _, err := NewSink(&Config{Sink: SinkFile}); err == nil || !strings.Contains(err.Error(), "bridge.file") {
+		t.Errorf("Expected an error about the missing bridge.file, got %v", err)
+	}
+	if _, err := NewSink(&Config{Sink: "kafka"}); err == nil || !strings.Contains(err.Error(), "kafka") {
+		t.Errorf("Expected an unknown sink error, got %v", err)
+	}
+}
+
+func TestEmitterWithFileSink(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+
+	config := &Config{Enabled: true, Sink: SinkFile, File: path, LogLevel: "info"}
+	sink, err := NewSink(config)
+	if err != nil {
+		t.Fatalf("NewSink failed: %v", err)
+	}
+
+	emitter := NewEmitterWithSink(config, sink, "0.2.4")
+	emitter.EmitMessageCreated("agent-1", "claude", "Claude", "Hello", "", 1, 10, 5, 5, 0.001, time.Second)
+	emitter.EmitConversationCompleted("completed", 1, 1, 10, 0.001, time.Second, nil)
+	if err := emitter.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	events := readNDJSON(t, path)
+	want := []EventType{EventBridgeConnected, EventMessageCreated, EventConversationCompleted}
+	if len(events) != len(want) {
+		t.Fatalf("Expected %d events, got %d", len(want), len(events))
+	}
+	for i, event := range events {
+		if event.Type != want[i] {
+			t.Errorf("Event %d: expected type %s, got %s", i, want[i], event.Type)
+		}
+	}
+}
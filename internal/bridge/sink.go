@@ -0,0 +1,28 @@
+package bridge
+
+import "fmt"
+
+// Sink is a destination for bridge events. The HTTP Client sends them to
+// AgentPipe Web; FileSink writes them to a local file.
+type Sink interface {
+	// SendEvent delivers an event, returning an error if it was dropped
+	SendEvent(event *Event) error
+	// SendEventAsync delivers an event without blocking the conversation
+	SendEventAsync(event *Event)
+	// Stats returns the delivery counts so far
+	Stats() DeliveryStats
+	// Close releases the sink's resources
+	Close() error
+}
+
+// NewSink returns the sink selected by config.Sink
+func NewSink(config *Config) (Sink, error) {
+	switch config.Sink {
+	case "", SinkHTTP:
+		return NewClient(config), nil
+	case SinkFile:
+		return NewFileSink(config.File)
+	default:
+		return nil, fmt.Errorf("unknown bridge sink %q (must be %s or %s)", config.Sink, SinkHTTP, SinkFile)
+	}
+}
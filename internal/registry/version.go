@@ -10,6 +10,12 @@ import (
 	"time"
 )
 
+// HTTPClient is the client used to fetch latest versions from package
+// registries. Tests and proxy setups can replace it.
+var HTTPClient = &http.Client{
+	Timeout: 10 * time.Second,
+}
+
 // VersionInfo contains version information for an agent
 type VersionInfo struct {
 	Installed string // Version currently installed (empty if not installed)
@@ -42,11 +48,7 @@ func getNPMLatestVersion(packageName string) (string, error) {
 	// Use npm registry API
 	url := fmt.Sprintf("https://registry.npmjs.org/%s/latest", packageName)
 
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	resp, err := client.Get(url)
+	resp, err := HTTPClient.Get(url)
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch npm package info: %w", err)
 	}
@@ -81,11 +83,7 @@ func getHomebrewLatestVersion(formulaName string) (string, error) {
 	// Use Homebrew Formulae API
 	url := fmt.Sprintf("https://formulae.brew.sh/api/formula/%s.json", formulaName)
 
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	resp, err := client.Get(url)
+	resp, err := HTTPClient.Get(url)
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch homebrew formula info: %w", err)
 	}
@@ -123,10 +121,6 @@ func getGitHubLatestRelease(repoName string) (string, error) {
 	// repoName should be in format "owner/repo"
 	url := fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", repoName)
 
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
@@ -136,7 +130,7 @@ func getGitHubLatestRelease(repoName string) (string, error) {
 	req.Header.Set("User-Agent", "agentpipe-cli")
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 
-	resp, err := client.Do(req)
+	resp, err := HTTPClient.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch github release info: %w", err)
 	}
@@ -184,11 +178,7 @@ func getScriptVersion(scriptURL string) (string, error) {
 }
 
 func fetchScriptContent(scriptURL string) (string, error) {
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	resp, err := client.Get(scriptURL)
+	resp, err := HTTPClient.Get(scriptURL)
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch script: %w", err)
 	}
@@ -268,11 +258,7 @@ func isExcludedPart(part string) bool {
 
 // getManifestVersion fetches version from a JSON manifest with "latest" field
 func getManifestVersion(manifestURL string) (string, error) {
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	resp, err := client.Get(manifestURL)
+	resp, err := HTTPClient.Get(manifestURL)
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch manifest: %w", err)
 	}
@@ -307,11 +293,7 @@ func getPyPILatestVersion(packageName string) (string, error) {
 	// Use PyPI JSON API
 	url := fmt.Sprintf("https://pypi.org/pypi/%s/json", packageName)
 
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	resp, err := client.Get(url)
+	resp, err := HTTPClient.Get(url)
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch PyPI package info: %w", err)
 	}
@@ -450,20 +432,52 @@ func containsDigit(s string) bool {
 //	 0 if v1 == v2
 //	 1 if v1 > v2
 //	error if versions cannot be parsed
+//
+// Versions with a numeric core follow semver precedence: build metadata
+// ("+build") is ignored, missing core parts count as 0, and a pre-release
+// ("-rc1") is lower than its release. Other strings fall back to comparing
+// the numeric prefix of each dot-separated part.
 func CompareVersions(v1, v2 string) (int, error) {
-	// Simple semantic version comparison
-	// Split on dots and compare each part
-
 	// Clean versions
 	v1 = strings.TrimPrefix(v1, "v")
 	v1 = strings.TrimPrefix(v1, "V")
 	v2 = strings.TrimPrefix(v2, "v")
 	v2 = strings.TrimPrefix(v2, "V")
 
-	parts1 := strings.Split(v1, ".")
-	parts2 := strings.Split(v2, ".")
+	core1, pre1, ok1 := parseSemver(v1)
+	core2, pre2, ok2 := parseSemver(v2)
+	if !ok1 || !ok2 {
+		return compareNumericParts(strings.Split(v1, "."), strings.Split(v2, ".")), nil
+	}
+
+	if cmp := compareNumericParts(core1, core2); cmp != 0 {
+		return cmp, nil
+	}
+	return comparePreRelease(pre1, pre2), nil
+}
+
+// parseSemver splits v into its dot-separated core parts and its pre-release,
+// dropping any build metadata. ok is false if a core part is not a number.
+func parseSemver(v string) (core []string, preRelease string, ok bool) {
+	if i := strings.Index(v, "+"); i >= 0 {
+		v = v[:i]
+	}
+	if i := strings.Index(v, "-"); i >= 0 {
+		v, preRelease = v[:i], v[i+1:]
+	}
+
+	core = strings.Split(v, ".")
+	for _, part := range core {
+		if !isNumeric(part) {
+			return nil, "", false
+		}
+	}
+	return core, preRelease, true
+}
 
-	// Compare each part
+// compareNumericParts compares versions part by part using the numeric prefix
+// of each part; a missing part counts as 0.
+func compareNumericParts(parts1, parts2 []string) int {
 	maxLen := len(parts1)
 	if len(parts2) > maxLen {
 		maxLen = len(parts2)
@@ -484,14 +498,81 @@ func CompareVersions(v1, v2 string) (int, error) {
 		}
 
 		if p1 < p2 {
-			return -1, nil
+			return -1
 		}
 		if p1 > p2 {
-			return 1, nil
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// comparePreRelease compares two pre-release strings by semver precedence. A
+// version without a pre-release is higher than one with it; otherwise the
+// dot-separated identifiers are compared in turn, numeric ones numerically
+// and below alphanumeric ones, and a shorter list of equal identifiers is
+// lower.
+func comparePreRelease(pre1, pre2 string) int {
+	switch {
+	case pre1 == pre2:
+		return 0
+	case pre1 == "":
+		return 1
+	case pre2 == "":
+		return -1
+	}
+
+	ids1 := strings.Split(pre1, ".")
+	ids2 := strings.Split(pre2, ".")
+	for i := 0; i < len(ids1) && i < len(ids2); i++ {
+		if cmp := comparePreReleaseID(ids1[i], ids2[i]); cmp != 0 {
+			return cmp
+		}
+	}
+
+	switch {
+	case len(ids1) < len(ids2):
+		return -1
+	case len(ids1) > len(ids2):
+		return 1
+	}
+	return 0
+}
+
+// comparePreReleaseID compares one pre-release identifier of each version.
+func comparePreReleaseID(id1, id2 string) int {
+	num1, num2 := isNumeric(id1), isNumeric(id2)
+	switch {
+	case num1 && num2:
+		// Compare by length first so long numbers don't overflow
+		id1 = strings.TrimLeft(id1, "0")
+		id2 = strings.TrimLeft(id2, "0")
+		if len(id1) != len(id2) {
+			if len(id1) < len(id2) {
+				return -1
+			}
+			return 1
 		}
+	case num1:
+		return -1
+	case num2:
+		return 1
 	}
+	return strings.Compare(id1, id2)
+}
 
-	return 0, nil
+// isNumeric reports whether s is a non-empty string of digits.
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
 }
 
 // extractNumericPrefix extracts the numeric prefix from a version part
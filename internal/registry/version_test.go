@@ -0,0 +1,138 @@
+package registry
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		name string
+		v1   string
+		v2   string
+		want int
+	}{
+		{"equal", "1.2.3", "1.2.3", 0},
+		{"patch lower", "1.2.3", "1.2.4", -1},
+		{"minor higher", "1.10.0", "1.9.9", 1},
+		{"v prefix", "v1.2.3", "1.2.3", 0},
+		{"shorter equal", "1.2", "1.2.0", 0},
+		{"shorter lower", "1.2", "1.2.1", -1},
+		{"longer higher", "1.2.0.1", "1.2", 1},
+		{"pre-release below release", "1.2.0-rc1", "1.2.0", -1},
+		{"release above pre-release", "1.2.0", "1.2.0-rc1", 1},
+		{"rc ordering", "1.2.0-rc1", "1.2.0-rc2", -1},
+		{"numeric identifiers compare numerically", "1.0.0-rc.2", "1.0.0-rc.10", -1},
+		{"numeric identifier below alphanumeric", "1.0.0-1", "1.0.0-alpha", -1},
+		{"alpha below beta", "1.0.0-alpha", "1.0.0-beta", -1},
+		{"fewer identifiers lower", "1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"pre-release of next version higher", "1.3.0-rc1", "1.2.9", 1},
+		{"build metadata ignored", "1.2.0+build.5", "1.2.0", 0},
+		{"build metadata ignored on pre-release", "1.2.0-rc1+abc", "1.2.0-rc1", 0},
+		{"build metadata with pre-release", "1.2.0-rc1+abc", "1.2.0", -1},
+		{"non-semver fallback", "2024.10.1b", "2024.10.2", -1},
+		{"non-semver fallback equal", "3beta.1", "3.1", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CompareVersions(tt.v1, tt.v2)
+			if err != nil {
+				t.Fatalf("CompareVersions(%q, %q) failed: %v", tt.v1, tt.v2, err)
+			}
+			if got != tt.want {
+				t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.v1, tt.v2, got, tt.want)
+			}
+		})
+	}
+}
+
+// rewriteTransport sends every request to a test server, keeping its path.
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (rt rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = rt.target.Scheme
+	req.URL.Host = rt.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// useTestServer points HTTPClient at a test server serving handler for the
+// duration of the test.
+func useTestServer(t *testing.T, handler http.Handler) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	target, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("invalid test server URL: %v", err)
+	}
+
+	orig := HTTPClient
+	HTTPClient = &http.Client{Transport: rewriteTransport{target: target}}
+	t.Cleanup(func() { HTTPClient = orig })
+}
+
+func TestGetLatestVersion(t *testing.T) {
+	responses := map[string]string{
+		"/@scope/pkg/latest":                `{"version": "1.2.3"}`,
+		"/api/formula/tool.json":            `{"versions": {"stable": "2.0.1"}}`,
+		"/repos/owner/repo/releases/latest": `{"tag_name": "v3.4.5"}`,
+		"/install.sh":                       "#!/bin/sh\nVER=\"0.9.0\"\n",
+		"/manifest.json":                    `{"latest": "4.0.0-rc1"}`,
+		"/pypi/tool/json":                   `{"info": {"version": "5.6.7"}}`,
+	}
+	useTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+
+	tests := []struct {
+		packageManager string
+		packageName    string
+		want           string
+	}{
+		{"npm", "@scope/pkg", "1.2.3"},
+		{"homebrew", "tool", "2.0.1"},
+		{"github", "owner/repo", "3.4.5"},
+		{"script", "https://example.com/install.sh", "0.9.0"},
+		{"manifest", "https://example.com/manifest.json", "4.0.0-rc1"},
+		{"pypi", "tool", "5.6.7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.packageManager, func(t *testing.T) {
+			a := &AgentDefinition{Name: "Test", PackageManager: tt.packageManager, PackageName: tt.packageName}
+			got, err := a.GetLatestVersion()
+			if err != nil {
+				t.Fatalf("GetLatestVersion failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetLatestVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetLatestVersionErrorStatus(t *testing.T) {
+	useTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+
+	a := &AgentDefinition{Name: "Test", PackageManager: "npm", PackageName: "pkg"}
+	_, err := a.GetLatestVersion()
+	if err == nil || !strings.Contains(err.Error(), "status 503") {
+		t.Errorf("expected a status 503 error, got %v", err)
+	}
+}
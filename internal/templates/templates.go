@@ -0,0 +1,66 @@
+// Package templates provides built-in conversation configs for common setups,
+// usable with "agentpipe run --template <name>".
+package templates
+
+import (
+	"embed"
+	"fmt"
+	"sort"
+
+	"github.com/kevinelliott/agentpipe/pkg/config"
+)
+
+//go:embed *.yaml
+var templatesFS embed.FS
+
+// Template describes a built-in conversation template.
+type Template struct {
+	// Name is the template name passed to --template
+	Name string `json:"name"`
+	// Description is a one-line summary of the conversation
+	Description string `json:"description"`
+}
+
+// descriptions lists every embedded template; each has a <name>.yaml file.
+var descriptions = map[string]string{
+	"brainstorm":  "Free-form ideation between a creative director, an engineer, and a user advocate",
+	"debate":      "A proponent and an opponent argue a motion while a moderator keeps score",
+	"code-review": "An author defends a change to a security reviewer and a maintainability reviewer",
+	"interview":   "An interviewer runs a technical interview with a candidate",
+}
+
+// List returns the built-in templates sorted by name.
+func List() []Template {
+	list := make([]Template, 0, len(descriptions))
+	for name, description := range descriptions {
+		list = append(list, Template{Name: name, Description: description})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Load parses the named template into a validated config with defaults applied.
+func Load(name string) (*config.Config, error) {
+	if _, ok := descriptions[name]; !ok {
+		return nil, fmt.Errorf("unknown template: %s (run 'agentpipe templates list')", name)
+	}
+
+	data, err := templatesFS.ReadFile(name + ".yaml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template %s: %w", name, err)
+	}
+
+	cfg, err := config.ParseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template %s: %w", name, err)
+	}
+	return cfg, nil
+}
+
+// Source returns the raw YAML of the named template.
+func Source(name string) ([]byte, error) {
+	if _, ok := descriptions[name]; !ok {
+		return nil, fmt.Errorf("unknown template: %s", name)
+	}
+	return templatesFS.ReadFile(name + ".yaml")
+}
@@ -0,0 +1,56 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedTemplatesParse(t *testing.T) {
+	list := List()
+	if len(list) < 4 {
+		t.Fatalf("expected at least 4 templates, got %d", len(list))
+	}
+
+	for _, tmpl := range list {
+		t.Run(tmpl.Name, func(t *testing.T) {
+			cfg, err := Load(tmpl.Name)
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if err := cfg.Validate(); err != nil {
+				t.Errorf("template config is invalid: %v", err)
+			}
+			if len(cfg.Agents) < 2 {
+				t.Errorf("expected at least 2 agents, got %d", len(cfg.Agents))
+			}
+			if cfg.Orchestrator.InitialPrompt == "" {
+				t.Error("expected an initial prompt")
+			}
+			if tmpl.Description == "" {
+				t.Error("expected a description")
+			}
+		})
+	}
+}
+
+func TestEveryEmbeddedFileIsListed(t *testing.T) {
+	entries, err := templatesFS.ReadDir(".")
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".yaml")
+		if _, ok := descriptions[name]; !ok {
+			t.Errorf("embedded template %s has no description", entry.Name())
+		}
+	}
+	if len(entries) != len(descriptions) {
+		t.Errorf("expected %d embedded files, got %d", len(descriptions), len(entries))
+	}
+}
+
+func TestLoadUnknownTemplate(t *testing.T) {
+	if _, err := Load("no-such-template"); err == nil || !strings.Contains(err.Error(), "unknown template") {
+		t.Errorf("expected unknown template error, got %v", err)
+	}
+}
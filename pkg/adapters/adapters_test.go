@@ -2,6 +2,12 @@ package adapters
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -550,3 +556,451 @@ func TestConversationFormatting(t *testing.T) {
 		}
 	})
 }
+
+func TestMCPConfigArgs(t *testing.T) {
+	servers := map[string]agent.MCPServer{
+		"filesystem": {Command: "npx", Args: []string{"-y", "@modelcontextprotocol/server-filesystem", "/tmp"}},
+		"docs":       {Type: "http", URL: "https://example.com/mcp", Headers: map[string]string{"Authorization": "Bearer x"}},
+	}
+
+	claude := &ClaudeAgent{}
+	claude.Config = agent.AgentConfig{Model: "claude-sonnet-4-5", MCPServers: servers}
+	args, err := claude.buildArgs()
+	if err != nil {
+		t.Fatalf("buildArgs failed: %v", err)
+	}
+	if len(args) != 4 || args[0] != "--model" || args[2] != "--mcp-config" {
+		t.Fatalf("unexpected claude args: %v", args)
+	}
+
+	var decoded struct {
+		MCPServers map[string]agent.MCPServer `json:"mcpServers"`
+	}
+	if err := json.Unmarshal([]byte(args[3]), &decoded); err != nil {
+		t.Fatalf("--mcp-config is not valid JSON: %v", err)
+	}
+	fs := decoded.MCPServers["filesystem"]
+	if fs.Command != "npx" || len(fs.Args) != 3 || fs.URL != "" {
+		t.Errorf("unexpected filesystem server: %+v", fs)
+	}
+	if docs := decoded.MCPServers["docs"]; docs.Type != "http" || docs.URL != "https://example.com/mcp" || docs.Headers["Authorization"] != "Bearer x" {
+		t.Errorf("unexpected docs server: %+v", docs)
+	}
+	if strings.Contains(args[3], `"command":""`) {
+		t.Errorf("expected empty fields to be omitted: %s", args[3])
+	}
+
+	amp := &AmpAgent{threadID: "T-1"}
+	amp.Config = agent.AgentConfig{MCPServers: servers}
+	ampArgs, err := amp.threadArgs("continue", amp.threadID)
+	if err != nil {
+		t.Fatalf("threadArgs failed: %v", err)
+	}
+	if len(ampArgs) != 5 || ampArgs[0] != "--mcp-config" || ampArgs[1] != args[3] ||
+		strings.Join(ampArgs[2:], " ") != "thread continue T-1" {
+		t.Errorf("unexpected amp args: %v", ampArgs)
+	}
+}
+
+func TestMCPConfigArgsWithoutServers(t *testing.T) {
+	claude := &ClaudeAgent{}
+	args, err := claude.buildArgs()
+	if err != nil || len(args) != 0 {
+		t.Errorf("expected no args without model or MCP servers, got %v (err=%v)", args, err)
+	}
+
+	amp := &AmpAgent{}
+	ampArgs, err := amp.threadArgs("new", "--stream-json")
+	if err != nil || strings.Join(ampArgs, " ") != "thread new --stream-json" {
+		t.Errorf("unexpected amp args: %v (err=%v)", ampArgs, err)
+	}
+}
+
+// writeStubCLI writes an executable shell script and returns its path.
+func writeStubCLI(t *testing.T, name, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("stub CLI scripts require a POSIX shell")
+	}
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755); err != nil {
+		t.Fatalf("failed to write stub %s: %v", name, err)
+	}
+	return path
+}
+
+// newSlowCursorAgent returns a cursor agent whose CLI is a stub that never answers.
+func newSlowCursorAgent(t *testing.T, config agent.AgentConfig) *CursorAgent {
+	t.Helper()
+
+	c := &CursorAgent{execPath: writeStubCLI(t, "cursor-agent", "exec sleep 30\n")}
+	if err := c.BaseAgent.Initialize(config); err != nil {
+		t.Fatalf("initialization failed: %v", err)
+	}
+	return c
+}
+
+func TestStreamHonorsContextDeadline(t *testing.T) {
+	c := newSlowCursorAgent(t, agent.AgentConfig{ID: "cursor-1", Type: "cursor", Name: "Cursor"})
+	messages := []agent.Message{{AgentID: "host", AgentName: "HOST", Content: "Hello", Role: "system"}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.SendMessage(ctx, messages)
+	elapsed := time.Since(start)
+
+	if err == nil || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected a deadline exceeded error, got: %v", err)
+	}
+	if elapsed > 5*time.Second {
+		t.Errorf("expected the call to end at the context deadline, took %v", elapsed)
+	}
+}
+
+func TestStreamTimeoutOverride(t *testing.T) {
+	c := newSlowCursorAgent(t, agent.AgentConfig{
+		ID:            "cursor-1",
+		Type:          "cursor",
+		Name:          "Cursor",
+		StreamTimeout: 200 * time.Millisecond,
+	})
+	messages := []agent.Message{{AgentID: "host", AgentName: "HOST", Content: "Hello", Role: "system"}}
+
+	start := time.Now()
+	_, err := c.SendMessage(context.Background(), messages)
+	elapsed := time.Since(start)
+
+	if err == nil || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected a deadline exceeded error, got: %v", err)
+	}
+	if elapsed > 5*time.Second {
+		t.Errorf("expected the call to end at the stream timeout, took %v", elapsed)
+	}
+}
+
+func TestParseClaudeJSON(t *testing.T) {
+	output := `{"type":"result","subtype":"success","is_error":false,"duration_ms":2841,"num_turns":1,` +
+		`"result":"Use a token bucket.\n","session_id":"abc","total_cost_usd":0.0123,` +
+		`"usage":{"input_tokens":12,"cache_creation_input_tokens":300,"cache_read_input_tokens":1500,"output_tokens":87}}`
+
+	text, usage, err := parseClaudeJSON(output)
+	if err != nil {
+		t.Fatalf("parseClaudeJSON failed: %v", err)
+	}
+	if text != "Use a token bucket." {
+		t.Errorf("expected assistant text, got %q", text)
+	}
+	if usage.InputTokens != 1812 || usage.OutputTokens != 87 {
+		t.Errorf("expected input=1812 output=87, got %+v", usage)
+	}
+
+	if _, _, err := parseClaudeJSON(`{"type":"result","is_error":true,"result":"Credit balance is too low"}`); err == nil ||
+		!strings.Contains(err.Error(), "Credit balance") {
+		t.Errorf("expected the CLI error to be returned, got %v", err)
+	}
+	if _, _, err := parseClaudeJSON("not json"); err == nil {
+		t.Error("expected an error for non-JSON output")
+	}
+}
+
+func TestParseGeminiJSON(t *testing.T) {
+	output := "Loaded cached credentials.\n" +
+		`{"response":"Postgres fits best.","stats":{"models":{` +
+		`"gemini-2.5-pro":{"api":{"totalRequests":1},"tokens":{"prompt":900,"candidates":40,"total":940,"cached":0,"thoughts":0}},` +
+		`"gemini-2.5-flash":{"api":{"totalRequests":1},"tokens":{"prompt":100,"candidates":10,"total":110}}}}}`
+
+	text, usage, err := parseGeminiJSON(output)
+	if err != nil {
+		t.Fatalf("parseGeminiJSON failed: %v", err)
+	}
+	if text != "Postgres fits best." {
+		t.Errorf("expected assistant text, got %q", text)
+	}
+	if usage.InputTokens != 1000 || usage.OutputTokens != 50 {
+		t.Errorf("expected input=1000 output=50, got %+v", usage)
+	}
+
+	if _, _, err := parseGeminiJSON(`{"error":{"type":"ApiError","message":"quota exceeded","code":429}}`); err == nil ||
+		!strings.Contains(err.Error(), "quota exceeded") {
+		t.Errorf("expected the API error to be returned, got %v", err)
+	}
+}
+
+func TestClaudeBuildArgsJSONOutput(t *testing.T) {
+	c := &ClaudeAgent{}
+	c.Config = agent.AgentConfig{Model: "claude-sonnet-4-5", UseJSONOutput: true}
+
+	args, err := c.buildArgs()
+	if err != nil {
+		t.Fatalf("buildArgs failed: %v", err)
+	}
+	if got := strings.Join(args, " "); got != "--print --output-format json --model claude-sonnet-4-5" {
+		t.Errorf("unexpected args: %s", got)
+	}
+
+	c.Config.UseJSONOutput = false
+	args, _ = c.buildArgs()
+	if strings.Contains(strings.Join(args, " "), "--output-format") {
+		t.Errorf("expected plain output without UseJSONOutput, got %v", args)
+	}
+}
+
+func TestAmpStreamFallsBackToRawOutput(t *testing.T) {
+	script := "cat >/dev/null\n" +
+		"echo '{\"thread_id\":\"T-123\"}'\n" +
+		"echo '{\"kind\":\"answer\",\"body\":\"Use Postgres.\"}'\n"
+	a := &AmpAgent{execPath: writeStubCLI(t, "amp", script)}
+	if err := a.BaseAgent.Initialize(agent.AgentConfig{ID: "amp-1", Type: "amp", Name: "Amp"}); err != nil {
+		t.Fatalf("initialization failed: %v", err)
+	}
+
+	var out strings.Builder
+	messages := []agent.Message{{AgentID: "host", AgentName: "HOST", Content: "Pick a database", Role: "system"}}
+	if err := a.StreamMessage(context.Background(), messages, &out); err != nil {
+		t.Fatalf("expected unrecognized output to be surfaced, got error: %v", err)
+	}
+
+	if out.String() != "Use Postgres." {
+		t.Errorf("expected the text of the raw output, got %q", out.String())
+	}
+	if strings.Contains(out.String(), "T-123") {
+		t.Errorf("expected the thread ID line to be left out, got %q", out.String())
+	}
+	if a.threadID != "T-123" {
+		t.Errorf("expected thread ID T-123, got %q", a.threadID)
+	}
+}
+
+func TestAmpContinueThreadSendsEveryNewMessage(t *testing.T) {
+	execPath := writeStubCLI(t, "amp", "cat > \"$0.prompt\"\necho ok\n")
+	a := &AmpAgent{execPath: execPath, threadID: "T-123", lastMessageIdx: 1}
+	if err := a.BaseAgent.Initialize(agent.AgentConfig{ID: "amp-1", Type: "amp", Name: "Amp", HistoryWindow: 1}); err != nil {
+		t.Fatalf("initialization failed: %v", err)
+	}
+
+	messages := []agent.Message{
+		{AgentID: "host", AgentName: "HOST", Content: "Pick a database", Role: "system"},
+		{AgentID: "claude", AgentName: "Claude", Content: "Postgres", Role: "agent"},
+		{AgentID: "gemini", AgentName: "Gemini", Content: "SQLite", Role: "agent"},
+		{AgentID: "codex", AgentName: "Codex", Content: "MySQL", Role: "agent"},
+	}
+	if _, err := a.SendMessage(context.Background(), messages); err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+
+	prompt, err := os.ReadFile(execPath + ".prompt")
+	if err != nil {
+		t.Fatalf("failed to read the prompt sent to amp: %v", err)
+	}
+	// The history window limits the context of a new thread, not the delta
+	for _, content := range []string{"Postgres", "SQLite", "MySQL"} {
+		if !strings.Contains(string(prompt), content) {
+			t.Errorf("expected the thread to receive %q, got prompt:\n%s", content, prompt)
+		}
+	}
+	if a.lastMessageIdx != len(messages) {
+		t.Errorf("expected lastMessageIdx %d, got %d", len(messages), a.lastMessageIdx)
+	}
+}
+
+func TestAmpResetSessionStartsNewThread(t *testing.T) {
+	a := &AmpAgent{threadID: "T-123", lastMessageIdx: 1}
+
+	var _ agent.Stateful = a
+	a.ResetSession()
+
+	if a.threadID != "" || a.lastMessageIdx != 0 {
+		t.Errorf("expected the thread to be forgotten, got thread %q at index %d", a.threadID, a.lastMessageIdx)
+	}
+}
+
+func TestCursorStreamFallsBackToRawOutput(t *testing.T) {
+	script := "cat >/dev/null\necho '{\"event\":\"final\",\"payload\":\"Use Postgres.\"}'\n"
+	c := &CursorAgent{execPath: writeStubCLI(t, "cursor-agent", script)}
+	if err := c.BaseAgent.Initialize(agent.AgentConfig{ID: "cursor-1", Type: "cursor", Name: "Cursor"}); err != nil {
+		t.Fatalf("initialization failed: %v", err)
+	}
+
+	messages := []agent.Message{{AgentID: "host", AgentName: "HOST", Content: "Pick a database", Role: "system"}}
+	response, err := c.SendMessage(context.Background(), messages)
+	if err != nil {
+		t.Fatalf("expected unrecognized output to be surfaced, got error: %v", err)
+	}
+	if response != "Use Postgres." {
+		t.Errorf("expected the text of the raw output, got %q", response)
+	}
+}
+
+func TestAmpStreamFallbackStopsOnCancellation(t *testing.T) {
+	script := "cat >/dev/null\n" +
+		"echo '{\"thread_id\":\"T-123\"}'\n" +
+		"echo '{\"kind\":\"answer\",\"body\":\"Use Postgres.\"}'\n" +
+		"exec sleep 30\n"
+	a := &AmpAgent{execPath: writeStubCLI(t, "amp", script)}
+	if err := a.BaseAgent.Initialize(agent.AgentConfig{ID: "amp-1", Type: "amp", Name: "Amp"}); err != nil {
+		t.Fatalf("initialization failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	var out strings.Builder
+	messages := []agent.Message{{AgentID: "host", AgentName: "HOST", Content: "Pick a database", Role: "system"}}
+	if err := a.StreamMessage(ctx, messages, &out); err == nil {
+		t.Fatal("expected an error when the context is cancelled")
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected no output after cancellation, got %q", out.String())
+	}
+}
+
+func TestUnparsedStreamText(t *testing.T) {
+	raw := `{"type":"message","id":"m-1","payload":{"parts":["Use","Postgres."]}}
+not json
+{"session_id":"s-1"}
+`
+	if got, want := unparsedStreamText(raw), "Use\nPostgres.\nnot json"; got != want {
+		t.Errorf("unparsedStreamText() = %q, want %q", got, want)
+	}
+}
+
+func TestAmpStreamReportsUsage(t *testing.T) {
+	tests := []struct {
+		name   string
+		lines  []string
+		want   agent.Usage
+		wantOK bool
+	}{
+		{
+			name: "result usage",
+			lines: []string{
+				`{"type":"assistant","message":{"content":[{"type":"text","text":"Use"}],"usage":{"input_tokens":10,"output_tokens":2}}}`,
+				`{"type":"text","content":"Use Postgres."}`,
+				`{"type":"result","result":"Use Postgres.","usage":{"input_tokens":40,"cache_read_input_tokens":1000,"output_tokens":25}}`,
+			},
+			want:   agent.Usage{InputTokens: 1040, OutputTokens: 25},
+			wantOK: true,
+		},
+		{
+			name: "assistant message usage is summed",
+			lines: []string{
+				`{"type":"assistant","message":{"usage":{"input_tokens":100,"output_tokens":5}}}`,
+				`{"type":"text","content":"Use Postgres."}`,
+				`{"type":"assistant","message":{"usage":{"input_tokens":150,"cache_creation_input_tokens":50,"output_tokens":20}}}`,
+			},
+			want:   agent.Usage{InputTokens: 300, OutputTokens: 25},
+			wantOK: true,
+		},
+		{
+			name:  "no usage",
+			lines: []string{`{"type":"text","content":"Use Postgres."}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script := "cat >/dev/null\necho '{\"thread_id\":\"T-123\"}'\n"
+			for _, line := range tt.lines {
+				script += "echo '" + line + "'\n"
+			}
+			a := &AmpAgent{execPath: writeStubCLI(t, "amp", script)}
+			if err := a.BaseAgent.Initialize(agent.AgentConfig{ID: "amp-1", Type: "amp", Name: "Amp"}); err != nil {
+				t.Fatalf("initialization failed: %v", err)
+			}
+			a.setUsage(&agent.Usage{InputTokens: 1, OutputTokens: 1}) // left over from an earlier call
+
+			var out strings.Builder
+			messages := []agent.Message{{AgentID: "host", AgentName: "HOST", Content: "Pick a database", Role: "system"}}
+			if err := a.StreamMessage(context.Background(), messages, &out); err != nil {
+				t.Fatalf("stream failed: %v", err)
+			}
+			if !strings.Contains(out.String(), "Use Postgres.") {
+				t.Errorf("expected the response text, got %q", out.String())
+			}
+
+			usage, ok := a.LastUsage()
+			if ok != tt.wantOK || usage != tt.want {
+				t.Errorf("expected usage %+v (reported: %v), got %+v (reported: %v)", tt.want, tt.wantOK, usage, ok)
+			}
+		})
+	}
+}
+
+func TestFilterRelevantMessagesTrimsToContextBudget(t *testing.T) {
+	g := &GroqAgent{}
+	if err := g.BaseAgent.Initialize(agent.AgentConfig{ID: "groq-1", Type: "groq", Name: "Groq", MaxContextTokens: 50}); err != nil {
+		t.Fatalf("initialization failed: %v", err)
+	}
+
+	long := strings.Repeat("word ", 60)
+	messages := []agent.Message{
+		{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Plan a trip to Lisbon"},
+		{AgentID: "claude", AgentName: "Claude", Role: "agent", Content: "Start in Alfama. " + long},
+		{AgentID: "groq-1", AgentName: "Groq", Role: "agent", Content: "My own earlier reply"},
+		{AgentID: "gemini", AgentName: "Gemini", Role: "agent", Content: "Then Belém. " + long},
+		{AgentID: "claude", AgentName: "Claude", Role: "agent", Content: "And Sintra for a day."},
+	}
+
+	relevant := g.filterRelevantMessages(messages)
+	if len(relevant) != 2 {
+		t.Fatalf("expected the initial prompt and the latest turn, got %d messages", len(relevant))
+	}
+	if relevant[0].Content != "Plan a trip to Lisbon" || relevant[1].Content != "And Sintra for a day." {
+		t.Errorf("unexpected messages: %q, %q", relevant[0].Content, relevant[1].Content)
+	}
+}
+
+func TestHistoryWindowLimitsPrompt(t *testing.T) {
+	c := &ClaudeAgent{}
+	if err := c.BaseAgent.Initialize(agent.AgentConfig{ID: "claude-1", Type: "claude", Name: "Claude", HistoryWindow: 3}); err != nil {
+		t.Fatalf("initialization failed: %v", err)
+	}
+
+	messages := []agent.Message{{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Plan a trip to Lisbon"}}
+	for i := 1; i <= 20; i++ {
+		messages = append(messages, agent.Message{AgentID: "gemini", AgentName: "Gemini", Role: "agent", Content: fmt.Sprintf("Stop number %d.", i)})
+	}
+
+	prompt := c.buildPrompt(c.filterRelevantMessages(messages), true)
+
+	if !strings.Contains(prompt, "Plan a trip to Lisbon") {
+		t.Error("expected the initial prompt to be kept")
+	}
+	for i := 1; i <= 20; i++ {
+		stop := fmt.Sprintf("Stop number %d.", i)
+		if want := i > 17; strings.Contains(prompt, stop) != want {
+			t.Errorf("%q: expected included=%v", stop, want)
+		}
+	}
+}
+
+func TestAgentsPreviewPrompt(t *testing.T) {
+	factories := map[string]agent.Factory{
+		"aider": NewAiderAgent, "amp": NewAmpAgent, "claude": NewClaudeAgent, "codex": NewCodexAgent,
+		"continue": NewContinueAgent, "copilot": NewCopilotAgent, "crush": NewCrushAgent, "cursor": NewCursorAgent,
+		"exec": NewExecAgent, "factory": NewFactoryAgent, "gemini": NewGeminiAgent, "grok": NewGrokAgent,
+		"groq": NewGroqAgent, "kimi": NewKimiAgent, "mistral": NewMistralAgent, "opencode": NewOpenCodeAgent,
+		"openrouter": NewOpenRouterAgent, "qoder": NewQoderAgent, "qwen": NewQwenAgent, "rovodev": NewRovoDevAgent,
+	}
+	if len(factories) != len(agent.RegisteredTypes()) {
+		t.Errorf("expected a factory for every registered type %v", agent.RegisteredTypes())
+	}
+
+	messages := []agent.Message{
+		{AgentID: "a1", AgentName: "Reviewer", Role: "system", Content: "Reviewer has joined the conversation"},
+		{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Plan a trip to Lisbon"},
+	}
+	for agentType, factory := range factories {
+		p, ok := factory().(agent.PromptPreviewer)
+		if !ok {
+			t.Errorf("%s: expected the agent to implement PromptPreviewer", agentType)
+			continue
+		}
+		if prompt := p.PreviewPrompt(messages); !strings.Contains(prompt, "Plan a trip to Lisbon") {
+			t.Errorf("%s: expected the initial prompt in the preview, got %q", agentType, prompt)
+		}
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
+	"regexp"
 	"strings"
 	"time"
 
@@ -42,11 +44,25 @@ func (a *AiderAgent) Initialize(config agent.AgentConfig) error {
 	}
 	a.execPath = path
 
+	// Aider edits files in its working directory, so it must exist up front
+	if a.Config.WorkDir != "" {
+		info, err := os.Stat(a.Config.WorkDir)
+		if err != nil || !info.IsDir() {
+			log.WithFields(map[string]interface{}{
+				"agent_id":   a.ID,
+				"agent_name": a.Name,
+				"work_dir":   a.Config.WorkDir,
+			}).Error("aider work_dir is not a directory")
+			return fmt.Errorf("aider work_dir %s is not a directory", a.Config.WorkDir)
+		}
+	}
+
 	log.WithFields(map[string]interface{}{
 		"agent_id":   a.ID,
 		"agent_name": a.Name,
 		"exec_path":  path,
 		"model":      a.Config.Model,
+		"work_dir":   a.Config.WorkDir,
 	}).Info("aider agent initialized successfully")
 
 	return nil
@@ -69,37 +85,42 @@ func (a *AiderAgent) HealthCheck(ctx context.Context) error {
 
 	log.WithField("agent_name", a.Name).Debug("starting aider health check")
 
-	// Check if the binary exists and is executable using --version
+	// Check that the binary responds to --version with an aider version
 	cmd := exec.CommandContext(ctx, a.execPath, "--version")
 	output, err := cmd.CombinedOutput()
-
 	if err != nil {
-		// Try with --help if --version doesn't work
-		log.WithField("agent_name", a.Name).Debug("--version check failed, trying --help")
-		cmd = exec.CommandContext(ctx, a.execPath, "--help")
-		output, err = cmd.CombinedOutput()
-
-		if err != nil {
-			// If both fail, the CLI is not properly installed
-			log.WithField("agent_name", a.Name).WithError(err).Error("aider health check failed: CLI not responding")
-			return fmt.Errorf("aider CLI not responding to --version or --help: %w", err)
-		}
+		log.WithField("agent_name", a.Name).WithError(err).Error("aider health check failed: CLI not responding")
+		return fmt.Errorf("aider CLI not responding to --version: %w", err)
 	}
 
-	// Check if output contains something that indicates it's Aider
-	outputStr := string(output)
-	if len(outputStr) < 10 {
+	version, ok := parseAiderVersion(string(output))
+	if !ok {
 		log.WithFields(map[string]interface{}{
-			"agent_name":    a.Name,
-			"output_length": len(outputStr),
-		}).Error("aider health check failed: output too short")
-		return fmt.Errorf("aider CLI returned suspiciously short output")
+			"agent_name": a.Name,
+			"output":     strings.TrimSpace(string(output)),
+		}).Error("aider health check failed: unrecognized version output")
+		return fmt.Errorf("aider CLI returned unrecognized version output: %s", strings.TrimSpace(string(output)))
 	}
 
-	log.WithField("agent_name", a.Name).Info("aider health check passed")
+	log.WithFields(map[string]interface{}{
+		"agent_name": a.Name,
+		"version":    version,
+	}).Info("aider health check passed")
 	return nil
 }
 
+// aiderVersionPattern matches the "aider 0.86.1" line printed by aider --version
+var aiderVersionPattern = regexp.MustCompile(`(?m)^aider v?(\d+\.\d+(?:\.\d+)?\S*)`)
+
+// parseAiderVersion extracts the version from aider --version output
+func parseAiderVersion(output string) (string, bool) {
+	match := aiderVersionPattern.FindStringSubmatch(strings.TrimSpace(output))
+	if match == nil {
+		return "", false
+	}
+	return match[1], true
+}
+
 func (a *AiderAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
 	if len(messages) == 0 {
 		return "", nil
@@ -119,7 +140,7 @@ func (a *AiderAgent) SendMessage(ctx context.Context, messages []agent.Message)
 	// Build command args - Aider uses --message for non-interactive mode
 	args := []string{
 		"--yes",       // Auto-confirm changes
-		"--no-git",    // Don't use git (we're in a conversation, not editing files)
+		"--no-git",    // Leave the work_dir's git history alone; edits stay uncommitted
 		"--no-stream", // Don't stream output for non-interactive mode
 		"--message", prompt,
 	}
@@ -129,8 +150,9 @@ func (a *AiderAgent) SendMessage(ctx context.Context, messages []agent.Message)
 		args = append([]string{"--model", a.Config.Model}, args...)
 	}
 
-	// Execute aider command
+	// Execute aider command in its working directory, where it may edit files
 	cmd := exec.CommandContext(ctx, a.execPath, args...)
+	cmd.Dir = a.Config.WorkDir
 
 	startTime := time.Now()
 	output, err := cmd.CombinedOutput()
@@ -180,7 +202,7 @@ func (a *AiderAgent) StreamMessage(ctx context.Context, messages []agent.Message
 	// Build command args for streaming mode
 	args := []string{
 		"--yes",    // Auto-confirm changes
-		"--no-git", // Don't use git
+		"--no-git", // Leave the work_dir's git history alone; edits stay uncommitted
 		"--message", prompt,
 	}
 
@@ -189,8 +211,9 @@ func (a *AiderAgent) StreamMessage(ctx context.Context, messages []agent.Message
 		args = append([]string{"--model", a.Config.Model}, args...)
 	}
 
-	// Execute aider command
+	// Execute aider command in its working directory, where it may edit files
 	cmd := exec.CommandContext(ctx, a.execPath, args...)
+	cmd.Dir = a.Config.WorkDir
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
@@ -244,7 +267,12 @@ func (a *AiderAgent) filterRelevantMessages(messages []agent.Message) []agent.Me
 		relevant = append(relevant, msg)
 	}
 
-	return relevant
+	return a.LimitHistory(relevant)
+}
+
+// PreviewPrompt returns the prompt SendMessage would send for messages
+func (a *AiderAgent) PreviewPrompt(messages []agent.Message) string {
+	return a.buildPrompt(a.filterRelevantMessages(messages), true)
 }
 
 func (a *AiderAgent) buildPrompt(messages []agent.Message, isInitialSession bool) string {
@@ -273,7 +301,7 @@ func (a *AiderAgent) buildPrompt(messages []agent.Message, isInitialSession bool
 		// IMPORTANT: Find the orchestrator's initial prompt (AgentID/AgentName = "host" or "system")
 		// Agent announcements are also system messages, but they come from specific agents
 		for _, msg := range messages {
-			if msg.Role == "system" && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
+			if msg.Role == agent.RoleSystem && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
 				// This is the orchestrator's initial prompt - show it prominently
 				initialPrompt = msg.Content
 			} else {
@@ -301,7 +329,7 @@ func (a *AiderAgent) buildPrompt(messages []agent.Message, isInitialSession bool
 			for _, msg := range otherMessages {
 				timestamp := time.Unix(msg.Timestamp, 0).Format("15:04:05")
 				// Include role indicator for system messages to make them clear
-				if msg.Role == "system" {
+				if msg.Role == agent.RoleSystem {
 					prompt.WriteString(fmt.Sprintf("[%s] SYSTEM: %s\n", timestamp, msg.Content))
 				} else {
 					prompt.WriteString(fmt.Sprintf("[%s] %s: %s\n", timestamp, msg.AgentName, msg.Content))
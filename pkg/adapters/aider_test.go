@@ -0,0 +1,128 @@
+package adapters
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+// installStubAider puts an aider script that prints versionOutput first on PATH.
+func installStubAider(t *testing.T, versionOutput string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("stub CLI scripts require a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	script := "#!/bin/sh\ncat <<'EOF'\n" + versionOutput + "\nEOF\n"
+	if err := os.WriteFile(filepath.Join(dir, "aider"), []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write stub aider: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestParseAiderVersion(t *testing.T) {
+	tests := []struct {
+		output  string
+		want    string
+		wantErr bool
+	}{
+		{output: "aider 0.86.1\n", want: "0.86.1"},
+		{output: "aider v0.82.0.dev12+g3c5a6ef", want: "0.82.0.dev12+g3c5a6ef"},
+		{output: "Newer aider version v0.86.2 is available.\naider 0.86.1", want: "0.86.1"},
+		{output: "usage: something-else [-h]", wantErr: true},
+		{output: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseAiderVersion(tt.output)
+		if ok == tt.wantErr {
+			t.Errorf("parseAiderVersion(%q) ok = %v, want %v", tt.output, ok, !tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("parseAiderVersion(%q) = %q, want %q", tt.output, got, tt.want)
+		}
+	}
+}
+
+func TestAiderHealthCheckWithStub(t *testing.T) {
+	installStubAider(t, "aider 0.86.1")
+
+	a := NewAiderAgent()
+	if err := a.Initialize(agent.AgentConfig{ID: "aider-1", Type: "aider", Name: "Aider"}); err != nil {
+		t.Fatalf("initialization failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := a.HealthCheck(ctx); err != nil {
+		t.Errorf("expected health check to pass, got: %v", err)
+	}
+}
+
+func TestAiderHealthCheckRejectsUnknownVersion(t *testing.T) {
+	installStubAider(t, "not the aider you are looking for")
+
+	a := NewAiderAgent()
+	if err := a.Initialize(agent.AgentConfig{ID: "aider-1", Type: "aider", Name: "Aider"}); err != nil {
+		t.Fatalf("initialization failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := a.HealthCheck(ctx); err == nil {
+		t.Error("expected health check to fail for unrecognized version output")
+	}
+}
+
+func TestAiderInitializeRequiresWorkDir(t *testing.T) {
+	installStubAider(t, "aider 0.86.1")
+
+	a := NewAiderAgent()
+	err := a.Initialize(agent.AgentConfig{
+		ID:      "aider-1",
+		Type:    "aider",
+		Name:    "Aider",
+		WorkDir: filepath.Join(t.TempDir(), "missing"),
+	})
+	if err == nil || !strings.Contains(err.Error(), "work_dir") {
+		t.Errorf("expected work_dir error, got: %v", err)
+	}
+}
+
+func TestAiderBuildPrompt(t *testing.T) {
+	a := &AiderAgent{}
+	a.Config = agent.AgentConfig{ID: "aider-1", Type: "aider", Name: "Aider", Prompt: "You make small, tested edits."}
+	a.ID = "aider-1"
+	a.Name = "Aider"
+	now := time.Now().Unix()
+
+	messages := []agent.Message{
+		{AgentID: "host", AgentName: "HOST", Content: "Add input validation to the parser", Timestamp: now, Role: "system"},
+		{AgentID: "reviewer", AgentName: "Reviewer", Content: "Reject empty strings.", Timestamp: now, Role: "agent"},
+		{AgentID: "aider-1", AgentName: "Aider", Content: "Edited parser.go", Timestamp: now, Role: "agent"},
+	}
+
+	prompt := a.buildPrompt(a.filterRelevantMessages(messages), true)
+
+	for _, want := range []string{
+		"You are 'Aider' participating in a multi-agent conversation.",
+		"YOUR ROLE AND INSTRUCTIONS:\nYou make small, tested edits.",
+		"YOUR TASK - PLEASE RESPOND TO THIS:",
+		"Add input validation to the parser",
+		"Reviewer: Reject empty strings.",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("expected prompt to contain %q, got: %s", want, prompt)
+		}
+	}
+	if strings.Contains(prompt, "Edited parser.go") {
+		t.Errorf("expected Aider's own messages to be filtered out, got: %s", prompt)
+	}
+}
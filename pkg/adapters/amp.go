@@ -16,15 +16,14 @@ import (
 )
 
 const (
-	// Amp-specific timeout constants
-	ampStreamTimeout = 60 * time.Second
-	ampReadDeadline  = 55 * time.Second
+	// ampHealthTimeout bounds the health check; streaming calls follow the caller's context
 	ampHealthTimeout = 5 * time.Second
 )
 
 // AmpAgent represents the Amp coding agent adapter
 type AmpAgent struct {
 	agent.BaseAgent
+	usageTracker
 	execPath       string
 	threadID       string // Current Amp thread ID for conversation continuity
 	lastMessageIdx int    // Index of last message sent to Amp (for incremental updates)
@@ -131,6 +130,9 @@ func (a *AmpAgent) SendMessage(ctx context.Context, messages []agent.Message) (s
 		"last_msg_idx":  a.lastMessageIdx,
 	}).Debug("sending message to amp CLI")
 
+	// Plain thread output carries no usage; the orchestrator estimates it
+	a.setUsage(nil)
+
 	// Get only new messages that haven't been sent to Amp yet
 	// IMPORTANT: Filter out this agent's own messages since Amp maintains them in the thread
 	newMessages := a.filterRelevantMessages(messages[a.lastMessageIdx:])
@@ -146,7 +148,7 @@ func (a *AmpAgent) SendMessage(ctx context.Context, messages []agent.Message) (s
 	if a.threadID == "" {
 		// Create a new thread with the initial conversation context
 		// For initial thread, send ALL messages except this agent's own
-		allRelevantMessages := a.filterRelevantMessages(messages)
+		allRelevantMessages := a.LimitHistory(a.filterRelevantMessages(messages))
 		output, err = a.createThread(ctx, allRelevantMessages, newMessages)
 	} else {
 		// Continue existing thread with just the new messages from OTHER agents
@@ -177,10 +179,20 @@ func (a *AmpAgent) SendMessage(ctx context.Context, messages []agent.Message) (s
 	return output, nil
 }
 
+// ResetSession forgets the Amp thread, so the next message starts a new one
+// with the full conversation context.
+func (a *AmpAgent) ResetSession() {
+	a.threadID = ""
+	a.lastMessageIdx = 0
+}
+
 // filterRelevantMessages filters out this agent's own messages
 // Since Amp maintains thread context server-side, we should NOT send:
 // 1. This agent's own responses (Amp already knows what it said)
 // 2. Only send messages from OTHER agents and system messages
+// The history window and context token limit only apply to the context that
+// starts a thread; later turns must send every new message, or the trimmed
+// ones would never reach the thread.
 func (a *AmpAgent) filterRelevantMessages(messages []agent.Message) []agent.Message {
 	relevant := make([]agent.Message, 0, len(messages))
 
@@ -201,7 +213,7 @@ func (a *AmpAgent) createThread(ctx context.Context, allMessages, newMessages []
 	// Count system messages to verify initial prompt is included
 	systemMsgCount := 0
 	for _, msg := range allMessages {
-		if msg.Role == "system" || strings.ToLower(msg.AgentName) == "system" {
+		if msg.Role == agent.RoleSystem || strings.ToLower(msg.AgentName) == "system" {
 			systemMsgCount++
 		}
 	}
@@ -265,7 +277,11 @@ func (a *AmpAgent) createThread(ctx context.Context, allMessages, newMessages []
 	}
 
 	// Now send the initial request as thread continue
-	continueCmd := exec.CommandContext(ctx, a.execPath, "thread", "continue", a.threadID)
+	args, err := a.threadArgs("continue", a.threadID)
+	if err != nil {
+		return "", err
+	}
+	continueCmd := exec.CommandContext(ctx, a.execPath, args...)
 	continueCmd.Stdin = strings.NewReader(prompt)
 
 	continueOutput, err := continueCmd.CombinedOutput()
@@ -298,7 +314,11 @@ func (a *AmpAgent) continueThread(ctx context.Context, newMessages []agent.Messa
 	prompt := a.buildPrompt(newMessages, false) // isInitialThread = false
 
 	// Continue thread: amp thread continue {thread_id}
-	cmd := exec.CommandContext(ctx, a.execPath, "thread", "continue", a.threadID)
+	args, err := a.threadArgs("continue", a.threadID)
+	if err != nil {
+		return "", err
+	}
+	cmd := exec.CommandContext(ctx, a.execPath, args...)
 	cmd.Stdin = strings.NewReader(prompt)
 
 	output, err := cmd.CombinedOutput()
@@ -328,9 +348,11 @@ func (a *AmpAgent) StreamMessage(ctx context.Context, messages []agent.Message,
 		"message_count": len(messages),
 		"thread_id":     a.threadID,
 		"last_msg_idx":  a.lastMessageIdx,
-		"timeout":       ampStreamTimeout.String(),
+		"timeout":       a.Config.StreamTimeout.String(),
 	}).Debug("starting amp streaming message")
 
+	a.setUsage(nil)
+
 	// Get only new messages that haven't been sent to Amp yet
 	// IMPORTANT: Filter out this agent's own messages since Amp maintains them in the thread
 	newMessages := a.filterRelevantMessages(messages[a.lastMessageIdx:])
@@ -339,8 +361,8 @@ func (a *AmpAgent) StreamMessage(ctx context.Context, messages []agent.Message,
 		return nil
 	}
 
-	// Create a context with timeout for streaming
-	streamCtx, cancel := context.WithTimeout(ctx, ampStreamTimeout)
+	// The caller's deadline governs unless the agent sets a stream_timeout override
+	streamCtx, cancel := streamContext(ctx, a.Config)
 	defer cancel()
 
 	var cmd *exec.Cmd
@@ -348,12 +370,12 @@ func (a *AmpAgent) StreamMessage(ctx context.Context, messages []agent.Message,
 
 	if a.threadID == "" {
 		// For initial thread, send ALL messages except this agent's own
-		allRelevantMessages := a.filterRelevantMessages(messages)
+		allRelevantMessages := a.LimitHistory(a.filterRelevantMessages(messages))
 
 		// Count system messages to verify initial prompt is included
 		systemMsgCount := 0
 		for _, msg := range allRelevantMessages {
-			if msg.Role == "system" || strings.ToLower(msg.AgentName) == "system" {
+			if msg.Role == agent.RoleSystem || strings.ToLower(msg.AgentName) == "system" {
 				systemMsgCount++
 			}
 		}
@@ -390,7 +412,11 @@ func (a *AmpAgent) StreamMessage(ctx context.Context, messages []agent.Message,
 		}
 
 		// Use --stream-json with thread new
-		cmd = exec.CommandContext(streamCtx, a.execPath, "thread", "new", "--stream-json")
+		args, err := a.threadArgs("new", "--stream-json")
+		if err != nil {
+			return err
+		}
+		cmd = exec.CommandContext(streamCtx, a.execPath, args...)
 	} else {
 		// Continue existing thread with just new messages
 		log.WithFields(map[string]interface{}{
@@ -402,7 +428,11 @@ func (a *AmpAgent) StreamMessage(ctx context.Context, messages []agent.Message,
 
 		prompt = a.buildPrompt(newMessages, false) // isInitialThread = false
 		// Use --stream-json with thread continue
-		cmd = exec.CommandContext(streamCtx, a.execPath, "thread", "continue", a.threadID, "--stream-json")
+		args, err := a.threadArgs("continue", a.threadID, "--stream-json")
+		if err != nil {
+			return err
+		}
+		cmd = exec.CommandContext(streamCtx, a.execPath, args...)
 	}
 
 	cmd.Stdin = strings.NewReader(prompt)
@@ -445,18 +475,13 @@ func (a *AmpAgent) StreamMessage(ctx context.Context, messages []agent.Message,
 	hasOutput := false
 	scanner := bufio.NewScanner(stdout)
 	var streamedContent strings.Builder
+	var rawOutput strings.Builder // everything amp printed, used if no line parses
+	var usage ampUsage
 	isFirstLine := a.threadID == "" // Track if we need to extract thread ID from first line
 
-	// Set a deadline for reading
-	readTimer := time.NewTimer(ampReadDeadline)
-	defer readTimer.Stop()
-
 scanLoop:
 	for scanner.Scan() {
 		select {
-		case <-readTimer.C:
-			// Reading timeout - stop processing
-			break scanLoop
 		case <-streamCtx.Done():
 			// Context canceled - stop processing
 			break scanLoop
@@ -465,6 +490,7 @@ scanLoop:
 
 			// If this is a new thread, first line should be the thread ID
 			if isFirstLine {
+				isFirstLine = false
 				// Try to extract thread ID from first line
 				var threadInfo struct {
 					ThreadID string `json:"thread_id"`
@@ -485,11 +511,16 @@ scanLoop:
 						}).Info("amp thread created from streaming")
 					}
 				}
-				isFirstLine = false
-				// Don't write thread ID line to output, continue to next line
-				continue
+				// Don't write the thread ID line to output; any other first line is content
+				if a.threadID != "" {
+					continue
+				}
 			}
 
+			rawOutput.WriteString(line)
+			rawOutput.WriteString("\n")
+			usage.add(line)
+
 			// Parse the JSON line and extract text content
 			if text := a.parseJSONLine(line); text != "" {
 				_, _ = fmt.Fprint(writer, text)
@@ -514,8 +545,24 @@ scanLoop:
 		log.WithField("agent_name", a.Name).WithError(err).Debug("amp process exited with error but produced output")
 	}
 
+	// amp exited cleanly but no line matched a known format: surface the text
+	// it printed rather than losing the turn to an output format change
+	if text := unparsedStreamText(rawOutput.String()); !hasOutput && text != "" && streamCtx.Err() == nil {
+		log.WithFields(map[string]interface{}{
+			"agent_name": a.Name,
+			"raw_length": rawOutput.Len(),
+		}).Warn("amp output did not match the expected stream format, using its text")
+		_, _ = fmt.Fprint(writer, text)
+		streamedContent.WriteString(text)
+		hasOutput = true
+	}
+
 	// Check if we got any output
 	if !hasOutput {
+		if err := streamCtx.Err(); err != nil {
+			log.WithField("agent_name", a.Name).WithError(err).Error("amp streaming timed out")
+			return fmt.Errorf("amp streaming timed out: %w", err)
+		}
 		stderrOutput := stderrBuf.String()
 		log.WithFields(map[string]interface{}{
 			"agent_name": a.Name,
@@ -529,6 +576,7 @@ scanLoop:
 
 	// Update the index of last sent message
 	a.lastMessageIdx = len(messages)
+	a.setUsage(usage.get())
 
 	duration := time.Since(startTime)
 	log.WithFields(map[string]interface{}{
@@ -541,6 +589,22 @@ scanLoop:
 	return nil
 }
 
+// threadArgs returns the arguments for an "amp thread" subcommand, preceded by
+// the agent's MCP servers so Amp can use them while responding.
+func (a *AmpAgent) threadArgs(subcommand ...string) ([]string, error) {
+	args, err := mcpConfigArgs(a.Config.MCPServers)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, "thread")
+	return append(args, subcommand...), nil
+}
+
+// PreviewPrompt returns the prompt SendMessage would send for messages
+func (a *AmpAgent) PreviewPrompt(messages []agent.Message) string {
+	return a.buildPrompt(a.LimitHistory(a.filterRelevantMessages(messages)), true)
+}
+
 // buildPrompt creates the final prompt for Amp with explicit context
 // For initial threads, we need to send setup BEFORE conversation to avoid confusion
 func (a *AmpAgent) buildPrompt(messages []agent.Message, isInitialThread bool) string {
@@ -571,7 +635,7 @@ func (a *AmpAgent) buildPrompt(messages []agent.Message, isInitialThread bool) s
 		// IMPORTANT: Find the orchestrator's initial prompt (AgentID/AgentName = "system" or "host")
 		// Agent announcements are also system messages, but they come from specific agents
 		for _, msg := range messages {
-			if msg.Role == "system" && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
+			if msg.Role == agent.RoleSystem && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
 				// This is the orchestrator's initial prompt - show it prominently
 				initialPrompt = msg.Content
 			} else {
@@ -599,7 +663,7 @@ func (a *AmpAgent) buildPrompt(messages []agent.Message, isInitialThread bool) s
 			for _, msg := range otherMessages {
 				timestamp := time.Unix(msg.Timestamp, 0).Format("15:04:05")
 				// Include role indicator for system messages to make them clear
-				if msg.Role == "system" {
+				if msg.Role == agent.RoleSystem {
 					prompt.WriteString(fmt.Sprintf("[%s] SYSTEM: %s\n", timestamp, msg.Content))
 				} else {
 					prompt.WriteString(fmt.Sprintf("[%s] %s: %s\n", timestamp, msg.AgentName, msg.Content))
@@ -621,7 +685,7 @@ func (a *AmpAgent) buildPrompt(messages []agent.Message, isInitialThread bool) s
 		prompt.WriteString("\n")
 		for _, msg := range messages {
 			timestamp := time.Unix(msg.Timestamp, 0).Format("15:04:05")
-			if msg.Role == "system" {
+			if msg.Role == agent.RoleSystem {
 				prompt.WriteString(fmt.Sprintf("[%s] SYSTEM: %s\n", timestamp, msg.Content))
 			} else {
 				prompt.WriteString(fmt.Sprintf("[%s] %s: %s\n", timestamp, msg.AgentName, msg.Content))
@@ -681,6 +745,70 @@ func (a *AmpAgent) parseJSONLine(line string) string {
 	return ""
 }
 
+// ampUsage collects the token usage reported in amp --stream-json output.
+// Assistant messages carry the usage of their own model call and are summed;
+// the closing result line carries the total for the response and replaces
+// the sum.
+type ampUsage struct {
+	usage *agent.Usage
+	final bool
+}
+
+// add records the usage on line, if any. Cache reads and writes count as
+// input tokens (see agent.Usage for what that means for cost).
+func (u *ampUsage) add(line string) {
+	if u.final {
+		return
+	}
+
+	type streamUsage struct {
+		InputTokens              int `json:"input_tokens"`
+		CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+		CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+		OutputTokens             int `json:"output_tokens"`
+	}
+	var msg struct {
+		Type    string          `json:"type"`
+		Usage   *streamUsage    `json:"usage"`
+		Message json.RawMessage `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(line), &msg); err != nil {
+		return
+	}
+
+	reported := msg.Usage
+	if reported == nil && len(msg.Message) > 0 && msg.Message[0] == '{' {
+		var inner struct {
+			Usage *streamUsage `json:"usage"`
+		}
+		if json.Unmarshal(msg.Message, &inner) == nil {
+			reported = inner.Usage
+		}
+	}
+	if reported == nil {
+		return
+	}
+
+	usage := agent.Usage{
+		InputTokens:  reported.InputTokens + reported.CacheCreationInputTokens + reported.CacheReadInputTokens,
+		OutputTokens: reported.OutputTokens,
+	}
+	if msg.Type == "result" {
+		u.usage, u.final = &usage, true
+		return
+	}
+	if u.usage == nil {
+		u.usage = &agent.Usage{}
+	}
+	u.usage.InputTokens += usage.InputTokens
+	u.usage.OutputTokens += usage.OutputTokens
+}
+
+// get returns the reported usage, or nil if amp reported none.
+func (u *ampUsage) get() *agent.Usage {
+	return u.usage
+}
+
 func init() {
 	agent.RegisterFactory("amp", NewAmpAgent)
 }
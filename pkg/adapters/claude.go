@@ -16,6 +16,7 @@ import (
 
 type ClaudeAgent struct {
 	agent.BaseAgent
+	usageTracker
 	execPath string
 }
 
@@ -111,18 +112,17 @@ func (c *ClaudeAgent) SendMessage(ctx context.Context, messages []agent.Message)
 		"message_count": len(messages),
 	}).Debug("sending message to claude CLI")
 
+	c.setUsage(nil)
+
 	// Filter out this agent's own messages
 	relevantMessages := c.filterRelevantMessages(messages)
 
 	// Build prompt with structured format
 	prompt := c.buildPrompt(relevantMessages, true)
 
-	// Build command args
-	args := []string{}
-
-	// Add model flag if specified
-	if c.Config.Model != "" {
-		args = append(args, "--model", c.Config.Model)
+	args, err := c.buildArgs()
+	if err != nil {
+		return "", err
 	}
 
 	// Claude CLI takes prompt via stdin
@@ -155,7 +155,17 @@ func (c *ClaudeAgent) SendMessage(ctx context.Context, messages []agent.Message)
 		"response_size": len(output),
 	}).Info("claude message sent successfully")
 
-	return string(output), nil
+	if !c.Config.UseJSONOutput {
+		return string(output), nil
+	}
+
+	text, usage, err := parseClaudeJSON(string(output))
+	if err != nil {
+		log.WithField("agent_name", c.Name).WithError(err).Error("failed to parse claude JSON output")
+		return "", err
+	}
+	c.setUsage(&usage)
+	return text, nil
 }
 
 func (c *ClaudeAgent) StreamMessage(ctx context.Context, messages []agent.Message, writer io.Writer) error {
@@ -168,18 +178,25 @@ func (c *ClaudeAgent) StreamMessage(ctx context.Context, messages []agent.Messag
 		"message_count": len(messages),
 	}).Debug("starting claude streaming message")
 
+	// JSON output arrives in one piece, so send it through SendMessage to capture usage
+	if c.Config.UseJSONOutput {
+		response, err := c.SendMessage(ctx, messages)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(writer, response)
+		return err
+	}
+
 	// Filter out this agent's own messages
 	relevantMessages := c.filterRelevantMessages(messages)
 
 	// Build prompt with structured format
 	prompt := c.buildPrompt(relevantMessages, true)
 
-	// Build command args
-	args := []string{}
-
-	// Add model flag if specified
-	if c.Config.Model != "" {
-		args = append(args, "--model", c.Config.Model)
+	args, err := c.buildArgs()
+	if err != nil {
+		return err
 	}
 
 	// Claude CLI takes prompt via stdin
@@ -225,6 +242,59 @@ func (c *ClaudeAgent) StreamMessage(ctx context.Context, messages []agent.Messag
 	return nil
 }
 
+// buildArgs returns the claude CLI arguments for the agent's model, output
+// format, and MCP servers.
+func (c *ClaudeAgent) buildArgs() ([]string, error) {
+	args := []string{}
+
+	if c.Config.UseJSONOutput {
+		args = append(args, "--print", "--output-format", "json")
+	}
+
+	// Add model flag if specified
+	if c.Config.Model != "" {
+		args = append(args, "--model", c.Config.Model)
+	}
+
+	mcpArgs, err := mcpConfigArgs(c.Config.MCPServers)
+	if err != nil {
+		return nil, err
+	}
+	return append(args, mcpArgs...), nil
+}
+
+// claudeJSONResult is the result object printed by claude --output-format json
+type claudeJSONResult struct {
+	Type    string `json:"type"`
+	IsError bool   `json:"is_error"`
+	Result  string `json:"result"`
+	Usage   struct {
+		InputTokens              int `json:"input_tokens"`
+		CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+		CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+		OutputTokens             int `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+// parseClaudeJSON extracts the response text and token usage from claude's
+// JSON output. Cache reads and writes count as input tokens (see agent.Usage
+// for what that means for cost).
+func parseClaudeJSON(output string) (string, agent.Usage, error) {
+	var result claudeJSONResult
+	if err := decodeJSONOutput(output, &result); err != nil {
+		return "", agent.Usage{}, fmt.Errorf("claude: %w", err)
+	}
+	if result.IsError {
+		return "", agent.Usage{}, fmt.Errorf("claude returned an error: %s", result.Result)
+	}
+
+	usage := agent.Usage{
+		InputTokens:  result.Usage.InputTokens + result.Usage.CacheCreationInputTokens + result.Usage.CacheReadInputTokens,
+		OutputTokens: result.Usage.OutputTokens,
+	}
+	return strings.TrimSpace(result.Result), usage, nil
+}
+
 // filterRelevantMessages filters out this agent's own messages
 // We exclude this agent's own messages to avoid showing Claude what it already said
 func (c *ClaudeAgent) filterRelevantMessages(messages []agent.Message) []agent.Message {
@@ -239,7 +309,12 @@ func (c *ClaudeAgent) filterRelevantMessages(messages []agent.Message) []agent.M
 		relevant = append(relevant, msg)
 	}
 
-	return relevant
+	return c.LimitHistory(relevant)
+}
+
+// PreviewPrompt returns the prompt SendMessage would send for messages
+func (c *ClaudeAgent) PreviewPrompt(messages []agent.Message) string {
+	return c.buildPrompt(c.filterRelevantMessages(messages), true)
 }
 
 func (c *ClaudeAgent) buildPrompt(messages []agent.Message, isInitialSession bool) string {
@@ -268,7 +343,7 @@ func (c *ClaudeAgent) buildPrompt(messages []agent.Message, isInitialSession boo
 		// IMPORTANT: Find the orchestrator's initial prompt (AgentID/AgentName = "host" or "system")
 		// Agent announcements are also system messages, but they come from specific agents
 		for _, msg := range messages {
-			if msg.Role == "system" && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
+			if msg.Role == agent.RoleSystem && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
 				// This is the orchestrator's initial prompt - show it prominently
 				initialPrompt = msg.Content
 			} else {
@@ -296,7 +371,7 @@ func (c *ClaudeAgent) buildPrompt(messages []agent.Message, isInitialSession boo
 			for _, msg := range otherMessages {
 				timestamp := time.Unix(msg.Timestamp, 0).Format("15:04:05")
 				// Include role indicator for system messages to make them clear
-				if msg.Role == "system" {
+				if msg.Role == agent.RoleSystem {
 					prompt.WriteString(fmt.Sprintf("[%s] SYSTEM: %s\n", timestamp, msg.Content))
 				} else {
 					prompt.WriteString(fmt.Sprintf("[%s] %s: %s\n", timestamp, msg.AgentName, msg.Content))
@@ -196,7 +196,12 @@ func (c *CodexAgent) filterRelevantMessages(messages []agent.Message) []agent.Me
 		}
 		relevant = append(relevant, msg)
 	}
-	return relevant
+	return c.LimitHistory(relevant)
+}
+
+// PreviewPrompt returns the prompt SendMessage would send for messages
+func (c *CodexAgent) PreviewPrompt(messages []agent.Message) string {
+	return c.buildPrompt(c.filterRelevantMessages(messages), true)
 }
 
 func (c *CodexAgent) buildPrompt(messages []agent.Message, isInitialSession bool) string {
@@ -222,7 +227,7 @@ func (c *CodexAgent) buildPrompt(messages []agent.Message, isInitialSession bool
 		// Find the orchestrator's initial prompt (AgentID="system" or "host")
 		// vs agent announcements (system messages from specific agents)
 		for _, msg := range messages {
-			if msg.Role == "system" && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
+			if msg.Role == agent.RoleSystem && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
 				// This is the orchestrator's initial prompt - show it prominently
 				initialPrompt = msg.Content
 			} else {
@@ -249,7 +254,7 @@ func (c *CodexAgent) buildPrompt(messages []agent.Message, isInitialSession bool
 			prompt.WriteString("\n")
 			for _, msg := range otherMessages {
 				timestamp := time.Unix(msg.Timestamp, 0).Format("15:04:05")
-				if msg.Role == "system" {
+				if msg.Role == agent.RoleSystem {
 					// Agent announcements come through as system messages
 					prompt.WriteString(fmt.Sprintf("[%s] SYSTEM: %s\n", timestamp, msg.Content))
 				} else {
@@ -1,8 +1,15 @@
 package adapters
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
 )
 
 // BuildAgentPrompt creates a standard prompt for multi-agent conversations
@@ -25,3 +32,202 @@ func BuildAgentPrompt(agentName string, customPrompt string, conversation string
 
 	return prompt.String()
 }
+
+// buildStructuredPrompt builds the three-part prompt the CLI adapters send:
+// the agent's identity and role, the orchestrator's initial prompt as the
+// task, then the rest of the conversation.
+func buildStructuredPrompt(agentName, rolePrompt string, messages []agent.Message) string {
+	var prompt strings.Builder
+
+	// PART 1: IDENTITY AND ROLE (always first)
+	prompt.WriteString("AGENT SETUP:\n")
+	prompt.WriteString(strings.Repeat("=", 60))
+	prompt.WriteString("\n")
+	prompt.WriteString(fmt.Sprintf("You are '%s' participating in a multi-agent conversation.\n\n", agentName))
+
+	if rolePrompt != "" {
+		prompt.WriteString("YOUR ROLE AND INSTRUCTIONS:\n")
+		prompt.WriteString(rolePrompt)
+		prompt.WriteString("\n")
+	}
+	prompt.WriteString(strings.Repeat("=", 60))
+	prompt.WriteString("\n\n")
+
+	if len(messages) == 0 {
+		return prompt.String()
+	}
+
+	// PART 2: THE TASK (the orchestrator's initial prompt; announcements are
+	// also system messages, but they come from specific agents)
+	var initialPrompt string
+	var otherMessages []agent.Message
+	for _, msg := range messages {
+		if msg.Role == agent.RoleSystem && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
+			initialPrompt = msg.Content
+		} else {
+			otherMessages = append(otherMessages, msg)
+		}
+	}
+
+	if initialPrompt != "" {
+		prompt.WriteString("YOUR TASK - PLEASE RESPOND TO THIS:\n")
+		prompt.WriteString(strings.Repeat("=", 60))
+		prompt.WriteString("\n")
+		prompt.WriteString(initialPrompt)
+		prompt.WriteString("\n")
+		prompt.WriteString(strings.Repeat("=", 60))
+		prompt.WriteString("\n\n")
+	}
+
+	// PART 3: CONVERSATION SO FAR
+	if len(otherMessages) > 0 {
+		prompt.WriteString("CONVERSATION SO FAR:\n")
+		prompt.WriteString(strings.Repeat("-", 60))
+		prompt.WriteString("\n")
+		for _, msg := range otherMessages {
+			timestamp := time.Unix(msg.Timestamp, 0).Format("15:04:05")
+			if msg.Role == agent.RoleSystem {
+				prompt.WriteString(fmt.Sprintf("[%s] SYSTEM: %s\n", timestamp, msg.Content))
+			} else {
+				prompt.WriteString(fmt.Sprintf("[%s] %s: %s\n", timestamp, msg.AgentName, msg.Content))
+			}
+		}
+		prompt.WriteString(strings.Repeat("-", 60))
+		prompt.WriteString("\n\n")
+	}
+
+	if initialPrompt != "" {
+		prompt.WriteString(fmt.Sprintf("Now respond to the task above as %s. Provide a direct, thoughtful answer.", agentName))
+	} else {
+		prompt.WriteString(fmt.Sprintf("Now, as %s, respond to the conversation.", agentName))
+	}
+
+	return prompt.String()
+}
+
+// mcpConfigArgs returns the --mcp-config flag carrying the agent's MCP servers
+// as {"mcpServers": {...}} JSON, the format accepted by the Claude and Amp CLIs.
+// It returns nil when no servers are configured.
+func mcpConfigArgs(servers map[string]agent.MCPServer) ([]string, error) {
+	if len(servers) == 0 {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(map[string]interface{}{"mcpServers": servers})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode MCP config: %w", err)
+	}
+	return []string{"--mcp-config", string(data)}, nil
+}
+
+// streamContext derives the context for a streaming CLI call. The caller's
+// deadline (the orchestrator's turn timeout) always applies; a positive
+// AgentConfig.StreamTimeout adds its own limit, so the earlier of the two
+// ends the call. StreamTimeout can shorten the turn timeout, never extend it.
+func streamContext(ctx context.Context, config agent.AgentConfig) (context.Context, context.CancelFunc) {
+	if config.StreamTimeout > 0 {
+		return context.WithTimeout(ctx, config.StreamTimeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// usageTracker records the token usage of an adapter's last response.
+// Adapters embed it to implement agent.UsageReporter.
+type usageTracker struct {
+	mu    sync.Mutex
+	usage *agent.Usage
+}
+
+// LastUsage returns the usage recorded for the most recent response.
+func (u *usageTracker) LastUsage() (agent.Usage, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.usage == nil {
+		return agent.Usage{}, false
+	}
+	return *u.usage, true
+}
+
+// setUsage records the usage of the latest response; nil clears it.
+func (u *usageTracker) setUsage(usage *agent.Usage) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.usage = usage
+}
+
+// decodeJSONOutput decodes the JSON object in CLI output into v, skipping any
+// log lines the CLI printed before it.
+func decodeJSONOutput(output string, v interface{}) error {
+	start := strings.Index(output, "{")
+	for start > 0 && output[start-1] != '\n' {
+		next := strings.Index(output[start+1:], "{")
+		if next == -1 {
+			start = -1
+			break
+		}
+		start += next + 1
+	}
+	if start == -1 {
+		return fmt.Errorf("no JSON object in output: %s", strings.TrimSpace(output))
+	}
+
+	if err := json.NewDecoder(strings.NewReader(output[start:])).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse JSON output: %w", err)
+	}
+	return nil
+}
+
+// streamMetadataKeys are JSON fields that label a stream line rather than
+// carry its text; unparsedStreamText leaves them out.
+var streamMetadataKeys = map[string]bool{
+	"type": true, "subtype": true, "kind": true, "event": true, "role": true,
+	"status": true, "model": true, "id": true, "uuid": true,
+	"thread_id": true, "session_id": true,
+}
+
+// unparsedStreamText extracts readable text from CLI stream output in a format
+// the adapter does not recognize: the string values of each JSON line, minus
+// metadata such as IDs and type tags, and any other line as it is.
+func unparsedStreamText(raw string) string {
+	var parts []string
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var value interface{}
+		if err := json.Unmarshal([]byte(line), &value); err != nil {
+			parts = append(parts, line)
+			continue
+		}
+		parts = appendJSONText(parts, value)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// appendJSONText appends the non-empty strings in value to parts, visiting
+// object keys in sorted order and skipping streamMetadataKeys.
+func appendJSONText(parts []string, value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		if s := strings.TrimSpace(v); s != "" {
+			parts = append(parts, s)
+		}
+	case []interface{}:
+		for _, item := range v {
+			parts = appendJSONText(parts, item)
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			if !streamMetadataKeys[key] {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			parts = appendJSONText(parts, v[key])
+		}
+	}
+	return parts
+}
@@ -294,7 +294,12 @@ func (c *ContinueAgent) filterRelevantMessages(messages []agent.Message) []agent
 		relevant = append(relevant, msg)
 	}
 
-	return relevant
+	return c.LimitHistory(relevant)
+}
+
+// PreviewPrompt returns the prompt SendMessage would send for messages
+func (c *ContinueAgent) PreviewPrompt(messages []agent.Message) string {
+	return c.buildPrompt(c.filterRelevantMessages(messages), true)
 }
 
 // buildPrompt constructs a structured prompt with three parts: identity, context, and instruction
@@ -324,7 +329,7 @@ func (c *ContinueAgent) buildPrompt(messages []agent.Message, isInitialSession b
 		// IMPORTANT: Find the orchestrator's initial prompt (AgentID/AgentName = "host" or "system")
 		// Agent announcements are also system messages, but they come from specific agents
 		for _, msg := range messages {
-			if msg.Role == "system" && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
+			if msg.Role == agent.RoleSystem && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
 				// This is the orchestrator's initial prompt - show it prominently
 				initialPrompt = msg.Content
 			} else {
@@ -352,7 +357,7 @@ func (c *ContinueAgent) buildPrompt(messages []agent.Message, isInitialSession b
 			for _, msg := range otherMessages {
 				timestamp := time.Unix(msg.Timestamp, 0).Format("15:04:05")
 				// Include role indicator for system messages to make them clear
-				if msg.Role == "system" {
+				if msg.Role == agent.RoleSystem {
 					prompt.WriteString(fmt.Sprintf("[%s] SYSTEM: %s\n", timestamp, msg.Content))
 				} else {
 					prompt.WriteString(fmt.Sprintf("[%s] %s: %s\n", timestamp, msg.AgentName, msg.Content))
@@ -248,7 +248,12 @@ func (c *CrushAgent) filterRelevantMessages(messages []agent.Message) []agent.Me
 		relevant = append(relevant, msg)
 	}
 
-	return relevant
+	return c.LimitHistory(relevant)
+}
+
+// PreviewPrompt returns the prompt SendMessage would send for messages
+func (c *CrushAgent) PreviewPrompt(messages []agent.Message) string {
+	return c.buildPrompt(c.filterRelevantMessages(messages), true)
 }
 
 func (c *CrushAgent) buildPrompt(messages []agent.Message, isInitialSession bool) string {
@@ -277,7 +282,7 @@ func (c *CrushAgent) buildPrompt(messages []agent.Message, isInitialSession bool
 		// IMPORTANT: Find the orchestrator's initial prompt (AgentID/AgentName = "host" or "system")
 		// Agent announcements are also system messages, but they come from specific agents
 		for _, msg := range messages {
-			if msg.Role == "system" && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
+			if msg.Role == agent.RoleSystem && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
 				// This is the orchestrator's initial prompt - show it prominently
 				initialPrompt = msg.Content
 			} else {
@@ -305,7 +310,7 @@ func (c *CrushAgent) buildPrompt(messages []agent.Message, isInitialSession bool
 			for _, msg := range otherMessages {
 				timestamp := time.Unix(msg.Timestamp, 0).Format("15:04:05")
 				// Include role indicator for system messages to make them clear
-				if msg.Role == "system" {
+				if msg.Role == agent.RoleSystem {
 					prompt.WriteString(fmt.Sprintf("[%s] SYSTEM: %s\n", timestamp, msg.Content))
 				} else {
 					prompt.WriteString(fmt.Sprintf("[%s] %s: %s\n", timestamp, msg.AgentName, msg.Content))
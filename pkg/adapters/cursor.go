@@ -15,12 +15,6 @@ import (
 	"github.com/kevinelliott/agentpipe/pkg/log"
 )
 
-const (
-	// Cursor-specific timeout constants
-	cursorStreamTimeout = 30 * time.Second
-	cursorReadDeadline  = 25 * time.Second
-)
-
 type CursorAgent struct {
 	agent.BaseAgent
 	execPath string
@@ -170,7 +164,7 @@ func (c *CursorAgent) StreamMessage(ctx context.Context, messages []agent.Messag
 		"agent_name":    c.Name,
 		"agent_type":    "cursor",
 		"message_count": len(messages),
-		"timeout":       cursorStreamTimeout.String(),
+		"timeout":       c.Config.StreamTimeout.String(),
 	}).Debug("starting cursor streaming message")
 
 	// Filter out this agent's own messages
@@ -179,9 +173,9 @@ func (c *CursorAgent) StreamMessage(ctx context.Context, messages []agent.Messag
 	// Build prompt with structured format
 	prompt := c.buildPrompt(relevantMessages, true)
 
-	// Create a context with timeout for streaming
-	// cursor-agent needs more time to respond (typically 10-15 seconds)
-	streamCtx, cancel := context.WithTimeout(ctx, cursorStreamTimeout)
+	// The caller's deadline governs unless the agent sets a stream_timeout override;
+	// cursor-agent typically needs 10-15 seconds to respond
+	streamCtx, cancel := streamContext(ctx, c.Config)
 	defer cancel()
 
 	// Use --print mode for streaming
@@ -235,22 +229,18 @@ func (c *CursorAgent) StreamMessage(ctx context.Context, messages []agent.Messag
 	hasOutput := false
 	scanner := bufio.NewScanner(stdout)
 	var streamedContent strings.Builder
-
-	// Set a deadline for reading - use NewTimer so we can stop it
-	readTimer := time.NewTimer(cursorReadDeadline)
-	defer readTimer.Stop()
+	var rawOutput strings.Builder // everything cursor-agent printed, used if no line parses
 
 scanLoop:
 	for scanner.Scan() {
 		select {
-		case <-readTimer.C:
-			// Reading timeout - stop processing
-			break scanLoop
 		case <-streamCtx.Done():
 			// Context canceled - stop processing
 			break scanLoop
 		default:
 			line := scanner.Text()
+			rawOutput.WriteString(line)
+			rawOutput.WriteString("\n")
 
 			// Check for result message which signals completion
 			if result := c.parseResultLine(line); result != "" {
@@ -290,8 +280,28 @@ scanLoop:
 		_ = cmd.Wait() // Clean up the process
 	}
 
+	// cursor-agent finished but no line matched a known format: surface the
+	// text it printed rather than losing the turn to an output format change
+	if text := unparsedStreamText(rawOutput.String()); !hasOutput && text != "" && streamCtx.Err() == nil {
+		log.WithFields(map[string]interface{}{
+			"agent_name": c.Name,
+			"agent_type": "cursor",
+			"raw_length": rawOutput.Len(),
+		}).Warn("cursor output did not match the expected stream format, using its text")
+		_, _ = fmt.Fprint(writer, text)
+		streamedContent.WriteString(text)
+		hasOutput = true
+	}
+
 	// Check if we got any output
 	if !hasOutput {
+		if err := streamCtx.Err(); err != nil {
+			log.WithFields(map[string]interface{}{
+				"agent_name": c.Name,
+				"agent_type": "cursor",
+			}).WithError(err).Error("cursor streaming timed out")
+			return fmt.Errorf("cursor-agent streaming timed out: %w", err)
+		}
 		stderrOutput := stderrBuf.String()
 		log.WithFields(map[string]interface{}{
 			"agent_name": c.Name,
@@ -322,7 +332,12 @@ func (c *CursorAgent) filterRelevantMessages(messages []agent.Message) []agent.M
 		}
 		relevant = append(relevant, msg)
 	}
-	return relevant
+	return c.LimitHistory(relevant)
+}
+
+// PreviewPrompt returns the prompt SendMessage would send for messages
+func (c *CursorAgent) PreviewPrompt(messages []agent.Message) string {
+	return c.buildPrompt(c.filterRelevantMessages(messages), true)
 }
 
 func (c *CursorAgent) buildPrompt(messages []agent.Message, isInitialSession bool) string {
@@ -348,7 +363,7 @@ func (c *CursorAgent) buildPrompt(messages []agent.Message, isInitialSession boo
 		// Find the orchestrator's initial prompt (AgentID="system" or "host")
 		// vs agent announcements (system messages from specific agents)
 		for _, msg := range messages {
-			if msg.Role == "system" && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
+			if msg.Role == agent.RoleSystem && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
 				// This is the orchestrator's initial prompt - show it prominently
 				initialPrompt = msg.Content
 			} else {
@@ -375,7 +390,7 @@ func (c *CursorAgent) buildPrompt(messages []agent.Message, isInitialSession boo
 			prompt.WriteString("\n")
 			for _, msg := range otherMessages {
 				timestamp := time.Unix(msg.Timestamp, 0).Format("15:04:05")
-				if msg.Role == "system" {
+				if msg.Role == agent.RoleSystem {
 					// Agent announcements come through as system messages
 					prompt.WriteString(fmt.Sprintf("[%s] SYSTEM: %s\n", timestamp, msg.Content))
 				} else {
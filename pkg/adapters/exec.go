@@ -0,0 +1,277 @@
+package adapters
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/log"
+)
+
+// execPromptPlaceholder is replaced with the prompt in an exec agent's args.
+const execPromptPlaceholder = "{{prompt}}"
+
+// ExecAgent runs any command-line tool described in the agent's config, so a
+// new CLI can join a conversation without a dedicated adapter. The prompt is
+// sent on stdin (stdin: true), in place of {{prompt}} in args, or otherwise as
+// the last argument. Output lines matching a strip pattern are dropped.
+type ExecAgent struct {
+	agent.BaseAgent
+	execPath      string
+	stripPatterns []*regexp.Regexp
+}
+
+// NewExecAgent creates a new exec agent instance
+func NewExecAgent() agent.Agent {
+	return &ExecAgent{}
+}
+
+// Initialize sets up the exec agent, resolving its command and compiling its strip patterns
+func (e *ExecAgent) Initialize(config agent.AgentConfig) error {
+	if err := e.BaseAgent.Initialize(config); err != nil {
+		log.WithFields(map[string]interface{}{
+			"agent_id":   config.ID,
+			"agent_name": config.Name,
+		}).WithError(err).Error("exec agent base initialization failed")
+		return err
+	}
+
+	if e.Config.Command == "" {
+		return fmt.Errorf("exec agent %s needs a command", e.ID)
+	}
+
+	path, err := exec.LookPath(e.Config.Command)
+	if err != nil {
+		log.WithFields(map[string]interface{}{
+			"agent_id":   e.ID,
+			"agent_name": e.Name,
+			"command":    e.Config.Command,
+		}).WithError(err).Error("exec command not found in PATH")
+		return fmt.Errorf("exec command %s not found: %w", e.Config.Command, err)
+	}
+	e.execPath = path
+
+	e.stripPatterns = make([]*regexp.Regexp, 0, len(e.Config.StripPatterns))
+	for _, pattern := range e.Config.StripPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid strip pattern %q for exec agent %s: %w", pattern, e.ID, err)
+		}
+		e.stripPatterns = append(e.stripPatterns, re)
+	}
+
+	log.WithFields(map[string]interface{}{
+		"agent_id":   e.ID,
+		"agent_name": e.Name,
+		"exec_path":  path,
+		"stdin":      e.Config.Stdin,
+	}).Info("exec agent initialized successfully")
+
+	return nil
+}
+
+// IsAvailable reports whether the configured command is on the PATH
+func (e *ExecAgent) IsAvailable() bool {
+	if e.Config.Command == "" {
+		return false
+	}
+	_, err := exec.LookPath(e.Config.Command)
+	return err == nil
+}
+
+// GetCLIVersion returns a placeholder, since an arbitrary command has no known
+// version flag
+func (e *ExecAgent) GetCLIVersion() string {
+	return "N/A (exec)"
+}
+
+// HealthCheck verifies that the command is still on the PATH. It does not run
+// the command, which may have side effects or cost money.
+func (e *ExecAgent) HealthCheck(ctx context.Context) error {
+	if e.execPath == "" {
+		log.WithField("agent_name", e.Name).Error("exec health check failed: not initialized")
+		return fmt.Errorf("exec agent not initialized")
+	}
+	if _, err := exec.LookPath(e.execPath); err != nil {
+		return fmt.Errorf("exec command %s is no longer available: %w", e.Config.Command, err)
+	}
+	return nil
+}
+
+// SendMessage runs the command with the conversation prompt and returns its
+// filtered output
+func (e *ExecAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	if len(messages) == 0 {
+		return "", nil
+	}
+
+	log.WithFields(map[string]interface{}{
+		"agent_name":    e.Name,
+		"message_count": len(messages),
+		"command":       e.Config.Command,
+	}).Debug("sending message to exec command")
+
+	cmd := e.buildCommand(ctx, messages)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	startTime := time.Now()
+	err := cmd.Run()
+	duration := time.Since(startTime)
+
+	if err != nil {
+		log.WithFields(map[string]interface{}{
+			"agent_name": e.Name,
+			"command":    e.Config.Command,
+			"duration":   duration.String(),
+		}).WithError(err).Error("exec command failed")
+		return "", fmt.Errorf("exec command %s failed: %w\nStderr: %s", e.Config.Command, err, strings.TrimSpace(stderr.String()))
+	}
+
+	response := e.cleanOutput(stdout.String())
+	if response == "" {
+		return "", fmt.Errorf("exec command %s produced no output", e.Config.Command)
+	}
+
+	log.WithFields(map[string]interface{}{
+		"agent_name":    e.Name,
+		"duration":      duration.String(),
+		"response_size": len(response),
+	}).Info("exec message sent successfully")
+
+	return response, nil
+}
+
+// StreamMessage runs the command and writes its filtered output line by line
+func (e *ExecAgent) StreamMessage(ctx context.Context, messages []agent.Message, writer io.Writer) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	streamCtx, cancel := streamContext(ctx, e.Config)
+	defer cancel()
+
+	cmd := e.buildCommand(streamCtx, messages)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		log.WithField("agent_name", e.Name).WithError(err).Error("failed to start exec command")
+		return fmt.Errorf("failed to start %s: %w", e.Config.Command, err)
+	}
+
+	startTime := time.Now()
+	scanner := bufio.NewScanner(stdout)
+	lineCount := 0
+	for scanner.Scan() {
+		line := scanner.Text()
+		if e.shouldSkipLine(line) {
+			continue
+		}
+		fmt.Fprintln(writer, line)
+		lineCount++
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading output: %w", err)
+	}
+	if err := cmd.Wait(); err != nil {
+		log.WithField("agent_name", e.Name).WithError(err).Error("exec streaming command failed")
+		return fmt.Errorf("exec command %s failed: %w\nStderr: %s", e.Config.Command, err, strings.TrimSpace(stderr.String()))
+	}
+
+	log.WithFields(map[string]interface{}{
+		"agent_name": e.Name,
+		"duration":   time.Since(startTime).String(),
+		"lines":      lineCount,
+	}).Info("exec streaming message completed")
+
+	return nil
+}
+
+// buildCommand returns the command for messages, with the prompt on stdin or
+// in its arguments, run in the agent's work_dir if set.
+func (e *ExecAgent) buildCommand(ctx context.Context, messages []agent.Message) *exec.Cmd {
+	prompt := e.PreviewPrompt(messages)
+
+	cmd := exec.CommandContext(ctx, e.execPath, e.buildArgs(prompt)...)
+	if e.Config.Stdin {
+		cmd.Stdin = strings.NewReader(prompt)
+	}
+	cmd.Dir = e.Config.WorkDir
+	return cmd
+}
+
+// PreviewPrompt returns the prompt SendMessage would send for messages
+func (e *ExecAgent) PreviewPrompt(messages []agent.Message) string {
+	return buildStructuredPrompt(e.Name, e.Config.Prompt, e.filterRelevantMessages(messages))
+}
+
+// buildArgs substitutes prompt for {{prompt}} in the configured args. Without
+// stdin or a placeholder, the prompt is appended as the last argument.
+func (e *ExecAgent) buildArgs(prompt string) []string {
+	args := make([]string, 0, len(e.Config.Args)+1)
+	substituted := false
+	for _, arg := range e.Config.Args {
+		if strings.Contains(arg, execPromptPlaceholder) {
+			arg = strings.ReplaceAll(arg, execPromptPlaceholder, prompt)
+			substituted = true
+		}
+		args = append(args, arg)
+	}
+	if !substituted && !e.Config.Stdin {
+		args = append(args, prompt)
+	}
+	return args
+}
+
+// filterRelevantMessages filters out this agent's own messages
+func (e *ExecAgent) filterRelevantMessages(messages []agent.Message) []agent.Message {
+	relevant := make([]agent.Message, 0, len(messages))
+	for _, msg := range messages {
+		if msg.AgentName == e.Name || msg.AgentID == e.ID {
+			continue
+		}
+		relevant = append(relevant, msg)
+	}
+	return e.LimitHistory(relevant)
+}
+
+// cleanOutput removes the lines matching a strip pattern
+func (e *ExecAgent) cleanOutput(output string) string {
+	lines := strings.Split(output, "\n")
+	cleanedLines := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if e.shouldSkipLine(line) {
+			continue
+		}
+		cleanedLines = append(cleanedLines, line)
+	}
+	return strings.TrimSpace(strings.Join(cleanedLines, "\n"))
+}
+
+// shouldSkipLine reports whether line matches one of the strip patterns
+func (e *ExecAgent) shouldSkipLine(line string) bool {
+	for _, re := range e.stripPatterns {
+		if re.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	agent.RegisterFactory("exec", NewExecAgent)
+}
@@ -0,0 +1,143 @@
+package adapters
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+// execTestMessages is a short conversation for exec agent tests.
+var execTestMessages = []agent.Message{
+	{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Design a rate limiter"},
+	{AgentID: "alice", AgentName: "Alice", Role: "agent", Content: "Use a token bucket."},
+	{AgentID: "exec-1", AgentName: "Tool", Role: "agent", Content: "My earlier answer"},
+}
+
+func newTestExecAgent(t *testing.T, config agent.AgentConfig) *ExecAgent {
+	t.Helper()
+	config.ID, config.Type, config.Name = "exec-1", "exec", "Tool"
+
+	e := NewExecAgent().(*ExecAgent)
+	if err := e.Initialize(config); err != nil {
+		t.Fatalf("initialization failed: %v", err)
+	}
+	return e
+}
+
+func TestExecAgentPromptDelivery(t *testing.T) {
+	// The stub prints a banner, its arguments, and whatever arrives on stdin
+	script := "echo 'Loading model...'\nfor arg in \"$@\"; do echo \"arg: $arg\"; done\n" +
+		"if [ ! -t 0 ]; then sed 's/^/stdin: /'; fi\n"
+
+	tests := []struct {
+		name   string
+		args   []string
+		stdin  bool
+		want   []string
+		absent []string
+	}{
+		{
+			name: "placeholder in args",
+			args: []string{"--message", "--{{prompt}}--"},
+			want: []string{"arg: --message", "arg: --AGENT SETUP:", "Design a rate limiter"},
+		},
+		{
+			name: "appended as the last argument",
+			args: []string{"--quiet"},
+			want: []string{"arg: --quiet", "arg: AGENT SETUP:"},
+		},
+		{
+			name:   "stdin",
+			args:   []string{"run"},
+			stdin:  true,
+			want:   []string{"arg: run", "stdin: AGENT SETUP:", "stdin: YOUR TASK - PLEASE RESPOND TO THIS:", "Alice: Use a token bucket."},
+			absent: []string{"arg: AGENT SETUP:"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestExecAgent(t, agent.AgentConfig{
+				Command:       writeStubCLI(t, "tool", script),
+				Args:          tt.args,
+				Stdin:         tt.stdin,
+				StripPatterns: []string{`^Loading model`},
+			})
+
+			response, err := e.SendMessage(context.Background(), execTestMessages)
+			if err != nil {
+				t.Fatalf("SendMessage failed: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(response, want) {
+					t.Errorf("expected %q in the response, got:\n%s", want, response)
+				}
+			}
+			for _, absent := range append(tt.absent, "Loading model", "My earlier answer") {
+				if strings.Contains(response, absent) {
+					t.Errorf("expected %q to be left out, got:\n%s", absent, response)
+				}
+			}
+		})
+	}
+}
+
+func TestExecAgentWithEcho(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("echo is a shell builtin on Windows")
+	}
+
+	e := newTestExecAgent(t, agent.AgentConfig{
+		Command:       "echo",
+		Args:          []string{"{{prompt}}"},
+		StripPatterns: []string{`^=+$`, `^-+$`, `^AGENT SETUP:$`},
+	})
+
+	var out strings.Builder
+	if err := e.StreamMessage(context.Background(), execTestMessages, &out); err != nil {
+		t.Fatalf("StreamMessage failed: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "You are 'Tool'") {
+		t.Errorf("expected the stripped lines to be dropped, got:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "Design a rate limiter\n") {
+		t.Errorf("expected the echoed prompt, got:\n%s", out.String())
+	}
+}
+
+func TestExecAgentFailures(t *testing.T) {
+	e := newTestExecAgent(t, agent.AgentConfig{Command: writeStubCLI(t, "tool", "echo 'quota exceeded' >&2\nexit 3\n")})
+	if _, err := e.SendMessage(context.Background(), execTestMessages); err == nil || !strings.Contains(err.Error(), "quota exceeded") {
+		t.Errorf("expected the command's stderr in the error, got %v", err)
+	}
+
+	e = newTestExecAgent(t, agent.AgentConfig{Command: writeStubCLI(t, "tool", "echo 'noise'\n"), StripPatterns: []string{"noise"}})
+	if _, err := e.SendMessage(context.Background(), execTestMessages); err == nil || !strings.Contains(err.Error(), "no output") {
+		t.Errorf("expected an error for empty output, got %v", err)
+	}
+}
+
+func TestExecAgentInitializeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  agent.AgentConfig
+		wantErr string
+	}{
+		{"missing command", agent.AgentConfig{}, "needs a command"},
+		{"unknown command", agent.AgentConfig{Command: "agentpipe-no-such-tool"}, "not found"},
+		{"invalid strip pattern", agent.AgentConfig{Command: "echo", StripPatterns: []string{"("}}, "invalid strip pattern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.ID, tt.config.Type, tt.config.Name = "exec-1", "exec", "Tool"
+			err := NewExecAgent().Initialize(tt.config)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -249,7 +249,12 @@ func (f *FactoryAgent) filterRelevantMessages(messages []agent.Message) []agent.
 		relevant = append(relevant, msg)
 	}
 
-	return relevant
+	return f.LimitHistory(relevant)
+}
+
+// PreviewPrompt returns the prompt SendMessage would send for messages
+func (f *FactoryAgent) PreviewPrompt(messages []agent.Message) string {
+	return f.buildPrompt(f.filterRelevantMessages(messages), true)
 }
 
 func (f *FactoryAgent) buildPrompt(messages []agent.Message, isInitialSession bool) string {
@@ -278,7 +283,7 @@ func (f *FactoryAgent) buildPrompt(messages []agent.Message, isInitialSession bo
 		// IMPORTANT: Find the orchestrator's initial prompt (AgentID/AgentName = "host" or "system")
 		// Agent announcements are also system messages, but they come from specific agents
 		for _, msg := range messages {
-			if msg.Role == "system" && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
+			if msg.Role == agent.RoleSystem && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
 				// This is the orchestrator's initial prompt - show it prominently
 				initialPrompt = msg.Content
 			} else {
@@ -306,7 +311,7 @@ func (f *FactoryAgent) buildPrompt(messages []agent.Message, isInitialSession bo
 			for _, msg := range otherMessages {
 				timestamp := time.Unix(msg.Timestamp, 0).Format("15:04:05")
 				// Include role indicator for system messages to make them clear
-				if msg.Role == "system" {
+				if msg.Role == agent.RoleSystem {
 					prompt.WriteString(fmt.Sprintf("[%s] SYSTEM: %s\n", timestamp, msg.Content))
 				} else {
 					prompt.WriteString(fmt.Sprintf("[%s] %s: %s\n", timestamp, msg.AgentName, msg.Content))
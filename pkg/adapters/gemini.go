@@ -16,6 +16,7 @@ import (
 
 type GeminiAgent struct {
 	agent.BaseAgent
+	usageTracker
 	execPath string
 }
 
@@ -113,6 +114,8 @@ func (g *GeminiAgent) SendMessage(ctx context.Context, messages []agent.Message)
 		"message_count": len(messages),
 	}).Debug("sending message to gemini CLI")
 
+	g.setUsage(nil)
+
 	// Filter out this agent's own messages
 	relevantMessages := g.filterRelevantMessages(messages)
 
@@ -127,6 +130,11 @@ func (g *GeminiAgent) SendMessage(ctx context.Context, messages []agent.Message)
 		args = append(args, "--model", g.Config.Model)
 	}
 
+	// JSON output carries exact token usage
+	if g.Config.UseJSONOutput {
+		args = append(args, "--output-format", "json")
+	}
+
 	// Use stdin for the prompt to avoid terminal detection issues
 	cmd := exec.CommandContext(ctx, g.execPath, args...)
 	cmd.Stdin = strings.NewReader(prompt)
@@ -203,6 +211,24 @@ func (g *GeminiAgent) SendMessage(ctx context.Context, messages []agent.Message)
 		}
 	}
 
+	if g.Config.UseJSONOutput {
+		text, usage, err := parseGeminiJSON(outputStr)
+		if err != nil {
+			log.WithField("agent_name", g.Name).WithError(err).Error("failed to parse gemini JSON output")
+			return "", err
+		}
+		g.setUsage(&usage)
+
+		log.WithFields(map[string]interface{}{
+			"agent_name":    g.Name,
+			"duration":      duration.String(),
+			"response_size": len(output),
+			"input_tokens":  usage.InputTokens,
+			"output_tokens": usage.OutputTokens,
+		}).Info("gemini message sent successfully")
+		return text, nil
+	}
+
 	// Clean up output (outputStr already defined above)
 	// Remove common prefixes and error traces
 	lines := strings.Split(outputStr, "\n")
@@ -272,6 +298,16 @@ func (g *GeminiAgent) StreamMessage(ctx context.Context, messages []agent.Messag
 		return nil
 	}
 
+	// JSON output arrives in one piece, so send it through SendMessage to capture usage
+	if g.Config.UseJSONOutput {
+		response, err := g.SendMessage(ctx, messages)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(writer, response)
+		return err
+	}
+
 	// Filter out this agent's own messages
 	relevantMessages := g.filterRelevantMessages(messages)
 
@@ -320,6 +356,41 @@ func (g *GeminiAgent) StreamMessage(ctx context.Context, messages []agent.Messag
 	return nil
 }
 
+// geminiJSONOutput is the object printed by gemini --output-format json
+type geminiJSONOutput struct {
+	Response string `json:"response"`
+	Error    *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+	Stats struct {
+		Models map[string]struct {
+			Tokens struct {
+				Prompt     int `json:"prompt"`
+				Candidates int `json:"candidates"`
+			} `json:"tokens"`
+		} `json:"models"`
+	} `json:"stats"`
+}
+
+// parseGeminiJSON extracts the response text and token usage from gemini's
+// JSON output, summing usage across every model the CLI called.
+func parseGeminiJSON(output string) (string, agent.Usage, error) {
+	var result geminiJSONOutput
+	if err := decodeJSONOutput(output, &result); err != nil {
+		return "", agent.Usage{}, fmt.Errorf("gemini: %w", err)
+	}
+	if result.Error != nil {
+		return "", agent.Usage{}, fmt.Errorf("gemini API error: %s", result.Error.Message)
+	}
+
+	var usage agent.Usage
+	for _, model := range result.Stats.Models {
+		usage.InputTokens += model.Tokens.Prompt
+		usage.OutputTokens += model.Tokens.Candidates
+	}
+	return strings.TrimSpace(result.Response), usage, nil
+}
+
 func (g *GeminiAgent) filterRelevantMessages(messages []agent.Message) []agent.Message {
 	relevant := make([]agent.Message, 0, len(messages))
 	for _, msg := range messages {
@@ -329,7 +400,12 @@ func (g *GeminiAgent) filterRelevantMessages(messages []agent.Message) []agent.M
 		}
 		relevant = append(relevant, msg)
 	}
-	return relevant
+	return g.LimitHistory(relevant)
+}
+
+// PreviewPrompt returns the prompt SendMessage would send for messages
+func (g *GeminiAgent) PreviewPrompt(messages []agent.Message) string {
+	return g.buildPrompt(g.filterRelevantMessages(messages), true)
 }
 
 func (g *GeminiAgent) buildPrompt(messages []agent.Message, isInitialSession bool) string {
@@ -355,7 +431,7 @@ func (g *GeminiAgent) buildPrompt(messages []agent.Message, isInitialSession boo
 		// Find the orchestrator's initial prompt (AgentID="system" or "host")
 		// vs agent announcements (system messages from specific agents)
 		for _, msg := range messages {
-			if msg.Role == "system" && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
+			if msg.Role == agent.RoleSystem && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
 				// This is the orchestrator's initial prompt - show it prominently
 				initialPrompt = msg.Content
 			} else {
@@ -382,7 +458,7 @@ func (g *GeminiAgent) buildPrompt(messages []agent.Message, isInitialSession boo
 			prompt.WriteString("\n")
 			for _, msg := range otherMessages {
 				timestamp := time.Unix(msg.Timestamp, 0).Format("15:04:05")
-				if msg.Role == "system" {
+				if msg.Role == agent.RoleSystem {
 					// Agent announcements come through as system messages
 					prompt.WriteString(fmt.Sprintf("[%s] SYSTEM: %s\n", timestamp, msg.Content))
 				} else {
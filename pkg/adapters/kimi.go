@@ -204,7 +204,12 @@ func (k *KimiAgent) filterRelevantMessages(messages []agent.Message) []agent.Mes
 		}
 		relevant = append(relevant, msg)
 	}
-	return relevant
+	return k.LimitHistory(relevant)
+}
+
+// PreviewPrompt returns the prompt SendMessage would send for messages
+func (k *KimiAgent) PreviewPrompt(messages []agent.Message) string {
+	return k.buildPrompt(k.filterRelevantMessages(messages))
 }
 
 func (k *KimiAgent) buildPrompt(messages []agent.Message) string {
@@ -229,7 +234,7 @@ func (k *KimiAgent) buildPrompt(messages []agent.Message) string {
 
 		// Find orchestrator's initial prompt (HOST) vs agent announcements (SYSTEM)
 		for _, msg := range messages {
-			if msg.Role == "system" && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
+			if msg.Role == agent.RoleSystem && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
 				initialPrompt = msg.Content
 			} else {
 				otherMessages = append(otherMessages, msg)
@@ -253,7 +258,7 @@ func (k *KimiAgent) buildPrompt(messages []agent.Message) string {
 			prompt.WriteString(strings.Repeat("-", 60))
 			prompt.WriteString("\n")
 			for _, msg := range otherMessages {
-				if msg.Role == "system" {
+				if msg.Role == agent.RoleSystem {
 					prompt.WriteString(fmt.Sprintf("SYSTEM: %s\n", msg.Content))
 				} else {
 					prompt.WriteString(fmt.Sprintf("%s: %s\n", msg.AgentName, msg.Content))
@@ -0,0 +1,371 @@
+package adapters
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/internal/registry"
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/log"
+)
+
+// MistralAgent runs Mistral models through Mistral's Vibe CLI (vibe).
+type MistralAgent struct {
+	agent.BaseAgent
+	execPath string
+}
+
+func NewMistralAgent() agent.Agent {
+	return &MistralAgent{}
+}
+
+func (m *MistralAgent) Initialize(config agent.AgentConfig) error {
+	if err := m.BaseAgent.Initialize(config); err != nil {
+		log.WithFields(map[string]interface{}{
+			"agent_id":   config.ID,
+			"agent_name": config.Name,
+		}).WithError(err).Error("mistral agent base initialization failed")
+		return err
+	}
+
+	path, err := exec.LookPath("vibe")
+	if err != nil {
+		log.WithFields(map[string]interface{}{
+			"agent_id":   m.ID,
+			"agent_name": m.Name,
+		}).WithError(err).Error("mistral CLI (vibe) not found in PATH")
+		return fmt.Errorf("mistral CLI (vibe) not found: %w", err)
+	}
+	m.execPath = path
+
+	log.WithFields(map[string]interface{}{
+		"agent_id":   m.ID,
+		"agent_name": m.Name,
+		"exec_path":  path,
+		"model":      m.Config.Model,
+	}).Info("mistral agent initialized successfully")
+
+	return nil
+}
+
+func (m *MistralAgent) IsAvailable() bool {
+	_, err := exec.LookPath("vibe")
+	return err == nil
+}
+
+func (m *MistralAgent) GetCLIVersion() string {
+	return registry.GetInstalledVersion("vibe")
+}
+
+func (m *MistralAgent) HealthCheck(ctx context.Context) error {
+	if m.execPath == "" {
+		log.WithField("agent_name", m.Name).Error("mistral health check failed: not initialized")
+		return fmt.Errorf("mistral CLI not initialized")
+	}
+
+	log.WithField("agent_name", m.Name).Debug("starting mistral health check")
+
+	// Check if the Mistral Vibe CLI binary exists and responds to --version
+	cmd := exec.CommandContext(ctx, m.execPath, "--version")
+	output, err := cmd.CombinedOutput()
+
+	if err != nil {
+		// Try with -V flag if --version doesn't work
+		log.WithField("agent_name", m.Name).Debug("--version check failed, trying -V")
+		cmd = exec.CommandContext(ctx, m.execPath, "-V")
+		output, err = cmd.CombinedOutput()
+
+		if err != nil {
+			// If both fail, the CLI is not properly installed
+			log.WithField("agent_name", m.Name).WithError(err).Error("mistral health check failed: CLI not responding")
+			return fmt.Errorf("mistral CLI not responding to --version or -V: %w", err)
+		}
+	}
+
+	// Check if output contains version information
+	outputStr := string(output)
+	if len(outputStr) < 3 {
+		log.WithFields(map[string]interface{}{
+			"agent_name":    m.Name,
+			"output_length": len(outputStr),
+		}).Error("mistral health check failed: output too short")
+		return fmt.Errorf("mistral CLI returned suspiciously short output")
+	}
+
+	log.WithField("agent_name", m.Name).Info("mistral health check passed")
+	return nil
+}
+
+func (m *MistralAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	if len(messages) == 0 {
+		return "", nil
+	}
+
+	log.WithFields(map[string]interface{}{
+		"agent_name":    m.Name,
+		"message_count": len(messages),
+	}).Debug("sending message to mistral CLI")
+
+	// Filter out this agent's own messages
+	relevantMessages := m.filterRelevantMessages(messages)
+
+	// Build prompt with structured format
+	prompt := m.buildPrompt(relevantMessages, true)
+
+	// Mistral Vibe CLI takes prompt via stdin
+	cmd := exec.CommandContext(ctx, m.execPath, m.buildArgs()...)
+	cmd.Stdin = strings.NewReader(prompt)
+
+	startTime := time.Now()
+	output, err := cmd.CombinedOutput()
+	duration := time.Since(startTime)
+
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			log.WithFields(map[string]interface{}{
+				"agent_name": m.Name,
+				"exit_code":  exitErr.ExitCode(),
+				"duration":   duration.String(),
+			}).WithError(err).Error("mistral execution failed with exit code")
+			return "", fmt.Errorf("mistral execution failed (exit code %d): %s", exitErr.ExitCode(), string(output))
+		}
+		log.WithFields(map[string]interface{}{
+			"agent_name": m.Name,
+			"duration":   duration.String(),
+		}).WithError(err).Error("mistral execution failed")
+		return "", fmt.Errorf("mistral execution failed: %w\nOutput: %s", err, string(output))
+	}
+
+	// Clean up output - remove system messages and login prompts
+	outputStr := string(output)
+	cleanedOutput := m.cleanOutput(outputStr)
+
+	log.WithFields(map[string]interface{}{
+		"agent_name":    m.Name,
+		"duration":      duration.String(),
+		"response_size": len(output),
+	}).Info("mistral message sent successfully")
+
+	return cleanedOutput, nil
+}
+
+func (m *MistralAgent) StreamMessage(ctx context.Context, messages []agent.Message, writer io.Writer) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	log.WithFields(map[string]interface{}{
+		"agent_name":    m.Name,
+		"message_count": len(messages),
+	}).Debug("starting mistral streaming message")
+
+	// Filter out this agent's own messages
+	relevantMessages := m.filterRelevantMessages(messages)
+
+	// Build prompt with structured format
+	prompt := m.buildPrompt(relevantMessages, true)
+
+	// Mistral Vibe CLI takes prompt via stdin
+	cmd := exec.CommandContext(ctx, m.execPath, m.buildArgs()...)
+	cmd.Stdin = strings.NewReader(prompt)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		log.WithField("agent_name", m.Name).WithError(err).Error("failed to create stdout pipe")
+		return fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		log.WithField("agent_name", m.Name).WithError(err).Error("failed to start mistral process")
+		return fmt.Errorf("failed to start mistral: %w", err)
+	}
+
+	startTime := time.Now()
+	scanner := bufio.NewScanner(stdout)
+	lineCount := 0
+	for scanner.Scan() {
+		line := scanner.Text()
+		// Skip system messages and authentication prompts
+		if m.shouldSkipLine(line) {
+			continue
+		}
+		fmt.Fprintln(writer, line)
+		lineCount++
+	}
+
+	if err := scanner.Err(); err != nil {
+		log.WithField("agent_name", m.Name).WithError(err).Error("error reading streaming output")
+		return fmt.Errorf("error reading output: %w", err)
+	}
+
+	if err := cmd.Wait(); err != nil {
+		log.WithField("agent_name", m.Name).WithError(err).Error("mistral streaming execution failed")
+		return fmt.Errorf("mistral execution failed: %w", err)
+	}
+
+	duration := time.Since(startTime)
+	log.WithFields(map[string]interface{}{
+		"agent_name": m.Name,
+		"duration":   duration.String(),
+		"lines":      lineCount,
+	}).Info("mistral streaming message completed")
+
+	return nil
+}
+
+// buildArgs returns the vibe arguments: the configured model (e.g.
+// mistral-large-latest) and temperature, when set.
+func (m *MistralAgent) buildArgs() []string {
+	args := []string{}
+	if m.Config.Model != "" {
+		args = append(args, "--model", m.Config.Model)
+	}
+	if m.Config.Temperature > 0 {
+		args = append(args, "--temperature", fmt.Sprintf("%.1f", m.Config.Temperature))
+	}
+	return args
+}
+
+// filterRelevantMessages filters out this agent's own messages
+// We exclude this agent's own messages to avoid showing Mistral what it already said
+func (m *MistralAgent) filterRelevantMessages(messages []agent.Message) []agent.Message {
+	relevant := make([]agent.Message, 0, len(messages))
+
+	for _, msg := range messages {
+		// Skip this agent's own messages
+		if msg.AgentName == m.Name || msg.AgentID == m.ID {
+			continue
+		}
+		// Include messages from other agents and system messages
+		relevant = append(relevant, msg)
+	}
+
+	return m.LimitHistory(relevant)
+}
+
+// PreviewPrompt returns the prompt SendMessage would send for messages
+func (m *MistralAgent) PreviewPrompt(messages []agent.Message) string {
+	return m.buildPrompt(m.filterRelevantMessages(messages), true)
+}
+
+func (m *MistralAgent) buildPrompt(messages []agent.Message, isInitialSession bool) string {
+	var prompt strings.Builder
+
+	// PART 1: IDENTITY AND ROLE (always first)
+	prompt.WriteString("AGENT SETUP:\n")
+	prompt.WriteString(strings.Repeat("=", 60))
+	prompt.WriteString("\n")
+	prompt.WriteString(fmt.Sprintf("You are '%s' participating in a multi-agent conversation.\n\n", m.Name))
+
+	if m.Config.Prompt != "" {
+		prompt.WriteString("YOUR ROLE AND INSTRUCTIONS:\n")
+		prompt.WriteString(m.Config.Prompt)
+		prompt.WriteString("\n")
+	}
+	prompt.WriteString(strings.Repeat("=", 60))
+	prompt.WriteString("\n\n")
+
+	// PART 2: CONVERSATION CONTEXT (after role is established)
+	if len(messages) > 0 {
+		// Deliver ALL existing messages including initial prompt and all conversation
+		var initialPrompt string
+		var otherMessages []agent.Message
+
+		// IMPORTANT: Find the orchestrator's initial prompt (AgentID/AgentName = "host" or "system")
+		// Agent announcements are also system messages, but they come from specific agents
+		for _, msg := range messages {
+			if msg.Role == agent.RoleSystem && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
+				// This is the orchestrator's initial prompt - show it prominently
+				initialPrompt = msg.Content
+			} else {
+				// ALL other messages (agent announcements, other system messages, agent responses)
+				otherMessages = append(otherMessages, msg)
+			}
+		}
+
+		// Show the initial prompt as a DIRECT INSTRUCTION
+		if initialPrompt != "" {
+			prompt.WriteString("YOUR TASK - PLEASE RESPOND TO THIS:\n")
+			prompt.WriteString(strings.Repeat("=", 60))
+			prompt.WriteString("\n")
+			prompt.WriteString(initialPrompt)
+			prompt.WriteString("\n")
+			prompt.WriteString(strings.Repeat("=", 60))
+			prompt.WriteString("\n\n")
+		}
+
+		// Then show ALL remaining conversation (system messages + agent messages)
+		if len(otherMessages) > 0 {
+			prompt.WriteString("CONVERSATION SO FAR:\n")
+			prompt.WriteString(strings.Repeat("-", 60))
+			prompt.WriteString("\n")
+			for _, msg := range otherMessages {
+				timestamp := time.Unix(msg.Timestamp, 0).Format("15:04:05")
+				// Include role indicator for system messages to make them clear
+				if msg.Role == agent.RoleSystem {
+					prompt.WriteString(fmt.Sprintf("[%s] SYSTEM: %s\n", timestamp, msg.Content))
+				} else {
+					prompt.WriteString(fmt.Sprintf("[%s] %s: %s\n", timestamp, msg.AgentName, msg.Content))
+				}
+			}
+			prompt.WriteString(strings.Repeat("-", 60))
+			prompt.WriteString("\n\n")
+		}
+
+		if initialPrompt != "" {
+			prompt.WriteString(fmt.Sprintf("Now respond to the task above as %s. Provide a direct, thoughtful answer.", m.Name))
+		} else {
+			prompt.WriteString(fmt.Sprintf("Now, as %s, respond to the conversation.", m.Name))
+		}
+	}
+
+	return prompt.String()
+}
+
+// cleanOutput removes system messages, login prompts, and other noise from Mistral output
+func (m *MistralAgent) cleanOutput(output string) string {
+	lines := strings.Split(output, "\n")
+	cleanedLines := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		if m.shouldSkipLine(line) {
+			continue
+		}
+		cleanedLines = append(cleanedLines, line)
+	}
+
+	return strings.TrimSpace(strings.Join(cleanedLines, "\n"))
+}
+
+// shouldSkipLine determines if a line should be filtered out from output
+func (m *MistralAgent) shouldSkipLine(line string) bool {
+	// Skip empty lines
+	if strings.TrimSpace(line) == "" {
+		return false // Keep empty lines for formatting
+	}
+
+	// Skip authentication banners and API key hints
+	if strings.Contains(line, "MISTRAL_API_KEY") ||
+		strings.Contains(line, "No API key found") ||
+		strings.Contains(line, "To authenticate") ||
+		strings.Contains(line, "/login") {
+		return true
+	}
+
+	// Skip the startup banner and configuration notices
+	if strings.Contains(line, "Mistral Vibe") ||
+		strings.Contains(line, "Loaded config from") {
+		return true
+	}
+
+	return false
+}
+
+func init() {
+	agent.RegisterFactory("mistral", NewMistralAgent)
+}
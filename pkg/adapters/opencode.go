@@ -186,7 +186,12 @@ func (o *OpenCodeAgent) filterRelevantMessages(messages []agent.Message) []agent
 		}
 		relevant = append(relevant, msg)
 	}
-	return relevant
+	return o.LimitHistory(relevant)
+}
+
+// PreviewPrompt returns the prompt SendMessage would send for messages
+func (o *OpenCodeAgent) PreviewPrompt(messages []agent.Message) string {
+	return o.buildPrompt(o.filterRelevantMessages(messages), true)
 }
 
 func (o *OpenCodeAgent) buildPrompt(messages []agent.Message, isInitialSession bool) string {
@@ -212,7 +217,7 @@ func (o *OpenCodeAgent) buildPrompt(messages []agent.Message, isInitialSession b
 		// Find the orchestrator's initial prompt (AgentID="system" or "host")
 		// vs agent announcements (system messages from specific agents)
 		for _, msg := range messages {
-			if msg.Role == "system" && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
+			if msg.Role == agent.RoleSystem && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
 				// This is the orchestrator's initial prompt - show it prominently
 				initialPrompt = msg.Content
 			} else {
@@ -239,7 +244,7 @@ func (o *OpenCodeAgent) buildPrompt(messages []agent.Message, isInitialSession b
 			prompt.WriteString("\n")
 			for _, msg := range otherMessages {
 				timestamp := time.Unix(msg.Timestamp, 0).Format("15:04:05")
-				if msg.Role == "system" {
+				if msg.Role == agent.RoleSystem {
 					// Agent announcements come through as system messages
 					prompt.WriteString(fmt.Sprintf("[%s] SYSTEM: %s\n", timestamp, msg.Content))
 				} else {
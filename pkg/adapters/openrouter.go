@@ -260,6 +260,17 @@ func (o *OpenRouterAgent) StreamMessage(ctx context.Context, messages []agent.Me
 	return nil
 }
 
+// PreviewPrompt returns the chat messages SendMessage would send for messages,
+// one "[role] content" block per message
+func (o *OpenRouterAgent) PreviewPrompt(messages []agent.Message) string {
+	apiMessages := o.buildConversationHistory(messages)
+	blocks := make([]string, 0, len(apiMessages))
+	for _, msg := range apiMessages {
+		blocks = append(blocks, fmt.Sprintf("[%s] %s", msg.Role, msg.Content))
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
 // buildConversationHistory converts AgentPipe messages to OpenAI API format.
 func (o *OpenRouterAgent) buildConversationHistory(messages []agent.Message) []client.ChatCompletionMessage {
 	apiMessages := make([]client.ChatCompletionMessage, 0)
@@ -272,8 +283,8 @@ func (o *OpenRouterAgent) buildConversationHistory(messages []agent.Message) []c
 		})
 	}
 
-	// Convert conversation messages
-	for _, msg := range messages {
+	// Convert conversation messages, limited to the history window and context budget
+	for _, msg := range o.LimitHistory(messages) {
 		// Skip this agent's own messages to avoid confusion
 		if msg.AgentName == o.Name || msg.AgentID == o.ID {
 			continue
@@ -283,16 +294,16 @@ func (o *OpenRouterAgent) buildConversationHistory(messages []agent.Message) []c
 		var content string
 
 		switch msg.Role {
-		case "system":
+		case agent.RoleSystem:
 			// System messages (orchestrator prompts, announcements)
 			role = "user" // Most APIs don't support multiple system messages, so use user role
 			content = fmt.Sprintf("[System] %s", msg.Content)
 
-		case "user":
+		case agent.RoleUser:
 			role = "user"
 			content = msg.Content
 
-		case "agent":
+		case agent.RoleAgent:
 			role = "user" // Treat other agents' messages as user messages
 			content = fmt.Sprintf("%s: %s", msg.AgentName, msg.Content)
 
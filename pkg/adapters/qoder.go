@@ -250,7 +250,12 @@ func (q *QoderAgent) filterRelevantMessages(messages []agent.Message) []agent.Me
 		}
 		relevant = append(relevant, msg)
 	}
-	return relevant
+	return q.LimitHistory(relevant)
+}
+
+// PreviewPrompt returns the prompt SendMessage would send for messages
+func (q *QoderAgent) PreviewPrompt(messages []agent.Message) string {
+	return q.buildPrompt(q.filterRelevantMessages(messages), true)
 }
 
 func (q *QoderAgent) buildPrompt(messages []agent.Message, isInitialSession bool) string {
@@ -277,7 +282,7 @@ func (q *QoderAgent) buildPrompt(messages []agent.Message, isInitialSession bool
 		// HOST = orchestrator's initial task/prompt (AgentID="host", AgentName="HOST")
 		// SYSTEM = agent join announcements and other system messages
 		for _, msg := range messages {
-			if msg.Role == "system" && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
+			if msg.Role == agent.RoleSystem && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
 				initialPrompt = msg.Content
 			} else {
 				otherMessages = append(otherMessages, msg)
@@ -302,7 +307,7 @@ func (q *QoderAgent) buildPrompt(messages []agent.Message, isInitialSession bool
 			prompt.WriteString("\n")
 			for _, msg := range otherMessages {
 				timestamp := time.Unix(msg.Timestamp, 0).Format("15:04:05")
-				if msg.Role == "system" {
+				if msg.Role == agent.RoleSystem {
 					prompt.WriteString(fmt.Sprintf("[%s] SYSTEM: %s\n", timestamp, msg.Content))
 				} else {
 					prompt.WriteString(fmt.Sprintf("[%s] %s: %s\n", timestamp, msg.AgentName, msg.Content))
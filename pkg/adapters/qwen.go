@@ -184,7 +184,12 @@ func (q *QwenAgent) filterRelevantMessages(messages []agent.Message) []agent.Mes
 		}
 		relevant = append(relevant, msg)
 	}
-	return relevant
+	return q.LimitHistory(relevant)
+}
+
+// PreviewPrompt returns the prompt SendMessage would send for messages
+func (q *QwenAgent) PreviewPrompt(messages []agent.Message) string {
+	return q.buildPrompt(q.filterRelevantMessages(messages), true)
 }
 
 func (q *QwenAgent) buildPrompt(messages []agent.Message, isInitialSession bool) string {
@@ -210,7 +215,7 @@ func (q *QwenAgent) buildPrompt(messages []agent.Message, isInitialSession bool)
 		// Find the orchestrator's initial prompt (AgentID="system" or "host")
 		// vs agent announcements (system messages from specific agents)
 		for _, msg := range messages {
-			if msg.Role == "system" && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
+			if msg.Role == agent.RoleSystem && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
 				// This is the orchestrator's initial prompt - show it prominently
 				initialPrompt = msg.Content
 			} else {
@@ -237,7 +242,7 @@ func (q *QwenAgent) buildPrompt(messages []agent.Message, isInitialSession bool)
 			prompt.WriteString("\n")
 			for _, msg := range otherMessages {
 				timestamp := time.Unix(msg.Timestamp, 0).Format("15:04:05")
-				if msg.Role == "system" {
+				if msg.Role == agent.RoleSystem {
 					// Agent announcements come through as system messages
 					prompt.WriteString(fmt.Sprintf("[%s] SYSTEM: %s\n", timestamp, msg.Content))
 				} else {
@@ -0,0 +1,367 @@
+package adapters
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/internal/registry"
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/log"
+)
+
+type RovoDevAgent struct {
+	agent.BaseAgent
+	execPath string
+}
+
+func NewRovoDevAgent() agent.Agent {
+	return &RovoDevAgent{}
+}
+
+func (r *RovoDevAgent) Initialize(config agent.AgentConfig) error {
+	if err := r.BaseAgent.Initialize(config); err != nil {
+		log.WithFields(map[string]interface{}{
+			"agent_id":   config.ID,
+			"agent_name": config.Name,
+		}).WithError(err).Error("rovodev agent base initialization failed")
+		return err
+	}
+
+	path, err := exec.LookPath("acli")
+	if err != nil {
+		log.WithFields(map[string]interface{}{
+			"agent_id":   r.ID,
+			"agent_name": r.Name,
+		}).WithError(err).Error("acli (Rovo Dev CLI) not found in PATH")
+		return fmt.Errorf("acli (Rovo Dev CLI) not found: %w", err)
+	}
+	r.execPath = path
+
+	log.WithFields(map[string]interface{}{
+		"agent_id":   r.ID,
+		"agent_name": r.Name,
+		"exec_path":  path,
+		"model":      r.Config.Model,
+	}).Info("rovodev agent initialized successfully")
+
+	return nil
+}
+
+func (r *RovoDevAgent) IsAvailable() bool {
+	_, err := exec.LookPath("acli")
+	return err == nil
+}
+
+func (r *RovoDevAgent) GetCLIVersion() string {
+	return registry.GetInstalledVersion("acli")
+}
+
+func (r *RovoDevAgent) HealthCheck(ctx context.Context) error {
+	if r.execPath == "" {
+		log.WithField("agent_name", r.Name).Error("rovodev health check failed: not initialized")
+		return fmt.Errorf("rovodev CLI not initialized")
+	}
+
+	log.WithField("agent_name", r.Name).Debug("starting rovodev health check")
+
+	// Check if the acli binary exists and responds to --version
+	cmd := exec.CommandContext(ctx, r.execPath, "--version")
+	output, err := cmd.CombinedOutput()
+
+	if err != nil {
+		// Try with -V flag if --version doesn't work
+		log.WithField("agent_name", r.Name).Debug("--version check failed, trying -V")
+		cmd = exec.CommandContext(ctx, r.execPath, "-V")
+		output, err = cmd.CombinedOutput()
+
+		if err != nil {
+			// If both fail, the CLI is not properly installed
+			log.WithField("agent_name", r.Name).WithError(err).Error("rovodev health check failed: CLI not responding")
+			return fmt.Errorf("acli not responding to --version or -V: %w", err)
+		}
+	}
+
+	// Check if output contains version information
+	outputStr := string(output)
+	if len(outputStr) < 3 {
+		log.WithFields(map[string]interface{}{
+			"agent_name":    r.Name,
+			"output_length": len(outputStr),
+		}).Error("rovodev health check failed: output too short")
+		return fmt.Errorf("acli returned suspiciously short output")
+	}
+
+	log.WithField("agent_name", r.Name).Info("rovodev health check passed")
+	return nil
+}
+
+func (r *RovoDevAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	if len(messages) == 0 {
+		return "", nil
+	}
+
+	log.WithFields(map[string]interface{}{
+		"agent_name":    r.Name,
+		"message_count": len(messages),
+	}).Debug("sending message to rovodev CLI")
+
+	// Filter out this agent's own messages
+	relevantMessages := r.filterRelevantMessages(messages)
+
+	// Build prompt with structured format
+	prompt := r.buildPrompt(relevantMessages, true)
+
+	// Rovo Dev runs non-interactively via "acli rovodev run <prompt>"
+	cmd := exec.CommandContext(ctx, r.execPath, r.runArgs(prompt)...)
+
+	startTime := time.Now()
+	output, err := cmd.CombinedOutput()
+	duration := time.Since(startTime)
+
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			log.WithFields(map[string]interface{}{
+				"agent_name": r.Name,
+				"exit_code":  exitErr.ExitCode(),
+				"duration":   duration.String(),
+			}).WithError(err).Error("rovodev execution failed with exit code")
+			return "", fmt.Errorf("rovodev execution failed (exit code %d): %s", exitErr.ExitCode(), string(output))
+		}
+		log.WithFields(map[string]interface{}{
+			"agent_name": r.Name,
+			"duration":   duration.String(),
+		}).WithError(err).Error("rovodev execution failed")
+		return "", fmt.Errorf("rovodev execution failed: %w\nOutput: %s", err, string(output))
+	}
+
+	// Clean up output - remove system messages and login prompts
+	outputStr := string(output)
+	cleanedOutput := r.cleanOutput(outputStr)
+
+	log.WithFields(map[string]interface{}{
+		"agent_name":    r.Name,
+		"duration":      duration.String(),
+		"response_size": len(output),
+	}).Info("rovodev message sent successfully")
+
+	return cleanedOutput, nil
+}
+
+func (r *RovoDevAgent) StreamMessage(ctx context.Context, messages []agent.Message, writer io.Writer) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	log.WithFields(map[string]interface{}{
+		"agent_name":    r.Name,
+		"message_count": len(messages),
+	}).Debug("starting rovodev streaming message")
+
+	// Filter out this agent's own messages
+	relevantMessages := r.filterRelevantMessages(messages)
+
+	// Build prompt with structured format
+	prompt := r.buildPrompt(relevantMessages, true)
+
+	// Rovo Dev runs non-interactively via "acli rovodev run <prompt>"
+	cmd := exec.CommandContext(ctx, r.execPath, r.runArgs(prompt)...)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		log.WithField("agent_name", r.Name).WithError(err).Error("failed to create stdout pipe")
+		return fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		log.WithField("agent_name", r.Name).WithError(err).Error("failed to start rovodev process")
+		return fmt.Errorf("failed to start rovodev: %w", err)
+	}
+
+	startTime := time.Now()
+	scanner := bufio.NewScanner(stdout)
+	lineCount := 0
+	for scanner.Scan() {
+		line := scanner.Text()
+		// Skip system messages and authentication prompts
+		if r.shouldSkipLine(line) {
+			continue
+		}
+		fmt.Fprintln(writer, line)
+		lineCount++
+	}
+
+	if err := scanner.Err(); err != nil {
+		log.WithField("agent_name", r.Name).WithError(err).Error("error reading streaming output")
+		return fmt.Errorf("error reading output: %w", err)
+	}
+
+	if err := cmd.Wait(); err != nil {
+		log.WithField("agent_name", r.Name).WithError(err).Error("rovodev streaming execution failed")
+		return fmt.Errorf("rovodev execution failed: %w", err)
+	}
+
+	duration := time.Since(startTime)
+	log.WithFields(map[string]interface{}{
+		"agent_name": r.Name,
+		"duration":   duration.String(),
+		"lines":      lineCount,
+	}).Info("rovodev streaming message completed")
+
+	return nil
+}
+
+// runArgs returns the acli arguments for a non-interactive Rovo Dev run.
+// Rovo Dev chooses its own model, so Config.Model is not passed.
+func (r *RovoDevAgent) runArgs(prompt string) []string {
+	return []string{"rovodev", "run", prompt}
+}
+
+// filterRelevantMessages filters out this agent's own messages
+// We exclude this agent's own messages to avoid showing Rovo Dev what it already said
+func (r *RovoDevAgent) filterRelevantMessages(messages []agent.Message) []agent.Message {
+	relevant := make([]agent.Message, 0, len(messages))
+
+	for _, msg := range messages {
+		// Skip this agent's own messages
+		if msg.AgentName == r.Name || msg.AgentID == r.ID {
+			continue
+		}
+		// Include messages from other agents and system messages
+		relevant = append(relevant, msg)
+	}
+
+	return r.LimitHistory(relevant)
+}
+
+// PreviewPrompt returns the prompt SendMessage would send for messages
+func (r *RovoDevAgent) PreviewPrompt(messages []agent.Message) string {
+	return r.buildPrompt(r.filterRelevantMessages(messages), true)
+}
+
+func (r *RovoDevAgent) buildPrompt(messages []agent.Message, isInitialSession bool) string {
+	var prompt strings.Builder
+
+	// PART 1: IDENTITY AND ROLE (always first)
+	prompt.WriteString("AGENT SETUP:\n")
+	prompt.WriteString(strings.Repeat("=", 60))
+	prompt.WriteString("\n")
+	prompt.WriteString(fmt.Sprintf("You are '%s' participating in a multi-agent conversation.\n\n", r.Name))
+
+	if r.Config.Prompt != "" {
+		prompt.WriteString("YOUR ROLE AND INSTRUCTIONS:\n")
+		prompt.WriteString(r.Config.Prompt)
+		prompt.WriteString("\n")
+	}
+	prompt.WriteString(strings.Repeat("=", 60))
+	prompt.WriteString("\n\n")
+
+	// PART 2: CONVERSATION CONTEXT (after role is established)
+	if len(messages) > 0 {
+		// Deliver ALL existing messages including initial prompt and all conversation
+		var initialPrompt string
+		var otherMessages []agent.Message
+
+		// IMPORTANT: Find the orchestrator's initial prompt (AgentID/AgentName = "host" or "system")
+		// Agent announcements are also system messages, but they come from specific agents
+		for _, msg := range messages {
+			if msg.Role == agent.RoleSystem && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST") && initialPrompt == "" {
+				// This is the orchestrator's initial prompt - show it prominently
+				initialPrompt = msg.Content
+			} else {
+				// ALL other messages (agent announcements, other system messages, agent responses)
+				otherMessages = append(otherMessages, msg)
+			}
+		}
+
+		// Show the initial prompt as a DIRECT INSTRUCTION
+		if initialPrompt != "" {
+			prompt.WriteString("YOUR TASK - PLEASE RESPOND TO THIS:\n")
+			prompt.WriteString(strings.Repeat("=", 60))
+			prompt.WriteString("\n")
+			prompt.WriteString(initialPrompt)
+			prompt.WriteString("\n")
+			prompt.WriteString(strings.Repeat("=", 60))
+			prompt.WriteString("\n\n")
+		}
+
+		// Then show ALL remaining conversation (system messages + agent messages)
+		if len(otherMessages) > 0 {
+			prompt.WriteString("CONVERSATION SO FAR:\n")
+			prompt.WriteString(strings.Repeat("-", 60))
+			prompt.WriteString("\n")
+			for _, msg := range otherMessages {
+				timestamp := time.Unix(msg.Timestamp, 0).Format("15:04:05")
+				// Include role indicator for system messages to make them clear
+				if msg.Role == agent.RoleSystem {
+					prompt.WriteString(fmt.Sprintf("[%s] SYSTEM: %s\n", timestamp, msg.Content))
+				} else {
+					prompt.WriteString(fmt.Sprintf("[%s] %s: %s\n", timestamp, msg.AgentName, msg.Content))
+				}
+			}
+			prompt.WriteString(strings.Repeat("-", 60))
+			prompt.WriteString("\n\n")
+		}
+
+		if initialPrompt != "" {
+			prompt.WriteString(fmt.Sprintf("Now respond to the task above as %s. Provide a direct, thoughtful answer.", r.Name))
+		} else {
+			prompt.WriteString(fmt.Sprintf("Now, as %s, respond to the conversation.", r.Name))
+		}
+	}
+
+	return prompt.String()
+}
+
+// cleanOutput removes system messages, login prompts, and other noise from Rovo Dev output
+func (r *RovoDevAgent) cleanOutput(output string) string {
+	lines := strings.Split(output, "\n")
+	cleanedLines := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		if r.shouldSkipLine(line) {
+			continue
+		}
+		cleanedLines = append(cleanedLines, line)
+	}
+
+	return strings.TrimSpace(strings.Join(cleanedLines, "\n"))
+}
+
+// shouldSkipLine determines if a line should be filtered out from output
+func (r *RovoDevAgent) shouldSkipLine(line string) bool {
+	// Skip empty lines
+	if strings.TrimSpace(line) == "" {
+		return false // Keep empty lines for formatting
+	}
+
+	trimmed := strings.TrimSpace(line)
+
+	// Skip login and authentication banners
+	if strings.Contains(line, "acli rovodev auth login") ||
+		strings.Contains(line, "Not logged in") ||
+		strings.Contains(line, "not logged in") ||
+		strings.HasPrefix(trimmed, "Logged in as") ||
+		strings.HasPrefix(trimmed, "Authenticated as") ||
+		strings.Contains(line, "Atlassian API token") {
+		return true
+	}
+
+	// Skip startup banners and session information
+	if strings.Contains(line, "Welcome to Rovo Dev") ||
+		strings.HasPrefix(trimmed, "Rovo Dev CLI") ||
+		strings.HasPrefix(trimmed, "Session context") ||
+		strings.HasPrefix(trimmed, "Using site") {
+		return true
+	}
+
+	return false
+}
+
+func init() {
+	agent.RegisterFactory("rovodev", NewRovoDevAgent)
+}
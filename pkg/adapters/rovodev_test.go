@@ -0,0 +1,96 @@
+package adapters
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+func newTestRovoDevAgent() *RovoDevAgent {
+	r := &RovoDevAgent{}
+	r.Config = agent.AgentConfig{ID: "rovo-1", Type: "rovodev", Name: "Rovo", Prompt: "You review Jira tickets."}
+	r.ID = "rovo-1"
+	r.Name = "Rovo"
+	return r
+}
+
+func TestRovoDevAgentInitialization(t *testing.T) {
+	rovoAgent := NewRovoDevAgent()
+
+	config := agent.AgentConfig{
+		ID:   "rovo-1",
+		Type: "rovodev",
+		Name: "Rovo",
+	}
+
+	err := rovoAgent.Initialize(config)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			t.Skip("acli not available, skipping test")
+		}
+		t.Fatalf("initialization failed: %v", err)
+	}
+
+	if rovoAgent.GetType() != "rovodev" {
+		t.Errorf("expected type 'rovodev', got '%s'", rovoAgent.GetType())
+	}
+}
+
+func TestRovoDevSendMessageWithStub(t *testing.T) {
+	// The stub prints a login banner, then echoes its subcommand and prompt
+	script := `echo "Welcome to Rovo Dev (beta)"
+echo "Logged in as dev@example.com"
+echo "subcommand: $1 $2"
+echo "$3" | grep -q "Summarize the sprint" && echo "The sprint is on track."
+`
+	r := newTestRovoDevAgent()
+	r.execPath = writeStubCLI(t, "acli", script)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	messages := []agent.Message{
+		{AgentID: "host", AgentName: "HOST", Content: "Summarize the sprint", Timestamp: time.Now().Unix(), Role: "system"},
+	}
+	got, err := r.SendMessage(ctx, messages)
+	if err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+
+	want := "subcommand: rovodev run\nThe sprint is on track."
+	if got != want {
+		t.Errorf("SendMessage() = %q, want %q", got, want)
+	}
+}
+
+func TestRovoDevCleanOutput(t *testing.T) {
+	r := newTestRovoDevAgent()
+
+	tests := []struct {
+		line string
+		skip bool
+	}{
+		{"Welcome to Rovo Dev (beta), Atlassian's AI coding agent", true},
+		{"Rovo Dev CLI v0.9.2", true},
+		{"You are not logged in. Run 'acli rovodev auth login' to authenticate.", true},
+		{"Logged in as dev@example.com", true},
+		{"Session context: /home/dev/project", true},
+		{"Using site: example.atlassian.net", true},
+		{"", false},
+		{"The sprint is on track.", false},
+	}
+	for _, tt := range tests {
+		if got := r.shouldSkipLine(tt.line); got != tt.skip {
+			t.Errorf("shouldSkipLine(%q) = %v, want %v", tt.line, got, tt.skip)
+		}
+	}
+
+	output := "Welcome to Rovo Dev (beta)\nLogged in as dev@example.com\n\nThree tickets remain.\n\nNone are blocked.\n"
+	want := "Three tickets remain.\n\nNone are blocked."
+	if got := r.cleanOutput(output); got != want {
+		t.Errorf("cleanOutput() = %q, want %q", got, want)
+	}
+}
@@ -23,12 +23,27 @@ type Message struct {
 	Content string
 	// Timestamp is the Unix timestamp when the message was created
 	Timestamp int64
-	// Role indicates the message type: "agent", "user", or "system"
+	// Role indicates the message type: one of the Role constants
 	Role string
 	// Metrics contains optional performance and cost metrics for agent responses
 	Metrics *ResponseMetrics
 }
 
+// Message roles. Transcripts, the TUI and bridge log entries render each role
+// distinctly.
+const (
+	// RoleAgent is a response from a conversation participant
+	RoleAgent = "agent"
+	// RoleUser is a message from a person, such as a TUI user or a scripted prompt
+	RoleUser = "user"
+	// RoleSystem is the initial prompt, an announcement, or an orchestrator notice
+	RoleSystem = "system"
+	// RoleTool is the output of a tool or command run on the conversation's behalf
+	RoleTool = "tool"
+	// RoleModerator is a moderator's decision, such as who speaks next
+	RoleModerator = "moderator"
+)
+
 // ResponseMetrics captures performance and cost information for an agent response.
 // This is used for monitoring, billing, and optimization purposes.
 type ResponseMetrics struct {
@@ -44,6 +59,9 @@ type ResponseMetrics struct {
 	Model string
 	// Cost is the estimated monetary cost of the API call in USD
 	Cost float64
+	// Cached is true when the response was served from the response cache
+	// instead of calling the agent
+	Cached bool
 }
 
 // AgentConfig defines the configuration for creating and initializing an agent.
@@ -57,6 +75,12 @@ type AgentConfig struct {
 	Name string `yaml:"name"`
 	// Prompt is the system prompt that defines the agent's behavior
 	Prompt string `yaml:"prompt"`
+	// PromptFile is a file the prompt is read from instead, relative to the config
+	// file; config.LoadConfig loads it into Prompt
+	PromptFile string `yaml:"prompt_file"`
+	// Persona names a reusable prompt from the config's personas map or
+	// ~/.agentpipe/personas.d; it is placed before Prompt
+	Persona string `yaml:"persona"`
 	// Announcement is the message shown when the agent joins
 	Announcement string `yaml:"announcement"`
 	// Model is the specific model to use (e.g., "claude-sonnet-4.5")
@@ -69,8 +93,65 @@ type AgentConfig struct {
 	RateLimit float64 `yaml:"rate_limit"`
 	// RateLimitBurst is the maximum burst size for rate limiting (default: 1)
 	RateLimitBurst int `yaml:"rate_limit_burst"`
+	// TokensPerMinute is the maximum estimated input tokens per minute for this agent (0 = unlimited)
+	TokensPerMinute int `yaml:"tokens_per_minute"`
+	// MaxContextTokens caps the estimated tokens of the conversation history sent
+	// to the agent; the oldest non-system messages are dropped to fit (0 = unlimited)
+	MaxContextTokens int `yaml:"max_context_tokens"`
+	// HistoryWindow limits the history sent to the agent to the initial prompt
+	// and the last HistoryWindow other messages (0 = unlimited)
+	HistoryWindow int `yaml:"history_window"`
+	// Timeout overrides the orchestrator's turn timeout for this agent (0 = use the turn timeout)
+	Timeout time.Duration `yaml:"timeout"`
+	// ResponseDelay overrides the orchestrator's pause after this agent responds (0 = use the orchestrator's response delay)
+	ResponseDelay time.Duration `yaml:"response_delay"`
+	// Weight is how many turns the agent takes per round in round-robin mode (0 = 1)
+	Weight int `yaml:"weight"`
+	// StreamTimeout limits streaming CLI calls to less than the turn timeout; a longer value has no effect (0 = the turn timeout alone)
+	StreamTimeout time.Duration `yaml:"stream_timeout"`
+	// WorkDir is the working directory for agents that edit files (e.g., aider); empty means the current directory
+	WorkDir string `yaml:"work_dir"`
+	// Language is the language the agent converses in (e.g., "Japanese"); empty means the conversation language
+	Language string `yaml:"language"`
+	// UseJSONOutput asks CLIs that support it (Claude, Gemini) for JSON output so
+	// exact token usage is reported instead of estimated
+	UseJSONOutput bool `yaml:"use_json_output"`
+	// Command is the executable an exec agent runs
+	Command string `yaml:"command"`
+	// Args are the exec agent's command arguments; {{prompt}} is replaced with the prompt
+	Args []string `yaml:"args"`
+	// Stdin sends an exec agent's prompt on stdin instead of as an argument
+	Stdin bool `yaml:"stdin"`
+	// StripPatterns are regular expressions; exec agent output lines matching any of them are dropped
+	StripPatterns []string `yaml:"strip_patterns"`
+	// Warmup sends a trivial prompt before the conversation starts to preload
+	// the agent's model (e.g., Ollama), so the first turn isn't slowed by it
+	Warmup bool `yaml:"warmup"`
+	// Filters rewrite the agent's responses (e.g., redact secrets) before they are stored or shown
+	Filters []ResponseFilter `yaml:"filters"`
 	// CustomSettings allows agent-specific configuration options
 	CustomSettings map[string]interface{} `yaml:"custom_settings"`
+	// MCPServers are MCP tool servers made available to the agent, keyed by server name.
+	// Only adapters whose CLI accepts an MCP config (Claude, Amp) use them.
+	MCPServers map[string]MCPServer `yaml:"mcp_servers"`
+}
+
+// MCPServer defines a Model Context Protocol tool server.
+// Local servers set Command (and optionally Args and Env); remote servers set URL.
+// The JSON form matches the "mcpServers" entries understood by the agent CLIs.
+type MCPServer struct {
+	// Type is the transport: "stdio" (default for Command), "http", or "sse"
+	Type string `yaml:"type" json:"type,omitempty"`
+	// Command is the executable that starts a local server
+	Command string `yaml:"command" json:"command,omitempty"`
+	// Args are the command-line arguments for Command
+	Args []string `yaml:"args" json:"args,omitempty"`
+	// Env sets environment variables for Command
+	Env map[string]string `yaml:"env" json:"env,omitempty"`
+	// URL is the endpoint of a remote server
+	URL string `yaml:"url" json:"url,omitempty"`
+	// Headers are sent with every request to a remote server
+	Headers map[string]string `yaml:"headers" json:"headers,omitempty"`
 }
 
 // Agent is the core interface that all agent implementations must satisfy.
@@ -106,6 +187,73 @@ type Agent interface {
 	GetPrompt() string
 }
 
+// TokenBudgeted is an optional interface for agents that limit how many tokens
+// they may consume per minute. BaseAgent implements it from AgentConfig.TokensPerMinute.
+type TokenBudgeted interface {
+	// GetTokensPerMinute returns the tokens-per-minute budget (0 = unlimited)
+	GetTokensPerMinute() int
+}
+
+// TimeLimited is an optional interface for agents with their own turn timeout.
+// BaseAgent implements it from AgentConfig.Timeout.
+type TimeLimited interface {
+	// GetTimeout returns the agent's turn timeout (0 = use the orchestrator's turn timeout)
+	GetTimeout() time.Duration
+}
+
+// Paced is an optional interface for agents with their own pause after they
+// respond. BaseAgent implements it from AgentConfig.ResponseDelay.
+type Paced interface {
+	// GetResponseDelay returns the agent's response delay (0 = use the orchestrator's response delay)
+	GetResponseDelay() time.Duration
+}
+
+// Weighted is an optional interface for agents that speak more than once per
+// round-robin round. BaseAgent implements it from AgentConfig.Weight.
+type Weighted interface {
+	// GetWeight returns the agent's turns per round (0 = 1)
+	GetWeight() int
+}
+
+// Multilingual is an optional interface for agents that converse in a language
+// other than the conversation's. BaseAgent implements it from AgentConfig.Language.
+type Multilingual interface {
+	// GetLanguage returns the agent's language ("" = the conversation language)
+	GetLanguage() string
+}
+
+// Usage is the token usage reported by an agent CLI for one response.
+type Usage struct {
+	// InputTokens is the number of prompt tokens, including cached ones. Cost
+	// estimates price them all at the model's input rate, so prompt cache reads
+	// (billed at a fraction of it) make the estimate an overestimate.
+	InputTokens int
+	// OutputTokens is the number of generated tokens
+	OutputTokens int
+}
+
+// UsageReporter is an optional interface for agents that know the exact token
+// usage of their last response. The orchestrator prefers it over estimates.
+type UsageReporter interface {
+	// LastUsage returns the usage of the most recent SendMessage call, or false if unknown
+	LastUsage() (Usage, bool)
+}
+
+// PromptPreviewer is an optional interface for agents that can show the prompt
+// they would send for a conversation without sending it, as used by --dry-run.
+type PromptPreviewer interface {
+	// PreviewPrompt returns the prompt SendMessage would send for messages
+	PreviewPrompt(messages []Message) string
+}
+
+// Stateful is an optional interface for agents that keep conversation state
+// across SendMessage calls, such as a server-side thread, and send only what
+// is new. Warm-up resets it so the first real turn starts a fresh session.
+type Stateful interface {
+	// ResetSession forgets the agent's session, so the next call starts a new one
+	ResetSession()
+}
+
 // BaseAgent provides a default implementation of common Agent interface methods.
 // Agent implementations can embed BaseAgent to avoid reimplementing basic functionality.
 type BaseAgent struct {
@@ -119,6 +267,8 @@ type BaseAgent struct {
 	Config AgentConfig
 	// Announcement is the custom join message
 	Announcement string
+
+	filters []compiledFilter // Config.Filters, compiled by Initialize
 }
 
 // GetID returns the unique identifier of the agent.
@@ -161,6 +311,36 @@ func (b *BaseAgent) GetRateLimitBurst() int {
 	return 1 // Default burst size
 }
 
+// GetTokensPerMinute returns the tokens-per-minute budget for this agent.
+// A value of 0 means unlimited (no token-based rate limiting).
+func (b *BaseAgent) GetTokensPerMinute() int {
+	return b.Config.TokensPerMinute
+}
+
+// GetTimeout returns the turn timeout for this agent.
+// A value of 0 means the orchestrator's turn timeout applies.
+func (b *BaseAgent) GetTimeout() time.Duration {
+	return b.Config.Timeout
+}
+
+// GetResponseDelay returns the pause after this agent responds.
+// A value of 0 means the orchestrator's response delay applies.
+func (b *BaseAgent) GetResponseDelay() time.Duration {
+	return b.Config.ResponseDelay
+}
+
+// GetWeight returns how many turns this agent takes per round-robin round.
+// A value of 0 means one turn.
+func (b *BaseAgent) GetWeight() int {
+	return b.Config.Weight
+}
+
+// GetLanguage returns the language this agent converses in.
+// An empty string means the agent uses the conversation language.
+func (b *BaseAgent) GetLanguage() string {
+	return b.Config.Language
+}
+
 // GetPrompt returns the system prompt for the agent.
 func (b *BaseAgent) GetPrompt() string {
 	return b.Config.Prompt
@@ -177,12 +357,19 @@ func (b *BaseAgent) Announce() string {
 }
 
 // Initialize configures the BaseAgent with the provided configuration.
-// This sets up the basic fields that all agents need.
+// This sets up the basic fields that all agents need and compiles the
+// response filters, returning an error if a filter pattern is invalid.
 func (b *BaseAgent) Initialize(config AgentConfig) error {
+	filters, err := compileFilters(config.Filters)
+	if err != nil {
+		return fmt.Errorf("agent %s: %w", config.ID, err)
+	}
+
 	b.ID = config.ID
 	b.Name = config.Name
 	b.Type = config.Type
 	b.Config = config
 	b.Announcement = config.Announcement
+	b.filters = filters
 	return nil
 }
@@ -0,0 +1,75 @@
+package agent
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ResponseFilter rewrites an agent's responses before they are stored or shown,
+// for example to redact leaked tokens or strip boilerplate preambles:
+//
+//	filters:
+//	  - pattern: 'sk-[A-Za-z0-9]{20,}'
+//	    replacement: '[redacted]'
+//	  - pattern: '(?i)^as an ai language model,?\s*'
+//
+// Replacement may refer to capture groups as $1 or ${name}; it defaults to
+// removing the match.
+type ResponseFilter struct {
+	// Pattern is the regular expression to match
+	Pattern string `yaml:"pattern"`
+	// Replacement is the text substituted for each match
+	Replacement string `yaml:"replacement"`
+}
+
+// ResponseFilterer is an optional interface for agents whose responses are
+// rewritten before the orchestrator stores them. BaseAgent implements it from
+// AgentConfig.Filters.
+type ResponseFilterer interface {
+	// FilterResponse returns content with the agent's filters applied
+	FilterResponse(content string) string
+	// HasResponseFilters reports whether FilterResponse may change content
+	HasResponseFilters() bool
+}
+
+// compiledFilter is a ResponseFilter with its pattern compiled.
+type compiledFilter struct {
+	re          *regexp.Regexp
+	replacement string
+}
+
+// compileFilters compiles the patterns of filters, failing on the first invalid one.
+func compileFilters(filters []ResponseFilter) ([]compiledFilter, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+
+	compiled := make([]compiledFilter, 0, len(filters))
+	for _, f := range filters {
+		re, err := regexp.Compile(f.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter pattern %q: %w", f.Pattern, err)
+		}
+		compiled = append(compiled, compiledFilter{re: re, replacement: f.Replacement})
+	}
+	return compiled, nil
+}
+
+// HasResponseFilters reports whether the agent has filters configured.
+func (b *BaseAgent) HasResponseFilters() bool {
+	return len(b.filters) > 0
+}
+
+// FilterResponse applies the agent's filters to content in order and trims the
+// whitespace a removed match leaves behind. Without filters, content is
+// returned unchanged.
+func (b *BaseAgent) FilterResponse(content string) string {
+	if len(b.filters) == 0 {
+		return content
+	}
+	for _, f := range b.filters {
+		content = f.re.ReplaceAllString(content, f.replacement)
+	}
+	return strings.TrimSpace(content)
+}
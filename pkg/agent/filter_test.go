@@ -0,0 +1,66 @@
+package agent
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFilterResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters []ResponseFilter
+		content string
+		want    string
+	}{
+		{
+			name:    "no filters",
+			content: "  Unchanged  ",
+			want:    "  Unchanged  ",
+		},
+		{
+			name:    "redacts tokens",
+			filters: []ResponseFilter{{Pattern: `sk-[A-Za-z0-9]{20,}`, Replacement: "[redacted]"}},
+			content: "Use sk-abcdefghijklmnopqrstuvwxyz and sk-ABCDEFGHIJKLMNOPQRSTUV to call the API.",
+			want:    "Use [redacted] and [redacted] to call the API.",
+		},
+		{
+			name:    "removes preamble",
+			filters: []ResponseFilter{{Pattern: `(?i)^as an ai language model,?\s*`}},
+			content: "As an AI language model, I think the second design is simpler.",
+			want:    "I think the second design is simpler.",
+		},
+		{
+			name: "applies filters in order with capture groups",
+			filters: []ResponseFilter{
+				{Pattern: `(?i)^as an ai language model,?\s*`},
+				{Pattern: `password=(\S+)`, Replacement: "password=[redacted:$1]"},
+				{Pattern: `\[redacted:\S+\]`, Replacement: "***"},
+			},
+			content: "As an AI language model, I found password=hunter2 in the logs.",
+			want:    "I found password=*** in the logs.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b BaseAgent
+			if err := b.Initialize(AgentConfig{ID: "a1", Filters: tt.filters}); err != nil {
+				t.Fatalf("Initialize() error = %v", err)
+			}
+			if got := b.FilterResponse(tt.content); got != tt.want {
+				t.Errorf("FilterResponse() = %q, want %q", got, tt.want)
+			}
+			if got := b.HasResponseFilters(); got != (len(tt.filters) > 0) {
+				t.Errorf("HasResponseFilters() = %v with %d filters", got, len(tt.filters))
+			}
+		})
+	}
+}
+
+func TestInitializeRejectsInvalidFilter(t *testing.T) {
+	var b BaseAgent
+	err := b.Initialize(AgentConfig{ID: "a1", Filters: []ResponseFilter{{Pattern: "sk-[a-z"}}})
+	if err == nil || !strings.Contains(err.Error(), `invalid filter pattern "sk-[a-z"`) {
+		t.Errorf("expected an invalid pattern error, got %v", err)
+	}
+}
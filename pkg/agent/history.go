@@ -0,0 +1,103 @@
+package agent
+
+import (
+	"github.com/kevinelliott/agentpipe/pkg/log"
+	"github.com/kevinelliott/agentpipe/pkg/utils"
+)
+
+// LimitHistory applies the agent's HistoryWindow and then its MaxContextTokens
+// to the messages it is about to be sent. Adapters call it on the relevant
+// messages before building a prompt.
+func (b *BaseAgent) LimitHistory(messages []Message) []Message {
+	return TrimHistory(WindowHistory(messages, b.Config.HistoryWindow), b.Config.MaxContextTokens)
+}
+
+// WindowHistory keeps the orchestrator's initial prompt and the last n other
+// messages. An n of 0 or less keeps every message. The messages slice is not
+// modified.
+func WindowHistory(messages []Message, n int) []Message {
+	if n <= 0 {
+		return messages
+	}
+
+	initial := initialPromptIndex(messages)
+	others := len(messages)
+	if initial >= 0 {
+		others--
+	}
+	if others <= n {
+		return messages
+	}
+
+	start := len(messages) - n
+	if initial >= start {
+		start-- // the initial prompt is in the window but doesn't count toward n
+	}
+
+	windowed := make([]Message, 0, n+1)
+	if initial >= 0 && initial < start {
+		windowed = append(windowed, messages[initial])
+	}
+	return append(windowed, messages[start:]...)
+}
+
+// initialPromptIndex returns the index of the orchestrator's initial prompt,
+// the first system message from the host, or -1 if there is none.
+func initialPromptIndex(messages []Message) int {
+	for i, msg := range messages {
+		if msg.Role == RoleSystem && (msg.AgentID == "host" || msg.AgentID == "system" || msg.AgentName == "HOST" || msg.AgentName == "System") {
+			return i
+		}
+	}
+	return -1
+}
+
+// TrimHistory drops the oldest non-system messages until the estimated tokens
+// of the messages' content fit in maxTokens. System messages, such as the
+// initial prompt, and the most recent message are always kept, so the result
+// can still exceed maxTokens. A maxTokens of 0 or less disables trimming.
+// The messages slice is not modified.
+func TrimHistory(messages []Message, maxTokens int) []Message {
+	if maxTokens <= 0 || len(messages) == 0 {
+		return messages
+	}
+
+	tokens := make([]int, len(messages))
+	total := 0
+	for i, msg := range messages {
+		tokens[i] = utils.EstimateTokens(msg.Content)
+		total += tokens[i]
+	}
+	if total <= maxTokens {
+		return messages
+	}
+
+	drop := make([]bool, len(messages))
+	dropped := 0
+	for i := 0; i < len(messages)-1 && total > maxTokens; i++ {
+		if messages[i].Role == RoleSystem {
+			continue
+		}
+		drop[i] = true
+		total -= tokens[i]
+		dropped++
+	}
+	if dropped == 0 {
+		return messages
+	}
+
+	trimmed := make([]Message, 0, len(messages)-dropped)
+	for i, msg := range messages {
+		if !drop[i] {
+			trimmed = append(trimmed, msg)
+		}
+	}
+
+	log.WithFields(map[string]interface{}{
+		"dropped_messages":   dropped,
+		"estimated_tokens":   total,
+		"max_context_tokens": maxTokens,
+	}).Debug("trimmed conversation history to fit the context budget")
+
+	return trimmed
+}
@@ -0,0 +1,122 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kevinelliott/agentpipe/pkg/utils"
+)
+
+// historyContents returns the content of each message, for comparisons.
+func historyContents(messages []Message) []string {
+	contents := make([]string, len(messages))
+	for i, msg := range messages {
+		contents[i] = msg.Content
+	}
+	return contents
+}
+
+func TestTrimHistory(t *testing.T) {
+	long := strings.Repeat("word ", 100) // ~100 tokens
+	messages := []Message{
+		{AgentID: "host", Role: "system", Content: "Plan a trip to Lisbon"},
+		{AgentID: "a", Role: "agent", Content: "turn 1 " + long},
+		{AgentID: "b", Role: "agent", Content: "turn 2 " + long},
+		{AgentID: "host", Role: "system", Content: "Bob has joined"},
+		{AgentID: "a", Role: "agent", Content: "turn 3 " + long},
+		{AgentID: "b", Role: "agent", Content: "turn 4 " + long},
+	}
+	perTurn := utils.EstimateTokens(messages[1].Content)
+	system := utils.EstimateTokens(messages[0].Content) + utils.EstimateTokens(messages[3].Content)
+
+	tests := []struct {
+		name      string
+		maxTokens int
+		want      []int // indexes into messages
+	}{
+		{"disabled", 0, []int{0, 1, 2, 3, 4, 5}},
+		{"fits", 10000, []int{0, 1, 2, 3, 4, 5}},
+		{"drops oldest turns", system + 2*perTurn + 5, []int{0, 3, 4, 5}},
+		{"keeps system messages and the last turn", 1, []int{0, 3, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TrimHistory(messages, tt.maxTokens)
+
+			want := make([]Message, len(tt.want))
+			for i, idx := range tt.want {
+				want[i] = messages[idx]
+			}
+			if strings.Join(historyContents(got), "|") != strings.Join(historyContents(want), "|") {
+				t.Errorf("expected messages %v, got %d messages", tt.want, len(got))
+			}
+		})
+	}
+
+	if len(messages) != 6 || messages[1].Content != "turn 1 "+long {
+		t.Error("expected the input slice to be left unchanged")
+	}
+}
+
+func TestTrimHistoryEmpty(t *testing.T) {
+	if got := TrimHistory(nil, 100); len(got) != 0 {
+		t.Errorf("expected no messages, got %v", got)
+	}
+}
+
+func TestWindowHistory(t *testing.T) {
+	announcement := Message{AgentID: "a", AgentName: "Alice", Role: "system", Content: "Alice has joined"}
+	initial := Message{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Plan a trip to Lisbon"}
+	turn := func(n int) Message {
+		return Message{AgentID: "a", AgentName: "Alice", Role: "agent", Content: fmt.Sprintf("turn %d", n)}
+	}
+
+	tests := []struct {
+		name     string
+		messages []Message
+		n        int
+		want     []string
+	}{
+		{
+			name:     "disabled",
+			messages: []Message{initial, turn(1), turn(2)},
+			n:        0,
+			want:     []string{"Plan a trip to Lisbon", "turn 1", "turn 2"},
+		},
+		{
+			name:     "long history",
+			messages: []Message{announcement, initial, turn(1), turn(2), turn(3), turn(4), turn(5)},
+			n:        2,
+			want:     []string{"Plan a trip to Lisbon", "turn 4", "turn 5"},
+		},
+		{
+			name:     "initial prompt inside the window",
+			messages: []Message{turn(1), turn(2), initial, turn(3)},
+			n:        2,
+			want:     []string{"turn 2", "Plan a trip to Lisbon", "turn 3"},
+		},
+		{
+			name:     "no initial prompt",
+			messages: []Message{turn(1), turn(2), turn(3)},
+			n:        1,
+			want:     []string{"turn 3"},
+		},
+		{
+			name:     "shorter than the window",
+			messages: []Message{initial, turn(1)},
+			n:        5,
+			want:     []string{"Plan a trip to Lisbon", "turn 1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := historyContents(WindowHistory(tt.messages, tt.n))
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package agent
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kevinelliott/agentpipe/pkg/log"
+)
+
+// Persona is a reusable system prompt that agents reference by name with
+// `persona: <name>` instead of repeating the prompt in every config.
+//
+// Each persona lives in its own file in ~/.agentpipe/personas.d/:
+//
+//	name: reviewer
+//	prompt: You are a skeptical code reviewer who asks for evidence.
+//
+// The name defaults to the file name without its extension.
+type Persona struct {
+	// Name is how agents reference the persona
+	Name string `yaml:"name"`
+	// Prompt is the system prompt the persona stands for
+	Prompt string `yaml:"prompt"`
+}
+
+var (
+	personasMu     sync.RWMutex
+	personas       map[string]string
+	personasLoaded bool
+)
+
+// DefaultPersonaDir returns the default persona directory: ~/.agentpipe/personas.d.
+func DefaultPersonaDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".agentpipe", "personas.d"), nil
+}
+
+// LoadPersonaDir reads every *.yaml and *.yml persona file in dir.
+// A missing directory yields no personas.
+func LoadPersonaDir(dir string) (map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("failed to read persona directory: %w", err)
+	}
+
+	loaded := make(map[string]string)
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read persona file %s: %w", path, err)
+		}
+
+		var p Persona
+		if err := yaml.Unmarshal(data, &p); err != nil {
+			return nil, fmt.Errorf("failed to parse persona file %s: %w", path, err)
+		}
+		if p.Name == "" {
+			p.Name = strings.TrimSuffix(entry.Name(), ext)
+		}
+		if strings.TrimSpace(p.Prompt) == "" {
+			return nil, fmt.Errorf("persona %s in %s has no prompt", p.Name, path)
+		}
+		if _, dup := loaded[p.Name]; dup {
+			return nil, fmt.Errorf("duplicate persona %s in %s", p.Name, path)
+		}
+		loaded[p.Name] = strings.TrimSpace(p.Prompt)
+	}
+
+	return loaded, nil
+}
+
+// SetPersonas replaces the persona registry. Passing nil clears it.
+func SetPersonas(p map[string]string) {
+	personasMu.Lock()
+	defer personasMu.Unlock()
+	personas = p
+	personasLoaded = true
+}
+
+// GetPersona returns the prompt of the named persona, loading
+// ~/.agentpipe/personas.d on first use.
+func GetPersona(name string) (string, bool) {
+	personasMu.RLock()
+	if personasLoaded {
+		defer personasMu.RUnlock()
+		prompt, ok := personas[name]
+		return prompt, ok
+	}
+	personasMu.RUnlock()
+
+	personasMu.Lock()
+	defer personasMu.Unlock()
+	if !personasLoaded {
+		personasLoaded = true
+		personas = loadDefaultPersonas()
+	}
+	prompt, ok := personas[name]
+	return prompt, ok
+}
+
+// loadDefaultPersonas loads the default persona directory, logging failures.
+func loadDefaultPersonas() map[string]string {
+	dir, err := DefaultPersonaDir()
+	if err != nil {
+		return nil
+	}
+
+	loaded, err := LoadPersonaDir(dir)
+	if err != nil {
+		log.WithError(err).WithField("dir", dir).Warn("failed to load personas")
+		return nil
+	}
+
+	if len(loaded) > 0 {
+		log.WithFields(map[string]interface{}{
+			"dir":      dir,
+			"personas": len(loaded),
+		}).Info("loaded personas")
+	}
+	return loaded
+}
+
+// resolvePersona expands config.Persona into config.Prompt. The persona
+// prompt comes first, followed by any inline prompt.
+func resolvePersona(config AgentConfig) (AgentConfig, error) {
+	if config.Persona == "" {
+		return config, nil
+	}
+
+	prompt, ok := GetPersona(config.Persona)
+	if !ok {
+		return config, fmt.Errorf("unknown persona %q for agent %s", config.Persona, config.ID)
+	}
+
+	if inline := strings.TrimSpace(config.Prompt); inline != "" {
+		prompt = prompt + "\n\n" + inline
+	}
+	config.Prompt = prompt
+	return config, nil
+}
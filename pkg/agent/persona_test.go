@@ -0,0 +1,89 @@
+package agent
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// personaTestAgent is a minimal agent for exercising CreateAgent.
+type personaTestAgent struct {
+	BaseAgent
+}
+
+func (a *personaTestAgent) SendMessage(ctx context.Context, messages []Message) (string, error) {
+	return "", nil
+}
+func (a *personaTestAgent) StreamMessage(ctx context.Context, messages []Message, w io.Writer) error {
+	return nil
+}
+func (a *personaTestAgent) IsAvailable() bool                     { return true }
+func (a *personaTestAgent) HealthCheck(ctx context.Context) error { return nil }
+func (a *personaTestAgent) GetCLIVersion() string                 { return "1.0.0" }
+
+func writePersonaFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write persona file: %v", err)
+	}
+}
+
+func TestLoadPersonaDir(t *testing.T) {
+	dir := t.TempDir()
+	writePersonaFile(t, dir, "reviewer.yaml", "prompt: You are a skeptical reviewer.\n")
+	writePersonaFile(t, dir, "pm.yml", "name: optimistic-pm\nprompt: |\n  You are an optimistic product manager.\n")
+	writePersonaFile(t, dir, "notes.txt", "ignored")
+
+	loaded, err := LoadPersonaDir(dir)
+	if err != nil {
+		t.Fatalf("LoadPersonaDir failed: %v", err)
+	}
+	if len(loaded) != 2 {
+		t.Fatalf("expected 2 personas, got %v", loaded)
+	}
+	if loaded["reviewer"] != "You are a skeptical reviewer." {
+		t.Errorf("expected the name to default to the file name, got %v", loaded)
+	}
+	if loaded["optimistic-pm"] != "You are an optimistic product manager." {
+		t.Errorf("unexpected optimistic-pm prompt: %q", loaded["optimistic-pm"])
+	}
+
+	writePersonaFile(t, dir, "empty.yaml", "name: empty\n")
+	if _, err := LoadPersonaDir(dir); err == nil || !strings.Contains(err.Error(), "has no prompt") {
+		t.Errorf("expected an error for a persona without a prompt, got %v", err)
+	}
+
+	if loaded, err := LoadPersonaDir(filepath.Join(dir, "missing")); err != nil || len(loaded) != 0 {
+		t.Errorf("expected no personas for a missing directory, got %v (err=%v)", loaded, err)
+	}
+}
+
+func TestCreateAgentResolvesPersona(t *testing.T) {
+	SetPersonas(map[string]string{"reviewer": "You are a skeptical reviewer."})
+	defer SetPersonas(nil)
+
+	RegisterFactory("persona-test", func() Agent { return &personaTestAgent{} })
+
+	a, err := CreateAgent(AgentConfig{ID: "p1", Type: "persona-test", Name: "Rev", Persona: "reviewer"})
+	if err != nil {
+		t.Fatalf("CreateAgent failed: %v", err)
+	}
+	if a.GetPrompt() != "You are a skeptical reviewer." {
+		t.Errorf("expected the persona prompt, got %q", a.GetPrompt())
+	}
+
+	a, err = CreateAgent(AgentConfig{ID: "p2", Type: "persona-test", Name: "Rev", Persona: "reviewer", Prompt: "Focus on error handling."})
+	if err != nil {
+		t.Fatalf("CreateAgent failed: %v", err)
+	}
+	if a.GetPrompt() != "You are a skeptical reviewer.\n\nFocus on error handling." {
+		t.Errorf("expected the inline prompt appended to the persona, got %q", a.GetPrompt())
+	}
+
+	if _, err := CreateAgent(AgentConfig{ID: "p3", Type: "persona-test", Name: "Rev", Persona: "nobody"}); err == nil || !strings.Contains(err.Error(), "unknown persona") {
+		t.Errorf("expected an unknown persona error, got %v", err)
+	}
+}
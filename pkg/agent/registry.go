@@ -2,6 +2,7 @@ package agent
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 )
 
@@ -24,6 +25,27 @@ func RegisterFactory(agentType string, factory Factory) {
 	defaultRegistry.factories[agentType] = factory
 }
 
+// HasFactory reports whether an adapter is registered for agentType.
+func HasFactory(agentType string) bool {
+	defaultRegistry.mu.RLock()
+	defer defaultRegistry.mu.RUnlock()
+	_, ok := defaultRegistry.factories[agentType]
+	return ok
+}
+
+// RegisteredTypes returns the agent types with a registered adapter, sorted.
+func RegisteredTypes() []string {
+	defaultRegistry.mu.RLock()
+	defer defaultRegistry.mu.RUnlock()
+
+	types := make([]string, 0, len(defaultRegistry.factories))
+	for agentType := range defaultRegistry.factories {
+		types = append(types, agentType)
+	}
+	sort.Strings(types)
+	return types
+}
+
 func CreateAgent(config AgentConfig) (Agent, error) {
 	defaultRegistry.mu.RLock()
 	factory, ok := defaultRegistry.factories[config.Type]
@@ -33,6 +55,11 @@ func CreateAgent(config AgentConfig) (Agent, error) {
 		return nil, fmt.Errorf("unknown agent type: %s", config.Type)
 	}
 
+	config, err := resolvePersona(config)
+	if err != nil {
+		return nil, err
+	}
+
 	agent := factory()
 	if err := agent.Initialize(config); err != nil {
 		return nil, fmt.Errorf("failed to initialize agent: %w", err)
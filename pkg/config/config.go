@@ -6,11 +6,17 @@ package config
 import (
 	"fmt"
 	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/theme"
 )
 
 // Config is the top-level configuration structure for AgentPipe.
@@ -18,6 +24,11 @@ import (
 type Config struct {
 	// Version is the configuration file format version
 	Version string `yaml:"version"`
+	// Extends is the path of a base config file this one is merged on top of
+	Extends string `yaml:"extends,omitempty"`
+	// Personas maps persona names to reusable system prompts that agents
+	// reference with persona: <name>
+	Personas map[string]string `yaml:"personas,omitempty"`
 	// Agents is the list of agent configurations
 	Agents []agent.AgentConfig `yaml:"agents"`
 	// Orchestrator defines conversation orchestration settings
@@ -26,11 +37,15 @@ type Config struct {
 	Logging LoggingConfig `yaml:"logging"`
 	// Bridge defines streaming bridge settings
 	Bridge BridgeConfig `yaml:"bridge"`
+	// Theme is the color theme of the TUI and console output: "auto"
+	// (default, detected from $COLORFGBG), "dark" or "light"
+	Theme string `yaml:"theme,omitempty"`
 }
 
 // OrchestratorConfig defines how the orchestrator manages conversations.
 type OrchestratorConfig struct {
-	// Mode is the orchestration mode: "round-robin", "reactive", or "free-form"
+	// Mode is the orchestration mode: "round-robin", "reactive", "free-form",
+	// "moderator", or a mode added with orchestrator.RegisterMode
 	Mode string `yaml:"mode"`
 	// MaxTurns is the maximum number of conversation turns (0 = unlimited)
 	MaxTurns int `yaml:"max_turns"`
@@ -38,10 +53,52 @@ type OrchestratorConfig struct {
 	TurnTimeout time.Duration `yaml:"turn_timeout"`
 	// ResponseDelay is the pause between agent responses
 	ResponseDelay time.Duration `yaml:"response_delay"`
+	// ResponseJitter adds a random extra pause of up to this long after each response (0 = none)
+	ResponseJitter time.Duration `yaml:"response_jitter"`
 	// InitialPrompt is an optional starting prompt for the conversation
 	InitialPrompt string `yaml:"initial_prompt"`
 	// Summary defines conversation summary generation settings
 	Summary SummaryConfig `yaml:"summary"`
+	// Seed makes reactive agent selection reproducible (0 = random)
+	Seed int64 `yaml:"seed"`
+	// StopOnError ends the conversation when an agent fails after all retries
+	StopOnError bool `yaml:"stop_on_error"`
+	// SharedMemory lets agents keep shared notes with MEMORY[key]=value lines
+	SharedMemory bool `yaml:"shared_memory"`
+	// FinalSummary has a participant summarize the conversation when it ends
+	FinalSummary bool `yaml:"final_summary"`
+	// FinalSummaryAgent is the ID of the agent that writes the final summary (default: first agent)
+	FinalSummaryAgent string `yaml:"final_summary_agent"`
+	// Cache reuses agent responses from earlier runs with an identical context
+	Cache bool `yaml:"cache"`
+	// CacheTTL is how long cached responses are reused (default: 24h)
+	CacheTTL time.Duration `yaml:"cache_ttl"`
+	// Language is the language of the shared history (default: English)
+	Language string `yaml:"language"`
+	// TranslatorAgent is the agent type that translates for agents with a language set
+	TranslatorAgent string `yaml:"translator_agent"`
+	// StopPhrase ends the conversation once StopConsecutive responses in a row contain it
+	StopPhrase string `yaml:"stop_phrase"`
+	// StopConsecutive is how many consecutive responses must contain StopPhrase (default: number of agents)
+	StopConsecutive int `yaml:"stop_consecutive"`
+	// ModeratorAgent is the ID of the agent that picks the next speaker in moderator mode (default: first agent)
+	ModeratorAgent string `yaml:"moderator_agent"`
+	// Stream shows responses in the TUI as they are generated
+	Stream bool `yaml:"stream"`
+	// GlobalRateLimit caps requests per second across all agents (0 = unlimited)
+	GlobalRateLimit float64 `yaml:"global_rate_limit"`
+	// GlobalRateLimitBurst is the burst capacity of the global rate limit (default: 1)
+	GlobalRateLimitBurst int `yaml:"global_rate_limit_burst"`
+	// MaxCost ends the conversation once agent responses have cost this much in USD (0 = no budget)
+	MaxCost float64 `yaml:"max_cost"`
+	// MaxTokens ends the conversation once agent responses have used this many tokens in total (0 = no budget)
+	MaxTokens int `yaml:"max_tokens"`
+	// MaxConsecutiveFailures disables an agent for the rest of the run after this many failed turns in a row (0 = never)
+	MaxConsecutiveFailures int `yaml:"max_consecutive_failures"`
+	// ReactiveCooldown is how many turns an agent is deprioritized in reactive mode after speaking (0 = uniform random)
+	ReactiveCooldown int `yaml:"reactive_cooldown"`
+	// MaxDuration ends the conversation once it has run this long, e.g. 10m (0 = no limit)
+	MaxDuration time.Duration `yaml:"max_duration"`
 }
 
 // SummaryConfig defines conversation summary generation behavior.
@@ -58,7 +115,7 @@ type LoggingConfig struct {
 	Enabled bool `yaml:"enabled"`
 	// ChatLogDir is the directory where chat logs are stored
 	ChatLogDir string `yaml:"chat_log_dir"`
-	// LogFormat is either "text" or "json"
+	// LogFormat is "text", "json", or "markdown"
 	LogFormat string `yaml:"log_format"`
 	// ShowMetrics determines if token/cost metrics are logged
 	ShowMetrics bool `yaml:"show_metrics"`
@@ -112,20 +169,58 @@ func NewDefaultConfig() *Config {
 }
 
 // LoadConfig loads and validates a configuration from a YAML file.
-// It applies default values for any missing optional fields.
-// Returns an error if the file cannot be read, parsed, or is invalid.
+// It applies default values for any missing optional fields, and expands
+// ${VAR} and ${VAR:-default} in agent names, prompts, announcements and models,
+// the initial prompt, the chat log directory, and the bridge URL and API key.
+// A file with an extends key is merged on top of the file it names (relative
+// paths resolve against the including file's directory). An agent's
+// prompt_file, relative to the file that sets it, is read into its prompt, and
+// its persona from the personas map is merged into the prompt, persona first.
+// Returns an error if the file cannot be read, parsed, or is invalid; validation
+// problems are listed with their line in the file.
 func LoadConfig(path string) (*Config, error) {
-	data, err := os.ReadFile(path)
+	config, err := loadConfigFile(path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
+		return nil, err
 	}
 
+	err = config.resolvePersonas()
+	if err == nil {
+		err = config.Validate()
+	}
+	if err != nil {
+		if data, readErr := os.ReadFile(path); readErr == nil {
+			setErrorLines(err, data)
+		}
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	config.applyDefaults()
+
+	return config, nil
+}
+
+// ParseConfig parses, validates, and applies defaults to configuration data.
+// The data may be YAML or JSON (JSON is valid YAML); keys use the YAML names.
+// Unlike LoadConfig it neither expands environment variables nor follows
+// extends or prompt_file, since the data may come from an untrusted source such
+// as an API request. For the same reason it rejects exec agents and MCP servers
+// started from a command, which would run arbitrary commands on this host.
+func ParseConfig(data []byte) (*Config, error) {
 	var config Config
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
-	if err := config.Validate(); err != nil {
+	err := config.resolvePersonas()
+	if err == nil {
+		err = config.Validate()
+	}
+	if err == nil {
+		err = config.validateUntrusted()
+	}
+	if err != nil {
+		setErrorLines(err, data)
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
@@ -134,6 +229,39 @@ func LoadConfig(path string) (*Config, error) {
 	return &config, nil
 }
 
+// validateUntrusted reports the agents of a config from an untrusted source
+// that would run a command of the config's choosing: exec agents and MCP
+// servers with a command.
+func (c *Config) validateUntrusted() error {
+	var errs ValidationErrors
+	add := func(field, format string, args ...interface{}) {
+		errs = append(errs, &FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for i, a := range c.Agents {
+		field := fmt.Sprintf("agents[%d]", i)
+		if a.Type == "exec" || a.Command != "" {
+			add(field+".type", "exec agents are only supported in config files loaded from disk")
+		}
+
+		serverNames := make([]string, 0, len(a.MCPServers))
+		for name := range a.MCPServers {
+			serverNames = append(serverNames, name)
+		}
+		sort.Strings(serverNames)
+		for _, name := range serverNames {
+			if a.MCPServers[name].Command != "" {
+				add(field+".mcp_servers."+name, "MCP servers started from a command are only supported in config files loaded from disk; use a url")
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
 // SaveConfig writes the configuration to a YAML file.
 // The file is created with 0600 permissions (read/write for owner only).
 func (c *Config) SaveConfig(path string) error {
@@ -149,41 +277,168 @@ func (c *Config) SaveConfig(path string) error {
 	return nil
 }
 
+// validModes holds the orchestrator modes accepted by Validate.
+var validModes = struct {
+	mu    sync.RWMutex
+	names map[string]bool
+}{
+	names: map[string]bool{
+		"round-robin": true,
+		"reactive":    true,
+		"free-form":   true,
+		"moderator":   true,
+	},
+}
+
+// RegisterMode adds an orchestrator mode name accepted by Validate.
+// orchestrator.RegisterMode calls it for custom modes.
+func RegisterMode(name string) {
+	validModes.mu.Lock()
+	validModes.names[name] = true
+	validModes.mu.Unlock()
+}
+
+// UnregisterMode removes a mode name added with RegisterMode.
+// orchestrator.UnregisterMode calls it.
+func UnregisterMode(name string) {
+	validModes.mu.Lock()
+	delete(validModes.names, name)
+	validModes.mu.Unlock()
+}
+
+// AgentTypes returns the agent types Validate accepts. When nil, the default,
+// agent types are not checked; programs that link in the adapters set it (the
+// agentpipe CLI uses agent.RegisteredTypes), so validation never depends on
+// which adapters happen to be registered.
+var AgentTypes func() []string
+
 // Validate checks the configuration for errors.
 // It ensures at least one agent is configured, all required fields are present,
-// agent IDs are unique, and the orchestration mode is valid.
+// agent IDs are unique, agent types are known (when AgentTypes is set), the
+// orchestration mode is valid, and turn counts and timeouts are not negative.
+// Every problem found is reported in a ValidationErrors.
 func (c *Config) Validate() error {
+	var errs ValidationErrors
+	add := func(field, format string, args ...interface{}) {
+		errs = append(errs, &FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
 	if len(c.Agents) == 0 {
-		return fmt.Errorf("at least one agent must be configured")
+		add("agents", "at least one agent must be configured")
 	}
 
+	var knownTypes []string
+	if AgentTypes != nil {
+		knownTypes = AgentTypes()
+	}
 	agentIDs := make(map[string]bool)
-	for _, agent := range c.Agents {
-		if agent.ID == "" {
-			return fmt.Errorf("agent ID cannot be empty")
+	for i, a := range c.Agents {
+		field := fmt.Sprintf("agents[%d]", i)
+		if a.ID == "" {
+			add(field+".id", "agent ID cannot be empty")
+		} else if agentIDs[a.ID] {
+			add(field+".id", "duplicate agent ID: %s", a.ID)
+		}
+		agentIDs[a.ID] = true
+
+		if a.Type == "" {
+			add(field+".type", "agent type cannot be empty for agent %s", a.ID)
+		} else if AgentTypes != nil && !slices.Contains(knownTypes, a.Type) {
+			add(field+".type", "unknown agent type %q (available: %s)", a.Type, strings.Join(knownTypes, ", "))
+		}
+		if a.Name == "" {
+			add(field+".name", "agent name cannot be empty for agent %s", a.ID)
 		}
-		if agent.Type == "" {
-			return fmt.Errorf("agent type cannot be empty for agent %s", agent.ID)
+		if a.PromptFile != "" {
+			add(field+".prompt_file", "prompt_file is only supported in config files loaded from disk")
 		}
-		if agent.Name == "" {
-			return fmt.Errorf("agent name cannot be empty for agent %s", agent.ID)
+		if a.Timeout < 0 {
+			add(field+".timeout", "timeout cannot be negative: %s", a.Timeout)
 		}
-		if agentIDs[agent.ID] {
-			return fmt.Errorf("duplicate agent ID: %s", agent.ID)
+		if a.StreamTimeout < 0 {
+			add(field+".stream_timeout", "stream timeout cannot be negative: %s", a.StreamTimeout)
+		}
+		if a.ResponseDelay < 0 {
+			add(field+".response_delay", "response delay cannot be negative: %s", a.ResponseDelay)
+		}
+		if a.MaxContextTokens < 0 {
+			add(field+".max_context_tokens", "max context tokens cannot be negative: %d", a.MaxContextTokens)
+		}
+		if a.HistoryWindow < 0 {
+			add(field+".history_window", "history window cannot be negative: %d", a.HistoryWindow)
+		}
+		for j, f := range a.Filters {
+			if _, err := regexp.Compile(f.Pattern); err != nil {
+				add(fmt.Sprintf("%s.filters[%d].pattern", field, j), "invalid filter pattern %q: %v", f.Pattern, err)
+			}
+		}
+
+		serverNames := make([]string, 0, len(a.MCPServers))
+		for name := range a.MCPServers {
+			serverNames = append(serverNames, name)
+		}
+		sort.Strings(serverNames)
+		for _, name := range serverNames {
+			server := a.MCPServers[name]
+			if server.Command == "" && server.URL == "" {
+				add(field+".mcp_servers."+name, "MCP server %s for agent %s needs a command or url", name, a.ID)
+			}
 		}
-		agentIDs[agent.ID] = true
 	}
 
-	validModes := map[string]bool{
-		"round-robin": true,
-		"reactive":    true,
-		"free-form":   true,
+	validModes.mu.RLock()
+	validMode := validModes.names[c.Orchestrator.Mode]
+	modes := make([]string, 0, len(validModes.names))
+	for name := range validModes.names {
+		modes = append(modes, name)
+	}
+	validModes.mu.RUnlock()
+	sort.Strings(modes)
+
+	if c.Orchestrator.Mode != "" && !validMode {
+		add("orchestrator.mode", "invalid orchestrator mode: %s (expected one of %s)", c.Orchestrator.Mode, strings.Join(modes, ", "))
+	}
+	if c.Orchestrator.MaxTurns < 0 {
+		add("orchestrator.max_turns", "max turns cannot be negative: %d", c.Orchestrator.MaxTurns)
+	}
+	if c.Orchestrator.TurnTimeout < 0 {
+		add("orchestrator.turn_timeout", "turn timeout cannot be negative: %s", c.Orchestrator.TurnTimeout)
+	}
+	if c.Orchestrator.ResponseDelay < 0 {
+		add("orchestrator.response_delay", "response delay cannot be negative: %s", c.Orchestrator.ResponseDelay)
+	}
+	if c.Orchestrator.ResponseJitter < 0 {
+		add("orchestrator.response_jitter", "response jitter cannot be negative: %s", c.Orchestrator.ResponseJitter)
+	}
+	if c.Orchestrator.GlobalRateLimit < 0 {
+		add("orchestrator.global_rate_limit", "global rate limit cannot be negative: %g", c.Orchestrator.GlobalRateLimit)
+	}
+	if c.Orchestrator.MaxCost < 0 {
+		add("orchestrator.max_cost", "max cost cannot be negative: %g", c.Orchestrator.MaxCost)
+	}
+	if c.Orchestrator.MaxTokens < 0 {
+		add("orchestrator.max_tokens", "max tokens cannot be negative: %d", c.Orchestrator.MaxTokens)
+	}
+	if c.Orchestrator.MaxConsecutiveFailures < 0 {
+		add("orchestrator.max_consecutive_failures", "max consecutive failures cannot be negative: %d", c.Orchestrator.MaxConsecutiveFailures)
+	}
+	if c.Orchestrator.ReactiveCooldown < 0 {
+		add("orchestrator.reactive_cooldown", "reactive cooldown cannot be negative: %d", c.Orchestrator.ReactiveCooldown)
+	}
+	if c.Orchestrator.MaxDuration < 0 {
+		add("orchestrator.max_duration", "max duration cannot be negative: %s", c.Orchestrator.MaxDuration)
+	}
+	if c.Orchestrator.CacheTTL < 0 {
+		add("orchestrator.cache_ttl", "cache TTL cannot be negative: %s", c.Orchestrator.CacheTTL)
 	}
 
-	if c.Orchestrator.Mode != "" && !validModes[c.Orchestrator.Mode] {
-		return fmt.Errorf("invalid orchestrator mode: %s", c.Orchestrator.Mode)
+	if !theme.Valid(c.Theme) {
+		add("theme", "invalid theme: %s (expected auto, dark or light)", c.Theme)
 	}
 
+	if len(errs) > 0 {
+		return errs
+	}
 	return nil
 }
 
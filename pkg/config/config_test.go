@@ -1,6 +1,9 @@
 package config
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -62,6 +65,36 @@ func TestConfigValidate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "duplicate agent ID",
 		},
+		{
+			name: "negative max context tokens",
+			config: &Config{
+				Agents: []agent.AgentConfig{
+					{ID: "agent1", Type: "claude", Name: "Agent 1", MaxContextTokens: -1},
+				},
+			},
+			wantErr: true,
+			errMsg:  "max context tokens cannot be negative",
+		},
+		{
+			name: "negative history window",
+			config: &Config{
+				Agents: []agent.AgentConfig{
+					{ID: "agent1", Type: "claude", Name: "Agent 1", HistoryWindow: -1},
+				},
+			},
+			wantErr: true,
+			errMsg:  "history window cannot be negative",
+		},
+		{
+			name: "invalid filter pattern",
+			config: &Config{
+				Agents: []agent.AgentConfig{
+					{ID: "agent1", Type: "claude", Name: "Agent 1", Filters: []agent.ResponseFilter{{Pattern: "sk-[a-z"}}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "agents[0].filters[0].pattern: invalid filter pattern",
+		},
 		{
 			name: "invalid mode",
 			config: &Config{
@@ -75,6 +108,126 @@ func TestConfigValidate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "invalid orchestrator mode",
 		},
+		{
+			name: "MCP server without command or url",
+			config: &Config{
+				Agents: []agent.AgentConfig{
+					{ID: "agent1", Type: "claude", Name: "Agent 1", MCPServers: map[string]agent.MCPServer{"fs": {Args: []string{"/tmp"}}}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "MCP server fs for agent agent1 needs a command or url",
+		},
+		{
+			name: "unknown agent type",
+			config: &Config{
+				Agents: []agent.AgentConfig{
+					{ID: "agent1", Type: "cluade", Name: "Agent 1"},
+				},
+			},
+			wantErr: true,
+			errMsg:  `agents[0].type: unknown agent type "cluade"`,
+		},
+		{
+			name: "missing agent name",
+			config: &Config{
+				Agents: []agent.AgentConfig{
+					{ID: "agent1", Type: "claude"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "agents[0].name: agent name cannot be empty",
+		},
+		{
+			name: "negative agent timeout",
+			config: &Config{
+				Agents: []agent.AgentConfig{
+					{ID: "agent1", Type: "claude", Name: "Agent 1", Timeout: -time.Second},
+				},
+			},
+			wantErr: true,
+			errMsg:  "agents[0].timeout: timeout cannot be negative",
+		},
+		{
+			name: "negative max turns",
+			config: &Config{
+				Agents:       []agent.AgentConfig{{ID: "agent1", Type: "claude", Name: "Agent 1"}},
+				Orchestrator: OrchestratorConfig{MaxTurns: -1},
+			},
+			wantErr: true,
+			errMsg:  "orchestrator.max_turns: max turns cannot be negative",
+		},
+		{
+			name: "negative max consecutive failures",
+			config: &Config{
+				Agents:       []agent.AgentConfig{{ID: "agent1", Type: "claude", Name: "Agent 1"}},
+				Orchestrator: OrchestratorConfig{MaxConsecutiveFailures: -1},
+			},
+			wantErr: true,
+			errMsg:  "orchestrator.max_consecutive_failures: max consecutive failures cannot be negative",
+		},
+		{
+			name: "negative reactive cooldown",
+			config: &Config{
+				Agents:       []agent.AgentConfig{{ID: "agent1", Type: "claude", Name: "Agent 1"}},
+				Orchestrator: OrchestratorConfig{ReactiveCooldown: -1},
+			},
+			wantErr: true,
+			errMsg:  "orchestrator.reactive_cooldown: reactive cooldown cannot be negative",
+		},
+		{
+			name: "negative max duration",
+			config: &Config{
+				Agents:       []agent.AgentConfig{{ID: "agent1", Type: "claude", Name: "Agent 1"}},
+				Orchestrator: OrchestratorConfig{MaxDuration: -time.Minute},
+			},
+			wantErr: true,
+			errMsg:  "orchestrator.max_duration: max duration cannot be negative",
+		},
+		{
+			name: "negative response jitter",
+			config: &Config{
+				Agents:       []agent.AgentConfig{{ID: "agent1", Type: "claude", Name: "Agent 1"}},
+				Orchestrator: OrchestratorConfig{ResponseJitter: -time.Second},
+			},
+			wantErr: true,
+			errMsg:  "orchestrator.response_jitter: response jitter cannot be negative",
+		},
+		{
+			name: "unknown theme",
+			config: &Config{
+				Agents: []agent.AgentConfig{{ID: "agent1", Type: "claude", Name: "Agent 1"}},
+				Theme:  "solarized",
+			},
+			wantErr: true,
+			errMsg:  "theme: invalid theme: solarized",
+		},
+		{
+			name: "negative agent response delay",
+			config: &Config{
+				Agents: []agent.AgentConfig{{ID: "agent1", Type: "claude", Name: "Agent 1", ResponseDelay: -time.Second}},
+			},
+			wantErr: true,
+			errMsg:  "agents[0].response_delay: response delay cannot be negative",
+		},
+		{
+			name: "negative turn timeout",
+			config: &Config{
+				Agents:       []agent.AgentConfig{{ID: "agent1", Type: "claude", Name: "Agent 1"}},
+				Orchestrator: OrchestratorConfig{TurnTimeout: -5 * time.Second},
+			},
+			wantErr: true,
+			errMsg:  "orchestrator.turn_timeout: turn timeout cannot be negative",
+		},
+		{
+			name: "negative response delay",
+			config: &Config{
+				Agents:       []agent.AgentConfig{{ID: "agent1", Type: "claude", Name: "Agent 1"}},
+				Orchestrator: OrchestratorConfig{ResponseDelay: -time.Second},
+			},
+			wantErr: true,
+			errMsg:  "orchestrator.response_delay: response delay cannot be negative",
+		},
 		{
 			name: "valid config",
 			config: &Config{
@@ -91,6 +244,8 @@ func TestConfigValidate(t *testing.T) {
 		},
 	}
 
+	setTestAgentTypes(t)
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			err := tt.config.Validate()
@@ -103,3 +258,165 @@ func TestConfigValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadConfigExpandsEnv(t *testing.T) {
+	t.Setenv("AGENTPIPE_TEST_MODEL", "claude-sonnet-4.5")
+	t.Setenv("AGENTPIPE_TEST_TOPIC", "")
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := `agents:
+  - id: agent1
+    type: claude
+    name: Agent 1
+    model: ${AGENTPIPE_TEST_MODEL}
+    prompt: "You review ${AGENTPIPE_TEST_LANGUAGE:-Go} code"
+  - id: agent2
+    type: gemini
+    name: Agent 2
+    model: ${AGENTPIPE_TEST_UNSET}
+orchestrator:
+  initial_prompt: "Topic: ${AGENTPIPE_TEST_TOPIC:-error handling}"
+`
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"set variable", cfg.Agents[0].Model, "claude-sonnet-4.5"},
+		{"unset variable with default", cfg.Agents[0].Prompt, "You review Go code"},
+		{"unset variable without default", cfg.Agents[1].Model, ""},
+		{"empty variable with default", cfg.Orchestrator.InitialPrompt, "Topic: error handling"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestParseConfigDoesNotExpandEnv(t *testing.T) {
+	t.Setenv("AGENTPIPE_TEST_SECRET", "s3cret")
+
+	cfg, err := ParseConfig([]byte(`agents:
+  - id: agent1
+    type: claude
+    name: Agent 1
+    prompt: ${AGENTPIPE_TEST_SECRET}
+`))
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+	if cfg.Agents[0].Prompt != "${AGENTPIPE_TEST_SECRET}" {
+		t.Errorf("expected the prompt to be left as is, got %q", cfg.Agents[0].Prompt)
+	}
+}
+
+func TestParseConfigRejectsCommands(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{
+			name: "exec agent",
+			yaml: "agents:\n  - id: a\n    type: exec\n    name: A\n    command: sh\n    args: [-c, id]\n",
+			want: "agents[0].type: exec agents are only supported in config files loaded from disk",
+		},
+		{
+			name: "MCP server with a command",
+			yaml: "agents:\n  - id: a\n    type: claude\n    name: A\n    mcp_servers:\n      files:\n        command: sh\n",
+			want: "agents[0].mcp_servers.files: MCP servers started from a command are only supported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseConfig([]byte(tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	// Remote MCP servers are allowed
+	if _, err := ParseConfig([]byte("agents:\n  - id: a\n    type: claude\n    name: A\n    mcp_servers:\n      docs:\n        url: https://example.com/mcp\n")); err != nil {
+		t.Errorf("expected an MCP server with a url to be accepted, got %v", err)
+	}
+}
+
+func TestValidateSkipsAgentTypesWithoutAgentTypes(t *testing.T) {
+	cfg := &Config{Agents: []agent.AgentConfig{{ID: "agent1", Type: "custom", Name: "Agent 1"}}}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected agent types to go unchecked without AgentTypes, got %v", err)
+	}
+
+	setTestAgentTypes(t)
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), `unknown agent type "custom" (available: claude, codex, gemini)`) {
+		t.Errorf("expected the unknown agent type to be reported, got %v", err)
+	}
+}
+
+// setTestAgentTypes makes Validate accept only the agent types used in these
+// tests, for the duration of t.
+func setTestAgentTypes(t *testing.T) {
+	t.Helper()
+	AgentTypes = func() []string { return []string{"claude", "codex", "gemini"} }
+	t.Cleanup(func() { AgentTypes = nil })
+}
+
+func TestConfigValidateReportsAllProblems(t *testing.T) {
+	setTestAgentTypes(t)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := `agents:
+  - id: agent1
+    type: cluade
+    name: Agent 1
+  - id: agent1
+    type: gemini
+orchestrator:
+  mode: round-robbin
+  max_turns: -2
+`
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadConfig(path)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ValidationErrors, got %T: %v", err, err)
+	}
+
+	want := []string{
+		"line 3: agents[0].type: unknown agent type",
+		"line 5: agents[1].id: duplicate agent ID: agent1",
+		"line 5: agents[1].name: agent name cannot be empty",
+		"line 8: orchestrator.mode: invalid orchestrator mode: round-robbin",
+		"line 9: orchestrator.max_turns: max turns cannot be negative",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d problems, got %d:\n%v", len(want), len(errs), err)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Errorf("problem %d: expected %q, got %q", i, w, errs[i].Error())
+		}
+	}
+	if !strings.Contains(err.Error(), "5 problems:") {
+		t.Errorf("expected the problem count in the message, got %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"os"
+	"regexp"
+)
+
+// envVarPattern matches ${VAR} and ${VAR:-default}.
+var envVarPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// expandEnv replaces ${VAR} with the value of the environment variable VAR and
+// ${VAR:-default} with that value, or default when VAR is unset or empty.
+// Unset variables without a default expand to an empty string.
+func expandEnv(s string) string {
+	return envVarPattern.ReplaceAllStringFunc(s, func(match string) string {
+		parts := envVarPattern.FindStringSubmatch(match)
+		if value := os.Getenv(parts[1]); value != "" {
+			return value
+		}
+		return parts[2]
+	})
+}
+
+// expandEnv applies environment variable expansion to the string fields that
+// commonly hold models, prompts, paths and credentials.
+func (c *Config) expandEnv() {
+	for i := range c.Agents {
+		a := &c.Agents[i]
+		a.Name = expandEnv(a.Name)
+		a.Prompt = expandEnv(a.Prompt)
+		a.PromptFile = expandEnv(a.PromptFile)
+		a.Announcement = expandEnv(a.Announcement)
+		a.Model = expandEnv(a.Model)
+	}
+
+	for name, prompt := range c.Personas {
+		c.Personas[name] = expandEnv(prompt)
+	}
+
+	c.Orchestrator.InitialPrompt = expandEnv(c.Orchestrator.InitialPrompt)
+	c.Logging.ChatLogDir = expandEnv(c.Logging.ChatLogDir)
+	c.Bridge.URL = expandEnv(c.Bridge.URL)
+	c.Bridge.APIKey = expandEnv(c.Bridge.APIKey)
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// loadConfigFile reads the configuration at path, expands environment
+// variables, loads prompt files, and, if it extends another file, merges it on
+// top of that base.
+// chain holds the files already being loaded, to detect cyclic extends.
+// The result is neither validated nor defaulted.
+func loadConfigFile(path string, chain []string) (*Config, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve config path %s: %w", path, err)
+	}
+	for _, seen := range chain {
+		if seen == abs {
+			return nil, fmt.Errorf("cyclic extends in config files: %s", strings.Join(append(chain, abs), " -> "))
+		}
+	}
+
+	data, err := os.ReadFile(abs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		if len(chain) > 0 {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", abs, err)
+		}
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	config.expandEnv()
+	if err := config.resolvePromptFiles(filepath.Dir(abs)); err != nil {
+		setErrorLines(err, data)
+		if len(chain) > 0 {
+			return nil, fmt.Errorf("invalid configuration in %s: %w", abs, err)
+		}
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if config.Extends == "" {
+		return &config, nil
+	}
+
+	basePath := config.Extends
+	if !filepath.IsAbs(basePath) {
+		basePath = filepath.Join(filepath.Dir(abs), basePath)
+	}
+	base, err := loadConfigFile(basePath, append(chain, abs))
+	if err != nil {
+		return nil, err
+	}
+
+	base.merge(&config)
+	base.Extends = ""
+	return base, nil
+}
+
+// merge overlays other onto c. Personas in other replace those with the same
+// name, agents with the same ID are merged field by field and other agents are
+// appended; the theme and orchestrator, logging and bridge fields
+// are overridden when set (non-zero) in other.
+func (c *Config) merge(other *Config) {
+	if other.Version != "" {
+		c.Version = other.Version
+	}
+
+	for name, prompt := range other.Personas {
+		if c.Personas == nil {
+			c.Personas = make(map[string]string)
+		}
+		c.Personas[name] = prompt
+	}
+
+	for _, a := range other.Agents {
+		merged := false
+		for i := range c.Agents {
+			if a.ID != "" && c.Agents[i].ID == a.ID {
+				overlay(reflect.ValueOf(&c.Agents[i]).Elem(), reflect.ValueOf(a))
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			c.Agents = append(c.Agents, a)
+		}
+	}
+
+	overlay(reflect.ValueOf(&c.Orchestrator).Elem(), reflect.ValueOf(other.Orchestrator))
+	overlay(reflect.ValueOf(&c.Logging).Elem(), reflect.ValueOf(other.Logging))
+	overlay(reflect.ValueOf(&c.Bridge).Elem(), reflect.ValueOf(other.Bridge))
+
+	if other.Theme != "" {
+		c.Theme = other.Theme
+	}
+}
+
+// overlay copies the non-zero fields of the struct src onto dst, recursing
+// into nested structs.
+func overlay(dst, src reflect.Value) {
+	for i := 0; i < src.NumField(); i++ {
+		field := src.Field(i)
+		target := dst.Field(i)
+		if !target.CanSet() {
+			continue
+		}
+		if field.Kind() == reflect.Struct {
+			overlay(target, field)
+			continue
+		}
+		if !field.IsZero() {
+			target.Set(field)
+		}
+	}
+}
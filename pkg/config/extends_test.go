@@ -0,0 +1,112 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConfigExtends(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFile(t, filepath.Join(dir, "shared", "base.yaml"), `agents:
+  - id: reviewer
+    type: claude
+    name: Reviewer
+    model: claude-sonnet-4.5
+    prompt: Review code carefully
+  - id: tester
+    type: gemini
+    name: Tester
+orchestrator:
+  mode: round-robin
+  max_turns: 6
+  turn_timeout: 45s
+  initial_prompt: Discuss the codebase
+logging:
+  enabled: true
+  log_format: markdown
+theme: light
+`)
+	path := filepath.Join(dir, "project", "agentpipe.yaml")
+	writeConfigFile(t, path, `extends: ../shared/base.yaml
+agents:
+  - id: reviewer
+    model: claude-opus-4
+  - id: writer
+    type: codex
+    name: Writer
+orchestrator:
+  max_turns: 12
+  initial_prompt: Review the payment service
+`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if len(cfg.Agents) != 3 {
+		t.Fatalf("expected 3 agents, got %d", len(cfg.Agents))
+	}
+	reviewer := cfg.Agents[0]
+	if reviewer.Model != "claude-opus-4" {
+		t.Errorf("expected the project model to override the base, got %q", reviewer.Model)
+	}
+	if reviewer.Name != "Reviewer" || reviewer.Prompt != "Review code carefully" || reviewer.Type != "claude" {
+		t.Errorf("expected unset agent fields to come from the base, got %+v", reviewer)
+	}
+	if cfg.Agents[1].ID != "tester" || cfg.Agents[2].ID != "writer" {
+		t.Errorf("expected base agents followed by new ones, got %s, %s", cfg.Agents[1].ID, cfg.Agents[2].ID)
+	}
+
+	if cfg.Orchestrator.MaxTurns != 12 || cfg.Orchestrator.InitialPrompt != "Review the payment service" {
+		t.Errorf("expected project orchestrator settings to win, got %+v", cfg.Orchestrator)
+	}
+	if cfg.Orchestrator.Mode != "round-robin" || cfg.Orchestrator.TurnTimeout != 45*time.Second {
+		t.Errorf("expected unset orchestrator settings to come from the base, got %+v", cfg.Orchestrator)
+	}
+	if cfg.Logging.LogFormat != "markdown" {
+		t.Errorf("expected the base log format, got %q", cfg.Logging.LogFormat)
+	}
+	if cfg.Theme != "light" {
+		t.Errorf("expected the base theme, got %q", cfg.Theme)
+	}
+	if cfg.Extends != "" {
+		t.Errorf("expected extends to be resolved, got %q", cfg.Extends)
+	}
+}
+
+func TestLoadConfigExtendsCycle(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFile(t, filepath.Join(dir, "a.yaml"), "extends: b.yaml\n")
+	writeConfigFile(t, filepath.Join(dir, "b.yaml"), "extends: a.yaml\n")
+
+	_, err := LoadConfig(filepath.Join(dir, "a.yaml"))
+	if err == nil {
+		t.Fatal("expected an error for cyclic extends")
+	}
+	if !strings.Contains(err.Error(), "cyclic extends") || !strings.Contains(err.Error(), "a.yaml -> ") {
+		t.Errorf("expected the cycle in the error, got %v", err)
+	}
+}
+
+func TestLoadConfigExtendsMissingBase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfigFile(t, path, "extends: missing.yaml\n")
+
+	if _, err := LoadConfig(path); err == nil || !strings.Contains(err.Error(), "missing.yaml") {
+		t.Errorf("expected an error naming the missing base, got %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+// resolvePersonas expands each agent's persona from the config's personas map
+// into its prompt: the persona prompt comes first, followed by any inline
+// prompt, and the agent's persona is cleared. Personas not in the map are left
+// for agent creation to look up in ~/.agentpipe/personas.d; a name found in
+// neither place is reported in a ValidationErrors.
+func (c *Config) resolvePersonas() error {
+	var errs ValidationErrors
+	for i := range c.Agents {
+		a := &c.Agents[i]
+		if a.Persona == "" {
+			continue
+		}
+
+		prompt, ok := c.Personas[a.Persona]
+		if !ok {
+			if _, found := agent.GetPersona(a.Persona); !found {
+				errs = append(errs, &FieldError{
+					Field:   fmt.Sprintf("agents[%d].persona", i),
+					Message: fmt.Sprintf("unknown persona: %s", a.Persona),
+				})
+			}
+			continue
+		}
+
+		if inline := strings.TrimSpace(a.Prompt); inline != "" {
+			prompt = prompt + "\n\n" + inline
+		}
+		a.Prompt = prompt
+		a.Persona = ""
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+func TestLoadConfigResolvesPersonas(t *testing.T) {
+	setTestAgentTypes(t)
+	agent.SetPersonas(nil)
+	defer agent.SetPersonas(nil)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfigFile(t, path, `personas:
+  reviewer: You are a skeptical code reviewer.
+  optimist: You see the upside of every idea.
+agents:
+  - id: security
+    type: claude
+    name: Security Reviewer
+    persona: reviewer
+    prompt: Focus on authentication.
+  - id: sunny
+    type: gemini
+    name: Sunny
+    persona: optimist
+  - id: plain
+    type: codex
+    name: Plain
+    prompt: Just answer.
+`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		got  agent.AgentConfig
+		want string
+	}{
+		{"persona and inline prompt", cfg.Agents[0], "You are a skeptical code reviewer.\n\nFocus on authentication."},
+		{"persona only", cfg.Agents[1], "You see the upside of every idea."},
+		{"no persona", cfg.Agents[2], "Just answer."},
+	}
+	for _, tt := range tests {
+		if tt.got.Prompt != tt.want {
+			t.Errorf("%s: got prompt %q, want %q", tt.name, tt.got.Prompt, tt.want)
+		}
+		if tt.got.Persona != "" {
+			t.Errorf("%s: expected the persona to be resolved, got %q", tt.name, tt.got.Persona)
+		}
+	}
+}
+
+func TestLoadConfigUnknownPersona(t *testing.T) {
+	setTestAgentTypes(t)
+	agent.SetPersonas(nil)
+	defer agent.SetPersonas(nil)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfigFile(t, path, `personas:
+  reviewer: You are a skeptical code reviewer.
+agents:
+  - id: security
+    type: claude
+    name: Security Reviewer
+    persona: reviwer
+`)
+
+	_, err := LoadConfig(path)
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Fatalf("expected one validation error, got %v", err)
+	}
+	if errs[0].Field != "agents[0].persona" || errs[0].Line != 7 || !strings.Contains(errs[0].Message, "reviwer") {
+		t.Errorf("unexpected error: %+v", errs[0])
+	}
+}
+
+func TestLoadConfigFallsBackToPersonaDir(t *testing.T) {
+	setTestAgentTypes(t)
+	agent.SetPersonas(map[string]string{"reviewer": "You are a skeptical code reviewer."})
+	defer agent.SetPersonas(nil)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfigFile(t, path, `agents:
+  - id: security
+    type: claude
+    name: Security Reviewer
+    persona: reviewer
+`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.Agents[0].Persona != "reviewer" || cfg.Agents[0].Prompt != "" {
+		t.Errorf("expected the persona to be left for agent creation, got %+v", cfg.Agents[0])
+	}
+}
+
+func TestLoadConfigExtendsMergesPersonas(t *testing.T) {
+	setTestAgentTypes(t)
+	agent.SetPersonas(nil)
+	defer agent.SetPersonas(nil)
+
+	dir := t.TempDir()
+	writeConfigFile(t, filepath.Join(dir, "base.yaml"), `personas:
+  reviewer: You are a skeptical code reviewer.
+  writer: You write clear prose.
+`)
+	path := filepath.Join(dir, "project.yaml")
+	writeConfigFile(t, path, `extends: base.yaml
+personas:
+  writer: You write terse prose.
+agents:
+  - id: a
+    type: claude
+    name: A
+    persona: reviewer
+  - id: b
+    type: gemini
+    name: B
+    persona: writer
+`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.Agents[0].Prompt != "You are a skeptical code reviewer." {
+		t.Errorf("expected the base persona, got %q", cfg.Agents[0].Prompt)
+	}
+	if cfg.Agents[1].Prompt != "You write terse prose." {
+		t.Errorf("expected the project persona to override the base, got %q", cfg.Agents[1].Prompt)
+	}
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// resolvePromptFiles reads each agent's prompt_file into its prompt and clears
+// prompt_file. Relative paths resolve against dir, the directory of the config
+// file that declares them. File contents get the same environment variable
+// expansion as inline prompts. Setting both prompt and prompt_file, or a file
+// that cannot be read, is reported in a ValidationErrors.
+func (c *Config) resolvePromptFiles(dir string) error {
+	var errs ValidationErrors
+	for i := range c.Agents {
+		a := &c.Agents[i]
+		if a.PromptFile == "" {
+			continue
+		}
+
+		field := fmt.Sprintf("agents[%d].prompt_file", i)
+		if a.Prompt != "" {
+			errs = append(errs, &FieldError{Field: field, Message: fmt.Sprintf("prompt and prompt_file cannot both be set for agent %s", a.ID)})
+			continue
+		}
+
+		path := a.PromptFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, &FieldError{Field: field, Message: fmt.Sprintf("cannot read prompt file %s: %v", path, unwrapPathError(err))})
+			continue
+		}
+
+		a.Prompt = expandEnv(strings.TrimSpace(string(data)))
+		a.PromptFile = ""
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// unwrapPathError drops the operation and path from an *os.PathError, since
+// the caller already names the path.
+func unwrapPathError(err error) error {
+	var pathErr *os.PathError
+	if errors.As(err, &pathErr) {
+		return pathErr.Err
+	}
+	return err
+}
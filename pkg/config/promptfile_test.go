@@ -0,0 +1,121 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigPromptFile(t *testing.T) {
+	setTestAgentTypes(t)
+	t.Setenv("AGENTPIPE_TEST_FOCUS", "security")
+
+	dir := t.TempDir()
+	absPrompt := filepath.Join(dir, "elsewhere", "writer.md")
+	writeConfigFile(t, absPrompt, "You write release notes.\n")
+	writeConfigFile(t, filepath.Join(dir, "project", "prompts", "reviewer.md"), "You are a careful reviewer.\n\nFocus on ${AGENTPIPE_TEST_FOCUS}.\n")
+
+	path := filepath.Join(dir, "project", "agentpipe.yaml")
+	writeConfigFile(t, path, `agents:
+  - id: reviewer
+    type: claude
+    name: Reviewer
+    prompt_file: prompts/reviewer.md
+  - id: writer
+    type: gemini
+    name: Writer
+    prompt_file: `+absPrompt+`
+`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if want := "You are a careful reviewer.\n\nFocus on security."; cfg.Agents[0].Prompt != want {
+		t.Errorf("expected the relative prompt file, got %q, want %q", cfg.Agents[0].Prompt, want)
+	}
+	if cfg.Agents[1].Prompt != "You write release notes." {
+		t.Errorf("expected the absolute prompt file, got %q", cfg.Agents[1].Prompt)
+	}
+	if cfg.Agents[0].PromptFile != "" {
+		t.Errorf("expected prompt_file to be resolved, got %q", cfg.Agents[0].PromptFile)
+	}
+}
+
+func TestLoadConfigPromptFileRelativeToExtendedFile(t *testing.T) {
+	setTestAgentTypes(t)
+
+	dir := t.TempDir()
+	writeConfigFile(t, filepath.Join(dir, "shared", "prompts", "reviewer.md"), "Shared reviewer prompt")
+	writeConfigFile(t, filepath.Join(dir, "shared", "base.yaml"), `agents:
+  - id: reviewer
+    type: claude
+    name: Reviewer
+    prompt_file: prompts/reviewer.md
+`)
+	path := filepath.Join(dir, "project", "agentpipe.yaml")
+	writeConfigFile(t, path, `extends: ../shared/base.yaml
+agents:
+  - id: reviewer
+    model: claude-opus-4
+`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.Agents[0].Prompt != "Shared reviewer prompt" {
+		t.Errorf("expected the base file's prompt_file to resolve against its own directory, got %q", cfg.Agents[0].Prompt)
+	}
+}
+
+func TestLoadConfigPromptFileErrors(t *testing.T) {
+	setTestAgentTypes(t)
+
+	dir := t.TempDir()
+	writeConfigFile(t, filepath.Join(dir, "reviewer.md"), "You are a careful reviewer.")
+
+	tests := []struct {
+		name    string
+		agent   string
+		wantErr string
+	}{
+		{
+			name:    "both set",
+			agent:   "    prompt: Review code\n    prompt_file: reviewer.md\n",
+			wantErr: "line 6: agents[0].prompt_file: prompt and prompt_file cannot both be set for agent reviewer",
+		},
+		{
+			name:    "missing file",
+			agent:   "    prompt_file: prompts/missing.md\n",
+			wantErr: "cannot read prompt file " + filepath.Join(dir, "prompts", "missing.md"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "agentpipe.yaml")
+			writeConfigFile(t, path, "agents:\n  - id: reviewer\n    type: claude\n    name: Reviewer\n"+tt.agent)
+
+			_, err := LoadConfig(path)
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("expected ValidationErrors, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected %q in the error, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParseConfigRejectsPromptFile(t *testing.T) {
+	setTestAgentTypes(t)
+
+	_, err := ParseConfig([]byte("agents:\n  - id: a\n    type: claude\n    name: A\n    prompt_file: /etc/passwd\n"))
+	if err == nil || !strings.Contains(err.Error(), "prompt_file is only supported") {
+		t.Errorf("expected prompt_file to be rejected, got %v", err)
+	}
+}
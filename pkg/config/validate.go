@@ -0,0 +1,111 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FieldError is a problem with one configuration field.
+type FieldError struct {
+	// Field is the YAML path of the field, e.g. "agents[1].type"
+	Field string
+	// Line is the line of the field in the config file (0 if unknown)
+	Line int
+	// Message describes the problem
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("line %d: %s: %s", e.Line, e.Field, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationErrors lists every problem found by Validate.
+type ValidationErrors []*FieldError
+
+func (e ValidationErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d problems:", len(e))
+	for _, fe := range e {
+		b.WriteString("\n  - ")
+		b.WriteString(fe.Error())
+	}
+	return b.String()
+}
+
+// setErrorLines fills in the line of each field in a ValidationErrors from the
+// YAML document data. Fields missing from the document get the line of their
+// closest parent. A document that extends another is skipped, since its fields
+// may come from the base file.
+func setErrorLines(err error, data []byte) {
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		return
+	}
+
+	var doc yaml.Node
+	if yaml.Unmarshal(data, &doc) != nil || len(doc.Content) == 0 {
+		return
+	}
+	root := doc.Content[0]
+	if key, _ := mappingEntry(root, "extends"); key != nil {
+		return
+	}
+
+	for _, fe := range errs {
+		fe.Line = fieldLine(root, fe.Field)
+	}
+}
+
+// fieldLine returns the line of the field at path ("agents[1].type") in node.
+func fieldLine(node *yaml.Node, path string) int {
+	line := 0
+	for _, part := range strings.Split(path, ".") {
+		name, index := part, -1
+		if i := strings.Index(part, "["); i >= 0 && strings.HasSuffix(part, "]") {
+			name = part[:i]
+			if n, err := strconv.Atoi(part[i+1 : len(part)-1]); err == nil {
+				index = n
+			}
+		}
+
+		key, value := mappingEntry(node, name)
+		if key == nil {
+			return line
+		}
+		node, line = value, key.Line
+
+		if index >= 0 {
+			if node.Kind != yaml.SequenceNode || index >= len(node.Content) {
+				return line
+			}
+			node = node.Content[index]
+			line = node.Line
+		}
+	}
+	return line
+}
+
+// mappingEntry returns the key and value nodes for name in a YAML mapping node,
+// or nils if node is not a mapping or has no such key.
+func mappingEntry(node *yaml.Node, name string) (*yaml.Node, *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == name {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+	return nil, nil
+}
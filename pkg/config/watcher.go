@@ -4,6 +4,7 @@ package config
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
@@ -11,6 +12,10 @@ import (
 	"github.com/kevinelliott/agentpipe/pkg/log"
 )
 
+// DefaultWatchDebounce is how long the watcher waits after the last write
+// before reloading, so multi-step editor saves trigger a single reload.
+const DefaultWatchDebounce = 200 * time.Millisecond
+
 // ConfigChangeCallback is called when the configuration file changes.
 // It receives the old and new configurations.
 type ConfigChangeCallback func(oldConfig, newConfig *Config)
@@ -24,6 +29,8 @@ type ConfigWatcher struct {
 	callbacks       []ConfigChangeCallback
 	stopChan        chan struct{}
 	reloadInProcess bool
+	debounce        time.Duration // quiet period before reloading (0 = reload on every event)
+	debounceTimer   *time.Timer   // pending reload, reset by each new event
 }
 
 // NewConfigWatcher creates a new configuration watcher.
@@ -49,6 +56,7 @@ func NewConfigWatcher(configPath string) (*ConfigWatcher, error) {
 		viper:      v,
 		callbacks:  make([]ConfigChangeCallback, 0),
 		stopChan:   make(chan struct{}),
+		debounce:   DefaultWatchDebounce,
 	}
 
 	log.WithField("config_path", configPath).Info("config watcher initialized")
@@ -63,6 +71,15 @@ func (cw *ConfigWatcher) GetConfig() *Config {
 	return cw.config
 }
 
+// SetDebounce sets how long the watcher waits after the last change event
+// before reloading (default: DefaultWatchDebounce). Events within the window
+// coalesce into a single reload; 0 reloads on every event.
+func (cw *ConfigWatcher) SetDebounce(d time.Duration) {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+	cw.debounce = d
+}
+
 // OnConfigChange registers a callback to be invoked when the config changes.
 // Callbacks are executed in the order they were registered.
 func (cw *ConfigWatcher) OnConfigChange(callback ConfigChangeCallback) {
@@ -88,13 +105,44 @@ func (cw *ConfigWatcher) StartWatching() {
 }
 
 // StopWatching stops monitoring the configuration file.
+// A pending debounced reload is canceled.
 func (cw *ConfigWatcher) StopWatching() {
+	cw.mu.Lock()
+	if cw.debounceTimer != nil {
+		cw.debounceTimer.Stop()
+	}
+	cw.mu.Unlock()
+
 	close(cw.stopChan)
 	log.Info("stopped watching config file")
 }
 
-// handleConfigChange is called when the config file changes.
+// handleConfigChange is called when the config file changes. The reload is
+// delayed until no further events arrive for the debounce interval, which also
+// avoids reading a half-written file.
 func (cw *ConfigWatcher) handleConfigChange(e fsnotify.Event) {
+	log.WithFields(map[string]interface{}{
+		"event":       e.Op.String(),
+		"config_path": e.Name,
+	}).Debug("config file event received")
+
+	cw.mu.Lock()
+	if cw.debounce <= 0 {
+		cw.mu.Unlock()
+		cw.reload(e)
+		return
+	}
+	if cw.debounceTimer != nil {
+		cw.debounceTimer.Stop()
+	}
+	cw.debounceTimer = time.AfterFunc(cw.debounce, func() {
+		cw.reload(e)
+	})
+	cw.mu.Unlock()
+}
+
+// reload reloads the config file and invokes the callbacks.
+func (cw *ConfigWatcher) reload(e fsnotify.Event) {
 	cw.mu.Lock()
 	if cw.reloadInProcess {
 		cw.mu.Unlock()
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"sync"
@@ -185,6 +186,63 @@ orchestrator:
 	}
 }
 
+// TestConfigWatcher_Debounce tests that rapid writes coalesce into one reload
+func TestConfigWatcher_Debounce(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "test-config.yaml")
+
+	configTemplate := `version: "1.0"
+agents:
+  - id: test-1
+    type: claude
+    name: TestAgent
+orchestrator:
+  mode: round-robin
+  max_turns: %d
+`
+
+	if err := os.WriteFile(configPath, []byte(fmt.Sprintf(configTemplate, 5)), 0600); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	watcher, err := NewConfigWatcher(configPath)
+	if err != nil {
+		t.Fatalf("Failed to create config watcher: %v", err)
+	}
+	defer watcher.StopWatching()
+	watcher.SetDebounce(300 * time.Millisecond)
+
+	var mu sync.Mutex
+	var calls []int
+	watcher.OnConfigChange(func(oldConfig, newConfig *Config) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, newConfig.Orchestrator.MaxTurns)
+	})
+
+	go watcher.StartWatching()
+	time.Sleep(100 * time.Millisecond)
+
+	// Several saves in quick succession, all within the debounce window
+	for turns := 6; turns <= 9; turns++ {
+		if err := os.WriteFile(configPath, []byte(fmt.Sprintf(configTemplate, turns)), 0600); err != nil {
+			t.Fatalf("Failed to update test config: %v", err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	time.Sleep(1 * time.Second)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(calls) != 1 {
+		t.Fatalf("Expected exactly 1 callback, got %d: %v", len(calls), calls)
+	}
+	if calls[0] != 9 {
+		t.Errorf("Expected the reload to see the last write (max_turns 9), got %d", calls[0])
+	}
+}
+
 // TestConfigWatcher_MultipleCallbacks tests multiple callbacks
 func TestConfigWatcher_MultipleCallbacks(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -0,0 +1,116 @@
+package conversation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/export"
+)
+
+// Format is a transcript output format understood by Render.
+type Format string
+
+const (
+	// FormatText renders one "[HH:MM:SS] Name (role): content" block per message,
+	// the same layout as text chat logs
+	FormatText Format = "text"
+	// FormatJSON renders the messages as an indented JSON array
+	FormatJSON Format = "json"
+	// FormatMarkdown renders the messages as a Markdown document
+	FormatMarkdown Format = "markdown"
+	// FormatHTML renders the messages as a standalone HTML page
+	FormatHTML Format = "html"
+	// FormatCSV renders one row of metrics per message, for spreadsheets
+	FormatCSV Format = "csv"
+)
+
+// ParseFormat converts a user-supplied format name into a Format.
+// "md" is accepted as an alias for markdown.
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "text", "txt":
+		return FormatText, nil
+	case "json":
+		return FormatJSON, nil
+	case "markdown", "md":
+		return FormatMarkdown, nil
+	case "html":
+		return FormatHTML, nil
+	case "csv":
+		return FormatCSV, nil
+	default:
+		return "", fmt.Errorf("unsupported format: %s (use text, json, markdown, html, or csv)", name)
+	}
+}
+
+// RenderOptions controls the Markdown and HTML output of RenderWithOptions.
+type RenderOptions struct {
+	// Title is the document title (default: "AgentPipe Conversation")
+	Title string
+	// IncludeMetrics adds token counts and costs
+	IncludeMetrics bool
+	// IncludeTimestamps adds message timestamps
+	IncludeTimestamps bool
+}
+
+// DefaultRenderOptions returns the options used by Render.
+func DefaultRenderOptions() RenderOptions {
+	return RenderOptions{
+		Title:             "AgentPipe Conversation",
+		IncludeMetrics:    true,
+		IncludeTimestamps: true,
+	}
+}
+
+// Render writes messages to w in the given format using DefaultRenderOptions.
+func Render(w io.Writer, messages []agent.Message, format Format) error {
+	return RenderWithOptions(w, messages, format, DefaultRenderOptions())
+}
+
+// RenderWithOptions writes messages to w in the given format.
+// Markdown and HTML output is produced by the export package; opts only affects
+// those formats.
+func RenderWithOptions(w io.Writer, messages []agent.Message, format Format, opts RenderOptions) error {
+	switch format {
+	case FormatText:
+		return renderText(w, messages)
+	case FormatJSON:
+		return renderJSON(w, messages)
+	case FormatCSV:
+		return export.ExportCSV(messages, w)
+	case FormatMarkdown, FormatHTML:
+		return export.NewExporter(export.ExportOptions{
+			Format:            export.Format(format),
+			IncludeMetrics:    opts.IncludeMetrics,
+			IncludeTimestamps: opts.IncludeTimestamps,
+			Title:             opts.Title,
+		}).Export(messages, w)
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+// renderText writes messages in the text chat log layout.
+func renderText(w io.Writer, messages []agent.Message) error {
+	for _, msg := range messages {
+		timestamp := time.Unix(msg.Timestamp, 0).Format("15:04:05")
+		if _, err := fmt.Fprintf(w, "[%s] %s (%s): %s\n\n", timestamp, msg.AgentName, msg.Role, msg.Content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderJSON writes messages as an indented JSON array.
+func renderJSON(w io.Writer, messages []agent.Message) error {
+	if messages == nil {
+		messages = []agent.Message{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(messages)
+}
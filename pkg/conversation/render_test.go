@@ -0,0 +1,104 @@
+package conversation
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+func renderTestMessages() []agent.Message {
+	ts := time.Date(2025, 1, 2, 15, 4, 5, 0, time.Local).Unix()
+	return []agent.Message{
+		{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Discuss testing", Timestamp: ts},
+		{AgentID: "claude-0", AgentName: "Claude", AgentType: "claude", Role: "agent", Content: "Tests are good", Timestamp: ts},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Format
+		wantErr bool
+	}{
+		{"text", FormatText, false},
+		{"JSON", FormatJSON, false},
+		{"md", FormatMarkdown, false},
+		{"markdown", FormatMarkdown, false},
+		{"html", FormatHTML, false},
+		{"csv", FormatCSV, false},
+		{"yaml", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseFormat(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseFormat(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRenderText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, renderTestMessages(), FormatText); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	want := "[15:04:05] HOST (system): Discuss testing\n\n[15:04:05] Claude (agent): Tests are good\n\n"
+	if buf.String() != want {
+		t.Errorf("unexpected text output:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
+
+func TestRenderJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, renderTestMessages(), FormatJSON); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	var got []agent.Message
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if len(got) != 2 || got[1].AgentName != "Claude" || got[1].Content != "Tests are good" {
+		t.Errorf("unexpected messages: %+v", got)
+	}
+}
+
+func TestRenderJSONEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, nil, FormatJSON); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("expected empty JSON array, got %q", buf.String())
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, renderTestMessages(), FormatMarkdown); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"### [SYSTEM]", "Discuss testing", "### Claude", "Tests are good"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown output missing %q", want)
+		}
+	}
+}
+
+func TestRenderUnsupportedFormat(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, renderTestMessages(), Format("yaml")); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
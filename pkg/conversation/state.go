@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/kevinelliott/agentpipe/pkg/agent"
@@ -136,6 +138,92 @@ func LoadState(path string) (*State, error) {
 	return &state, nil
 }
 
+// Fork returns a copy of the state truncated to its first upTo messages, which
+// can be resumed with different settings than the original. upTo is clamped to
+// the number of messages. The fork's metadata counts only the kept messages and
+// drops the summaries, which describe the whole conversation; its config is
+// shared with s.
+func (s *State) Fork(upTo int) State {
+	if upTo < 0 {
+		upTo = 0
+	}
+	if upTo > len(s.Messages) {
+		upTo = len(s.Messages)
+	}
+
+	fork := State{
+		Version:  s.Version,
+		SavedAt:  time.Now(),
+		Messages: append(make([]agent.Message, 0, upTo), s.Messages[:upTo]...),
+		Config:   s.Config,
+		Metadata: StateMetadata{
+			TotalTurns:    upTo,
+			TotalMessages: upTo,
+			StartedAt:     s.Metadata.StartedAt,
+			Description:   s.Metadata.Description,
+		},
+	}
+
+	// The conversation lasted until the last kept message, if its time is known
+	if upTo > 0 && !s.Metadata.StartedAt.IsZero() {
+		if ts := s.Messages[upTo-1].Timestamp; ts > 0 {
+			if d := time.Unix(ts, 0).Sub(s.Metadata.StartedAt); d > 0 {
+				fork.Metadata.TotalDuration = d.Milliseconds()
+			}
+		}
+	}
+
+	return fork
+}
+
+// CheckAgents returns an error unless agents are the agents the conversation
+// was saved with: the same IDs with the same types, in any order. States saved
+// without a config are checked against the agents that responded.
+func (s *State) CheckAgents(agents []agent.AgentConfig) error {
+	want := make(map[string]string)
+	if s.Config != nil {
+		for _, a := range s.Config.Agents {
+			want[a.ID] = a.Type
+		}
+	} else {
+		for _, msg := range s.Messages {
+			if msg.Role == agent.RoleAgent {
+				want[msg.AgentID] = msg.AgentType
+			}
+		}
+	}
+
+	have := make(map[string]string, len(agents))
+	for _, a := range agents {
+		have[a.ID] = a.Type
+	}
+
+	matches := len(have) == len(want) || s.Config == nil
+	for id, agentType := range want {
+		if t, ok := have[id]; !ok || (agentType != "" && t != agentType) {
+			matches = false
+		}
+	}
+	if !matches {
+		return fmt.Errorf("agents do not match the saved conversation: state has %s, config has %s",
+			describeAgents(want), describeAgents(have))
+	}
+	return nil
+}
+
+// describeAgents lists agents as "id (type)", sorted by ID.
+func describeAgents(agents map[string]string) string {
+	if len(agents) == 0 {
+		return "no agents"
+	}
+	list := make([]string, 0, len(agents))
+	for id, agentType := range agents {
+		list = append(list, fmt.Sprintf("%s (%s)", id, agentType))
+	}
+	sort.Strings(list)
+	return strings.Join(list, ", ")
+}
+
 // GetDefaultStateDir returns the default directory for saving conversation states.
 // This is ~/.agentpipe/states by default.
 func GetDefaultStateDir() (string, error) {
@@ -408,3 +408,101 @@ func TestState_RoundTrip(t *testing.T) {
 		t.Errorf("MaxTurns mismatch: expected 50, got %d", loadedState.Config.Orchestrator.MaxTurns)
 	}
 }
+
+func TestState_CheckAgents(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Agents = []agent.AgentConfig{
+		{ID: "claude-1", Type: "claude", Name: "Claude"},
+		{ID: "gemini-1", Type: "gemini", Name: "Gemini"},
+	}
+	state := NewState(nil, cfg, time.Now())
+
+	tests := []struct {
+		name    string
+		agents  []agent.AgentConfig
+		wantErr bool
+	}{
+		{name: "same agents in another order", agents: []agent.AgentConfig{{ID: "gemini-1", Type: "gemini"}, {ID: "claude-1", Type: "claude"}}},
+		{name: "missing agent", agents: []agent.AgentConfig{{ID: "claude-1", Type: "claude"}}, wantErr: true},
+		{name: "extra agent", agents: []agent.AgentConfig{{ID: "claude-1", Type: "claude"}, {ID: "gemini-1", Type: "gemini"}, {ID: "qwen-1", Type: "qwen"}}, wantErr: true},
+		{name: "different type", agents: []agent.AgentConfig{{ID: "claude-1", Type: "claude"}, {ID: "gemini-1", Type: "qwen"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := state.CheckAgents(tt.agents)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckAgents() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Without a saved config, the agents that responded must be present
+	legacy := &State{Messages: []agent.Message{{AgentID: "claude-1", AgentType: "claude", Role: "agent"}}}
+	if err := legacy.CheckAgents(cfg.Agents); err != nil {
+		t.Errorf("expected the responders to be found, got %v", err)
+	}
+	if err := legacy.CheckAgents(cfg.Agents[1:]); err == nil {
+		t.Error("expected an error when a responder is missing")
+	}
+}
+
+func TestState_Fork(t *testing.T) {
+	start := time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
+	messages := []agent.Message{
+		{AgentID: "host", AgentName: "HOST", Content: "Pick a name", Role: "system", Timestamp: start.Unix()},
+		{AgentID: "claude-1", AgentName: "Claude", Content: "Atlas", Role: "agent", Timestamp: start.Add(5 * time.Second).Unix()},
+		{AgentID: "gemini-1", AgentName: "Gemini", Content: "Orbit", Role: "agent", Timestamp: start.Add(9 * time.Second).Unix()},
+		{AgentID: "claude-1", AgentName: "Claude", Content: "Atlas it is", Role: "agent", Timestamp: start.Add(20 * time.Second).Unix()},
+	}
+	state := NewState(messages, config.NewDefaultConfig(), start)
+	state.Metadata.ShortText = "They chose Atlas."
+
+	tests := []struct {
+		name         string
+		upTo         int
+		wantMessages int
+		wantDuration int64
+	}{
+		{name: "at 0", upTo: 0, wantMessages: 0, wantDuration: 0},
+		{name: "mid", upTo: 2, wantMessages: 2, wantDuration: 5000},
+		{name: "end", upTo: 4, wantMessages: 4, wantDuration: 20000},
+		{name: "past the end", upTo: 10, wantMessages: 4, wantDuration: 20000},
+		{name: "negative", upTo: -1, wantMessages: 0, wantDuration: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fork := state.Fork(tt.upTo)
+
+			if len(fork.Messages) != tt.wantMessages {
+				t.Fatalf("expected %d messages, got %d", tt.wantMessages, len(fork.Messages))
+			}
+			for i, msg := range fork.Messages {
+				if msg.Content != messages[i].Content {
+					t.Errorf("message %d: expected %q, got %q", i, messages[i].Content, msg.Content)
+				}
+			}
+			if fork.Metadata.TotalMessages != tt.wantMessages || fork.Metadata.TotalTurns != tt.wantMessages {
+				t.Errorf("expected metadata to count %d messages, got %+v", tt.wantMessages, fork.Metadata)
+			}
+			if fork.Metadata.TotalDuration != tt.wantDuration {
+				t.Errorf("expected duration %dms, got %dms", tt.wantDuration, fork.Metadata.TotalDuration)
+			}
+			if fork.Metadata.ShortText != "" {
+				t.Error("expected the summary of the whole conversation to be dropped")
+			}
+			if fork.Config != state.Config || !fork.Metadata.StartedAt.Equal(start) {
+				t.Error("expected the fork to keep the config and start time")
+			}
+		})
+	}
+
+	// Changing a fork leaves the original intact
+	fork := state.Fork(2)
+	fork.Messages = append(fork.Messages, agent.Message{Content: "Nova"})
+	fork.Messages[0].Content = "changed"
+	if len(state.Messages) != 4 || state.Messages[0].Content != "Pick a name" || state.Messages[2].Content != "Orbit" {
+		t.Errorf("expected the original state to be unchanged, got %+v", state.Messages)
+	}
+}
@@ -1,12 +1,14 @@
 // Package export provides functionality to export conversations to different formats.
-// Supported formats include JSON, Markdown, and HTML.
+// Supported formats include JSON, Markdown, HTML, and CSV.
 package export
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"html"
 	"io"
+	"strconv"
 	"strings"
 	"time"
 
@@ -23,11 +25,13 @@ const (
 	FormatMarkdown Format = "markdown"
 	// FormatHTML exports conversation as HTML
 	FormatHTML Format = "html"
+	// FormatCSV exports per-message metrics as CSV
+	FormatCSV Format = "csv"
 )
 
 // ExportOptions contains options for exporting conversations.
 type ExportOptions struct {
-	// Format specifies the export format (json, markdown, html)
+	// Format specifies the export format (json, markdown, html, csv)
 	Format Format
 	// IncludeMetrics includes token counts and costs in export
 	IncludeMetrics bool
@@ -35,6 +39,8 @@ type ExportOptions struct {
 	IncludeTimestamps bool
 	// Title is an optional title for the exported conversation
 	Title string
+	// ExportedAt is the export time shown in Markdown and HTML (default: now)
+	ExportedAt time.Time
 }
 
 // Exporter handles conversation exports to different formats.
@@ -58,11 +64,71 @@ func (e *Exporter) Export(messages []agent.Message, writer io.Writer) error {
 		return e.exportMarkdown(messages, writer)
 	case FormatHTML:
 		return e.exportHTML(messages, writer)
+	case FormatCSV:
+		return ExportCSV(messages, writer)
 	default:
 		return fmt.Errorf("unsupported export format: %s", e.options.Format)
 	}
 }
 
+// ExportHTML writes messages to w as a standalone HTML page with metrics and
+// timestamps, titled "AgentPipe Conversation".
+func ExportHTML(messages []agent.Message, w io.Writer) error {
+	return NewExporter(ExportOptions{
+		Format:            FormatHTML,
+		IncludeMetrics:    true,
+		IncludeTimestamps: true,
+	}).Export(messages, w)
+}
+
+// csvHeader lists the columns written by ExportCSV.
+var csvHeader = []string{
+	"timestamp", "agent_name", "role", "model", "duration_ms",
+	"input_tokens", "output_tokens", "total_tokens", "cost",
+}
+
+// ExportCSV writes one row of metrics per message to w, for cost analysis in a
+// spreadsheet. Timestamps are RFC 3339 in UTC and costs are in USD. Messages
+// without metrics (system and user messages, older logs) leave the metric cells
+// empty rather than writing zeros, so they don't skew averages.
+func ExportCSV(messages []agent.Message, w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, msg := range messages {
+		row := []string{
+			time.Unix(msg.Timestamp, 0).UTC().Format(time.RFC3339),
+			msg.AgentName,
+			msg.Role,
+			"", "", "", "", "", "",
+		}
+		if m := msg.Metrics; m != nil {
+			row[3] = m.Model
+			row[4] = strconv.FormatInt(m.Duration.Milliseconds(), 10)
+			row[5] = strconv.Itoa(m.InputTokens)
+			row[6] = strconv.Itoa(m.OutputTokens)
+			row[7] = strconv.Itoa(m.TotalTokens)
+			row[8] = strconv.FormatFloat(m.Cost, 'f', 6, 64)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// exportedAt returns the export time to show.
+func (e *Exporter) exportedAt() time.Time {
+	if e.options.ExportedAt.IsZero() {
+		return time.Now()
+	}
+	return e.options.ExportedAt
+}
+
 // exportJSON exports messages as JSON.
 func (e *Exporter) exportJSON(messages []agent.Message, writer io.Writer) error {
 	output := struct {
@@ -72,7 +138,7 @@ func (e *Exporter) exportJSON(messages []agent.Message, writer io.Writer) error
 		Summary    *ExportSummary  `json:"summary,omitempty"`
 	}{
 		Title:      e.options.Title,
-		ExportedAt: time.Now().Format(time.RFC3339),
+		ExportedAt: e.exportedAt().Format(time.RFC3339),
 		Messages:   messages,
 	}
 
@@ -98,7 +164,7 @@ func (e *Exporter) exportMarkdown(messages []agent.Message, writer io.Writer) er
 
 	// Export metadata
 	sb.WriteString("*Exported: ")
-	sb.WriteString(time.Now().Format("2006-01-02 15:04:05"))
+	sb.WriteString(e.exportedAt().Format("2006-01-02 15:04:05"))
 	sb.WriteString("*\n\n")
 
 	// Summary
@@ -117,7 +183,7 @@ func (e *Exporter) exportMarkdown(messages []agent.Message, writer io.Writer) er
 
 	for _, msg := range messages {
 		// Agent/System badge
-		if msg.Role == "system" {
+		if msg.Role == agent.RoleSystem {
 			sb.WriteString("### [SYSTEM]")
 		} else {
 			sb.WriteString("### ")
@@ -172,6 +238,7 @@ func (e *Exporter) exportHTML(messages []agent.Message, writer io.Writer) error
 	// CSS
 	sb.WriteString("  <style>\n")
 	sb.WriteString(getCSS())
+	sb.WriteString(agentColorCSS())
 	sb.WriteString("  </style>\n")
 	sb.WriteString("</head>\n")
 	sb.WriteString("<body>\n")
@@ -180,7 +247,7 @@ func (e *Exporter) exportHTML(messages []agent.Message, writer io.Writer) error
 	sb.WriteString("  <div class=\"container\">\n")
 	sb.WriteString("    <header>\n")
 	sb.WriteString(fmt.Sprintf("      <h1>%s</h1>\n", html.EscapeString(title)))
-	sb.WriteString(fmt.Sprintf("      <p class=\"export-date\">Exported: %s</p>\n", time.Now().Format("2006-01-02 15:04:05")))
+	sb.WriteString(fmt.Sprintf("      <p class=\"export-date\">Exported: %s</p>\n", e.exportedAt().Format("2006-01-02 15:04:05")))
 	sb.WriteString("    </header>\n\n")
 
 	// Summary
@@ -201,36 +268,39 @@ func (e *Exporter) exportHTML(messages []agent.Message, writer io.Writer) error
 	sb.WriteString("    <div class=\"conversation\">\n")
 	sb.WriteString("      <h2>Conversation</h2>\n")
 
+	colors := make(map[string]int)
 	for _, msg := range messages {
-		roleClass := "message-agent"
-		if msg.Role == "system" {
-			roleClass = "message-system"
+		if msg.Role == agent.RoleSystem && !isHostMessage(msg) {
+			e.writeHTMLSystemMessage(&sb, msg)
+			continue
+		}
+
+		var roleClass string
+		name := msg.AgentName
+		if isHostMessage(msg) {
+			roleClass = "message-host"
+			name = "HOST"
+		} else {
+			// Agents get the TUI colors in the order they first speak
+			color, ok := colors[msg.AgentName]
+			if !ok {
+				color = len(colors) % len(agentPalette)
+				colors[msg.AgentName] = color
+			}
+			roleClass = fmt.Sprintf("message-agent agent-color-%d", color)
 		}
 
 		sb.WriteString(fmt.Sprintf("      <div class=\"message %s\">\n", roleClass))
 
 		// Header
 		sb.WriteString("        <div class=\"message-header\">\n")
-		if msg.Role == "system" {
-			sb.WriteString("          <span class=\"agent-name system\">SYSTEM</span>\n")
-		} else {
-			sb.WriteString(fmt.Sprintf("          <span class=\"agent-name\">%s</span>\n", html.EscapeString(msg.AgentName)))
-		}
-
-		if e.options.IncludeTimestamps {
-			timestamp := time.Unix(msg.Timestamp, 0).Format("15:04:05")
-			sb.WriteString(fmt.Sprintf("          <span class=\"timestamp\">%s</span>\n", timestamp))
-		}
+		sb.WriteString(fmt.Sprintf("          <span class=\"agent-name\">%s</span>\n", html.EscapeString(name)))
+		e.writeHTMLTimestamp(&sb, msg)
 		sb.WriteString("        </div>\n")
 
 		// Content
 		sb.WriteString("        <div class=\"message-content\">\n")
-		// Convert newlines to <br> tags
-		content := html.EscapeString(msg.Content)
-		content = strings.ReplaceAll(content, "\n", "<br>")
-		sb.WriteString("          ")
-		sb.WriteString(content)
-		sb.WriteString("\n")
+		sb.WriteString(renderHTMLContent(msg.Content))
 		sb.WriteString("        </div>\n")
 
 		// Metrics
@@ -254,6 +324,124 @@ func (e *Exporter) exportHTML(messages []agent.Message, writer io.Writer) error
 	return err
 }
 
+// writeHTMLSystemMessage writes a system message as a collapsed <details>
+// element whose summary shows the message's first line.
+func (e *Exporter) writeHTMLSystemMessage(sb *strings.Builder, msg agent.Message) {
+	preview := strings.TrimSpace(msg.Content)
+	if i := strings.IndexByte(preview, '\n'); i >= 0 {
+		preview = strings.TrimSpace(preview[:i]) + " …"
+	}
+	if runes := []rune(preview); len(runes) > 80 {
+		preview = string(runes[:79]) + "…"
+	}
+
+	sb.WriteString("      <details class=\"message message-system\">\n")
+	sb.WriteString("        <summary class=\"message-header\">\n")
+	sb.WriteString("          <span class=\"agent-name system\">SYSTEM</span>\n")
+	sb.WriteString(fmt.Sprintf("          <span class=\"system-preview\">%s</span>\n", html.EscapeString(preview)))
+	e.writeHTMLTimestamp(sb, msg)
+	sb.WriteString("        </summary>\n")
+	sb.WriteString("        <div class=\"message-content\">\n")
+	sb.WriteString(renderHTMLContent(msg.Content))
+	sb.WriteString("        </div>\n")
+	sb.WriteString("      </details>\n\n")
+}
+
+// writeHTMLTimestamp writes the message time if timestamps are enabled.
+func (e *Exporter) writeHTMLTimestamp(sb *strings.Builder, msg agent.Message) {
+	if e.options.IncludeTimestamps {
+		timestamp := time.Unix(msg.Timestamp, 0).Format("15:04:05")
+		sb.WriteString(fmt.Sprintf("          <span class=\"timestamp\">%s</span>\n", timestamp))
+	}
+}
+
+// isHostMessage reports whether msg is the host's prompt rather than a system notice.
+func isHostMessage(msg agent.Message) bool {
+	return msg.Role == agent.RoleSystem && (msg.AgentID == "host" || msg.AgentName == "HOST")
+}
+
+// renderHTMLContent escapes message content for HTML. Fenced code blocks
+// (```lang ... ```) become <pre><code class="language-lang"> elements so
+// they keep their formatting; other line breaks become <br>.
+func renderHTMLContent(content string) string {
+	var sb strings.Builder
+	var text, code []string
+	inCode := false
+	language := ""
+
+	flushText := func() {
+		// Blank lines next to a code block would only add stray breaks
+		for len(text) > 0 && strings.TrimSpace(text[0]) == "" {
+			text = text[1:]
+		}
+		for len(text) > 0 && strings.TrimSpace(text[len(text)-1]) == "" {
+			text = text[:len(text)-1]
+		}
+		if len(text) > 0 {
+			escaped := make([]string, len(text))
+			for i, line := range text {
+				escaped[i] = html.EscapeString(line)
+			}
+			sb.WriteString("          <p>" + strings.Join(escaped, "<br>") + "</p>\n")
+		}
+		text = nil
+	}
+	flushCode := func() {
+		class := ""
+		if language != "" {
+			class = fmt.Sprintf(" class=\"language-%s\"", html.EscapeString(language))
+		}
+		sb.WriteString(fmt.Sprintf("          <pre><code%s>%s</code></pre>\n", class, html.EscapeString(strings.Join(code, "\n"))))
+		code = nil
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		fence := strings.TrimSpace(line)
+		if strings.HasPrefix(fence, "```") {
+			if inCode {
+				flushCode()
+				inCode = false
+				continue
+			}
+			flushText()
+			inCode = true
+			language = ""
+			if fields := strings.Fields(strings.TrimPrefix(fence, "```")); len(fields) > 0 {
+				language = fields[0]
+			}
+			continue
+		}
+		if inCode {
+			code = append(code, line)
+		} else {
+			text = append(text, line)
+		}
+	}
+
+	// An unterminated fence runs to the end of the message
+	if inCode {
+		flushCode()
+	}
+	flushText()
+	return sb.String()
+}
+
+// agentPalette holds the TUI's agent colors (xterm-256 colors 63, 212, 86,
+// 214, 99, 51, 226, and 201) as hex values.
+var agentPalette = []string{"#5f5fff", "#ff87d7", "#5fffd7", "#ffaf00", "#875fff", "#00ffff", "#ffff00", "#ff00ff"}
+
+// agentColorCSS returns the agent-color-N rules for the HTML export. Agent
+// names are badges in the agent's color, like the console output.
+func agentColorCSS() string {
+	var sb strings.Builder
+	for i, color := range agentPalette {
+		sb.WriteString(fmt.Sprintf("\n    .agent-color-%d { border-left-color: %s; }", i, color))
+		sb.WriteString(fmt.Sprintf("\n    .agent-color-%d .agent-name { background-color: %s; color: #000; padding: 0 6px; border-radius: 3px; }", i, color))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
 // ExportSummary contains summary statistics for an exported conversation.
 type ExportSummary struct {
 	TotalMessages int     `json:"total_messages"`
@@ -351,6 +539,25 @@ func getCSS() string {
       border-left-color: #95a5a6;
       background-color: #fafafa;
     }
+    .message-system summary {
+      cursor: pointer;
+      margin-bottom: 0;
+    }
+    .message-system[open] summary {
+      margin-bottom: 10px;
+    }
+    .message-host {
+      border-left-color: #875fff;
+    }
+    .system-preview {
+      flex: 1;
+      margin: 0 10px;
+      color: #7f8c8d;
+      font-style: italic;
+      overflow: hidden;
+      text-overflow: ellipsis;
+      white-space: nowrap;
+    }
     .message-header {
       display: flex;
       justify-content: space-between;
@@ -375,6 +582,21 @@ func getCSS() string {
       margin: 10px 0;
       line-height: 1.8;
     }
+    .message-content p {
+      margin: 0 0 10px 0;
+    }
+    pre {
+      background-color: #282c34;
+      color: #abb2bf;
+      padding: 12px;
+      border-radius: 6px;
+      overflow-x: auto;
+      line-height: 1.4;
+    }
+    code {
+      font-family: 'SFMono-Regular', Menlo, Consolas, 'Liberation Mono', monospace;
+      font-size: 0.9em;
+    }
     .message-metrics {
       margin-top: 10px;
       padding-top: 10px;
@@ -2,7 +2,11 @@ package export
 
 import (
 	"bytes"
+	"encoding/csv"
 	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -431,3 +435,104 @@ func createTestMessages() []agent.Message {
 		},
 	}
 }
+
+var updateGolden = flag.Bool("update", false, "update golden files in testdata")
+
+func TestExportHTMLGolden(t *testing.T) {
+	start := time.Date(2025, 1, 15, 10, 30, 0, 0, time.Local)
+	messages := []agent.Message{
+		{AgentID: "host", AgentName: "HOST", Content: "How should we parse config files?", Timestamp: start.Unix(), Role: "system"},
+		{AgentID: "claude-1", AgentName: "Claude", AgentType: "claude", Content: "Claude joined the conversation.\nModel: claude-sonnet-4", Timestamp: start.Unix(), Role: "system"},
+		{AgentID: "claude-1", AgentName: "Claude", AgentType: "claude", Content: "Use the standard library:\n\n```go\nif err := yaml.Unmarshal(data, &cfg); err != nil {\n\treturn fmt.Errorf(\"parse: %w\", err)\n}\n```\n\nThen validate <cfg> & apply defaults.", Timestamp: start.Add(5 * time.Second).Unix(), Role: "agent",
+			Metrics: &agent.ResponseMetrics{Duration: 2500 * time.Millisecond, TotalTokens: 120, Cost: 0.0018}},
+		{AgentID: "gemini-1", AgentName: "Gemini", AgentType: "gemini", Content: "Agreed. Unterminated fences still render:\n```\nvalidate(cfg)", Timestamp: start.Add(9 * time.Second).Unix(), Role: "agent"},
+		{AgentID: "claude-1", AgentName: "Claude", AgentType: "claude", Content: "Ship it.", Timestamp: start.Add(12 * time.Second).Unix(), Role: "agent"},
+	}
+
+	var buf bytes.Buffer
+	err := NewExporter(ExportOptions{
+		Format:            FormatHTML,
+		IncludeMetrics:    true,
+		IncludeTimestamps: true,
+		Title:             "Config Parsing",
+		ExportedAt:        start.Add(time.Minute),
+	}).Export(messages, &buf)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	golden := filepath.Join("testdata", "conversation.html")
+	if *updateGolden {
+		if err := os.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with -update to create it): %v", err)
+	}
+	if buf.String() != string(want) {
+		t.Errorf("HTML export does not match %s (run with -update to regenerate):\n%s", golden, buf.String())
+	}
+}
+
+func TestExportHTMLFunction(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportHTML(createTestMessages(), &buf); err != nil {
+		t.Fatalf("ExportHTML failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "<title>AgentPipe Conversation</title>") {
+		t.Error("expected the default title")
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	messages := createTestMessages()
+	messages[0].AgentName = "Host, \"moderator\"" // needs quoting
+	messages[1].Timestamp = time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC).Unix()
+
+	var buf bytes.Buffer
+	if err := ExportCSV(messages, &buf); err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+	if len(records) != len(messages)+1 {
+		t.Fatalf("expected a header and %d rows, got %d records", len(messages), len(records))
+	}
+
+	wantHeader := "timestamp,agent_name,role,model,duration_ms,input_tokens,output_tokens,total_tokens,cost"
+	if got := strings.Join(records[0], ","); got != wantHeader {
+		t.Errorf("unexpected header: %s", got)
+	}
+
+	system := records[1]
+	if system[1] != messages[0].AgentName || system[2] != "system" {
+		t.Errorf("unexpected system row: %q", system)
+	}
+	for i, cell := range system[3:] {
+		if cell != "" {
+			t.Errorf("expected empty metric cells without metrics, column %s = %q", csvHeader[i+3], cell)
+		}
+	}
+
+	want := []string{"2025-01-15T10:30:00Z", "Agent1", "agent", "test-model", "100", "50", "50", "100", "0.001000"}
+	for i := range want {
+		if records[2][i] != want[i] {
+			t.Errorf("column %s: expected %q, got %q", csvHeader[i], want[i], records[2][i])
+		}
+	}
+}
+
+func TestExportCSVFormat(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewExporter(ExportOptions{Format: FormatCSV}).Export(createTestMessages(), &buf); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "timestamp,agent_name,") {
+		t.Errorf("expected CSV output, got:\n%s", buf.String())
+	}
+}
@@ -13,10 +13,19 @@ import (
 
 	"github.com/kevinelliott/agentpipe/internal/bridge"
 	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/theme"
+)
+
+// Chat log file formats accepted by NewChatLogger.
+const (
+	LogFormatText     = "text"
+	LogFormatJSON     = "json"
+	LogFormatMarkdown = "markdown"
 )
 
 type ChatLogger struct {
 	logFile     *os.File
+	transcript  io.Writer // Receives log file entries when there is no log file
 	logFormat   string
 	console     io.Writer
 	agentColors map[string]lipgloss.Style
@@ -26,53 +35,70 @@ type ChatLogger struct {
 	jsonEmitter *bridge.StdoutEmitter // For JSON mode output
 }
 
-var colors = []lipgloss.Color{
-	lipgloss.Color("63"),  // Blue
-	lipgloss.Color("212"), // Pink
-	lipgloss.Color("86"),  // Green
-	lipgloss.Color("214"), // Orange
-	lipgloss.Color("99"),  // Purple
-	lipgloss.Color("51"),  // Cyan
-	lipgloss.Color("226"), // Yellow
-	lipgloss.Color("201"), // Magenta
-}
+// palette is the color theme of console output (see SetTheme)
+var palette theme.Theme
 
 var (
+	systemStyle         lipgloss.Style
+	systemBadgeStyle    lipgloss.Style
+	timestampStyle      lipgloss.Style
+	errorStyle          lipgloss.Style
+	separatorStyle      lipgloss.Style
+	toolStyle           lipgloss.Style
+	toolBadgeStyle      lipgloss.Style
+	moderatorStyle      lipgloss.Style
+	moderatorBadgeStyle lipgloss.Style
+)
+
+func init() {
+	SetTheme(theme.Dark)
+}
+
+// SetTheme sets the colors of console output. It applies to agents that are
+// given a color afterwards, so call it before creating a ChatLogger.
+func SetTheme(t theme.Theme) {
+	palette = t
+
 	systemStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("244")).
-			Italic(true)
+		Foreground(t.Muted).
+		Italic(true)
 
 	systemBadgeStyle = lipgloss.NewStyle().
-				Background(lipgloss.Color("235")).
-				Foreground(lipgloss.Color("244")).
-				Padding(0, 1).
-				MarginRight(1)
+		Background(t.Surface).
+		Foreground(t.Muted).
+		Padding(0, 1).
+		MarginRight(1)
 
 	timestampStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("238"))
+		Foreground(t.Faint)
 
 	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196")).
-			Bold(true)
-
-	// Unused styles commented out to pass linting
-	// errorBadgeStyle = lipgloss.NewStyle().
-	// 		Background(lipgloss.Color("196")).
-	// 		Foreground(lipgloss.Color("255")).
-	// 		Bold(true).
-	// 		Padding(0, 1).
-	// 		MarginRight(1)
+		Foreground(t.Error).
+		Bold(true)
 
 	separatorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("236"))
-
-	// messageBoxStyle = lipgloss.NewStyle().
-	// 		BorderStyle(lipgloss.RoundedBorder()).
-	// 		BorderForeground(lipgloss.Color("238")).
-	// 		PaddingLeft(1).
-	// 		PaddingRight(1).
-	// 		MarginBottom(1)
-)
+		Foreground(t.Separator)
+
+	toolStyle = lipgloss.NewStyle().
+		Foreground(t.Text)
+
+	toolBadgeStyle = lipgloss.NewStyle().
+		Background(t.Faint).
+		Foreground(t.Text).
+		Padding(0, 1).
+		MarginRight(1)
+
+	moderatorStyle = lipgloss.NewStyle().
+		Foreground(t.Warning).
+		Italic(true)
+
+	moderatorBadgeStyle = lipgloss.NewStyle().
+		Background(t.WarningSurface).
+		Foreground(t.BadgeText).
+		Bold(true).
+		Padding(0, 1).
+		MarginRight(1)
+}
 
 func NewChatLogger(logDir string, logFormat string, console io.Writer, showMetrics bool) (*ChatLogger, error) {
 	if logDir == "" {
@@ -91,7 +117,11 @@ func NewChatLogger(logDir string, logFormat string, console io.Writer, showMetri
 
 	// Create log file with timestamp
 	timestamp := time.Now().Format("2006-01-02_15-04-05")
-	logPath := filepath.Join(logDir, fmt.Sprintf("chat_%s.log", timestamp))
+	ext := "log"
+	if logFormat == LogFormatMarkdown {
+		ext = "md"
+	}
+	logPath := filepath.Join(logDir, fmt.Sprintf("chat_%s.%s", timestamp, ext))
 
 	logFile, err := os.Create(logPath)
 	if err != nil {
@@ -113,10 +143,7 @@ func NewChatLogger(logDir string, logFormat string, console io.Writer, showMetri
 		showMetrics: showMetrics,
 	}
 
-	// Write header to log file
-	logger.writeToFile("=== AgentPipe Chat Log ===\n")
-	logger.writeToFile("Started: " + time.Now().Format("2006-01-02 15:04:05") + "\n")
-	logger.writeToFile("=====================================\n\n")
+	logger.writeHeader(time.Now())
 
 	if console != nil {
 		fmt.Fprintf(console, "\n📝 Chat logged to: %s\n", logPath)
@@ -125,6 +152,43 @@ func NewChatLogger(logDir string, logFormat string, console io.Writer, showMetri
 	return logger, nil
 }
 
+// NewTranscriptLogger creates a ChatLogger that renders messages to w instead
+// of creating a log file, e.g. to replay a saved conversation. The text format
+// renders messages with the console styling used during live runs; the json
+// and markdown formats write the same entries as the corresponding log files.
+// A markdown transcript starts with a header showing started.
+func NewTranscriptLogger(w io.Writer, logFormat string, showMetrics bool, started time.Time) *ChatLogger {
+	logger := &ChatLogger{
+		logFormat:   logFormat,
+		agentColors: make(map[string]lipgloss.Style),
+		termWidth:   80,
+		showMetrics: showMetrics,
+	}
+
+	switch logFormat {
+	case LogFormatJSON:
+		logger.transcript = w
+	case LogFormatMarkdown:
+		logger.transcript = w
+		logger.writeHeader(started)
+	default:
+		logger.console = w
+	}
+	return logger
+}
+
+// writeHeader writes the log file header with the conversation start time.
+func (l *ChatLogger) writeHeader(started time.Time) {
+	if l.logFormat == LogFormatMarkdown {
+		l.writeToFile("# AgentPipe Chat Log\n\n")
+		l.writeToFile("**Started:** " + started.Format("2006-01-02 15:04:05") + "\n\n")
+		return
+	}
+	l.writeToFile("=== AgentPipe Chat Log ===\n")
+	l.writeToFile("Started: " + started.Format("2006-01-02 15:04:05") + "\n")
+	l.writeToFile("=====================================\n\n")
+}
+
 // SetJSONEmitter sets the JSON emitter for JSON-only output mode
 func (l *ChatLogger) SetJSONEmitter(emitter *bridge.StdoutEmitter) {
 	l.jsonEmitter = emitter
@@ -136,7 +200,7 @@ func (l *ChatLogger) getAgentColor(agentName string) lipgloss.Style {
 	}
 
 	// Assign a new color
-	color := colors[l.colorIndex%len(colors)]
+	color := palette.AgentColors[l.colorIndex%len(palette.AgentColors)]
 	l.colorIndex++
 
 	style := lipgloss.NewStyle().
@@ -152,7 +216,7 @@ func (l *ChatLogger) getAgentBadgeStyle(agentName string) lipgloss.Style {
 		color := style.GetForeground()
 		return lipgloss.NewStyle().
 			Background(color).
-			Foreground(lipgloss.Color("0")).
+			Foreground(palette.OnColor).
 			Bold(true).
 			Padding(0, 1).
 			MarginRight(1)
@@ -160,8 +224,8 @@ func (l *ChatLogger) getAgentBadgeStyle(agentName string) lipgloss.Style {
 
 	// Default badge
 	return lipgloss.NewStyle().
-		Background(lipgloss.Color("240")).
-		Foreground(lipgloss.Color("255")).
+		Background(palette.Border).
+		Foreground(palette.BadgeText).
 		Padding(0, 1).
 		MarginRight(1)
 }
@@ -207,21 +271,72 @@ func (l *ChatLogger) emitJSONLog(msg agent.Message) {
 
 // writeFileLog writes a message to the log file
 func (l *ChatLogger) writeFileLog(msg agent.Message, timestamp string) {
-	if l.logFile == nil {
+	if l.logFile == nil && l.transcript == nil {
 		return
 	}
 
-	if l.logFormat == "json" {
+	switch l.logFormat {
+	case LogFormatJSON:
 		data, err := json.Marshal(msg)
 		if err == nil {
 			l.writeToFile(string(data) + "\n")
 		}
-	} else {
+	case LogFormatMarkdown:
+		l.writeToFile(l.formatMarkdown(msg, timestamp))
+	default:
 		l.writeToFile(fmt.Sprintf("[%s] %s (%s): %s\n\n",
 			timestamp, msg.AgentName, msg.Role, msg.Content))
 	}
 }
 
+// formatMarkdown renders a message as a Markdown transcript entry. Agent and
+// host messages get a heading and a blockquote; tool output gets a heading and
+// a code block; system and moderator messages are italic lines.
+func (l *ChatLogger) formatMarkdown(msg agent.Message, timestamp string) string {
+	var b strings.Builder
+
+	isHost := msg.Role == agent.RoleSystem && (msg.AgentID == "host" || msg.AgentName == "HOST")
+	if (msg.Role == agent.RoleSystem && !isHost) || msg.Role == agent.RoleModerator {
+		prefix := ""
+		if msg.Role == agent.RoleModerator {
+			prefix = "⚖️ " + msg.AgentName + ": "
+		}
+		for _, line := range strings.Split(strings.TrimSpace(msg.Content), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				fmt.Fprintf(&b, "*[%s] %s%s*\n", timestamp, prefix, line)
+			}
+		}
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	if msg.Role == agent.RoleTool {
+		fmt.Fprintf(&b, "### [%s] 🔧 %s (tool)\n\n", timestamp, msg.AgentName)
+		fmt.Fprintf(&b, "```\n%s\n```\n\n", strings.TrimRight(msg.Content, "\n"))
+		return b.String()
+	}
+
+	name := msg.AgentName
+	if msg.AgentType != "" {
+		name = fmt.Sprintf("%s (%s)", msg.AgentName, msg.AgentType)
+	}
+	fmt.Fprintf(&b, "### [%s] %s\n\n", timestamp, name)
+
+	for _, line := range strings.Split(strings.TrimRight(msg.Content, "\n"), "\n") {
+		if line == "" {
+			b.WriteString(">\n")
+		} else {
+			b.WriteString("> " + line + "\n")
+		}
+	}
+	b.WriteString("\n")
+
+	if l.showMetrics && msg.Metrics != nil {
+		fmt.Fprintf(&b, "<sub>%s</sub>\n\n", formatMetrics(msg.Metrics))
+	}
+	return b.String()
+}
+
 // writeConsoleLog writes a formatted message to the console
 func (l *ChatLogger) writeConsoleLog(msg agent.Message, timestamp string) {
 	if l.console == nil {
@@ -238,12 +353,16 @@ func (l *ChatLogger) writeConsoleLog(msg agent.Message, timestamp string) {
 	output.WriteString(timestampStyle.Render("🕐 " + timestamp + " "))
 
 	// Format agent name with badge
-	isHost := msg.Role == "system" && (msg.AgentID == "host" || msg.AgentName == "HOST")
-	isSystemMsg := msg.Role == "system" && !isHost
+	isHost := msg.Role == agent.RoleSystem && (msg.AgentID == "host" || msg.AgentName == "HOST")
 
-	if isSystemMsg {
+	switch {
+	case msg.Role == agent.RoleSystem && !isHost:
 		l.writeSystemMessage(&output, msg)
-	} else {
+	case msg.Role == agent.RoleTool:
+		l.writeToolMessage(&output, msg)
+	case msg.Role == agent.RoleModerator:
+		l.writeModeratorMessage(&output, msg)
+	default:
 		l.writeAgentMessage(&output, msg, isHost)
 	}
 
@@ -257,6 +376,24 @@ func (l *ChatLogger) writeSystemMessage(output *strings.Builder, msg agent.Messa
 	output.WriteString(systemStyle.Render(msg.Content))
 }
 
+// writeToolMessage formats and writes a tool output message, muted so it
+// stands apart from the agents' responses
+func (l *ChatLogger) writeToolMessage(output *strings.Builder, msg agent.Message) {
+	output.WriteString(toolBadgeStyle.Render(" 🔧 " + msg.AgentName + " "))
+	output.WriteString("\n\n")
+
+	for _, line := range strings.Split(l.wrapText(msg.Content, 2), "\n") {
+		output.WriteString(toolStyle.Render(line))
+		output.WriteString("\n")
+	}
+}
+
+// writeModeratorMessage formats and writes a moderator's decision
+func (l *ChatLogger) writeModeratorMessage(output *strings.Builder, msg agent.Message) {
+	output.WriteString(moderatorBadgeStyle.Render(" ⚖️ " + msg.AgentName + " "))
+	output.WriteString(moderatorStyle.Render(msg.Content))
+}
+
 // writeAgentMessage formats and writes an agent message
 func (l *ChatLogger) writeAgentMessage(output *strings.Builder, msg agent.Message, isHost bool) {
 	var badgeStyle, contentStyle lipgloss.Style
@@ -294,13 +431,13 @@ func (l *ChatLogger) writeAgentMessage(output *strings.Builder, msg agent.Messag
 // getHostStyles returns the badge and content styles for host messages
 func (l *ChatLogger) getHostStyles() (lipgloss.Style, lipgloss.Style) {
 	badgeStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color("99")). // Purple
-		Foreground(lipgloss.Color("0")).
+		Background(palette.Secondary).
+		Foreground(palette.OnColor).
 		Bold(true).
 		Padding(0, 1).
 		MarginRight(1)
 	contentStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("99")).
+		Foreground(palette.Secondary).
 		Bold(true)
 	return badgeStyle, contentStyle
 }
@@ -308,16 +445,22 @@ func (l *ChatLogger) getHostStyles() (lipgloss.Style, lipgloss.Style) {
 // writeMetrics formats and writes metrics to the output
 func (l *ChatLogger) writeMetrics(output *strings.Builder, metrics *agent.ResponseMetrics) {
 	metricsStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
+		Foreground(palette.Border).
 		Italic(true)
 
-	metricsStr := fmt.Sprintf("(%.2fs, %d tokens, $%.6f)",
+	output.WriteString(" ")
+	output.WriteString(metricsStyle.Render(formatMetrics(metrics)))
+}
+
+// formatMetrics summarizes a response's duration, tokens, and cost.
+func formatMetrics(metrics *agent.ResponseMetrics) string {
+	if metrics.Cached {
+		return fmt.Sprintf("(cached, %d tokens)", metrics.TotalTokens)
+	}
+	return fmt.Sprintf("(%.2fs, %d tokens, $%.6f)",
 		metrics.Duration.Seconds(),
 		metrics.TotalTokens,
 		metrics.Cost)
-
-	output.WriteString(" ")
-	output.WriteString(metricsStyle.Render(metricsStr))
 }
 
 func (l *ChatLogger) LogError(agentName string, err error) {
@@ -339,8 +482,12 @@ func (l *ChatLogger) LogError(agentName string, err error) {
 	}
 
 	// Write to file
-	if l.logFile != nil {
-		l.writeToFile(fmt.Sprintf("[%s] ERROR - %s: %v\n", timestamp, agentName, err))
+	if l.logFile != nil || l.transcript != nil {
+		if l.logFormat == LogFormatMarkdown {
+			l.writeToFile(fmt.Sprintf("**[%s] ERROR - %s:** %v\n\n", timestamp, agentName, err))
+		} else {
+			l.writeToFile(fmt.Sprintf("[%s] ERROR - %s: %v\n", timestamp, agentName, err))
+		}
 	}
 
 	// Write to console
@@ -360,7 +507,7 @@ func (l *ChatLogger) LogSystem(message string) {
 		AgentName: "System",
 		Content:   message,
 		Timestamp: time.Now().Unix(),
-		Role:      "system",
+		Role:      agent.RoleSystem,
 	}
 	l.LogMessage(msg)
 }
@@ -416,6 +563,12 @@ func (l *ChatLogger) wrapText(text string, indent int) string {
 }
 
 func (l *ChatLogger) writeToFile(content string) {
+	if l.transcript != nil {
+		if _, err := io.WriteString(l.transcript, content); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing transcript: %v\n", err)
+		}
+		return
+	}
 	if l.logFile != nil {
 		if _, err := l.logFile.WriteString(content); err != nil {
 			fmt.Fprintf(os.Stderr, "Error writing to log file: %v\n", err)
@@ -428,8 +581,13 @@ func (l *ChatLogger) writeToFile(content string) {
 
 func (l *ChatLogger) Close() {
 	if l.logFile != nil {
-		l.writeToFile("\n=== Chat Ended ===\n")
-		l.writeToFile("Ended: " + time.Now().Format("2006-01-02 15:04:05") + "\n")
+		if l.logFormat == LogFormatMarkdown {
+			l.writeToFile("---\n\n")
+			l.writeToFile("**Ended:** " + time.Now().Format("2006-01-02 15:04:05") + "\n")
+		} else {
+			l.writeToFile("\n=== Chat Ended ===\n")
+			l.writeToFile("Ended: " + time.Now().Format("2006-01-02 15:04:05") + "\n")
+		}
 		l.logFile.Close()
 	}
 }
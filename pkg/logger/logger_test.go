@@ -14,6 +14,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/theme"
 )
 
 func TestNewChatLoggerWithoutLogDir(t *testing.T) {
@@ -476,7 +477,7 @@ func TestColorCycling(t *testing.T) {
 	}
 
 	// Create more agents than available colors
-	numAgents := len(colors) + 3
+	numAgents := len(palette.AgentColors) + 3
 	colorsSeen := make(map[string]int)
 
 	for i := 0; i < numAgents; i++ {
@@ -506,6 +507,22 @@ func TestColorCycling(t *testing.T) {
 	}
 }
 
+func TestSetTheme(t *testing.T) {
+	SetTheme(theme.Light)
+	t.Cleanup(func() { SetTheme(theme.Dark) })
+
+	logger := &ChatLogger{agentColors: make(map[string]lipgloss.Style)}
+	if got := logger.getAgentColor("Alice").GetForeground(); got != theme.Light.AgentColors[0] {
+		t.Errorf("expected first agent color %v, got %v", theme.Light.AgentColors[0], got)
+	}
+	if got := systemStyle.GetForeground(); got != theme.Light.Muted {
+		t.Errorf("expected system color %v, got %v", theme.Light.Muted, got)
+	}
+	if got := moderatorBadgeStyle.GetBackground(); got != theme.Light.WarningSurface {
+		t.Errorf("expected moderator badge background %v, got %v", theme.Light.WarningSurface, got)
+	}
+}
+
 func TestLoggerWithNilConsole(t *testing.T) {
 	tempDir := t.TempDir()
 
@@ -550,3 +567,138 @@ func TestMinFunction(t *testing.T) {
 		}
 	}
 }
+
+func TestLogMessageToFileMarkdown(t *testing.T) {
+	tempDir := t.TempDir()
+
+	logger, err := NewChatLogger(tempDir, LogFormatMarkdown, nil, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	now := time.Now().Unix()
+	logger.LogMessage(agent.Message{AgentID: "host", AgentName: "HOST", Content: "Discuss testing", Timestamp: now, Role: "system"})
+	logger.LogMessage(agent.Message{
+		AgentID:   "claude-1",
+		AgentName: "Claude",
+		AgentType: "claude",
+		Content:   "First paragraph.\n\n```go\nfmt.Println(\"hi\")\n```",
+		Timestamp: now,
+		Role:      "agent",
+		Metrics:   &agent.ResponseMetrics{Duration: 1500 * time.Millisecond, TotalTokens: 42, Cost: 0.0012},
+	})
+	logger.LogSystem("Maximum turns reached.")
+	logger.Close()
+
+	files, _ := os.ReadDir(tempDir)
+	if len(files) != 1 || filepath.Ext(files[0].Name()) != ".md" {
+		t.Fatalf("expected a single .md log file, got %v", files)
+	}
+	content, err := os.ReadFile(filepath.Join(tempDir, files[0].Name()))
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+
+	var headings, quoted, italic []string
+	var sawStarted, sawMetrics bool
+	for _, line := range strings.Split(string(content), "\n") {
+		switch {
+		case strings.HasPrefix(line, "# "):
+			headings = append(headings, line)
+		case strings.HasPrefix(line, "### "):
+			headings = append(headings, line)
+		case strings.HasPrefix(line, ">"):
+			quoted = append(quoted, line)
+		case strings.HasPrefix(line, "*[") && strings.HasSuffix(line, "*"):
+			italic = append(italic, line)
+		case strings.HasPrefix(line, "**Started:** "):
+			sawStarted = true
+		case line == "<sub>(1.50s, 42 tokens, $0.001200)</sub>":
+			sawMetrics = true
+		}
+	}
+
+	ts := time.Unix(now, 0).Format("15:04:05")
+	wantHeadings := []string{"# AgentPipe Chat Log", "### [" + ts + "] HOST", "### [" + ts + "] Claude (claude)"}
+	if strings.Join(headings, "|") != strings.Join(wantHeadings, "|") {
+		t.Errorf("expected headings %q, got %q", wantHeadings, headings)
+	}
+	if !sawStarted {
+		t.Error("expected a start time under the title")
+	}
+	wantQuoted := []string{"> Discuss testing", "> First paragraph.", ">", "> ```go", "> fmt.Println(\"hi\")", "> ```"}
+	if strings.Join(quoted, "|") != strings.Join(wantQuoted, "|") {
+		t.Errorf("expected blockquoted content %q, got %q", wantQuoted, quoted)
+	}
+	if len(italic) != 1 || !strings.Contains(italic[0], "Maximum turns reached.") {
+		t.Errorf("expected the system message as an italic line, got %q", italic)
+	}
+	if !sawMetrics {
+		t.Errorf("expected a metrics line, got:\n%s", content)
+	}
+}
+
+func TestLogMessageToolAndModeratorRoles(t *testing.T) {
+	tool := agent.Message{AgentID: "lint", AgentName: "Linter", Content: "main.go:3: unused variable", Timestamp: time.Now().Unix(), Role: agent.RoleTool}
+	moderator := agent.Message{AgentID: "chair", AgentName: "Chair", Content: "Calls on Bob", Timestamp: time.Now().Unix(), Role: agent.RoleModerator}
+
+	var buf bytes.Buffer
+	logger := NewTranscriptLogger(&buf, LogFormatText, false, time.Now())
+	logger.LogMessage(tool)
+	logger.LogMessage(moderator)
+
+	output := buf.String()
+	for _, want := range []string{"🔧 Linter", "main.go:3: unused variable", "⚖️ Chair", "Calls on Bob"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected console output to contain %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "SYSTEM") {
+		t.Errorf("tool and moderator messages should not be rendered as system messages:\n%s", output)
+	}
+
+	ts := time.Unix(tool.Timestamp, 0).Format("15:04:05")
+	buf.Reset()
+	logger = NewTranscriptLogger(&buf, LogFormatMarkdown, false, time.Now())
+	logger.LogMessage(tool)
+	logger.LogMessage(moderator)
+
+	markdown := buf.String()
+	if !strings.Contains(markdown, "### ["+ts+"] 🔧 Linter (tool)\n\n```\nmain.go:3: unused variable\n```\n") {
+		t.Errorf("expected tool output as a code block, got:\n%s", markdown)
+	}
+	if !strings.Contains(markdown, "*["+ts+"] ⚖️ Chair: Calls on Bob*\n") {
+		t.Errorf("expected the moderator's decision as an italic line, got:\n%s", markdown)
+	}
+}
+
+func TestLogMessageRolesRoundTrip(t *testing.T) {
+	for _, format := range []string{LogFormatText, LogFormatJSON} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger, err := NewChatLogger("", format, nil, false)
+			if err != nil {
+				t.Fatalf("NewChatLogger failed: %v", err)
+			}
+			logger.transcript = &buf
+
+			roles := []string{agent.RoleAgent, agent.RoleUser, agent.RoleSystem, agent.RoleTool, agent.RoleModerator}
+			for _, role := range roles {
+				logger.LogMessage(agent.Message{AgentID: role + "-id", AgentName: "Name-" + role, Content: "from " + role, Timestamp: time.Now().Unix(), Role: role})
+			}
+
+			messages, err := ParseLog(&buf)
+			if err != nil {
+				t.Fatalf("ParseLog failed: %v", err)
+			}
+			if len(messages) != len(roles) {
+				t.Fatalf("expected %d messages, got %d: %+v", len(roles), len(messages), messages)
+			}
+			for i, role := range roles {
+				if messages[i].Role != role || messages[i].Content != "from "+role {
+					t.Errorf("message %d: got role %q content %q, want role %q", i, messages[i].Role, messages[i].Content, role)
+				}
+			}
+		})
+	}
+}
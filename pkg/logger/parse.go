@@ -0,0 +1,122 @@
+package logger
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+var (
+	// logMessageLine matches the first line of a text-format message:
+	// "[15:04:05] Name (role): content"
+	logMessageLine = regexp.MustCompile(`^\[(\d{2}:\d{2}:\d{2})\] (.+?) \((agent|system|user|tool|moderator)\): ?(.*)$`)
+	// logErrorLine matches an error entry: "[15:04:05] ERROR - Name: error"
+	logErrorLine = regexp.MustCompile(`^\[\d{2}:\d{2}:\d{2}\] ERROR - `)
+)
+
+// ParseLog reads a chat log written by ChatLogger and returns its messages.
+// Both the text and JSON log formats are supported.
+//
+// Text logs only record the time of day for each message, so timestamps are
+// rebuilt from the "Started:" header date, rolling over to the next day when the
+// clock wraps. Agent IDs are not recorded in text logs; the agent name is used
+// instead. Error entries are skipped.
+func ParseLog(r io.Reader) ([]agent.Message, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	var (
+		messages []agent.Message
+		current  *agent.Message
+		content  []string
+		day      time.Time
+		lastTime time.Time
+	)
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		current.Content = strings.TrimRight(strings.Join(content, "\n"), "\n")
+		messages = append(messages, *current)
+		current = nil
+		content = nil
+	}
+
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+
+		// JSON-format entries are one message per line
+		if current == nil && strings.HasPrefix(line, "{") {
+			var msg agent.Message
+			if err := json.Unmarshal([]byte(line), &msg); err != nil {
+				return nil, fmt.Errorf("line %d: invalid JSON message: %w", lineNum, err)
+			}
+			messages = append(messages, msg)
+			continue
+		}
+
+		if started, ok := strings.CutPrefix(line, "Started: "); ok && current == nil {
+			if t, err := time.ParseInLocation("2006-01-02 15:04:05", strings.TrimSpace(started), time.Local); err == nil {
+				day = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+			}
+			continue
+		}
+
+		if m := logMessageLine.FindStringSubmatch(line); m != nil {
+			flush()
+
+			ts := parseLogTime(m[1], day)
+			if !lastTime.IsZero() && ts.Before(lastTime) {
+				// The clock wrapped past midnight
+				day = day.AddDate(0, 0, 1)
+				ts = ts.AddDate(0, 0, 1)
+			}
+			lastTime = ts
+
+			current = &agent.Message{
+				AgentID:   m[2],
+				AgentName: m[2],
+				Role:      m[3],
+				Timestamp: ts.Unix(),
+			}
+			content = []string{m[4]}
+			continue
+		}
+
+		if logErrorLine.MatchString(line) {
+			flush()
+			continue
+		}
+
+		if current != nil {
+			content = append(content, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read log: %w", err)
+	}
+	flush()
+
+	return messages, nil
+}
+
+// parseLogTime combines an "HH:MM:SS" clock time with day.
+func parseLogTime(clock string, day time.Time) time.Time {
+	t, err := time.Parse("15:04:05", clock)
+	if err != nil {
+		return day
+	}
+	if day.IsZero() {
+		day = time.Date(1970, 1, 1, 0, 0, 0, 0, time.Local)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.Local)
+}
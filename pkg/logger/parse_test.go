@@ -0,0 +1,98 @@
+package logger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+func TestParseLogText(t *testing.T) {
+	log := `=== AgentPipe Chat Log ===
+Started: 2025-01-15 23:59:50
+=====================================
+
+[23:59:50] HOST (system): Discuss tabs vs spaces
+
+[23:59:58] Claude (agent): Spaces.
+
+Consistency matters more than the choice.
+
+[23:59:59] ERROR - Gemini: timeout
+[00:00:05] Gemini (agent): Tabs (obviously): they are configurable.
+
+`
+	messages, err := ParseLog(strings.NewReader(log))
+	if err != nil {
+		t.Fatalf("ParseLog failed: %v", err)
+	}
+	if len(messages) != 3 {
+		t.Fatalf("expected 3 messages, got %d: %+v", len(messages), messages)
+	}
+
+	if messages[0].AgentName != "HOST" || messages[0].Role != "system" || messages[0].Content != "Discuss tabs vs spaces" {
+		t.Errorf("unexpected first message: %+v", messages[0])
+	}
+	if messages[1].Content != "Spaces.\n\nConsistency matters more than the choice." {
+		t.Errorf("multi-line content not preserved: %q", messages[1].Content)
+	}
+	if messages[2].AgentName != "Gemini" || messages[2].Content != "Tabs (obviously): they are configurable." {
+		t.Errorf("unexpected third message: %+v", messages[2])
+	}
+
+	want := time.Date(2025, 1, 15, 23, 59, 58, 0, time.Local).Unix()
+	if messages[1].Timestamp != want {
+		t.Errorf("expected timestamp %d, got %d", want, messages[1].Timestamp)
+	}
+	// The clock wrapped past midnight
+	want = time.Date(2025, 1, 16, 0, 0, 5, 0, time.Local).Unix()
+	if messages[2].Timestamp != want {
+		t.Errorf("expected next-day timestamp %d, got %d", want, messages[2].Timestamp)
+	}
+}
+
+func TestParseLogRoundTripsChatLogger(t *testing.T) {
+	for _, format := range []string{"text", "json"} {
+		t.Run(format, func(t *testing.T) {
+			dir := t.TempDir()
+			l, err := NewChatLogger(dir, format, nil, false)
+			if err != nil {
+				t.Fatalf("NewChatLogger failed: %v", err)
+			}
+
+			ts := time.Now().Unix()
+			l.LogMessage(agent.Message{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Topic", Timestamp: ts})
+			l.LogMessage(agent.Message{AgentID: "claude-0", AgentName: "Claude", Role: "agent", Content: "Line one\nLine two", Timestamp: ts})
+			l.LogError("Gemini", errors.New("boom"))
+			l.Close()
+
+			files, _ := os.ReadDir(dir)
+			f, err := os.Open(filepath.Join(dir, files[0].Name()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			messages, err := ParseLog(f)
+			if err != nil {
+				t.Fatalf("ParseLog failed: %v", err)
+			}
+			if len(messages) != 2 {
+				t.Fatalf("expected 2 messages, got %d: %+v", len(messages), messages)
+			}
+			if messages[1].AgentName != "Claude" || messages[1].Content != "Line one\nLine two" || messages[1].Timestamp != ts {
+				t.Errorf("message not preserved: %+v", messages[1])
+			}
+		})
+	}
+}
+
+func TestParseLogInvalidJSON(t *testing.T) {
+	if _, err := ParseLog(strings.NewReader("{not json}\n")); err == nil {
+		t.Error("expected error for invalid JSON line")
+	}
+}
@@ -14,7 +14,7 @@ const (
 
 // Metrics contains all Prometheus metrics for AgentPipe.
 type Metrics struct {
-	// AgentRequests counts total agent requests by agent name and status (success/error)
+	// AgentRequests counts total agent requests by agent name and status (success/error/cached)
 	AgentRequests *prometheus.CounterVec
 
 	// AgentRequestDuration tracks agent request duration in seconds
@@ -35,6 +35,9 @@ type Metrics struct {
 	// ConversationTurns counts total conversation turns by mode
 	ConversationTurns *prometheus.CounterVec
 
+	// ConversationDuration tracks whole-conversation duration in seconds by mode
+	ConversationDuration *prometheus.HistogramVec
+
 	// MessageSize tracks message size distribution in bytes
 	MessageSize *prometheus.HistogramVec
 
@@ -129,6 +132,16 @@ func NewMetrics(registry prometheus.Registerer) *Metrics {
 			[]string{"mode"},
 		),
 
+		ConversationDuration: promauto.With(registry).NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: Namespace,
+				Name:      "conversation_duration_seconds",
+				Help:      "Conversation duration in seconds by mode",
+				Buckets:   []float64{1, 5, 10, 30, 60, 120, 300, 600, 1200, 1800, 3600},
+			},
+			[]string{"mode"},
+		),
+
 		MessageSize: promauto.With(registry).NewHistogramVec(
 			prometheus.HistogramOpts{
 				Namespace: Namespace,
@@ -201,6 +214,11 @@ func (m *Metrics) RecordConversationTurn(mode string) {
 	m.ConversationTurns.WithLabelValues(mode).Inc()
 }
 
+// RecordConversationDuration records the duration of a whole conversation in seconds.
+func (m *Metrics) RecordConversationDuration(mode string, seconds float64) {
+	m.ConversationDuration.WithLabelValues(mode).Observe(seconds)
+}
+
 // RecordMessageSize records the size of a message in bytes.
 func (m *Metrics) RecordMessageSize(agentName, direction string, sizeBytes int) {
 	m.MessageSize.WithLabelValues(agentName, direction).Observe(float64(sizeBytes))
@@ -225,6 +243,7 @@ func (m *Metrics) Reset() {
 	m.AgentErrors.Reset()
 	m.ActiveConversations.Set(0)
 	m.ConversationTurns.Reset()
+	m.ConversationDuration.Reset()
 	m.MessageSize.Reset()
 	m.RetryAttempts.Reset()
 	m.RateLimitHits.Reset()
@@ -37,6 +37,9 @@ func TestNewMetrics(t *testing.T) {
 	if m.ConversationTurns == nil {
 		t.Error("ConversationTurns should be initialized")
 	}
+	if m.ConversationDuration == nil {
+		t.Error("ConversationDuration should be initialized")
+	}
 	if m.MessageSize == nil {
 		t.Error("MessageSize should be initialized")
 	}
@@ -198,6 +201,25 @@ func TestRecordConversationTurn(t *testing.T) {
 	}
 }
 
+// TestRecordConversationDuration tests recording conversation durations
+func TestRecordConversationDuration(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	m := NewMetrics(registry)
+
+	m.RecordConversationDuration("round-robin", 42)
+	m.RecordConversationDuration("round-robin", 900)
+	m.RecordConversationDuration("reactive", 3)
+
+	if count := testutil.CollectAndCount(m.ConversationDuration); count != 2 {
+		t.Errorf("Expected 2 mode series, got %d", count)
+	}
+
+	m.Reset()
+	if count := testutil.CollectAndCount(m.ConversationDuration); count != 0 {
+		t.Errorf("Expected ConversationDuration to be reset, got %d series", count)
+	}
+}
+
 // TestRecordMessageSize tests recording message sizes
 func TestRecordMessageSize(t *testing.T) {
 	registry := prometheus.NewRegistry()
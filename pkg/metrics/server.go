@@ -3,6 +3,7 @@ package metrics
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"time"
 
@@ -91,6 +92,20 @@ func (s *Server) Start() error {
 	return nil
 }
 
+// Serve serves metrics on an existing listener, which lets callers bind the
+// address first and report a failure to listen before serving starts.
+// This method blocks until the server is stopped or encounters an error.
+func (s *Server) Serve(l net.Listener) error {
+	log.WithField("addr", l.Addr().String()).Info("starting metrics server")
+
+	if err := s.server.Serve(l); err != nil && err != http.ErrServerClosed {
+		log.WithError(err).Error("metrics server failed")
+		return fmt.Errorf("metrics server failed: %w", err)
+	}
+
+	return nil
+}
+
 // Stop gracefully stops the metrics server.
 func (s *Server) Stop(ctx context.Context) error {
 	log.Info("stopping metrics server")
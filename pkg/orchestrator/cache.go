@@ -0,0 +1,137 @@
+package orchestrator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+// DefaultCacheTTL is how long cached responses are reused by default.
+const DefaultCacheTTL = 24 * time.Hour
+
+// ResponseCache stores agent responses on disk so that re-running the same
+// conversation returns the earlier responses instead of calling the agents.
+// Entries are keyed by the agent's ID, type, prompt and model, and the
+// conversation context sent to the agent, and expire after the TTL.
+type ResponseCache struct {
+	dir string
+	ttl time.Duration
+}
+
+// cacheEntry is the on-disk form of a cached response.
+type cacheEntry struct {
+	AgentID   string    `json:"agent_id"`
+	Model     string    `json:"model"`
+	Response  string    `json:"response"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewResponseCache creates a cache in dir. A ttl of 0 uses DefaultCacheTTL.
+func NewResponseCache(dir string, ttl time.Duration) *ResponseCache {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &ResponseCache{dir: dir, ttl: ttl}
+}
+
+// GetDefaultCacheDir returns the default response cache directory.
+// This is ~/.agentpipe/cache by default.
+func GetDefaultCacheDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".agentpipe", "cache"), nil
+}
+
+// Get returns the cached response for key if it exists and has not expired.
+func (c *ResponseCache) Get(key string) (string, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return "", false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return "", false
+	}
+	if time.Since(entry.CreatedAt) > c.ttl {
+		return "", false
+	}
+	return entry.Response, true
+}
+
+// Put stores a response under key.
+func (c *ResponseCache) Put(key, agentID, model, response string) error {
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(cacheEntry{
+		AgentID:   agentID,
+		Model:     model,
+		Response:  response,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	// Write to a temporary file of our own first so readers never see a partial
+	// entry and concurrent runs writing the same key don't clobber each other
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return nil
+}
+
+func (c *ResponseCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// responseCacheKey hashes the agent's ID, type, prompt and model, and the
+// context sent to the agent. Timestamps and metrics are left out so identical
+// conversations in different runs produce the same key.
+func responseCacheKey(a agent.Agent, model string, messages []agent.Message) string {
+	type keyMessage struct {
+		AgentID   string `json:"agent_id"`
+		AgentName string `json:"agent_name"`
+		Role      string `json:"role"`
+		Content   string `json:"content"`
+	}
+
+	history := make([]keyMessage, len(messages))
+	for i, msg := range messages {
+		history[i] = keyMessage{msg.AgentID, msg.AgentName, msg.Role, msg.Content}
+	}
+
+	data, _ := json.Marshal(struct {
+		AgentID   string       `json:"agent_id"`
+		AgentType string       `json:"agent_type"`
+		Prompt    string       `json:"prompt"`
+		Model     string       `json:"model"`
+		Messages  []keyMessage `json:"messages"`
+	}{a.GetID(), a.GetType(), a.GetPrompt(), model, history})
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,98 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+// runCachedConversation runs a one-turn conversation with caching in dir and
+// returns the agent's message.
+func runCachedConversation(t *testing.T, dir string, ttl time.Duration, mock *MockAgent) agent.Message {
+	t.Helper()
+
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		MaxTurns:          1,
+		TurnTimeout:       5 * time.Second,
+		ResponseDelay:     time.Millisecond,
+		RetryInitialDelay: time.Millisecond,
+		InitialPrompt:     "What is 6 x 7?",
+		Cache:             true,
+		CacheDir:          dir,
+		CacheTTL:          ttl,
+	}, nil)
+	orch.AddAgent(mock)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	messages := orch.GetMessages()
+	last := messages[len(messages)-1]
+	if last.Role != "agent" || last.Metrics == nil {
+		t.Fatalf("expected an agent message with metrics, got %+v", last)
+	}
+	return last
+}
+
+func TestResponseCacheHit(t *testing.T) {
+	dir := t.TempDir()
+
+	first := &MockAgent{id: "a", name: "A", agentType: "mock", model: "m1", available: true, sendMessageResp: "42"}
+	msg := runCachedConversation(t, dir, time.Hour, first)
+	if first.callCount != 1 || msg.Metrics.Cached {
+		t.Fatalf("expected the first run to call the agent, got calls=%d cached=%v", first.callCount, msg.Metrics.Cached)
+	}
+
+	second := &MockAgent{id: "a", name: "A", agentType: "mock", model: "m1", available: true, sendMessageResp: "a different answer"}
+	msg = runCachedConversation(t, dir, time.Hour, second)
+	if second.callCount != 0 {
+		t.Errorf("expected a cache hit without calling the agent, got %d calls", second.callCount)
+	}
+	if msg.Content != "42" || !msg.Metrics.Cached || msg.Metrics.Cost != 0 {
+		t.Errorf("expected the cached response marked as cached, got %q (metrics %+v)", msg.Content, msg.Metrics)
+	}
+
+	// A different model is a different key
+	other := &MockAgent{id: "a", name: "A", agentType: "mock", model: "m2", available: true, sendMessageResp: "forty-two"}
+	msg = runCachedConversation(t, dir, time.Hour, other)
+	if other.callCount != 1 || msg.Content != "forty-two" || msg.Metrics.Cached {
+		t.Errorf("expected a cache miss for another model, got calls=%d content=%q", other.callCount, msg.Content)
+	}
+}
+
+func TestResponseCacheExpires(t *testing.T) {
+	dir := t.TempDir()
+
+	runCachedConversation(t, dir, time.Nanosecond, &MockAgent{id: "a", name: "A", agentType: "mock", available: true, sendMessageResp: "old"})
+	time.Sleep(time.Millisecond)
+
+	mock := &MockAgent{id: "a", name: "A", agentType: "mock", available: true, sendMessageResp: "new"}
+	msg := runCachedConversation(t, dir, time.Nanosecond, mock)
+	if mock.callCount != 1 || msg.Content != "new" {
+		t.Errorf("expected an expired entry to be ignored, got calls=%d content=%q", mock.callCount, msg.Content)
+	}
+}
+
+func TestResponseCacheKeyIgnoresTimestamps(t *testing.T) {
+	messages := []agent.Message{{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Hi", Timestamp: 1}}
+	later := []agent.Message{{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Hi", Timestamp: 2}}
+
+	a := &MockAgent{id: "a", agentType: "mock"}
+
+	if responseCacheKey(a, "m", messages) != responseCacheKey(a, "m", later) {
+		t.Error("expected timestamps not to affect the cache key")
+	}
+	if responseCacheKey(a, "m", messages) == responseCacheKey(&MockAgent{id: "b", agentType: "mock"}, "m", messages) {
+		t.Error("expected the agent ID to affect the cache key")
+	}
+	if responseCacheKey(a, "m", messages) == responseCacheKey(&MockAgent{id: "a", agentType: "other"}, "m", messages) {
+		t.Error("expected the agent type to affect the cache key")
+	}
+	if responseCacheKey(a, "m", messages) == responseCacheKey(&MockAgent{id: "a", agentType: "mock", prompt: "Be terse"}, "m", messages) {
+		t.Error("expected the agent prompt to affect the cache key")
+	}
+}
@@ -0,0 +1,73 @@
+package orchestrator
+
+import (
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+// cooldownWeights returns the selection weight of each candidate in reactive
+// mode with a ReactiveCooldown of n. An agent that spoke within the last n
+// agent turns gets no weight while any candidate outside that window remains;
+// the others are weighted by how long ago they last spoke, so quiet agents are
+// more likely to be picked. If every candidate is cooling down, the one that
+// spoke least recently is the most likely.
+func (o *Orchestrator) cooldownWeights(candidates []agent.Agent, n int) []int {
+	since := o.turnsSinceSpoke()
+	quiet := len(o.agents) + n // weight of agents that haven't spoken yet
+
+	turns := make([]int, len(candidates))
+	anyOutside := false
+	for i, a := range candidates {
+		turns[i] = quiet
+		if t, ok := since[a.GetID()]; ok && t < quiet {
+			turns[i] = t
+		}
+		anyOutside = anyOutside || turns[i] > n
+	}
+
+	weights := make([]int, len(candidates))
+	for i, t := range turns {
+		switch {
+		case !anyOutside:
+			weights[i] = t
+		case t > n:
+			weights[i] = t - n
+		}
+	}
+	return weights
+}
+
+// turnsSinceSpoke maps each agent that has responded to the number of agent
+// turns since its latest response (1 = it gave the latest response).
+func (o *Orchestrator) turnsSinceSpoke() map[string]int {
+	messages := o.getMessages()
+	since := make(map[string]int)
+	turn := 0
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role != agent.RoleAgent {
+			continue
+		}
+		turn++
+		if _, seen := since[messages[i].AgentID]; !seen {
+			since[messages[i].AgentID] = turn
+		}
+	}
+	return since
+}
+
+// pickWeighted returns a random index into weights, each chosen in proportion
+// to its weight. The weights must not all be zero.
+func (o *Orchestrator) pickWeighted(weights []int) int {
+	total := 0
+	for _, w := range weights {
+		total += w
+	}
+
+	r := o.rng.Intn(total)
+	for i, w := range weights {
+		if r < w {
+			return i
+		}
+		r -= w
+	}
+	return len(weights) - 1
+}
@@ -0,0 +1,114 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+func TestReactiveCooldownAvoidsRecentSpeakers(t *testing.T) {
+	for _, tt := range []struct {
+		agents   int
+		cooldown int
+	}{
+		{agents: 4, cooldown: 2},
+		{agents: 5, cooldown: 3},
+		{agents: 3, cooldown: 2},
+	} {
+		for seed := int64(1); seed <= 5; seed++ {
+			orch := NewOrchestrator(OrchestratorConfig{
+				Mode:             ModeReactive,
+				MaxTurns:         30,
+				TurnTimeout:      5 * time.Second,
+				ResponseDelay:    time.Millisecond,
+				Seed:             seed,
+				ReactiveCooldown: tt.cooldown,
+			}, nil)
+			for i := 1; i <= tt.agents; i++ {
+				orch.AddAgent(&MockAgent{
+					id:              fmt.Sprintf("agent-%d", i),
+					name:            fmt.Sprintf("Agent%d", i),
+					agentType:       "mock",
+					available:       true,
+					sendMessageResp: "ok",
+				})
+			}
+
+			if err := orch.Start(context.Background()); err != nil {
+				t.Fatalf("Start failed: %v", err)
+			}
+
+			var speakers []string
+			for _, msg := range orch.GetMessages() {
+				if msg.Role == "agent" {
+					speakers = append(speakers, msg.AgentID)
+				}
+			}
+			if len(speakers) != 30 {
+				t.Fatalf("expected 30 responses, got %d", len(speakers))
+			}
+			for i, id := range speakers {
+				for j := i - tt.cooldown; j < i; j++ {
+					if j >= 0 && speakers[j] == id {
+						t.Fatalf("%d agents, cooldown %d, seed %d: %s spoke at turns %d and %d: %v", tt.agents, tt.cooldown, seed, id, j+1, i+1, speakers)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestCooldownWeights(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{Mode: ModeReactive, ReactiveCooldown: 2}, nil)
+	agents := []agent.Agent{
+		&MockAgent{id: "a", name: "A"},
+		&MockAgent{id: "b", name: "B"},
+		&MockAgent{id: "c", name: "C"},
+		&MockAgent{id: "d", name: "D"},
+	}
+	for _, a := range agents {
+		orch.agents = append(orch.agents, a)
+	}
+	for _, id := range []string{"a", "b", "c"} {
+		orch.messages = append(orch.messages, agent.Message{AgentID: id, Role: "agent"})
+	}
+
+	// b and c spoke within the last 2 turns; d never spoke and is favored over a
+	got := orch.cooldownWeights(agents[:3], 2)
+	if fmt.Sprint(got) != "[1 0 0]" {
+		t.Errorf("weights with an agent outside the window = %v, want [1 0 0]", got)
+	}
+	got = orch.cooldownWeights(agents, 2)
+	if fmt.Sprint(got) != "[1 0 0 4]" {
+		t.Errorf("weights with a new agent = %v, want [1 0 0 4]", got)
+	}
+
+	// With every candidate cooling down, the least recent speaker is most likely
+	got = orch.cooldownWeights(agents[1:3], 2)
+	if fmt.Sprint(got) != "[2 1]" {
+		t.Errorf("weights with every candidate cooling down = %v, want [2 1]", got)
+	}
+}
+
+func TestPickWeightedIsReproducibleWithSeed(t *testing.T) {
+	picks := func(seed int64) []int {
+		orch := NewOrchestrator(OrchestratorConfig{Mode: ModeReactive, ReactiveCooldown: 2, Seed: seed}, nil)
+		got := make([]int, 20)
+		for i := range got {
+			got[i] = orch.pickWeighted([]int{1, 2, 3, 4})
+		}
+		return got
+	}
+
+	if first, second := picks(42), picks(42); fmt.Sprint(first) != fmt.Sprint(second) {
+		t.Errorf("expected the same picks for the same seed, got %v and %v", first, second)
+	}
+
+	// Without a seed the orchestrator still has its own random source
+	if got := NewOrchestrator(OrchestratorConfig{Mode: ModeReactive}, nil).pickWeighted([]int{0, 1}); got != 1 {
+		t.Errorf("expected the only weighted index, got %d", got)
+	}
+}
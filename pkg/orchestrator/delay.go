@@ -0,0 +1,40 @@
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+// responseDelay returns the pause after a's turn: its own response delay when
+// it has one, otherwise the configured ResponseDelay, plus a random jitter of
+// up to ResponseJitter. a is nil when no agent took a turn.
+func (o *Orchestrator) responseDelay(a agent.Agent) time.Duration {
+	delay := o.config.ResponseDelay
+	if paced, ok := a.(agent.Paced); ok && paced.GetResponseDelay() > 0 {
+		delay = paced.GetResponseDelay()
+	}
+
+	if jitter := int64(o.config.ResponseJitter); jitter > 0 {
+		delay += time.Duration(o.rng.Int63n(jitter))
+	}
+	return delay
+}
+
+// pause waits out the response delay after a's turn, returning early if ctx
+// is done.
+func (o *Orchestrator) pause(ctx context.Context, a agent.Agent) {
+	delay := o.responseDelay(a)
+	if o.sleep != nil {
+		o.sleep(delay)
+		return
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
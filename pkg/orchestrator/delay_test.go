@@ -0,0 +1,86 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// pacedMockAgent is a MockAgent with its own response delay.
+type pacedMockAgent struct {
+	*MockAgent
+	responseDelay time.Duration
+}
+
+func (a *pacedMockAgent) GetResponseDelay() time.Duration {
+	return a.responseDelay
+}
+
+func TestPerAgentResponseDelay(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      2,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: 50 * time.Millisecond,
+	}, nil)
+	var waited []time.Duration
+	orch.sleep = func(d time.Duration) { waited = append(waited, d) }
+
+	orch.AddAgent(&pacedMockAgent{
+		MockAgent:     &MockAgent{id: "slow", name: "Slow", agentType: "mock", available: true, sendMessageResp: "hmm"},
+		responseDelay: 300 * time.Millisecond,
+	})
+	orch.AddAgent(&MockAgent{id: "default", name: "Default", agentType: "mock", available: true, sendMessageResp: "ok"})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	want := []time.Duration{300 * time.Millisecond, 50 * time.Millisecond, 300 * time.Millisecond, 50 * time.Millisecond}
+	if len(waited) != len(want) {
+		t.Fatalf("expected %d pauses, got %v", len(want), waited)
+	}
+	for i := range want {
+		if waited[i] != want[i] {
+			t.Errorf("pause %d = %s, want %s", i, waited[i], want[i])
+		}
+	}
+}
+
+func TestResponseJitter(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		ResponseDelay:  100 * time.Millisecond,
+		ResponseJitter: 50 * time.Millisecond,
+		Seed:           7,
+	}, nil)
+	paced := &pacedMockAgent{MockAgent: &MockAgent{id: "a1", name: "A1"}, responseDelay: time.Second}
+
+	seen := map[time.Duration]bool{}
+	for i := 0; i < 20; i++ {
+		d := orch.responseDelay(nil)
+		if d < 100*time.Millisecond || d >= 150*time.Millisecond {
+			t.Fatalf("delay %s is outside [100ms, 150ms)", d)
+		}
+		seen[d] = true
+
+		if d := orch.responseDelay(paced); d < time.Second || d >= time.Second+50*time.Millisecond {
+			t.Fatalf("agent delay %s is outside [1s, 1.05s)", d)
+		}
+	}
+	if len(seen) < 2 {
+		t.Error("expected the jitter to vary the delay")
+	}
+}
+
+func TestPauseReturnsWhenCanceled(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{ResponseDelay: time.Minute}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	orch.pause(ctx, nil)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected pause to end when the context is done, took %s", elapsed)
+	}
+}
@@ -0,0 +1,41 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/log"
+)
+
+// durationContext returns the context a conversation runs in: ctx with a
+// MaxDuration deadline, if one is set.
+func (o *Orchestrator) durationContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if o.config.MaxDuration <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, o.config.MaxDuration)
+}
+
+// maxDurationReached reports whether a mode's run ended with runErr because
+// the MaxDuration deadline of runCtx passed, rather than because ctx was
+// canceled, and if so ends the conversation with a system message.
+func (o *Orchestrator) maxDurationReached(ctx, runCtx context.Context, runErr error) bool {
+	if ctx.Err() != nil || !errors.Is(runCtx.Err(), context.DeadlineExceeded) || !errors.Is(runErr, context.DeadlineExceeded) {
+		return false
+	}
+
+	endMsg := "Maximum duration reached. Conversation ended."
+	log.WithFields(map[string]interface{}{
+		"max_duration": o.config.MaxDuration.String(),
+		"elapsed":      time.Since(o.conversationStart).String(),
+	}).Info("maximum duration reached, ending conversation")
+	if o.logger != nil {
+		o.logger.LogSystem(endMsg)
+	}
+	if o.writer != nil {
+		fmt.Fprintln(o.writer, "\n[System] "+endMsg)
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMaxDurationEndsConversation(t *testing.T) {
+	for _, mode := range []ConversationMode{ModeRoundRobin, ModeReactive, ModeFreeForm, ModeModerator} {
+		t.Run(string(mode), func(t *testing.T) {
+			var buf bytes.Buffer
+			orch := NewOrchestrator(OrchestratorConfig{
+				Mode:          mode,
+				MaxTurns:      100,
+				MaxDuration:   150 * time.Millisecond,
+				TurnTimeout:   5 * time.Second,
+				ResponseDelay: time.Millisecond,
+			}, &buf)
+			orch.AddAgent(&MockAgent{id: "a1", name: "A1", agentType: "mock", available: true, sendMessageResp: "A2", sendDelay: 40 * time.Millisecond})
+			orch.AddAgent(&MockAgent{id: "a2", name: "A2", agentType: "mock", available: true, sendMessageResp: "one", sendDelay: 40 * time.Millisecond})
+			orch.AddAgent(&MockAgent{id: "a3", name: "A3", agentType: "mock", available: true, sendMessageResp: "two", sendDelay: 40 * time.Millisecond})
+
+			start := time.Now()
+			err := orch.Start(context.Background())
+			elapsed := time.Since(start)
+
+			if err != nil {
+				t.Fatalf("expected reaching the maximum duration to end the conversation normally, got %v", err)
+			}
+			if elapsed > time.Second {
+				t.Errorf("expected the run to end after about 150ms, took %s", elapsed)
+			}
+			if !strings.Contains(buf.String(), "Maximum duration reached") {
+				t.Errorf("expected the maximum duration to be reported, got:\n%s", buf.String())
+			}
+			if orch.FailedResponses() != 0 {
+				t.Errorf("a turn cut short by the deadline should not count as failed, got %d", orch.FailedResponses())
+			}
+		})
+	}
+}
+
+func TestMaxDurationDoesNotMaskCancellation(t *testing.T) {
+	var buf bytes.Buffer
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxDuration:   time.Minute,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: time.Millisecond,
+	}, &buf)
+	orch.AddAgent(&MockAgent{id: "a1", name: "A1", agentType: "mock", available: true, sendMessageResp: "one", sendDelay: 20 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if err := orch.Start(ctx); err == nil {
+		t.Fatal("expected the caller's cancellation to be returned")
+	}
+	if strings.Contains(buf.String(), "Maximum duration reached") {
+		t.Error("a canceled conversation should not report the maximum duration")
+	}
+}
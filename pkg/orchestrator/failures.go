@@ -0,0 +1,89 @@
+package orchestrator
+
+import (
+	"fmt"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/log"
+)
+
+// recordFailedResponse counts an agent turn that failed after all retries.
+// With MaxConsecutiveFailures set, an agent whose turns fail that many times
+// in a row is disabled for the rest of the run.
+func (o *Orchestrator) recordFailedResponse(a agent.Agent) {
+	o.mu.Lock()
+	o.failedResponses++
+	o.consecutiveFailures[a.GetID()]++
+	failures := o.consecutiveFailures[a.GetID()]
+	disable := o.config.MaxConsecutiveFailures > 0 && failures >= o.config.MaxConsecutiveFailures && !o.disabledAgents[a.GetID()]
+	if disable {
+		o.disabledAgents[a.GetID()] = true
+	}
+	o.mu.Unlock()
+
+	if !disable {
+		return
+	}
+
+	msg := fmt.Sprintf("Agent %s disabled after %d consecutive failures.", a.GetName(), failures)
+	log.WithFields(map[string]interface{}{
+		"agent_id":   a.GetID(),
+		"agent_name": a.GetName(),
+		"failures":   failures,
+	}).Warn("agent disabled after consecutive failures")
+	if o.logger != nil {
+		o.logger.LogSystem(msg)
+	}
+	if o.writer != nil {
+		fmt.Fprintln(o.writer, "\n[System] "+msg)
+	}
+}
+
+// resetFailures clears a's consecutive failure count after a successful turn.
+func (o *Orchestrator) resetFailures(a agent.Agent) {
+	o.mu.Lock()
+	delete(o.consecutiveFailures, a.GetID())
+	o.mu.Unlock()
+}
+
+// AgentDisabled reports whether a was disabled after MaxConsecutiveFailures
+// failed turns in a row. Disabled agents are skipped for the rest of the run.
+// This method is thread-safe.
+func (o *Orchestrator) AgentDisabled(a agent.Agent) bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.disabledAgents[a.GetID()]
+}
+
+// activeAgents returns the agents that are not disabled.
+func (o *Orchestrator) activeAgents(agents []agent.Agent) []agent.Agent {
+	active := make([]agent.Agent, 0, len(agents))
+	for _, a := range agents {
+		if !o.AgentDisabled(a) {
+			active = append(active, a)
+		}
+	}
+	return active
+}
+
+// allDisabled reports whether every one of agents is disabled.
+func (o *Orchestrator) allDisabled(agents []agent.Agent) bool {
+	for _, a := range agents {
+		if !o.AgentDisabled(a) {
+			return false
+		}
+	}
+	return true
+}
+
+// announceAllDisabled reports that the conversation ended because every agent
+// is disabled.
+func (o *Orchestrator) announceAllDisabled() {
+	endMsg := "All agents are disabled. Conversation ended."
+	if o.logger != nil {
+		o.logger.LogSystem(endMsg)
+	}
+	if o.writer != nil {
+		fmt.Fprintln(o.writer, "\n[System] "+endMsg)
+	}
+}
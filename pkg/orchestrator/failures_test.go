@@ -0,0 +1,127 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+// flakyAgent fails every other turn, starting with the first.
+type flakyAgent struct {
+	MockAgent
+}
+
+func (f *flakyAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	f.callCount++
+	if f.callCount%2 == 1 {
+		return "", errors.New("flaky failure")
+	}
+	return "recovered", nil
+}
+
+func newFailureTestOrchestrator(mode ConversationMode, maxTurns int, w io.Writer) *Orchestrator {
+	return NewOrchestrator(OrchestratorConfig{
+		Mode:                   mode,
+		MaxTurns:               maxTurns,
+		TurnTimeout:            5 * time.Second,
+		ResponseDelay:          time.Millisecond,
+		MaxRetries:             0,
+		RetryInitialDelay:      time.Millisecond,
+		MaxConsecutiveFailures: 2,
+		Seed:                   1,
+	}, w)
+}
+
+func TestMaxConsecutiveFailuresDisablesAgent(t *testing.T) {
+	for _, mode := range []ConversationMode{ModeRoundRobin, ModeReactive, ModeFreeForm} {
+		t.Run(string(mode), func(t *testing.T) {
+			var buf bytes.Buffer
+			orch := newFailureTestOrchestrator(mode, 6, &buf)
+
+			failing := &MockAgent{id: "failing", name: "Failing", agentType: "mock", available: true, sendMessageErr: errors.New("auth expired")}
+			working := &MockAgent{id: "working", name: "Working", agentType: "mock", available: true, sendMessageResp: "Still here"}
+			orch.AddAgent(failing)
+			orch.AddAgent(working)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := orch.Start(ctx); err != nil {
+				t.Fatalf("Start failed: %v", err)
+			}
+
+			if failing.callCount != 2 {
+				t.Errorf("expected the failing agent to be called twice before it was disabled, got %d", failing.callCount)
+			}
+			if !orch.AgentDisabled(failing) || orch.AgentDisabled(working) {
+				t.Error("expected only the failing agent to be disabled")
+			}
+			if working.callCount < 3 {
+				t.Errorf("expected the working agent to keep responding, got %d calls", working.callCount)
+			}
+			if n := strings.Count(buf.String(), "Agent Failing disabled after 2 consecutive failures."); n != 1 {
+				t.Errorf("expected the agent to be reported disabled once, got %d times:\n%s", n, buf.String())
+			}
+		})
+	}
+}
+
+func TestMaxConsecutiveFailuresResetsOnSuccess(t *testing.T) {
+	orch := newFailureTestOrchestrator(ModeRoundRobin, 6, nil)
+
+	flaky := &flakyAgent{MockAgent{id: "flaky", name: "Flaky", agentType: "mock", available: true}}
+	orch.AddAgent(flaky)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := orch.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	if orch.AgentDisabled(flaky) {
+		t.Error("an agent that recovers between failures should not be disabled")
+	}
+	if flaky.callCount != 6 {
+		t.Errorf("expected the agent to take every turn, got %d calls", flaky.callCount)
+	}
+}
+
+func TestAllAgentsDisabledEndsConversation(t *testing.T) {
+	var buf bytes.Buffer
+	orch := newFailureTestOrchestrator(ModeRoundRobin, 0, &buf)
+
+	a1 := &MockAgent{id: "a1", name: "A1", agentType: "mock", available: true, sendMessageErr: errors.New("down")}
+	a2 := &MockAgent{id: "a2", name: "A2", agentType: "mock", available: true, sendMessageErr: errors.New("down")}
+	orch.AddAgent(a1)
+	orch.AddAgent(a2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := orch.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "All agents are disabled. Conversation ended.") {
+		t.Errorf("expected the conversation to end once every agent is disabled, got:\n%s", buf.String())
+	}
+	if a1.callCount != 2 || a2.callCount != 2 {
+		t.Errorf("expected 2 calls per agent, got %d and %d", a1.callCount, a2.callCount)
+	}
+	if n := strings.Count(buf.String(), "All agents are disabled."); n != 1 {
+		t.Errorf("expected the end to be announced once, got %d times:\n%s", n, buf.String())
+	}
+
+	// Checking again is silent
+	buf.Reset()
+	if !orch.allDisabled(orch.Agents()) {
+		t.Error("expected every agent to be disabled")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected allDisabled to write nothing, got %q", buf.String())
+	}
+}
@@ -0,0 +1,129 @@
+package orchestrator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/log"
+)
+
+// ResponseFilter inspects an agent response before it is stored or displayed.
+// Filter returns the content to keep, which may be rewritten (e.g., redacted).
+// When blocked is true the response is dropped and replaced with a system notice.
+type ResponseFilter interface {
+	Filter(content string) (filtered string, blocked bool)
+}
+
+// ResponseFilterFunc adapts a function to the ResponseFilter interface.
+type ResponseFilterFunc func(content string) (string, bool)
+
+// Filter calls f(content).
+func (f ResponseFilterFunc) Filter(content string) (string, bool) {
+	return f(content)
+}
+
+// PatternFilter matches responses against a word list and regular expressions.
+// Words match case-insensitively on word boundaries. By default a match blocks
+// the response; with Redact set, matches are replaced with Replacement instead.
+type PatternFilter struct {
+	patterns []*regexp.Regexp
+
+	// Redact replaces matches instead of blocking the response
+	Redact bool
+
+	// Replacement is the text substituted for matches when Redact is set
+	Replacement string
+}
+
+// NewPatternFilter creates a PatternFilter from blocked words and regular expressions.
+func NewPatternFilter(words []string, patterns []string) (*PatternFilter, error) {
+	f := &PatternFilter{Replacement: "[redacted]"}
+
+	for _, word := range words {
+		word = strings.TrimSpace(word)
+		if word == "" {
+			continue
+		}
+		f.patterns = append(f.patterns, regexp.MustCompile(`(?i)\b`+regexp.QuoteMeta(word)+`\b`))
+	}
+
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter pattern %q: %w", pattern, err)
+		}
+		f.patterns = append(f.patterns, re)
+	}
+
+	return f, nil
+}
+
+// Filter blocks or redacts content that matches any word or pattern.
+func (f *PatternFilter) Filter(content string) (string, bool) {
+	for _, re := range f.patterns {
+		if !re.MatchString(content) {
+			continue
+		}
+		if !f.Redact {
+			return content, true
+		}
+		content = re.ReplaceAllLiteralString(content, f.Replacement)
+	}
+	return content, false
+}
+
+// AddResponseFilter registers a filter applied to every agent response.
+// Filters run in the order they are added; a blocking filter stops the chain.
+// This method is thread-safe.
+func (o *Orchestrator) AddResponseFilter(f ResponseFilter) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.filters = append(o.filters, f)
+}
+
+// filterResponse runs content through the registered filters.
+func (o *Orchestrator) filterResponse(content string) (string, bool) {
+	o.mu.RLock()
+	filters := o.filters
+	o.mu.RUnlock()
+
+	for _, f := range filters {
+		var blocked bool
+		content, blocked = f.Filter(content)
+		if blocked {
+			return "", true
+		}
+	}
+	return content, false
+}
+
+// addBlockedNotice records a system notice in place of a blocked response.
+func (o *Orchestrator) addBlockedNotice(a agent.Agent) {
+	log.WithFields(map[string]interface{}{
+		"agent_id":   a.GetID(),
+		"agent_name": a.GetName(),
+	}).Warn("agent response blocked by response filter")
+
+	notice := agent.Message{
+		AgentID:   "system",
+		AgentName: "System",
+		Content:   fmt.Sprintf("A response from %s was blocked by the content filter.", a.GetName()),
+		Timestamp: time.Now().Unix(),
+		Role:      agent.RoleSystem,
+	}
+
+	o.mu.Lock()
+	o.messages = append(o.messages, notice)
+	o.mu.Unlock()
+
+	if o.logger != nil {
+		o.logger.LogMessage(notice)
+	}
+	o.notifyMessage(notice)
+	if o.writer != nil {
+		fmt.Fprintf(o.writer, "\n[System] %s\n", notice.Content)
+	}
+}
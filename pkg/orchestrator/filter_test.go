@@ -0,0 +1,165 @@
+package orchestrator
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+func TestPatternFilter(t *testing.T) {
+	f, err := NewPatternFilter([]string{"darn"}, []string{`\d{3}-\d{2}-\d{4}`})
+	if err != nil {
+		t.Fatalf("NewPatternFilter failed: %v", err)
+	}
+
+	tests := []struct {
+		content     string
+		wantBlocked bool
+	}{
+		{"A perfectly clean answer.", false},
+		{"Well, DARN it.", true},
+		{"Darnell is not a match.", false},
+		{"My SSN is 123-45-6789.", true},
+	}
+	for _, tt := range tests {
+		got, blocked := f.Filter(tt.content)
+		if blocked != tt.wantBlocked {
+			t.Errorf("Filter(%q) blocked = %v, want %v", tt.content, blocked, tt.wantBlocked)
+		}
+		if !blocked && got != tt.content {
+			t.Errorf("Filter(%q) changed clean content to %q", tt.content, got)
+		}
+	}
+
+	f.Redact = true
+	if got, blocked := f.Filter("Darn, the SSN 123-45-6789 leaked."); blocked || got != "[redacted], the SSN [redacted] leaked." {
+		t.Errorf("unexpected redaction: %q (blocked=%v)", got, blocked)
+	}
+
+	if _, err := NewPatternFilter(nil, []string{"("}); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+}
+
+func TestResponseFilterBlocksAndPassesThrough(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		MaxTurns:          1,
+		TurnTimeout:       5 * time.Second,
+		ResponseDelay:     time.Millisecond,
+		RetryInitialDelay: time.Millisecond,
+	}, nil)
+
+	filter, err := NewPatternFilter([]string{"secret"}, nil)
+	if err != nil {
+		t.Fatalf("NewPatternFilter failed: %v", err)
+	}
+	orch.AddResponseFilter(filter)
+
+	orch.AddAgent(&MockAgent{id: "a", name: "Leaky", agentType: "mock", available: true, sendMessageResp: "The secret code is 42."})
+	orch.AddAgent(&MockAgent{id: "b", name: "Polite", agentType: "mock", available: true, sendMessageResp: "Nothing to see here."})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	var agentMessages []agent.Message
+	var notices []agent.Message
+	for _, msg := range orch.GetMessages() {
+		if strings.Contains(msg.Content, "secret code") {
+			t.Errorf("blocked content was stored: %+v", msg)
+		}
+		switch {
+		case msg.Role == "agent":
+			agentMessages = append(agentMessages, msg)
+		case strings.Contains(msg.Content, "blocked by the content filter"):
+			notices = append(notices, msg)
+		}
+	}
+
+	if len(notices) != 1 || notices[0].Role != "system" || !strings.Contains(notices[0].Content, "Leaky") {
+		t.Errorf("expected one system notice for Leaky, got %+v", notices)
+	}
+	if len(agentMessages) != 1 || agentMessages[0].AgentName != "Polite" || agentMessages[0].Content != "Nothing to see here." {
+		t.Errorf("expected Polite's response unchanged, got %+v", agentMessages)
+	}
+}
+
+func TestResponseFilterRedacts(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		MaxTurns:          1,
+		TurnTimeout:       5 * time.Second,
+		ResponseDelay:     time.Millisecond,
+		RetryInitialDelay: time.Millisecond,
+	}, nil)
+
+	orch.AddResponseFilter(ResponseFilterFunc(func(content string) (string, bool) {
+		return strings.ReplaceAll(content, "hunter2", "*******"), false
+	}))
+	orch.AddAgent(&MockAgent{id: "a", name: "A", agentType: "mock", available: true, sendMessageResp: "My password is hunter2"})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	messages := orch.GetMessages()
+	last := messages[len(messages)-1]
+	if last.Role != "agent" || last.Content != "My password is *******" {
+		t.Errorf("expected redacted agent message, got %+v", last)
+	}
+}
+
+// filteringAgent is a MockAgent with the response filters of its config.
+type filteringAgent struct {
+	*MockAgent
+	base agent.BaseAgent
+}
+
+func (f *filteringAgent) FilterResponse(content string) string {
+	return f.base.FilterResponse(content)
+}
+
+func (f *filteringAgent) HasResponseFilters() bool {
+	return f.base.HasResponseFilters()
+}
+
+func TestAgentResponseFiltersApplied(t *testing.T) {
+	var buf strings.Builder
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		MaxTurns:          1,
+		TurnTimeout:       5 * time.Second,
+		ResponseDelay:     time.Millisecond,
+		RetryInitialDelay: time.Millisecond,
+	}, &buf)
+
+	a := &filteringAgent{MockAgent: &MockAgent{
+		id: "a", name: "A", agentType: "mock", available: true,
+		sendMessageResp: "As an AI language model, I'd rotate the key sk-abcdefghijklmnopqrstuvwx today.",
+	}}
+	err := a.base.Initialize(agent.AgentConfig{ID: "a", Filters: []agent.ResponseFilter{
+		{Pattern: `(?i)^as an ai language model,?\s*`},
+		{Pattern: `sk-[A-Za-z0-9]{20,}`, Replacement: "[redacted]"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orch.AddAgent(a)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	messages := orch.GetMessages()
+	last := messages[len(messages)-1]
+	if want := "I'd rotate the key [redacted] today."; last.Role != "agent" || last.Content != want {
+		t.Errorf("expected %q to be stored, got %+v", want, last)
+	}
+	if out := buf.String(); !strings.Contains(out, "[redacted]") || strings.Contains(out, "sk-abc") || strings.Contains(out, "As an AI") {
+		t.Errorf("expected the filtered response to be displayed, got:\n%s", buf.String())
+	}
+}
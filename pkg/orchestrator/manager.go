@@ -0,0 +1,253 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/log"
+)
+
+// Conversation states reported by ConversationInfo.State.
+const (
+	ConversationRunning   = "running"
+	ConversationCompleted = "completed"
+	ConversationStopped   = "stopped"
+	ConversationFailed    = "error"
+)
+
+// ErrConversationNotFound is returned by Manager.Stop and Manager.Remove for an
+// unknown conversation ID.
+var ErrConversationNotFound = errors.New("conversation not found")
+
+// ErrConversationRunning is returned by Manager.Remove for a conversation that
+// has not ended yet.
+var ErrConversationRunning = errors.New("conversation is still running")
+
+// Manager runs several independent conversations in one process.
+// Each conversation gets its own context and goroutine, so stopping one
+// does not affect the others. Conversations stay in the Manager, with their
+// messages, until they are removed with Remove.
+type Manager struct {
+	// ctx is the parent of every conversation; canceled by StopAll
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu            sync.RWMutex
+	conversations map[string]*ManagedConversation
+}
+
+// ManagedConversation is a conversation started by a Manager.
+type ManagedConversation struct {
+	id     string
+	orch   *Orchestrator
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu          sync.RWMutex
+	state       string
+	err         error
+	startedAt   time.Time
+	completedAt time.Time
+}
+
+// ConversationInfo is a point-in-time view of a managed conversation.
+type ConversationInfo struct {
+	// ID is the conversation ID given to Manager.Start
+	ID string
+	// State is one of ConversationRunning, ConversationCompleted, ConversationStopped, or ConversationFailed
+	State string
+	// Err is the error the conversation ended with, if any
+	Err error
+	// StartedAt is when the conversation was started
+	StartedAt time.Time
+	// CompletedAt is when the conversation ended (zero while running)
+	CompletedAt time.Time
+	// Messages is the number of messages in the conversation so far
+	Messages int
+}
+
+// NewManager creates a Manager with no conversations.
+func NewManager() *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{
+		ctx:           ctx,
+		cancel:        cancel,
+		conversations: make(map[string]*ManagedConversation),
+	}
+}
+
+// Start runs orch in the background under the given conversation ID.
+// It returns an error if the ID is empty or already in use.
+func (m *Manager) Start(id string, orch *Orchestrator) (*ManagedConversation, error) {
+	if id == "" {
+		return nil, fmt.Errorf("conversation ID cannot be empty")
+	}
+	if orch == nil {
+		return nil, fmt.Errorf("orchestrator cannot be nil")
+	}
+
+	m.mu.Lock()
+	if _, exists := m.conversations[id]; exists {
+		m.mu.Unlock()
+		return nil, fmt.Errorf("conversation already exists: %s", id)
+	}
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	conv := &ManagedConversation{
+		id:        id,
+		orch:      orch,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+		state:     ConversationRunning,
+		startedAt: time.Now(),
+	}
+	m.conversations[id] = conv
+	m.mu.Unlock()
+
+	log.WithField("conversation_id", id).Debug("managed conversation started")
+
+	go func() {
+		defer close(conv.done)
+		defer cancel()
+		err := orch.Start(ctx)
+		conv.finish(ctx, err)
+	}()
+
+	return conv, nil
+}
+
+// Stop cancels the conversation with the given ID and waits for it to end.
+// Stopping a conversation that has already ended is a no-op.
+func (m *Manager) Stop(id string) error {
+	conv, ok := m.Get(id)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrConversationNotFound, id)
+	}
+
+	conv.cancel()
+	<-conv.done
+	return nil
+}
+
+// Remove forgets the ended conversation with the given ID, freeing its
+// orchestrator and messages. Stop a running conversation first.
+func (m *Manager) Remove(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	conv, ok := m.conversations[id]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrConversationNotFound, id)
+	}
+	select {
+	case <-conv.done:
+	default:
+		return fmt.Errorf("%w: %s", ErrConversationRunning, id)
+	}
+
+	delete(m.conversations, id)
+	log.WithField("conversation_id", id).Debug("managed conversation removed")
+	return nil
+}
+
+// StopAll cancels every running conversation and waits for them to end.
+func (m *Manager) StopAll() {
+	m.cancel()
+	for _, conv := range m.all() {
+		<-conv.done
+	}
+}
+
+// Get returns the conversation with the given ID.
+func (m *Manager) Get(id string) (*ManagedConversation, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	conv, ok := m.conversations[id]
+	return conv, ok
+}
+
+// List returns the current state of every conversation, oldest first.
+func (m *Manager) List() []ConversationInfo {
+	conversations := m.all()
+	infos := make([]ConversationInfo, 0, len(conversations))
+	for _, conv := range conversations {
+		infos = append(infos, conv.Info())
+	}
+	sort.SliceStable(infos, func(i, j int) bool {
+		return infos[i].StartedAt.Before(infos[j].StartedAt)
+	})
+	return infos
+}
+
+// all returns a snapshot of the managed conversations.
+func (m *Manager) all() []*ManagedConversation {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	conversations := make([]*ManagedConversation, 0, len(m.conversations))
+	for _, conv := range m.conversations {
+		conversations = append(conversations, conv)
+	}
+	return conversations
+}
+
+// ID returns the conversation ID.
+func (c *ManagedConversation) ID() string {
+	return c.id
+}
+
+// Orchestrator returns the orchestrator running the conversation.
+func (c *ManagedConversation) Orchestrator() *Orchestrator {
+	return c.orch
+}
+
+// Done returns a channel that is closed when the conversation ends.
+func (c *ManagedConversation) Done() <-chan struct{} {
+	return c.done
+}
+
+// Wait blocks until the conversation ends and returns its error, if any.
+func (c *ManagedConversation) Wait() error {
+	<-c.done
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.err
+}
+
+// Info returns the current state of the conversation.
+func (c *ManagedConversation) Info() ConversationInfo {
+	messages := len(c.orch.GetMessages())
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return ConversationInfo{
+		ID:          c.id,
+		State:       c.state,
+		Err:         c.err,
+		StartedAt:   c.startedAt,
+		CompletedAt: c.completedAt,
+		Messages:    messages,
+	}
+}
+
+// finish records how the conversation ended. A conversation canceled through
+// its own context was stopped rather than failed.
+func (c *ManagedConversation) finish(ctx context.Context, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.completedAt = time.Now()
+	switch {
+	case ctx.Err() != nil && (err == nil || errors.Is(err, context.Canceled)):
+		c.state = ConversationStopped
+	case err != nil:
+		c.state = ConversationFailed
+		c.err = err
+		log.WithError(err).WithField("conversation_id", c.id).Warn("conversation ended with error")
+	default:
+		c.state = ConversationCompleted
+	}
+}
@@ -0,0 +1,212 @@
+package orchestrator
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// newManagedTestOrchestrator returns an orchestrator whose single agent answers
+// every turn after delay; maxTurns 0 runs until stopped.
+func newManagedTestOrchestrator(id string, maxTurns int, delay time.Duration) *Orchestrator {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		MaxTurns:          maxTurns,
+		TurnTimeout:       5 * time.Second,
+		ResponseDelay:     time.Millisecond,
+		RetryInitialDelay: time.Millisecond,
+		InitialPrompt:     "Talk amongst yourselves",
+	}, nil)
+	orch.AddAgent(&MockAgent{
+		id:              id,
+		name:            id,
+		agentType:       "mock",
+		available:       true,
+		sendMessageResp: "reply from " + id,
+		sendDelay:       delay,
+	})
+	return orch
+}
+
+// waitForMessages waits until the conversation has more than n messages.
+func waitForMessages(t *testing.T, conv *ManagedConversation, n int) int {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if got := conv.Info().Messages; got > n {
+			return got
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("conversation %s did not grow past %d messages", conv.ID(), n)
+	return 0
+}
+
+func TestManagerRunsConversationsIndependently(t *testing.T) {
+	m := NewManager()
+	defer m.StopAll()
+
+	a, err := m.Start("conv-a", newManagedTestOrchestrator("alice", 0, 5*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Start conv-a failed: %v", err)
+	}
+	b, err := m.Start("conv-b", newManagedTestOrchestrator("bob", 0, 5*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Start conv-b failed: %v", err)
+	}
+
+	waitForMessages(t, a, 2)
+	waitForMessages(t, b, 2)
+
+	infos := m.List()
+	if len(infos) != 2 || infos[0].ID != "conv-a" || infos[1].ID != "conv-b" {
+		t.Fatalf("expected conv-a and conv-b in start order, got %+v", infos)
+	}
+	for _, info := range infos {
+		if info.State != ConversationRunning {
+			t.Errorf("expected %s to be running, got %s", info.ID, info.State)
+		}
+	}
+
+	// Each conversation only sees its own agent
+	for _, msg := range a.Orchestrator().GetMessages() {
+		if msg.AgentID == "bob" {
+			t.Errorf("conv-a contains a message from conv-b's agent: %+v", msg)
+		}
+	}
+
+	if err := m.Stop("conv-a"); err != nil {
+		t.Fatalf("Stop conv-a failed: %v", err)
+	}
+	if info := a.Info(); info.State != ConversationStopped || info.CompletedAt.IsZero() {
+		t.Errorf("expected conv-a to be stopped, got %+v", info)
+	}
+
+	// conv-b keeps going after conv-a is stopped
+	before := b.Info().Messages
+	waitForMessages(t, b, before)
+	if state := b.Info().State; state != ConversationRunning {
+		t.Errorf("expected conv-b to still be running, got %s", state)
+	}
+
+	if err := m.Stop("conv-b"); err != nil {
+		t.Fatalf("Stop conv-b failed: %v", err)
+	}
+	if state := b.Info().State; state != ConversationStopped {
+		t.Errorf("expected conv-b to be stopped, got %s", state)
+	}
+
+	// Stopped conversations stay listed
+	if got := len(m.List()); got != 2 {
+		t.Errorf("expected 2 listed conversations, got %d", got)
+	}
+}
+
+func TestManagerConversationCompletes(t *testing.T) {
+	m := NewManager()
+	defer m.StopAll()
+
+	conv, err := m.Start("short", newManagedTestOrchestrator("alice", 2, 0))
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if err := conv.Wait(); err != nil {
+		t.Fatalf("expected conversation to complete, got %v", err)
+	}
+
+	got, ok := m.Get("short")
+	if !ok || got != conv {
+		t.Fatal("expected Get to return the started conversation")
+	}
+	if state := got.Info().State; state != ConversationCompleted {
+		t.Errorf("expected %s, got %s", ConversationCompleted, state)
+	}
+
+	// Stopping a finished conversation is a no-op
+	if err := m.Stop("short"); err != nil {
+		t.Errorf("expected no error stopping a finished conversation, got %v", err)
+	}
+	if state := got.Info().State; state != ConversationCompleted {
+		t.Errorf("expected state to stay %s, got %s", ConversationCompleted, state)
+	}
+}
+
+func TestManagerErrors(t *testing.T) {
+	m := NewManager()
+	defer m.StopAll()
+
+	if _, err := m.Start("", newManagedTestOrchestrator("alice", 1, 0)); err == nil {
+		t.Error("expected an error for an empty conversation ID")
+	}
+
+	if _, err := m.Start("dup", newManagedTestOrchestrator("alice", 0, 5*time.Millisecond)); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if _, err := m.Start("dup", newManagedTestOrchestrator("bob", 1, 0)); err == nil {
+		t.Error("expected an error for a duplicate conversation ID")
+	}
+
+	if err := m.Stop("missing"); !errors.Is(err, ErrConversationNotFound) {
+		t.Errorf("expected ErrConversationNotFound, got %v", err)
+	}
+	if _, ok := m.Get("missing"); ok {
+		t.Error("expected Get to report a missing conversation")
+	}
+
+	// A conversation that fails to start is reported as an error
+	failed, err := m.Start("empty", NewOrchestrator(OrchestratorConfig{Mode: ModeRoundRobin}, nil))
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if err := failed.Wait(); err == nil {
+		t.Error("expected an error for a conversation without agents")
+	}
+	if info := failed.Info(); info.State != ConversationFailed || info.Err == nil {
+		t.Errorf("expected a failed conversation, got %+v", info)
+	}
+}
+
+func TestManagerRemove(t *testing.T) {
+	m := NewManager()
+	defer m.StopAll()
+
+	done, err := m.Start("done", newManagedTestOrchestrator("alice", 1, 0))
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if err := done.Wait(); err != nil {
+		t.Fatalf("expected conversation to complete, got %v", err)
+	}
+	if _, err := m.Start("running", newManagedTestOrchestrator("bob", 0, 5*time.Millisecond)); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	if err := m.Remove("running"); !errors.Is(err, ErrConversationRunning) {
+		t.Errorf("expected ErrConversationRunning, got %v", err)
+	}
+	if err := m.Remove("done"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, ok := m.Get("done"); ok {
+		t.Error("expected Get to no longer find a removed conversation")
+	}
+	for _, info := range m.List() {
+		if info.ID == "done" {
+			t.Error("expected List to no longer include a removed conversation")
+		}
+	}
+	if err := m.Remove("done"); !errors.Is(err, ErrConversationNotFound) {
+		t.Errorf("expected ErrConversationNotFound removing twice, got %v", err)
+	}
+
+	// A stopped conversation can be removed too
+	if err := m.Stop("running"); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+	if err := m.Remove("running"); err != nil {
+		t.Errorf("expected a stopped conversation to be removable, got %v", err)
+	}
+	if got := len(m.List()); got != 0 {
+		t.Errorf("expected no conversations left, got %d", got)
+	}
+}
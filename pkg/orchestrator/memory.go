@@ -0,0 +1,159 @@
+package orchestrator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/log"
+)
+
+// memoryDirective matches a shared-memory write in an agent response:
+// "MEMORY[key]=value", one per line.
+var memoryDirective = regexp.MustCompile(`(?m)MEMORY\[([^\]\n]+)\]=(.*)$`)
+
+// ContextStore is a key-value scratchpad shared by every agent in a conversation.
+// Its contents are sent to agents as a "Shared notes" system message before each turn.
+// All methods are safe for concurrent use.
+type ContextStore struct {
+	mu      sync.RWMutex
+	entries map[string]string
+}
+
+// NewContextStore creates an empty ContextStore.
+func NewContextStore() *ContextStore {
+	return &ContextStore{entries: make(map[string]string)}
+}
+
+// Get returns the value stored under key.
+func (s *ContextStore) Get(key string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	value, ok := s.entries[key]
+	return value, ok
+}
+
+// Set stores value under key. An empty value deletes the key.
+func (s *ContextStore) Set(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if value == "" {
+		delete(s.entries, key)
+		return
+	}
+	s.entries[key] = value
+}
+
+// All returns a copy of every entry.
+func (s *ContextStore) All() map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := make(map[string]string, len(s.entries))
+	for k, v := range s.entries {
+		entries[k] = v
+	}
+	return entries
+}
+
+// Len returns the number of entries.
+func (s *ContextStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.entries)
+}
+
+// Notes formats the entries as a "Shared notes" section, sorted by key.
+// It returns an empty string when the store is empty.
+func (s *ContextStore) Notes() string {
+	entries := s.All()
+	if len(entries) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(entries))
+	for k := range entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("Shared notes:")
+	for _, k := range keys {
+		fmt.Fprintf(&b, "\n- %s: %s", k, entries[k])
+	}
+	return b.String()
+}
+
+// parseMemoryDirectives returns the MEMORY[key]=value writes in content, in order.
+// Keys and values are trimmed.
+func parseMemoryDirectives(content string) [][2]string {
+	matches := memoryDirective.FindAllStringSubmatch(content, -1)
+	writes := make([][2]string, 0, len(matches))
+	for _, m := range matches {
+		key := strings.TrimSpace(m[1])
+		if key == "" {
+			continue
+		}
+		writes = append(writes, [2]string{key, strings.TrimSpace(m[2])})
+	}
+	return writes
+}
+
+// GetMemory returns a copy of the shared notes.
+func (o *Orchestrator) GetMemory() map[string]string {
+	return o.memory.All()
+}
+
+// SetMemory stores a shared note visible to every agent from its next turn.
+// An empty value deletes the note.
+func (o *Orchestrator) SetMemory(key, value string) {
+	o.memory.Set(key, value)
+}
+
+// recordMemory applies the MEMORY[key]=value directives in an agent's response
+// when shared memory is enabled.
+func (o *Orchestrator) recordMemory(a agent.Agent, content string) {
+	if !o.config.SharedMemory {
+		return
+	}
+
+	for _, write := range parseMemoryDirectives(content) {
+		o.memory.Set(write[0], write[1])
+		log.WithFields(map[string]interface{}{
+			"agent_name": a.GetName(),
+			"key":        write[0],
+		}).Debug("shared memory updated")
+	}
+}
+
+// sharedNotesMessage builds the system message that carries the shared notes
+// into an agent's prompt. ok is false when there is nothing to send.
+func (o *Orchestrator) sharedNotesMessage() (msg agent.Message, ok bool) {
+	notes := o.memory.Notes()
+	if o.config.SharedMemory {
+		instructions := "To share a fact with every participant, write MEMORY[key]=value on its own line. An empty value removes the note."
+		if notes == "" {
+			notes = "Shared notes: (none yet)"
+		}
+		notes = notes + "\n\n" + instructions
+	}
+	if notes == "" {
+		return agent.Message{}, false
+	}
+
+	return agent.Message{
+		AgentID:   "system",
+		AgentName: "System",
+		Content:   notes,
+		Timestamp: time.Now().Unix(),
+		Role:      agent.RoleSystem,
+	}, true
+}
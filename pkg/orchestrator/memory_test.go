@@ -0,0 +1,163 @@
+package orchestrator
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+// memoryAgent replies with scripted responses and records every prompt it receives.
+type memoryAgent struct {
+	MockAgent
+	responses []string
+	prompts   [][]agent.Message
+}
+
+func (m *memoryAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	m.prompts = append(m.prompts, messages)
+	response := m.responses[0]
+	if len(m.responses) > 1 {
+		m.responses = m.responses[1:]
+	}
+	return response, nil
+}
+
+// sharedNotes returns the shared-notes system message of a prompt, if any.
+func sharedNotes(prompt []agent.Message) string {
+	for _, msg := range prompt {
+		if msg.Role == "system" && strings.HasPrefix(msg.Content, "Shared notes:") {
+			return msg.Content
+		}
+	}
+	return ""
+}
+
+func TestParseMemoryDirectives(t *testing.T) {
+	content := "We agreed on Postgres.\nMEMORY[database]=postgres 16\n  MEMORY[ owner ]= Alice \nMEMORY[]=ignored\nMEMORY[deadline]="
+
+	writes := parseMemoryDirectives(content)
+	want := [][2]string{{"database", "postgres 16"}, {"owner", "Alice"}, {"deadline", ""}}
+	if len(writes) != len(want) {
+		t.Fatalf("expected %d writes, got %d: %v", len(want), len(writes), writes)
+	}
+	for i := range want {
+		if writes[i] != want[i] {
+			t.Errorf("write %d: expected %v, got %v", i, want[i], writes[i])
+		}
+	}
+}
+
+func TestContextStoreNotes(t *testing.T) {
+	store := NewContextStore()
+	if store.Notes() != "" {
+		t.Errorf("expected no notes for an empty store, got %q", store.Notes())
+	}
+
+	store.Set("zeta", "last")
+	store.Set("alpha", "first")
+	if got := store.Notes(); got != "Shared notes:\n- alpha: first\n- zeta: last" {
+		t.Errorf("unexpected notes: %q", got)
+	}
+
+	store.Set("zeta", "")
+	if _, ok := store.Get("zeta"); ok || store.Len() != 1 {
+		t.Errorf("expected empty value to delete the key, got %v", store.All())
+	}
+}
+
+func TestSharedMemoryParseAndInject(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		MaxTurns:          2,
+		TurnTimeout:       5 * time.Second,
+		ResponseDelay:     time.Millisecond,
+		RetryInitialDelay: time.Millisecond,
+		SharedMemory:      true,
+	}, nil)
+
+	alice := &memoryAgent{
+		MockAgent: MockAgent{id: "alice", name: "Alice", agentType: "mock", available: true},
+		responses: []string{"Let's use Postgres.\nMEMORY[database]=postgres", "Still postgres."},
+	}
+	bob := &memoryAgent{
+		MockAgent: MockAgent{id: "bob", name: "Bob", agentType: "mock", available: true},
+		responses: []string{"Agreed.\nMEMORY[owner]=Bob", "Done."},
+	}
+	orch.AddAgent(alice)
+	orch.AddAgent(bob)
+	orch.SetMemory("goal", "pick a database")
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	if len(alice.prompts) != 2 || len(bob.prompts) != 2 {
+		t.Fatalf("expected 2 turns each, got alice=%d bob=%d", len(alice.prompts), len(bob.prompts))
+	}
+
+	// First turn: only the programmatic note, plus the convention instructions
+	first := sharedNotes(alice.prompts[0])
+	if !strings.Contains(first, "- goal: pick a database") || strings.Contains(first, "database: postgres") {
+		t.Errorf("unexpected notes on the first turn: %q", first)
+	}
+	if !strings.Contains(first, "MEMORY[key]=value") {
+		t.Errorf("expected the notes to explain the convention: %q", first)
+	}
+
+	// Bob sees what Alice wrote in the same round
+	if notes := sharedNotes(bob.prompts[0]); !strings.Contains(notes, "- database: postgres") {
+		t.Errorf("expected Bob to see Alice's note, got %q", notes)
+	}
+
+	// Notes persist into later rounds
+	second := sharedNotes(alice.prompts[1])
+	for _, want := range []string{"- database: postgres", "- goal: pick a database", "- owner: Bob"} {
+		if !strings.Contains(second, want) {
+			t.Errorf("expected %q in second-round notes, got %q", want, second)
+		}
+	}
+
+	memory := orch.GetMemory()
+	if len(memory) != 3 || memory["database"] != "postgres" || memory["owner"] != "Bob" {
+		t.Errorf("unexpected memory: %v", memory)
+	}
+
+	// Notes are injected into prompts, not stored in the transcript
+	for _, msg := range orch.GetMessages() {
+		if strings.HasPrefix(msg.Content, "Shared notes:") {
+			t.Errorf("shared notes leaked into the conversation history: %+v", msg)
+		}
+	}
+}
+
+func TestSharedMemoryDisabledIgnoresDirectives(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		MaxTurns:          2,
+		TurnTimeout:       5 * time.Second,
+		ResponseDelay:     time.Millisecond,
+		RetryInitialDelay: time.Millisecond,
+	}, nil)
+
+	a := &memoryAgent{
+		MockAgent: MockAgent{id: "a", name: "A", agentType: "mock", available: true},
+		responses: []string{"MEMORY[key]=value"},
+	}
+	orch.AddAgent(a)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	if len(orch.GetMemory()) != 0 {
+		t.Errorf("expected directives to be ignored, got %v", orch.GetMemory())
+	}
+	for _, prompt := range a.prompts {
+		if notes := sharedNotes(prompt); notes != "" {
+			t.Errorf("expected no shared notes, got %q", notes)
+		}
+	}
+}
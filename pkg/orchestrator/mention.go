@@ -0,0 +1,75 @@
+package orchestrator
+
+import (
+	"strings"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/log"
+)
+
+// mentionedAgent returns the agent addressed as @Name or @ID in the last
+// message, so reactive mode can hand it the next turn. Matching is
+// case-insensitive and must be on word boundaries ("@Bob" does not match
+// "@Bobby" or "alice@bob.example"). The earliest mention wins; on a tie, the
+// longer name does. The message's author and disabled agents are never
+// chosen. It returns nil if no other agent is mentioned.
+func (o *Orchestrator) mentionedAgent() agent.Agent {
+	messages := o.getMessages()
+	if len(messages) == 0 {
+		return nil
+	}
+	last := messages[len(messages)-1]
+	if !strings.Contains(last.Content, "@") {
+		return nil
+	}
+
+	content := strings.ToLower(last.Content)
+	var chosen agent.Agent
+	chosenIndex, chosenLength := -1, 0
+	for _, a := range o.Agents() {
+		if a.GetID() == last.AgentID || o.AgentDisabled(a) {
+			continue
+		}
+		for _, candidate := range []string{a.GetName(), a.GetID()} {
+			i, length := findMention(content, strings.ToLower(candidate))
+			if i < 0 {
+				continue
+			}
+			if chosenIndex < 0 || i < chosenIndex || (i == chosenIndex && length > chosenLength) {
+				chosen, chosenIndex, chosenLength = a, i, length
+			}
+		}
+	}
+
+	if chosen != nil {
+		log.WithFields(map[string]interface{}{
+			"mentioned_by": last.AgentName,
+			"agent_name":   chosen.GetName(),
+		}).Debug("next speaker chosen by mention")
+	}
+	return chosen
+}
+
+// findMention returns the index and length of the first "@name" in content
+// on word boundaries, or -1 if there is none. content and name must
+// already be lowercase.
+func findMention(content, name string) (int, int) {
+	if name == "" {
+		return -1, 0
+	}
+	mention := "@" + name
+	for start := 0; start < len(content); {
+		i := strings.Index(content[start:], mention)
+		if i < 0 {
+			return -1, 0
+		}
+		i += start
+		end := i + len(mention)
+		afterWord := i > 0 && isWordByte(content[i-1]) // an email address, not a mention
+		if !afterWord && (end == len(content) || !isWordByte(content[end]) || !isWordByte(content[end-1])) {
+			return i, len(mention)
+		}
+		start = i + 1
+	}
+	return -1, 0
+}
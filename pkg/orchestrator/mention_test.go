@@ -0,0 +1,88 @@
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+func newMentionTestOrchestrator(lastMessage agent.Message) *Orchestrator {
+	orch := NewOrchestrator(OrchestratorConfig{Mode: ModeReactive, Seed: 7}, nil)
+	orch.AddAgent(&MockAgent{id: "alice-1", name: "Alice"})
+	orch.AddAgent(&MockAgent{id: "bob-1", name: "Bob"})
+	orch.AddAgent(&MockAgent{id: "bob-jr", name: "Bob Jr"})
+	orch.AddAgent(&MockAgent{id: "carol-1", name: "Carol"})
+	orch.InjectMessage(lastMessage)
+	return orch
+}
+
+func TestSelectNextAgentPrefersMention(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		content string
+		want    string
+	}{
+		{name: "mention by name", from: "alice-1", content: "@Bob, what do you think?", want: "bob-1"},
+		{name: "case-insensitive", from: "alice-1", content: "I agree. @carol?", want: "carol-1"},
+		{name: "mention by ID", from: "alice-1", content: "Over to @carol-1.", want: "carol-1"},
+		{name: "longer name wins", from: "alice-1", content: "@Bob Jr, your turn", want: "bob-jr"},
+		{name: "earliest mention wins", from: "alice-1", content: "@Carol then @Bob", want: "carol-1"},
+		{name: "self-mention skipped for another mention", from: "alice-1", content: "@Alice here, @Bob go ahead", want: "bob-1"},
+		{name: "user message", from: "user", content: "@Alice please summarize", want: "alice-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orch := newMentionTestOrchestrator(agent.Message{AgentID: tt.from, Content: tt.content, Role: "agent"})
+			for i := 0; i < 10; i++ {
+				if got := orch.selectNextAgent(tt.from); got == nil || got.GetID() != tt.want {
+					t.Fatalf("expected %s to be selected, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSelectNextAgentFallsBackWithoutMention(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "no mention", content: "What does everyone think?"},
+		{name: "unknown mention", content: "@Dave, any thoughts?"},
+		{name: "self-mention", content: "@Alice here again."},
+		{name: "partial name", content: "@Carolyn, any thoughts?"},
+		{name: "email address", content: "Write to alice@bob.example"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orch := newMentionTestOrchestrator(agent.Message{AgentID: "alice-1", Content: tt.content, Role: "agent"})
+
+			seen := make(map[string]bool)
+			for i := 0; i < 50; i++ {
+				got := orch.selectNextAgent("alice-1")
+				if got == nil || got.GetID() == "alice-1" {
+					t.Fatalf("expected a random agent other than the last speaker, got %v", got)
+				}
+				seen[got.GetID()] = true
+			}
+			if len(seen) < 2 {
+				t.Errorf("expected random selection among the other agents, only saw %v", seen)
+			}
+		})
+	}
+}
+
+func TestSelectNextAgentIgnoresDisabledMention(t *testing.T) {
+	orch := newMentionTestOrchestrator(agent.Message{AgentID: "alice-1", Content: "@Bob?", Role: "agent"})
+	orch.config.MaxConsecutiveFailures = 1
+	orch.recordFailedResponse(orch.Agents()[1])
+
+	for i := 0; i < 20; i++ {
+		if got := orch.selectNextAgent("alice-1"); got == nil || got.GetID() == "bob-1" || got.GetID() == "alice-1" {
+			t.Fatalf("expected a disabled mention to fall back to random selection, got %v", got)
+		}
+	}
+}
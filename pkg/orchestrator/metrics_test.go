@@ -0,0 +1,99 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/kevinelliott/agentpipe/pkg/metrics"
+)
+
+func TestOrchestratorRecordsMetrics(t *testing.T) {
+	m := metrics.NewMetrics(prometheus.NewRegistry())
+
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		MaxTurns:          2,
+		TurnTimeout:       5 * time.Second,
+		ResponseDelay:     time.Millisecond,
+		MaxRetries:        1,
+		RetryInitialDelay: time.Millisecond,
+		InitialPrompt:     "Compare two sorting algorithms",
+	}, nil)
+	orch.SetMetrics(m)
+
+	// Flaky fails once and succeeds on retry; Broken never succeeds
+	orch.AddAgent(&MockAgent{id: "flaky", name: "Flaky", agentType: "mock", available: true, sendMessageResp: "Quicksort", failFirstN: 1})
+	orch.AddAgent(&MockAgent{id: "broken", name: "Broken", agentType: "mock", available: true, sendMessageErr: errors.New("connection refused")})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	if got := testutil.ToFloat64(m.AgentRequests.WithLabelValues("Flaky", "mock", "success")); got != 2 {
+		t.Errorf("expected 2 successful requests for Flaky, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.AgentRequests.WithLabelValues("Broken", "mock", "error")); got != 2 {
+		t.Errorf("expected 2 failed requests for Broken, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.AgentErrors.WithLabelValues("Broken", "mock", "unknown")); got != 2 {
+		t.Errorf("expected 2 errors for Broken, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.RetryAttempts.WithLabelValues("Flaky", "mock")); got != 1 {
+		t.Errorf("expected 1 retry for Flaky, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.RetryAttempts.WithLabelValues("Broken", "mock")); got != 2 {
+		t.Errorf("expected 2 retries for Broken, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.AgentTokens.WithLabelValues("Flaky", "mock", "output")); got <= 0 {
+		t.Errorf("expected output tokens for Flaky, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.ConversationTurns.WithLabelValues("round-robin")); got != 2 {
+		t.Errorf("expected 2 conversation turns, got %v", got)
+	}
+	if got := testutil.CollectAndCount(m.AgentRequestDuration); got != 1 {
+		t.Errorf("expected request durations for one agent, got %d", got)
+	}
+	if got := testutil.CollectAndCount(m.ConversationDuration); got != 1 {
+		t.Errorf("expected a conversation duration, got %d", got)
+	}
+	if got := testutil.ToFloat64(m.ActiveConversations); got != 0 {
+		t.Errorf("expected no active conversations after Start returns, got %v", got)
+	}
+}
+
+func TestOrchestratorActiveConversationsDuringRun(t *testing.T) {
+	m := metrics.NewMetrics(prometheus.NewRegistry())
+
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: time.Millisecond,
+	}, nil)
+	orch.SetMetrics(m)
+	orch.AddAgent(&MockAgent{id: "slow", name: "Slow", agentType: "mock", available: true, sendMessageResp: "done", sendDelay: 100 * time.Millisecond})
+
+	done := make(chan error, 1)
+	go func() { done <- orch.Start(context.Background()) }()
+
+	deadline := time.After(time.Second)
+	for testutil.ToFloat64(m.ActiveConversations) != 1 {
+		select {
+		case <-deadline:
+			t.Fatal("expected an active conversation while Start runs")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if got := testutil.ToFloat64(m.ActiveConversations); got != 0 {
+		t.Errorf("expected the active conversation to end, got %v", got)
+	}
+}
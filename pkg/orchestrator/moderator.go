@@ -0,0 +1,263 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/log"
+	"github.com/kevinelliott/agentpipe/pkg/utils"
+)
+
+// moderator returns the ModeratorAgentID agent (default: the first agent).
+func (o *Orchestrator) moderator() (agent.Agent, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	if len(o.agents) == 0 {
+		return nil, fmt.Errorf("no agents configured")
+	}
+	if id := o.config.ModeratorAgentID; id != "" {
+		for _, a := range o.agents {
+			if a.GetID() == id {
+				return a, nil
+			}
+		}
+		return nil, fmt.Errorf("moderator agent %s is not in the conversation", id)
+	}
+	return o.agents[0], nil
+}
+
+// runModerator asks the moderator agent to name the next speaker before every
+// turn. When the moderator's reply names no participant, the turn goes to the
+// next speaker in round-robin order. Each response counts as one turn.
+func (o *Orchestrator) runModerator(ctx context.Context) error {
+	moderator, err := o.moderator()
+	if err != nil {
+		return err
+	}
+
+	var speakers []agent.Agent
+	for _, a := range o.Agents() {
+		if a.GetID() != moderator.GetID() {
+			speakers = append(speakers, a)
+		}
+	}
+	if len(speakers) == 0 {
+		return fmt.Errorf("moderator mode needs at least one agent besides the moderator %s", moderator.GetName())
+	}
+
+	turns := o.ResumedTurns()
+	nextRound := o.resumedRounds(len(speakers))
+	fallbackIndex := o.ResumedTurns()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if o.config.MaxTurns > 0 && turns >= o.config.MaxTurns {
+			endMsg := "Maximum turns reached. Conversation ended."
+			if o.logger != nil {
+				o.logger.LogSystem(endMsg)
+			}
+			if o.writer != nil {
+				fmt.Fprintln(o.writer, "\n[System] "+endMsg)
+			}
+			break
+		}
+
+		if o.allDisabled(speakers) {
+			o.announceAllDisabled()
+			break
+		}
+
+		// A moderated round is as many responses as there are speakers
+		if turns >= nextRound*len(speakers) {
+			if !o.beginRound(nextRound) {
+				break
+			}
+			nextRound++
+		}
+
+		if !o.continueBeforeTurn() {
+			break
+		}
+
+		active := o.activeAgents(speakers)
+		nextAgent, usage := o.askModerator(ctx, moderator, active)
+		if nextAgent == nil {
+			nextAgent = active[fallbackIndex%len(active)]
+			log.WithFields(map[string]interface{}{
+				"moderator": moderator.GetName(),
+				"fallback":  nextAgent.GetName(),
+			}).Debug("moderator named no participant, using round-robin order")
+		}
+		fallbackIndex++
+
+		o.recordModeratorDecision(moderator, nextAgent, usage)
+
+		if err := o.getAgentResponse(ctx, nextAgent); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err() // the conversation ended mid-turn
+			}
+			o.recordFailedResponse(nextAgent)
+			if o.config.StopOnError {
+				return o.stopOnAgentError(nextAgent, err)
+			}
+			if o.writer != nil {
+				fmt.Fprintf(o.writer, "\n[Error] Agent %s failed: %v\n", nextAgent.GetName(), err)
+			}
+		} else {
+			turns++
+			if o.stopConditionMet() {
+				break
+			}
+		}
+
+		o.pause(ctx, nextAgent)
+	}
+
+	return nil
+}
+
+// recordModeratorDecision adds the moderator's choice of the next speaker to
+// the conversation as a moderator message, so transcripts show who called on whom.
+// The moderator query's usage rides on the message so budgets and stats count it.
+func (o *Orchestrator) recordModeratorDecision(moderator, next agent.Agent, usage *agent.ResponseMetrics) {
+	msg := agent.Message{
+		AgentID:   moderator.GetID(),
+		AgentName: moderator.GetName(),
+		AgentType: moderator.GetType(),
+		Content:   fmt.Sprintf("Calls on %s", next.GetName()),
+		Timestamp: time.Now().Unix(),
+		Role:      agent.RoleModerator,
+		Metrics:   usage,
+	}
+
+	o.mu.Lock()
+	o.messages = append(o.messages, msg)
+	o.mu.Unlock()
+
+	if o.logger != nil {
+		o.logger.LogMessage(msg)
+	}
+	o.notifyMessage(msg)
+	if o.writer != nil {
+		fmt.Fprintf(o.writer, "\n[Moderator] %s calls on %s\n", moderator.GetName(), next.GetName())
+	}
+}
+
+// askModerator asks the moderator who should speak next and returns the named
+// speaker, or nil if the moderator failed or named no participant, along with
+// the query's token and cost usage (nil if no reply came back).
+func (o *Orchestrator) askModerator(ctx context.Context, moderator agent.Agent, speakers []agent.Agent) (agent.Agent, *agent.ResponseMetrics) {
+	names := make([]string, 0, len(speakers))
+	for _, a := range speakers {
+		names = append(names, a.GetName())
+	}
+
+	messages := o.getMessages()
+	messages = append(messages, agent.Message{
+		AgentID:   "host",
+		AgentName: "HOST",
+		Content: fmt.Sprintf("You are moderating this conversation. Decide who should speak next from: %s. "+
+			"Reply with only that participant's name.", strings.Join(names, ", ")),
+		Timestamp: time.Now().Unix(),
+		Role:      agent.RoleUser,
+	})
+
+	if err := o.waitGlobalRateLimit(ctx, moderator); err != nil {
+		log.WithError(err).WithField("moderator", moderator.GetName()).Warn("moderator failed to pick the next speaker")
+		return nil, nil
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, o.turnTimeout(moderator))
+	defer cancel()
+
+	startTime := time.Now()
+	reply, err := moderator.SendMessage(timeoutCtx, messages)
+	if err != nil {
+		log.WithError(err).WithField("moderator", moderator.GetName()).Warn("moderator failed to pick the next speaker")
+		return nil, nil
+	}
+
+	return parseModeratorChoice(reply, speakers), o.moderatorUsage(moderator, messages, reply, time.Since(startTime))
+}
+
+// moderatorUsage counts a moderator query's tokens and cost the way
+// getAgentResponse counts a turn, and records them in the Prometheus metrics.
+func (o *Orchestrator) moderatorUsage(moderator agent.Agent, messages []agent.Message, reply string, duration time.Duration) *agent.ResponseMetrics {
+	model := moderator.GetModel()
+
+	var inputBuilder strings.Builder
+	for _, msg := range messages {
+		inputBuilder.WriteString(msg.Content)
+		inputBuilder.WriteString(" ")
+	}
+	inputTokens := utils.CountTokens(model, inputBuilder.String())
+	outputTokens := utils.CountTokens(model, reply)
+	if reporter, ok := moderator.(agent.UsageReporter); ok {
+		if usage, ok := reporter.LastUsage(); ok {
+			inputTokens, outputTokens = usage.InputTokens, usage.OutputTokens
+		}
+	}
+	cost := utils.EstimateCost(model, inputTokens, outputTokens)
+
+	if o.metrics != nil {
+		o.metrics.RecordAgentRequest(moderator.GetName(), moderator.GetType(), "success")
+		o.metrics.RecordAgentDuration(moderator.GetName(), moderator.GetType(), duration.Seconds())
+		o.metrics.RecordAgentTokens(moderator.GetName(), moderator.GetType(), "input", inputTokens)
+		o.metrics.RecordAgentTokens(moderator.GetName(), moderator.GetType(), "output", outputTokens)
+		o.metrics.RecordAgentCost(moderator.GetName(), moderator.GetType(), model, cost)
+	}
+
+	return &agent.ResponseMetrics{
+		Duration:     duration,
+		InputTokens:  inputTokens,
+		OutputTokens: outputTokens,
+		TotalTokens:  inputTokens + outputTokens,
+		Model:        model,
+		Cost:         cost,
+	}
+}
+
+// parseModeratorChoice returns the speaker named in reply. A reply that is
+// exactly a speaker's name or ID wins; otherwise the earliest mention does.
+// Matching is case-insensitive. It returns nil if no speaker is named.
+func parseModeratorChoice(reply string, speakers []agent.Agent) agent.Agent {
+	normalized := strings.ToLower(strings.Trim(strings.TrimSpace(reply), ".!\"'*`"))
+	if normalized == "" {
+		return nil
+	}
+
+	for _, a := range speakers {
+		if normalized == strings.ToLower(a.GetName()) || normalized == strings.ToLower(a.GetID()) {
+			return a
+		}
+	}
+
+	var chosen agent.Agent
+	chosenIndex, chosenLength := -1, 0
+	for _, a := range speakers {
+		for _, candidate := range []string{a.GetName(), a.GetID()} {
+			candidate = strings.ToLower(candidate)
+			if candidate == "" {
+				continue
+			}
+			i := strings.Index(normalized, candidate)
+			if i < 0 {
+				continue
+			}
+			// Prefer the earliest mention; on a tie, the longer name ("Bob Jr" over "Bob")
+			if chosenIndex < 0 || i < chosenIndex || (i == chosenIndex && len(candidate) > chosenLength) {
+				chosen, chosenIndex, chosenLength = a, i, len(candidate)
+			}
+		}
+	}
+	return chosen
+}
@@ -0,0 +1,227 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/logger"
+	"github.com/kevinelliott/agentpipe/pkg/utils"
+)
+
+func newModeratorTestOrchestrator(maxTurns int, moderatorID string) *Orchestrator {
+	return NewOrchestrator(OrchestratorConfig{
+		Mode:              ModeModerator,
+		MaxTurns:          maxTurns,
+		TurnTimeout:       5 * time.Second,
+		ResponseDelay:     time.Millisecond,
+		RetryInitialDelay: time.Millisecond,
+		InitialPrompt:     "Debate: tabs or spaces?",
+		ModeratorAgentID:  moderatorID,
+	}, nil)
+}
+
+// speakingOrder returns the IDs of the agents that responded, in order.
+func speakingOrder(orch *Orchestrator) []string {
+	var order []string
+	for _, msg := range orch.GetMessages() {
+		if msg.Role == agent.RoleAgent {
+			order = append(order, msg.AgentID)
+		}
+	}
+	return order
+}
+
+func TestModeratorRoutesTurns(t *testing.T) {
+	orch := newModeratorTestOrchestrator(3, "")
+	moderator := &memoryAgent{
+		MockAgent: MockAgent{id: "mod", name: "Moderator", agentType: "mock", available: true},
+		responses: []string{"Carol", "bob", "Next up: Carol."},
+	}
+	orch.AddAgent(moderator)
+	orch.AddAgent(&MockAgent{id: "bob", name: "Bob", agentType: "mock", available: true, sendMessageResp: "Tabs."})
+	orch.AddAgent(&MockAgent{id: "carol", name: "Carol", agentType: "mock", available: true, sendMessageResp: "Spaces."})
+	var transcript bytes.Buffer
+	orch.SetLogger(logger.NewTranscriptLogger(&transcript, logger.LogFormatJSON, false, time.Now()))
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	if got := strings.Join(speakingOrder(orch), ","); got != "carol,bob,carol" {
+		t.Errorf("expected speaking order carol,bob,carol, got %s", got)
+	}
+
+	// The moderator's decisions are logged and added to the conversation with
+	// the moderator role
+	logged, err := logger.ParseLog(&transcript)
+	if err != nil {
+		t.Fatalf("ParseLog failed: %v", err)
+	}
+	var decisions []string
+	for _, msg := range logged {
+		if msg.Role == agent.RoleModerator {
+			decisions = append(decisions, msg.AgentName+": "+msg.Content)
+		}
+	}
+	if got := strings.Join(decisions, "|"); got != "Moderator: Calls on Carol|Moderator: Calls on Bob|Moderator: Calls on Carol" {
+		t.Errorf("unexpected logged moderator decisions: %s", got)
+	}
+	var history []string
+	for _, msg := range orch.GetMessages() {
+		if msg.Role == agent.RoleModerator {
+			history = append(history, msg.Content)
+		}
+	}
+	if got := strings.Join(history, "|"); got != "Calls on Carol|Calls on Bob|Calls on Carol" {
+		t.Errorf("unexpected moderator decisions in the conversation: %s", got)
+	}
+
+	if len(moderator.prompts) != 3 {
+		t.Fatalf("expected the moderator to be asked before each of 3 turns, got %d", len(moderator.prompts))
+	}
+	prompt := moderator.prompts[0]
+	if last := prompt[len(prompt)-1].Content; !strings.Contains(last, "Bob, Carol") {
+		t.Errorf("expected the moderator to be offered Bob and Carol, got %q", last)
+	}
+	// The moderator sees the conversation so far, including the previous turn
+	var sawCarol bool
+	for _, msg := range moderator.prompts[1] {
+		sawCarol = sawCarol || (msg.AgentID == "carol" && msg.Content == "Spaces.")
+	}
+	if !sawCarol {
+		t.Errorf("expected the moderator to see Carol's response, got %v", moderator.prompts[1])
+	}
+}
+
+func TestModeratorFallsBackToRoundRobin(t *testing.T) {
+	orch := newModeratorTestOrchestrator(4, "")
+	orch.AddAgent(&memoryAgent{
+		MockAgent: MockAgent{id: "mod", name: "Moderator", agentType: "mock", available: true},
+		responses: []string{"Carol", "Dave should go", "I cannot decide", "Carol"},
+	})
+	orch.AddAgent(&MockAgent{id: "bob", name: "Bob", agentType: "mock", available: true, sendMessageResp: "Tabs."})
+	orch.AddAgent(&MockAgent{id: "carol", name: "Carol", agentType: "mock", available: true, sendMessageResp: "Spaces."})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	// Turns 2 and 3 name no participant and follow round-robin order (Carol, Bob)
+	if got := strings.Join(speakingOrder(orch), ","); got != "carol,carol,bob,carol" {
+		t.Errorf("expected speaking order carol,carol,bob,carol, got %s", got)
+	}
+}
+
+func TestModeratorAgentID(t *testing.T) {
+	orch := newModeratorTestOrchestrator(2, "mod")
+	orch.AddAgent(&MockAgent{id: "bob", name: "Bob", agentType: "mock", available: true, sendMessageResp: "Tabs."})
+	orch.AddAgent(&memoryAgent{
+		MockAgent: MockAgent{id: "mod", name: "Moderator", agentType: "mock", available: true},
+		responses: []string{"bob"},
+	})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if got := strings.Join(speakingOrder(orch), ","); got != "bob,bob" {
+		t.Errorf("expected only Bob to speak, got %s", got)
+	}
+}
+
+func TestModeratorUsageCountsTowardTotals(t *testing.T) {
+	// Each moderator query costs 1000 output tokens * $0.20/1K = $0.20 and
+	// each of Bob's responses 500 output tokens * $0.20/1K = $0.10
+	utils.SetPricing(&utils.PricingConfig{Models: map[string]utils.ModelRate{
+		"budget-test-model": {OutputPer1K: 0.20},
+	}})
+	defer utils.SetPricing(nil)
+
+	newOrchestrator := func(maxTurns int, maxCost float64) *Orchestrator {
+		orch := newModeratorTestOrchestrator(maxTurns, "")
+		orch.config.MaxCost = maxCost
+		orch.AddAgent(&pricedAgent{
+			MockAgent: MockAgent{id: "mod", name: "Moderator", agentType: "mock", model: "budget-test-model", available: true, sendMessageResp: "Bob"},
+			usage:     agent.Usage{InputTokens: 100, OutputTokens: 1000},
+		})
+		orch.AddAgent(&pricedAgent{
+			MockAgent: MockAgent{id: "bob", name: "Bob", agentType: "mock", model: "budget-test-model", available: true, sendMessageResp: "Tabs."},
+			usage:     agent.Usage{OutputTokens: 500},
+		})
+		return orch
+	}
+
+	orch := newOrchestrator(2, 0)
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	stats := orch.GetStats()
+	if stats.AgentMessages != 2 || stats.TotalTokens != 3200 || math.Abs(stats.TotalCost-0.60) > 1e-9 {
+		t.Errorf("expected 2 responses, 3200 tokens and $0.60 including the moderator, got %d, %d and $%.2f",
+			stats.AgentMessages, stats.TotalTokens, stats.TotalCost)
+	}
+	for _, s := range stats.PerAgent {
+		if s.AgentID == "mod" && (s.Messages != 0 || s.Tokens != 2200 || math.Abs(s.Cost-0.40) > 1e-9) {
+			t.Errorf("expected the moderator's queries under its own totals, got %+v", s)
+		}
+	}
+
+	// The first query and response together cross a $0.25 budget
+	orch = newOrchestrator(10, 0.25)
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if got := len(speakingOrder(orch)); got != 1 {
+		t.Errorf("expected the cost budget to end the conversation after 1 response, got %d", got)
+	}
+}
+
+func TestModeratorErrors(t *testing.T) {
+	orch := newModeratorTestOrchestrator(1, "missing")
+	orch.AddAgent(&MockAgent{id: "bob", name: "Bob", agentType: "mock", available: true, sendMessageResp: "Tabs."})
+	if err := orch.Start(context.Background()); err == nil || !strings.Contains(err.Error(), "not in the conversation") {
+		t.Errorf("expected an unknown moderator error, got %v", err)
+	}
+
+	orch = newModeratorTestOrchestrator(1, "")
+	orch.AddAgent(&MockAgent{id: "mod", name: "Moderator", agentType: "mock", available: true, sendMessageResp: "mod"})
+	if err := orch.Start(context.Background()); err == nil || !strings.Contains(err.Error(), "besides the moderator") {
+		t.Errorf("expected an error without other speakers, got %v", err)
+	}
+}
+
+func TestParseModeratorChoice(t *testing.T) {
+	speakers := []agent.Agent{
+		&MockAgent{id: "bob", name: "Bob"},
+		&MockAgent{id: "bob-jr", name: "Bob Jr"},
+		&MockAgent{id: "carol", name: "Carol"},
+	}
+
+	tests := []struct {
+		reply string
+		want  string
+	}{
+		{reply: "Carol", want: "carol"},
+		{reply: "  **Bob.**\n", want: "bob"},
+		{reply: "bob-jr", want: "bob-jr"},
+		{reply: "Bob Jr should answer that.", want: "bob-jr"},
+		{reply: "Let's hear from Carol, then Bob.", want: "carol"},
+		{reply: "Dave", want: ""},
+		{reply: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		got := parseModeratorChoice(tt.reply, speakers)
+		gotID := ""
+		if got != nil {
+			gotID = got.GetID()
+		}
+		if gotID != tt.want {
+			t.Errorf("parseModeratorChoice(%q) = %q, want %q", tt.reply, gotID, tt.want)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/config"
+)
+
+// ModeRunner runs the turn-taking loop of a conversation mode.
+// Run is called by Start after the initial prompt has been added and returns
+// when the conversation is over.
+type ModeRunner interface {
+	Run(ctx context.Context) error
+}
+
+// ModeRunnerFunc adapts a function to the ModeRunner interface.
+type ModeRunnerFunc func(ctx context.Context) error
+
+// Run calls f(ctx).
+func (f ModeRunnerFunc) Run(ctx context.Context) error {
+	return f(ctx)
+}
+
+// ModeFactory creates the ModeRunner for an orchestrator.
+type ModeFactory func(*Orchestrator) ModeRunner
+
+var modeRegistry = struct {
+	mu        sync.RWMutex
+	factories map[ConversationMode]ModeFactory
+}{
+	factories: make(map[ConversationMode]ModeFactory),
+}
+
+func init() {
+	RegisterMode(string(ModeRoundRobin), func(o *Orchestrator) ModeRunner {
+		return ModeRunnerFunc(o.runRoundRobin)
+	})
+	RegisterMode(string(ModeReactive), func(o *Orchestrator) ModeRunner {
+		return ModeRunnerFunc(o.runReactive)
+	})
+	RegisterMode(string(ModeFreeForm), func(o *Orchestrator) ModeRunner {
+		return ModeRunnerFunc(o.runFreeForm)
+	})
+	RegisterMode(string(ModeModerator), func(o *Orchestrator) ModeRunner {
+		return ModeRunnerFunc(o.runModerator)
+	})
+}
+
+// RegisterMode makes a conversation mode available to Start under name.
+// Registering an existing name replaces it. The name is also accepted by
+// config validation, so it can be used as orchestrator.mode in config files.
+// Custom modes drive the conversation with Agents, Config, and TakeTurn.
+func RegisterMode(name string, factory ModeFactory) {
+	modeRegistry.mu.Lock()
+	modeRegistry.factories[ConversationMode(name)] = factory
+	modeRegistry.mu.Unlock()
+
+	config.RegisterMode(name)
+}
+
+// UnregisterMode removes a mode added with RegisterMode, so Start and config
+// validation no longer accept it.
+func UnregisterMode(name string) {
+	modeRegistry.mu.Lock()
+	delete(modeRegistry.factories, ConversationMode(name))
+	modeRegistry.mu.Unlock()
+
+	config.UnregisterMode(name)
+}
+
+// RegisteredModes returns the names of all registered modes, sorted.
+func RegisteredModes() []string {
+	modeRegistry.mu.RLock()
+	defer modeRegistry.mu.RUnlock()
+
+	names := make([]string, 0, len(modeRegistry.factories))
+	for mode := range modeRegistry.factories {
+		names = append(names, string(mode))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasMode reports whether a mode is registered under name.
+func HasMode(name string) bool {
+	_, ok := lookupMode(ConversationMode(name))
+	return ok
+}
+
+// modeDescriptions describes the built-in modes for "agentpipe run --list-modes".
+var modeDescriptions = map[ConversationMode]string{
+	ModeRoundRobin: "Agents take turns in a fixed circular order",
+	ModeReactive:   "A random agent responds next, never the same agent twice in a row, unless one is @mentioned",
+	ModeFreeForm:   "Every agent may respond each round if it wants to participate",
+	ModeModerator:  "A moderator agent names who speaks next before every turn",
+}
+
+// ModeDescription returns a one-line description of the named mode.
+func ModeDescription(name string) string {
+	if description, ok := modeDescriptions[ConversationMode(name)]; ok {
+		return description
+	}
+	return "Custom mode"
+}
+
+// lookupMode returns the factory registered for mode.
+func lookupMode(mode ConversationMode) (ModeFactory, bool) {
+	modeRegistry.mu.RLock()
+	defer modeRegistry.mu.RUnlock()
+
+	factory, ok := modeRegistry.factories[mode]
+	return factory, ok
+}
+
+// Agents returns the agents in the conversation, in the order they were added.
+func (o *Orchestrator) Agents() []agent.Agent {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	agents := make([]agent.Agent, len(o.agents))
+	copy(agents, o.agents)
+	return agents
+}
+
+// Config returns the orchestrator's configuration.
+func (o *Orchestrator) Config() OrchestratorConfig {
+	return o.config
+}
+
+// TakeTurn asks a for a response and adds it to the conversation.
+// It reports whether the agent responded. A failed turn is counted and
+// reported; the returned error is non-nil only when the conversation must stop
+// (StopOnError, ErrStoppedBeforeTurn when the BeforeTurn callback ends it, or
+// ctx's error when ctx ends during the turn).
+// Agents disabled after MaxConsecutiveFailures are not asked.
+func (o *Orchestrator) TakeTurn(ctx context.Context, a agent.Agent) (bool, error) {
+	if o.AgentDisabled(a) {
+		return false, nil
+	}
+	if !o.continueBeforeTurn() {
+		return false, ErrStoppedBeforeTurn
+	}
+	if err := o.getAgentResponse(ctx, a); err != nil {
+		if ctx.Err() != nil {
+			return false, ctx.Err() // the conversation ended mid-turn
+		}
+		o.recordFailedResponse(a)
+		if o.config.StopOnError {
+			return false, o.stopOnAgentError(a, err)
+		}
+		if o.logger != nil {
+			o.logger.LogError(a.GetName(), err)
+		}
+		if o.writer != nil {
+			fmt.Fprintf(o.writer, "\n[Error] Agent %s failed: %v\n", a.GetName(), err)
+		}
+		return false, nil
+	}
+	return true, nil
+}
@@ -9,6 +9,7 @@ import (
 	"io"
 	"math"
 	"math/rand"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -30,15 +31,18 @@ type ConversationMode string
 const (
 	// ModeRoundRobin has agents take turns in a fixed circular order
 	ModeRoundRobin ConversationMode = "round-robin"
-	// ModeReactive randomly selects the next agent, but never the same agent twice in a row
+	// ModeReactive randomly selects the next agent, but never the same agent twice in a row.
+	// An agent @mentioned in the last message is selected instead.
 	ModeReactive ConversationMode = "reactive"
 	// ModeFreeForm allows all agents to respond if they want to participate
 	ModeFreeForm ConversationMode = "free-form"
+	// ModeModerator has a moderator agent name the next speaker before every turn
+	ModeModerator ConversationMode = "moderator"
 )
 
 // OrchestratorConfig contains configuration for an Orchestrator instance.
 type OrchestratorConfig struct {
-	// Mode determines how agents take turns (round-robin, reactive, or free-form)
+	// Mode determines how agents take turns (round-robin, reactive, free-form, or moderator)
 	Mode ConversationMode
 	// TurnTimeout is the maximum time an agent has to respond
 	TurnTimeout time.Duration
@@ -56,33 +60,161 @@ type OrchestratorConfig struct {
 	RetryMaxDelay time.Duration
 	// RetryMultiplier is the multiplier for exponential backoff (typically 2.0)
 	RetryMultiplier float64
+	// RetryJitter randomizes each retry delay by up to this fraction (0.0-1.0) either
+	// way, so agents that failed together don't retry in lockstep
+	RetryJitter float64
 	// Summary defines conversation summary generation settings
 	Summary config.SummaryConfig
+	// Seed makes random agent selection reproducible (0 = seed from the clock)
+	Seed int64
+	// StopOnError ends the conversation with an error when an agent still fails after
+	// all retries, instead of skipping it and continuing
+	StopOnError bool
+	// Script is a list of prompts injected as user messages at the start of successive
+	// rounds. When set, the conversation ends once every prompt has been answered.
+	Script []string
+	// FinalSummary asks a participant to summarize the conversation when it ends.
+	// The summary is appended as a final system message and reported as the
+	// conversation summary, replacing the Summary agent.
+	FinalSummary bool
+	// FinalSummaryAgent is the ID of the agent that writes the final summary
+	// (default: the first agent)
+	FinalSummaryAgent string
+	// SharedMemory lets agents write shared notes with MEMORY[key]=value lines in
+	// their responses and tells them about the convention in every prompt
+	SharedMemory bool
+	// Cache serves agent responses from an on-disk cache when an agent is sent
+	// the same context as in an earlier run, instead of calling the agent
+	Cache bool
+	// CacheDir is the cache location (default: ~/.agentpipe/cache)
+	CacheDir string
+	// CacheTTL is how long cached responses are reused (default: 24h)
+	CacheTTL time.Duration
+	// Language is the language of the shared history (default: English). Agents
+	// with a different language get messages translated when a translator is set.
+	Language string
+	// TranslatorAgent is the agent type used to translate messages for agents
+	// with a language set (empty = no translation unless SetTranslator is called)
+	TranslatorAgent string
+	// StopPhrase ends the conversation early once StopConsecutive agent responses
+	// in a row contain it (e.g., "agreed"); empty disables the check
+	StopPhrase string
+	// StopConsecutive is how many consecutive responses must contain StopPhrase
+	// (default: the number of agents)
+	StopConsecutive int
+	// ModeratorAgentID is the ID of the agent that picks the next speaker in
+	// moderator mode (default: the first agent). The moderator does not take turns.
+	ModeratorAgentID string
+	// Stream reads responses through each agent's StreamMessage and passes the
+	// partial text to the handler set with SetStreamHandler as it arrives
+	Stream bool
+	// GlobalRateLimit caps agent requests per second across the whole conversation,
+	// in addition to each agent's own rate limit (0 = unlimited)
+	GlobalRateLimit float64
+	// GlobalRateLimitBurst is the burst capacity of the global rate limit (default: 1)
+	GlobalRateLimitBurst int
+	// MaxCost ends the conversation once agent responses and moderator queries
+	// have cost this much in USD (0 = no budget)
+	MaxCost float64
+	// MaxTokens ends the conversation once agent responses and moderator queries
+	// have used this many tokens, input and output combined (0 = no budget)
+	MaxTokens int
+	// MaxConsecutiveFailures disables an agent for the rest of the run once this
+	// many of its turns in a row have failed after all retries (0 = never)
+	MaxConsecutiveFailures int
+	// ReactiveCooldown is how many agent turns an agent is passed over in
+	// reactive mode after it speaks, while other agents are available
+	// (0 = uniform random selection)
+	ReactiveCooldown int
+	// MaxDuration ends the conversation once it has run this long, counted from
+	// Start (0 = no limit). Unlike canceling Start's context, reaching it ends
+	// the conversation normally.
+	MaxDuration time.Duration
+	// ResponseJitter adds a random extra pause of up to this long to each
+	// response delay, so replies feel less mechanical (0 = none)
+	ResponseJitter time.Duration
+}
+
+// ConfigFrom returns the orchestrator configuration for the orchestrator
+// section of cfg. Settings that don't come from the config file, such as the
+// script and retry policy, are left for the caller to set.
+func ConfigFrom(cfg *config.Config) OrchestratorConfig {
+	oc := cfg.Orchestrator
+	return OrchestratorConfig{
+		Mode:                   ConversationMode(oc.Mode),
+		TurnTimeout:            oc.TurnTimeout,
+		MaxTurns:               oc.MaxTurns,
+		ResponseDelay:          oc.ResponseDelay,
+		InitialPrompt:          oc.InitialPrompt,
+		Summary:                oc.Summary,
+		Seed:                   oc.Seed,
+		StopOnError:            oc.StopOnError,
+		SharedMemory:           oc.SharedMemory,
+		FinalSummary:           oc.FinalSummary,
+		FinalSummaryAgent:      oc.FinalSummaryAgent,
+		Cache:                  oc.Cache,
+		CacheTTL:               oc.CacheTTL,
+		Language:               oc.Language,
+		TranslatorAgent:        oc.TranslatorAgent,
+		ModeratorAgentID:       oc.ModeratorAgent,
+		StopPhrase:             oc.StopPhrase,
+		StopConsecutive:        oc.StopConsecutive,
+		Stream:                 oc.Stream,
+		GlobalRateLimit:        oc.GlobalRateLimit,
+		GlobalRateLimitBurst:   oc.GlobalRateLimitBurst,
+		MaxCost:                oc.MaxCost,
+		MaxTokens:              oc.MaxTokens,
+		MaxConsecutiveFailures: oc.MaxConsecutiveFailures,
+		ReactiveCooldown:       oc.ReactiveCooldown,
+		MaxDuration:            oc.MaxDuration,
+		ResponseJitter:         oc.ResponseJitter,
+	}
 }
 
 // Orchestrator coordinates multi-agent conversations.
 // It manages agent registration, turn-taking, message history, and logging.
 // All methods are safe for concurrent use.
 type Orchestrator struct {
-	config            OrchestratorConfig
-	agents            []agent.Agent
-	messages          []agent.Message
-	rateLimiters      map[string]*ratelimit.Limiter // per-agent rate limiters
-	middlewareChain   *middleware.Chain             // message processing middleware
-	mu                sync.RWMutex
-	writer            io.Writer
-	logger            *logger.ChatLogger
-	currentTurnNumber int                     // tracks the current turn number for middleware context
-	metrics           *metrics.Metrics        // Prometheus metrics for monitoring
-	bridgeEmitter     bridge.BridgeEmitter    // optional streaming bridge for real-time updates
-	conversationStart time.Time               // conversation start time for duration tracking
-	commandInfo       *bridge.CommandInfo     // information about the command that started this conversation
-	summary           *bridge.SummaryMetadata // conversation summary (populated after completion if enabled)
+	config              OrchestratorConfig
+	agents              []agent.Agent
+	messages            []agent.Message
+	rateLimiters        map[string]*ratelimit.Limiter // per-agent rate limiters
+	tokenLimiters       map[string]*ratelimit.Limiter // per-agent tokens-per-minute limiters
+	globalLimiter       *ratelimit.Limiter            // conversation-wide request limiter
+	middlewareChain     *middleware.Chain             // message processing middleware
+	mu                  sync.RWMutex
+	writer              io.Writer
+	logger              *logger.ChatLogger
+	currentTurnNumber   int                     // tracks the current turn number for middleware context
+	metrics             *metrics.Metrics        // Prometheus metrics for monitoring
+	bridgeEmitter       bridge.BridgeEmitter    // optional streaming bridge for real-time updates
+	conversationStart   time.Time               // conversation start time for duration tracking
+	commandInfo         *bridge.CommandInfo     // information about the command that started this conversation
+	summary             *bridge.SummaryMetadata // conversation summary (populated after completion if enabled)
+	rng                 *rand.Rand              // random source for agent selection and jitter, seeded from Seed (safe for concurrent use)
+	failedResponses     int                     // agent turns that failed after all retries
+	consecutiveFailures map[string]int          // failed turns in a row by agent ID
+	disabledAgents      map[string]bool         // agents disabled after MaxConsecutiveFailures
+	memory              *ContextStore           // shared notes injected into every prompt
+	filters             []ResponseFilter        // applied to every agent response before it is stored
+	cache               *ResponseCache          // cross-run response cache (nil = disabled)
+	translator          Translator              // translates messages for multilingual agents (nil = disabled)
+	translations        sync.Map                // memoized translations keyed by language and text
+	stopConditions      []StopCondition         // checked after every agent response to end the conversation early
+	resumed             bool                    // history was loaded from an earlier run with LoadMessages
+	resumedTurns        int                     // agent responses in the loaded history
+	streamHandler       StreamHandler           // receives partial responses when Stream is enabled
+	messageCallbacks    []MessageCallback       // notified of every message added to the conversation
+	beforeTurn          func() bool             // called before every agent turn; false ends the conversation
+	sleep               func(time.Duration)     // replaces the response delay wait in tests (nil = wait on a timer)
 }
 
+// ErrNoProgress indicates that a conversation ended without any agent response.
+var ErrNoProgress = errors.New("conversation made no progress: no agent produced a response")
+
 // NewOrchestrator creates a new Orchestrator with the given configuration.
 // Default values are applied if TurnTimeout (30s) or ResponseDelay (1s) are zero.
-// Retry defaults: MaxRetries=3, InitialDelay=1s, MaxDelay=30s, Multiplier=2.0.
+// Retry defaults: MaxRetries=3, InitialDelay=1s, MaxDelay=30s, Multiplier=2.0, Jitter=0.1.
 // To disable retries, explicitly set all retry fields (at minimum RetryInitialDelay)
 // The writer receives formatted conversation output for display (e.g., TUI).
 func NewOrchestrator(config OrchestratorConfig, writer io.Writer) *Orchestrator {
@@ -101,6 +233,7 @@ func NewOrchestrator(config OrchestratorConfig, writer io.Writer) *Orchestrator
 		config.RetryInitialDelay = 1 * time.Second
 		config.RetryMaxDelay = 30 * time.Second
 		config.RetryMultiplier = 2.0
+		config.RetryJitter = 0.1
 	} else {
 		// Retry config is being used, apply individual defaults for unset fields
 		if config.RetryInitialDelay == 0 {
@@ -112,17 +245,45 @@ func NewOrchestrator(config OrchestratorConfig, writer io.Writer) *Orchestrator
 		if config.RetryMultiplier == 0 {
 			config.RetryMultiplier = 2.0
 		}
-		// Don't override MaxRetries if user set other retry fields
+		// Don't override MaxRetries or RetryJitter if user set other retry fields
+	}
+
+	// Every random choice draws from rng, so a seed reproduces all of them
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(&lockedSource{src: rand.NewSource(seed)})
+
+	var cache *ResponseCache
+	if config.Cache {
+		dir := config.CacheDir
+		if dir == "" {
+			var err error
+			if dir, err = GetDefaultCacheDir(); err != nil {
+				log.WithError(err).Warn("response cache disabled")
+			}
+		}
+		if dir != "" {
+			cache = NewResponseCache(dir, config.CacheTTL)
+		}
 	}
 
 	return &Orchestrator{
-		config:            config,
-		agents:            make([]agent.Agent, 0),
-		messages:          make([]agent.Message, 0),
-		rateLimiters:      make(map[string]*ratelimit.Limiter),
-		middlewareChain:   middleware.NewChain(),
-		writer:            writer,
-		currentTurnNumber: 0,
+		config:              config,
+		agents:              make([]agent.Agent, 0),
+		messages:            make([]agent.Message, 0),
+		rateLimiters:        make(map[string]*ratelimit.Limiter),
+		tokenLimiters:       make(map[string]*ratelimit.Limiter),
+		globalLimiter:       ratelimit.NewLimiter(config.GlobalRateLimit, config.GlobalRateLimitBurst),
+		middlewareChain:     middleware.NewChain(),
+		writer:              writer,
+		currentTurnNumber:   0,
+		rng:                 rng,
+		memory:              NewContextStore(),
+		cache:               cache,
+		consecutiveFailures: make(map[string]int),
+		disabledAgents:      make(map[string]bool),
 	}
 }
 
@@ -168,6 +329,36 @@ func (o *Orchestrator) SetCommandInfo(info *bridge.CommandInfo) {
 	o.commandInfo = info
 }
 
+// MessageCallback receives each message as it is added to the conversation,
+// including announcements, prompts, and agent responses with their metrics.
+type MessageCallback func(msg agent.Message)
+
+// OnMessage registers a callback notified of every new message. Callbacks are
+// called synchronously, in registration order, as each message is stored, so
+// they should return quickly, and they must not call back into the orchestrator.
+// This method is thread-safe.
+func (o *Orchestrator) OnMessage(cb MessageCallback) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.messageCallbacks = append(o.messageCallbacks, cb)
+}
+
+// notifyMessage passes msg to the registered message callbacks.
+func (o *Orchestrator) notifyMessage(msg agent.Message) {
+	o.mu.RLock()
+	callbacks := o.messageCallbacks
+	o.mu.RUnlock()
+
+	runMessageCallbacks(callbacks, msg)
+}
+
+// runMessageCallbacks calls each callback with msg.
+func runMessageCallbacks(callbacks []MessageCallback, msg agent.Message) {
+	for _, cb := range callbacks {
+		cb(msg)
+	}
+}
+
 // emitConversationCompleted emits the conversation.completed event if bridge is enabled.
 // This helper method calculates the conversation statistics and duration.
 func (o *Orchestrator) emitConversationCompleted(status string, summary *bridge.SummaryMetadata) {
@@ -287,33 +478,62 @@ func parseDualSummary(response string) (shortText, fullText string, err error) {
 }
 
 // generateSummary generates a summary of the conversation using the configured summary agent.
+// With FinalSummary, a participant writes it and it is also appended to the conversation.
 // Returns nil if summary is disabled or if generation fails.
 func (o *Orchestrator) generateSummary(ctx context.Context) *bridge.SummaryMetadata {
 	// Check if summary is enabled
-	if !o.config.Summary.Enabled {
+	if !o.config.Summary.Enabled && !o.config.FinalSummary {
 		return nil
 	}
 
 	// Get conversation messages
 	messages := o.getMessages()
-	if len(messages) == 0 {
+	if summaryTranscript(messages) == "" {
 		return nil
 	}
 
-	// Build conversation text for summary
-	var conversationText strings.Builder
-	for _, msg := range messages {
-		// Skip system messages
-		if msg.Role == "system" {
-			continue
-		}
-		conversationText.WriteString(fmt.Sprintf("%s: %s\n\n", msg.AgentName, msg.Content))
+	summaryAgent, err := o.summarizer()
+	if err != nil {
+		log.WithError(err).Warn("failed to create summary agent")
+		return nil
 	}
 
-	if conversationText.Len() == 0 {
+	// Generate summary with a timeout
+	summaryCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if err := o.waitGlobalRateLimit(summaryCtx, summaryAgent); err != nil {
+		log.WithError(err).Warn("failed to generate conversation summary")
 		return nil
 	}
 
+	summaryMetadata, err := Summarize(summaryCtx, summaryAgent, messages)
+	if err != nil {
+		log.WithError(err).Warn("failed to generate conversation summary")
+		return nil
+	}
+
+	// Store summary in orchestrator for later access
+	o.mu.Lock()
+	o.summary = summaryMetadata
+	o.mu.Unlock()
+
+	if o.config.FinalSummary {
+		o.appendFinalSummary(summaryAgent, summaryMetadata.Text)
+	}
+
+	return summaryMetadata
+}
+
+// Summarize asks a for a short (1-2 sentence) and a full summary of the
+// agent messages in messages. It returns the summaries together with the
+// agent, model, token, cost and duration details of the request.
+func Summarize(ctx context.Context, a agent.Agent, messages []agent.Message) (*bridge.SummaryMetadata, error) {
+	transcript := summaryTranscript(messages)
+	if transcript == "" {
+		return nil, fmt.Errorf("no agent messages to summarize")
+	}
+
 	// Create summary prompt for dual summaries
 	summaryPrompt := fmt.Sprintf(`Please provide two summaries of the following conversation:
 
@@ -327,30 +547,7 @@ FULL: [your detailed summary here]
 Do not include meta-commentary about the conversation structure (e.g., "This is a conversation between agents").
 
 Conversation:
-%s`, conversationText.String())
-
-	// Create a temporary agent for summary generation
-	summaryAgent, err := agent.CreateAgent(agent.AgentConfig{
-		ID:   "summary-agent",
-		Type: o.config.Summary.Agent,
-		Name: "Summary",
-	})
-
-	if err != nil || summaryAgent == nil {
-		log.WithField("agent_type", o.config.Summary.Agent).WithError(err).Warn("failed to create summary agent")
-		return nil
-	}
-
-	// Initialize the summary agent
-	err = summaryAgent.Initialize(agent.AgentConfig{
-		ID:   "summary-agent",
-		Type: o.config.Summary.Agent,
-		Name: "Summary",
-	})
-	if err != nil {
-		log.WithError(err).Warn("failed to initialize summary agent")
-		return nil
-	}
+%s`, transcript)
 
 	// Create summary messages
 	summaryMessages := []agent.Message{
@@ -359,24 +556,20 @@ Conversation:
 			AgentName: "SYSTEM",
 			Content:   summaryPrompt,
 			Timestamp: time.Now().Unix(),
-			Role:      "user",
+			Role:      agent.RoleUser,
 		},
 	}
 
-	// Generate summary with a timeout
-	summaryCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
-	defer cancel()
-
 	// Calculate input tokens from conversation text
-	inputTokens := utils.EstimateTokens(conversationText.String())
+	model := a.GetModel()
+	inputTokens := utils.CountTokens(model, transcript)
 
 	startTime := time.Now()
-	response, err := summaryAgent.SendMessage(summaryCtx, summaryMessages)
+	response, err := a.SendMessage(ctx, summaryMessages)
 	duration := time.Since(startTime)
 
 	if err != nil {
-		log.WithError(err).Warn("failed to generate conversation summary")
-		return nil
+		return nil, err
 	}
 
 	// Parse dual summary from response
@@ -399,29 +592,104 @@ Conversation:
 	}
 
 	// Calculate metrics
-	outputTokens := utils.EstimateTokens(response)
+	outputTokens := utils.CountTokens(model, response)
 	totalTokens := inputTokens + outputTokens
-	model := summaryAgent.GetModel()
 	cost := utils.EstimateCost(model, inputTokens, outputTokens)
 
-	summaryMetadata := &bridge.SummaryMetadata{
+	return &bridge.SummaryMetadata{
 		ShortText:    shortSummary,
 		Text:         fullSummary,
-		AgentType:    o.config.Summary.Agent,
+		AgentType:    a.GetType(),
 		Model:        model,
 		InputTokens:  inputTokens,
 		OutputTokens: outputTokens,
 		TotalTokens:  totalTokens,
 		Cost:         cost,
 		DurationMs:   duration.Milliseconds(),
+	}, nil
+}
+
+// summaryTranscript renders the non-system messages as "Name: content" blocks
+// for the summary prompt.
+func summaryTranscript(messages []agent.Message) string {
+	var conversationText strings.Builder
+	for _, msg := range messages {
+		// Skip system messages
+		if msg.Role == agent.RoleSystem {
+			continue
+		}
+		conversationText.WriteString(fmt.Sprintf("%s: %s\n\n", msg.AgentName, msg.Content))
+	}
+	return conversationText.String()
+}
+
+// summarizer returns the agent that writes the conversation summary. With
+// FinalSummary it is the FinalSummaryAgent participant (default: the first
+// agent); otherwise a temporary agent of the configured summary type.
+func (o *Orchestrator) summarizer() (agent.Agent, error) {
+	if o.config.FinalSummary {
+		o.mu.RLock()
+		defer o.mu.RUnlock()
+
+		if len(o.agents) == 0 {
+			return nil, fmt.Errorf("no agents configured")
+		}
+		if id := o.config.FinalSummaryAgent; id != "" {
+			for _, a := range o.agents {
+				if a.GetID() == id {
+					return a, nil
+				}
+			}
+			return nil, fmt.Errorf("summary agent %s is not in the conversation", id)
+		}
+		return o.agents[0], nil
+	}
+
+	// Create a temporary agent for summary generation
+	summaryAgent, err := agent.CreateAgent(agent.AgentConfig{
+		ID:   "summary-agent",
+		Type: o.config.Summary.Agent,
+		Name: "Summary",
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create summary agent %s: %w", o.config.Summary.Agent, err)
+	}
+
+	// Initialize the summary agent
+	err = summaryAgent.Initialize(agent.AgentConfig{
+		ID:   "summary-agent",
+		Type: o.config.Summary.Agent,
+		Name: "Summary",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize summary agent: %w", err)
+	}
+
+	return summaryAgent, nil
+}
+
+// appendFinalSummary adds the summary to the end of the conversation as a system message.
+func (o *Orchestrator) appendFinalSummary(a agent.Agent, summary string) {
+	msg := agent.Message{
+		AgentID:   "summary",
+		AgentName: "Summary",
+		Content:   fmt.Sprintf("Conversation summary (by %s):\n\n%s", a.GetName(), summary),
+		Timestamp: time.Now().Unix(),
+		Role:      agent.RoleSystem,
 	}
 
-	// Store summary in orchestrator for later access
 	o.mu.Lock()
-	o.summary = summaryMetadata
+	o.messages = append(o.messages, msg)
 	o.mu.Unlock()
 
-	return summaryMetadata
+	if o.logger != nil {
+		o.logger.LogMessage(msg)
+	}
+	o.notifyMessage(msg)
+	if o.writer != nil {
+		fmt.Fprintf(o.writer, "\n[Summary] %s\n", summary)
+	}
 }
 
 // AddMiddleware adds a middleware to the orchestrator's processing chain.
@@ -459,34 +727,77 @@ func (o *Orchestrator) AddAgent(a agent.Agent) {
 	rateLimitBurst := a.GetRateLimitBurst()
 	o.rateLimiters[a.GetID()] = ratelimit.NewLimiter(rateLimit, rateLimitBurst)
 
+	// Create tokens-per-minute limiter if the agent has a token budget
+	tokensPerMinute := 0
+	if tb, ok := a.(agent.TokenBudgeted); ok {
+		tokensPerMinute = tb.GetTokensPerMinute()
+	}
+	if tokensPerMinute > 0 {
+		o.tokenLimiters[a.GetID()] = ratelimit.NewTokenLimiter(tokensPerMinute)
+	}
+
 	log.WithFields(map[string]interface{}{
-		"agent_id":   a.GetID(),
-		"agent_name": a.GetName(),
-		"agent_type": a.GetType(),
-		"rate_limit": rateLimit,
-		"burst":      rateLimitBurst,
+		"agent_id":          a.GetID(),
+		"agent_name":        a.GetName(),
+		"agent_type":        a.GetType(),
+		"rate_limit":        rateLimit,
+		"burst":             rateLimitBurst,
+		"tokens_per_minute": tokensPerMinute,
 	}).Info("agent added to orchestrator")
 
-	announcement := agent.Message{
-		AgentID:   a.GetID(),
-		AgentName: a.GetName(),
-		AgentType: a.GetType(),
-		Content:   a.Announce(),
-		Timestamp: time.Now().Unix(),
-		Role:      "system",
-	}
+	announcement := announcementMessage(a)
 	o.messages = append(o.messages, announcement)
 
 	// Log using the logger if available
 	if o.logger != nil {
 		o.logger.LogMessage(announcement)
 	}
+	// The lock is held here, so the callbacks are read directly
+	runMessageCallbacks(o.messageCallbacks, announcement)
 	// Always write to writer if available (for TUI)
 	if o.writer != nil {
 		fmt.Fprintf(o.writer, "\n[System] %s\n", announcement.Content)
 	}
 }
 
+// announcementMessage returns the system message announcing that a joined the conversation.
+func announcementMessage(a agent.Agent) agent.Message {
+	return agent.Message{
+		AgentID:   a.GetID(),
+		AgentName: a.GetName(),
+		AgentType: a.GetType(),
+		Content:   a.Announce(),
+		Timestamp: time.Now().Unix(),
+		Role:      agent.RoleSystem,
+	}
+}
+
+// initialPromptMessage returns the host message that opens the conversation with prompt.
+func initialPromptMessage(prompt string) agent.Message {
+	return agent.Message{
+		AgentID:   "host",
+		AgentName: "HOST",
+		Content:   prompt,
+		Timestamp: time.Now().Unix(),
+		Role:      agent.RoleSystem,
+	}
+}
+
+// OpeningMessages returns the messages a new conversation starts with: an
+// announcement for each agent followed by the initial prompt, if any. It lets
+// callers such as --dry-run see what the first speaker will be sent without
+// starting the conversation.
+func OpeningMessages(agents []agent.Agent, initialPrompt string) []agent.Message {
+	messages := make([]agent.Message, 0, len(agents)+1)
+	for _, a := range agents {
+		messages = append(messages, announcementMessage(a))
+	}
+	if initialPrompt != "" {
+		messages = append(messages, initialPromptMessage(initialPrompt))
+	}
+	return messages
+}
+
 // Start begins the multi-agent conversation using the configured orchestration mode.
 // It returns an error if no agents are registered or if the orchestration mode is invalid.
 // The conversation continues until MaxTurns is reached, the context is canceled, or an error occurs.
@@ -512,6 +823,20 @@ func (o *Orchestrator) Start(ctx context.Context) error {
 
 	// Record conversation start time for duration tracking
 	o.conversationStart = time.Now()
+	if o.metrics != nil {
+		defer func() {
+			o.metrics.RecordConversationDuration(string(o.config.Mode), time.Since(o.conversationStart).Seconds())
+		}()
+	}
+
+	// Create the translator agent if a participant converses in another language
+	o.setupTranslator()
+
+	// End the conversation early once agents agree, if a stop phrase is set
+	o.setupStopConditions()
+
+	// Load the agents' tokenizers now rather than in the middle of a turn
+	o.preloadTokenizers()
 
 	// Track return error to determine status
 	var runErr error
@@ -575,14 +900,10 @@ func (o *Orchestrator) Start(ctx context.Context) error {
 		)
 	}
 
-	if o.config.InitialPrompt != "" {
-		initialMsg := agent.Message{
-			AgentID:   "host",
-			AgentName: "HOST",
-			Content:   o.config.InitialPrompt,
-			Timestamp: time.Now().Unix(),
-			Role:      "system",
-		}
+	if o.resumed {
+		o.announceResume()
+	} else if o.config.InitialPrompt != "" {
+		initialMsg := initialPromptMessage(o.config.InitialPrompt)
 		o.mu.Lock()
 		o.messages = append(o.messages, initialMsg)
 		o.mu.Unlock()
@@ -591,34 +912,41 @@ func (o *Orchestrator) Start(ctx context.Context) error {
 		if o.logger != nil {
 			o.logger.LogMessage(initialMsg)
 		}
+		o.notifyMessage(initialMsg)
 		// Always write to writer if available (for TUI)
 		if o.writer != nil {
 			fmt.Fprintf(o.writer, "\n[HOST] %s\n", initialMsg.Content)
 		}
 	}
 
-	switch o.config.Mode {
-	case ModeRoundRobin:
-		runErr = o.runRoundRobin(ctx)
-		return runErr
-	case ModeReactive:
-		runErr = o.runReactive(ctx)
-		return runErr
-	case ModeFreeForm:
-		runErr = o.runFreeForm(ctx)
-		return runErr
-	default:
+	factory, ok := lookupMode(o.config.Mode)
+	if !ok {
 		log.WithField("mode", o.config.Mode).Error("unknown conversation mode")
 		errMsg := fmt.Sprintf("unknown conversation mode: %s", o.config.Mode)
 		o.emitConversationError(errMsg, "configuration", "orchestrator")
 		runErr = fmt.Errorf("unknown conversation mode: %s", o.config.Mode)
 		return runErr
 	}
+
+	runCtx, cancel := o.durationContext(ctx)
+	defer cancel()
+
+	runErr = factory(o).Run(runCtx)
+	if errors.Is(runErr, ErrStoppedBeforeTurn) {
+		runErr = nil // a requested stop, not a failure
+	}
+	if o.maxDurationReached(ctx, runCtx, runErr) {
+		runErr = nil
+	}
+	return runErr
 }
 
 func (o *Orchestrator) runRoundRobin(ctx context.Context) error {
-	turns := 0
-	agentIndex := 0
+	order := o.roundRobinOrder()
+
+	// A resumed conversation continues mid-round with the next agent
+	turns := o.ResumedTurns() / len(order)
+	agentIndex := o.ResumedTurns() % len(order)
 
 	for {
 		select {
@@ -638,22 +966,44 @@ func (o *Orchestrator) runRoundRobin(ctx context.Context) error {
 			break
 		}
 
-		currentAgent := o.agents[agentIndex]
+		if o.allDisabled(order) {
+			o.announceAllDisabled()
+			break
+		}
+
+		if agentIndex == 0 && !o.beginRound(turns) {
+			break
+		}
 
-		if err := o.getAgentResponse(ctx, currentAgent); err != nil {
-			if o.logger != nil {
-				o.logger.LogError(currentAgent.GetName(), err)
-				o.logger.LogSystem("Continuing conversation with remaining agents...")
+		// Disabled agents' turns are skipped without a response delay
+		if currentAgent := order[agentIndex]; !o.AgentDisabled(currentAgent) {
+			if !o.continueBeforeTurn() {
+				break
 			}
-			if o.writer != nil {
-				fmt.Fprintf(o.writer, "\n[Error] Agent %s failed: %v\n", currentAgent.GetName(), err)
-				fmt.Fprintf(o.writer, "[Info] Continuing conversation with remaining agents...\n")
+			if err := o.getAgentResponse(ctx, currentAgent); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err() // the conversation ended mid-turn
+				}
+				o.recordFailedResponse(currentAgent)
+				if o.config.StopOnError {
+					return o.stopOnAgentError(currentAgent, err)
+				}
+				if o.logger != nil {
+					o.logger.LogError(currentAgent.GetName(), err)
+					o.logger.LogSystem("Continuing conversation with remaining agents...")
+				}
+				if o.writer != nil {
+					fmt.Fprintf(o.writer, "\n[Error] Agent %s failed: %v\n", currentAgent.GetName(), err)
+					fmt.Fprintf(o.writer, "[Info] Continuing conversation with remaining agents...\n")
+				}
+			} else if o.stopConditionMet() {
+				break
 			}
-		}
 
-		time.Sleep(o.config.ResponseDelay)
+			o.pause(ctx, currentAgent)
+		}
 
-		agentIndex = (agentIndex + 1) % len(o.agents)
+		agentIndex = (agentIndex + 1) % len(order)
 		if agentIndex == 0 {
 			turns++
 		}
@@ -663,8 +1013,9 @@ func (o *Orchestrator) runRoundRobin(ctx context.Context) error {
 }
 
 func (o *Orchestrator) runReactive(ctx context.Context) error {
-	turns := 0
-	lastSpeaker := ""
+	turns := o.ResumedTurns()
+	lastSpeaker := o.lastResponder()
+	nextRound := o.resumedRounds(len(o.agents))
 
 	for {
 		select {
@@ -684,29 +1035,56 @@ func (o *Orchestrator) runReactive(ctx context.Context) error {
 			break
 		}
 
+		if o.allDisabled(o.Agents()) {
+			o.announceAllDisabled()
+			break
+		}
+
+		// A reactive round is as many responses as there are agents
+		if turns >= nextRound*len(o.agents) {
+			if !o.beginRound(nextRound) {
+				break
+			}
+			nextRound++
+		}
+
 		nextAgent := o.selectNextAgent(lastSpeaker)
 		if nextAgent == nil {
-			time.Sleep(o.config.ResponseDelay)
+			o.pause(ctx, nil)
 			continue
 		}
+		if !o.continueBeforeTurn() {
+			break
+		}
 
 		if err := o.getAgentResponse(ctx, nextAgent); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err() // the conversation ended mid-turn
+			}
+			o.recordFailedResponse(nextAgent)
+			if o.config.StopOnError {
+				return o.stopOnAgentError(nextAgent, err)
+			}
 			if o.writer != nil {
 				fmt.Fprintf(o.writer, "\n[Error] Agent %s failed: %v\n", nextAgent.GetName(), err)
 			}
 		} else {
 			lastSpeaker = nextAgent.GetID()
 			turns++
+			if o.stopConditionMet() {
+				break
+			}
 		}
 
-		time.Sleep(o.config.ResponseDelay)
+		o.pause(ctx, nextAgent)
 	}
 
 	return nil
 }
 
 func (o *Orchestrator) runFreeForm(ctx context.Context) error {
-	turns := 0
+	turns := o.ResumedTurns()
+	round := o.resumedRounds(len(o.agents))
 
 	for {
 		select {
@@ -726,16 +1104,43 @@ func (o *Orchestrator) runFreeForm(ctx context.Context) error {
 			break
 		}
 
-		for _, a := range o.agents {
-			if shouldRespond(o.getMessages(), a) {
+		if o.allDisabled(o.Agents()) {
+			o.announceAllDisabled()
+			break
+		}
+
+		if !o.beginRound(round) {
+			break
+		}
+		round++
+
+		// The last active agent carries on alone once every other agent is disabled
+		active := o.activeAgents(o.Agents())
+		alone := len(active) == 1 && len(o.agents) > 1
+
+		for _, a := range active {
+			if alone || shouldRespond(o.getMessages(), a) {
+				if !o.continueBeforeTurn() {
+					return nil
+				}
 				if err := o.getAgentResponse(ctx, a); err != nil {
+					if ctx.Err() != nil {
+						return ctx.Err() // the conversation ended mid-turn
+					}
+					o.recordFailedResponse(a)
+					if o.config.StopOnError {
+						return o.stopOnAgentError(a, err)
+					}
 					if o.writer != nil {
 						fmt.Fprintf(o.writer, "\n[Error] Agent %s failed: %v\n", a.GetName(), err)
 					}
 				} else {
 					turns++
+					if o.stopConditionMet() {
+						return nil
+					}
 				}
-				time.Sleep(o.config.ResponseDelay)
+				o.pause(ctx, a)
 			}
 		}
 	}
@@ -743,7 +1148,88 @@ func (o *Orchestrator) runFreeForm(ctx context.Context) error {
 	return nil
 }
 
-func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) error {
+// beginRound is called at the start of every round (0-based). If a script is
+// configured, it injects the round's prompt and returns false once the script
+// is exhausted, ending the conversation.
+func (o *Orchestrator) beginRound(round int) bool {
+	if len(o.config.Script) == 0 {
+		return true
+	}
+
+	if round >= len(o.config.Script) {
+		endMsg := "Script complete. Conversation ended."
+		if o.logger != nil {
+			o.logger.LogSystem(endMsg)
+		}
+		if o.writer != nil {
+			fmt.Fprintln(o.writer, "\n[System] "+endMsg)
+		}
+		return false
+	}
+
+	log.WithFields(map[string]interface{}{
+		"round":         round + 1,
+		"total_rounds":  len(o.config.Script),
+		"prompt_length": len(o.config.Script[round]),
+	}).Info("injecting scripted prompt")
+
+	o.InjectMessage(agent.Message{
+		AgentID:   "user",
+		AgentName: "User",
+		Content:   o.config.Script[round],
+		Timestamp: time.Now().Unix(),
+		Role:      agent.RoleUser,
+	})
+	return true
+}
+
+// InjectMessage adds a message to the conversation history while it is running,
+// for example a user interjection. Agents see it on their next turn.
+// This method is thread-safe.
+func (o *Orchestrator) InjectMessage(msg agent.Message) {
+	if msg.Timestamp == 0 {
+		msg.Timestamp = time.Now().Unix()
+	}
+	if msg.Role == "" {
+		msg.Role = agent.RoleUser
+	}
+
+	o.mu.Lock()
+	o.messages = append(o.messages, msg)
+	o.mu.Unlock()
+
+	if o.logger != nil {
+		o.logger.LogMessage(msg)
+	}
+	o.notifyMessage(msg)
+	if o.writer != nil {
+		fmt.Fprintf(o.writer, "\n[%s] %s\n", msg.AgentName, msg.Content)
+	}
+}
+
+// stopOnAgentError reports an agent failure that ends the conversation in strict mode.
+func (o *Orchestrator) stopOnAgentError(a agent.Agent, err error) error {
+	log.WithError(err).WithField("agent_name", a.GetName()).Error("stopping conversation: agent failed in strict mode")
+
+	endMsg := fmt.Sprintf("Agent %s failed: %v. Stopping conversation (strict mode).", a.GetName(), err)
+	if o.logger != nil {
+		o.logger.LogError(a.GetName(), err)
+		o.logger.LogSystem(endMsg)
+	}
+	if o.writer != nil {
+		fmt.Fprintf(o.writer, "\n[Error] %s\n", endMsg)
+	}
+
+	return fmt.Errorf("agent %s failed: %w", a.GetName(), err)
+}
+
+func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) (err error) {
+	defer func() {
+		if err == nil {
+			o.resetFailures(a)
+		}
+	}()
+
 	// Apply rate limiting before attempting to get response
 	o.mu.RLock()
 	limiter := o.rateLimiters[a.GetID()]
@@ -766,27 +1252,68 @@ func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) erro
 
 	messages := o.getMessages()
 
+	// Shared notes go first so every agent sees the current scratchpad
+	if notes, ok := o.sharedNotesMessage(); ok {
+		messages = append([]agent.Message{notes}, messages...)
+	}
+
+	// Agents with their own language see the others' messages translated
+	messages = o.translateInbound(ctx, a, messages)
+
+	// Get model from agent; it selects the tokenizer and pricing
+	model := a.GetModel()
+
 	// Calculate input tokens from conversation history (once, outside retry loop)
 	var inputBuilder strings.Builder
 	for _, msg := range messages {
 		inputBuilder.WriteString(msg.Content)
 		inputBuilder.WriteString(" ")
 	}
-	inputTokens := utils.EstimateTokens(inputBuilder.String())
+	inputTokens := utils.CountTokens(model, inputBuilder.String())
+
+	// Serve the turn from the response cache when an identical context was seen before
+	var response string
+	var startTime time.Time
+	var cacheKey string
+	cached := false
+	if o.cache != nil {
+		cacheKey = responseCacheKey(a, model, messages)
+		startTime = time.Now()
+		response, cached = o.cache.Get(cacheKey)
+	}
+
+	// Reserve the estimated input tokens against the agent's tokens-per-minute budget
+	o.mu.RLock()
+	tokenLimiter := o.tokenLimiters[a.GetID()]
+	o.mu.RUnlock()
+
+	if tokenLimiter != nil && !cached {
+		if err := tokenLimiter.WaitN(ctx, inputTokens); err != nil {
+			if o.metrics != nil {
+				o.metrics.RecordRateLimitHit(a.GetName())
+			}
+
+			log.WithFields(map[string]interface{}{
+				"agent_id":     a.GetID(),
+				"agent_name":   a.GetName(),
+				"input_tokens": inputTokens,
+			}).WithError(err).Error("token rate limit wait failed")
+			return fmt.Errorf("token rate limit wait failed: %w", err)
+		}
+	}
 
 	log.WithFields(map[string]interface{}{
 		"agent_id":     a.GetID(),
 		"agent_name":   a.GetName(),
 		"input_tokens": inputTokens,
 		"max_retries":  o.config.MaxRetries,
+		"cached":       cached,
 	}).Debug("requesting agent response")
 
-	// Retry loop with exponential backoff
+	// Retry loop with exponential backoff (skipped on a cache hit)
 	var lastErr error
-	var response string
-	var startTime time.Time
 
-	for attempt := 0; attempt <= o.config.MaxRetries; attempt++ {
+	for attempt := 0; !cached && attempt <= o.config.MaxRetries; attempt++ {
 		// Apply exponential backoff delay before retry (skip on first attempt)
 		if attempt > 0 {
 			// Record retry attempt metric
@@ -810,13 +1337,26 @@ func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) erro
 			case <-ctx.Done():
 				return ctx.Err()
 			}
+
+			// Respect the (possibly reduced) rate before retrying a rate-limited request
+			if limiter != nil && classifyError(lastErr) == "rate_limit" {
+				if err := limiter.Wait(ctx); err != nil {
+					return fmt.Errorf("rate limit wait failed: %w", err)
+				}
+			}
+		}
+
+		// The global limiter is shared by all agents, so it bounds the conversation
+		// as a whole; every request sent, including retries, counts against it
+		if err := o.waitGlobalRateLimit(ctx, a); err != nil {
+			return err
 		}
 
-		timeoutCtx, cancel := context.WithTimeout(ctx, o.config.TurnTimeout)
+		timeoutCtx, cancel := context.WithTimeout(ctx, o.turnTimeout(a))
 		startTime = time.Now()
 
 		// Attempt to get response
-		response, lastErr = a.SendMessage(timeoutCtx, messages)
+		response, lastErr = o.sendMessage(timeoutCtx, a, messages)
 		cancel()
 
 		if lastErr == nil {
@@ -829,6 +1369,11 @@ func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) erro
 			break
 		}
 
+		// Back off the agent's request rate when the upstream reports a rate limit
+		if classifyError(lastErr) == "rate_limit" {
+			o.handleRateLimitError(a, limiter)
+		}
+
 		// Log retry attempt
 		if o.logger != nil {
 			o.logger.LogError(a.GetName(), fmt.Errorf("attempt %d/%d failed: %w", attempt+1, o.config.MaxRetries+1, lastErr))
@@ -853,12 +1398,7 @@ func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) erro
 		}).WithError(lastErr).Error("all agent request attempts failed")
 
 		// Determine error type
-		errorType := "unknown"
-		if strings.Contains(lastErr.Error(), "timeout") || strings.Contains(lastErr.Error(), "deadline") {
-			errorType = "timeout"
-		} else if strings.Contains(lastErr.Error(), "rate limit") {
-			errorType = "rate_limit"
-		}
+		errorType := classifyError(lastErr)
 
 		// Record error metric
 		if o.metrics != nil {
@@ -872,16 +1412,36 @@ func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) erro
 		return lastErr
 	}
 
-	// Calculate metrics
+	// Let an adaptively backed-off limiter recover toward its configured rate
+	if limiter != nil {
+		limiter.RecordSuccess()
+	}
+
+	// Calculate metrics; agents that report exact usage replace the estimates
 	duration := time.Since(startTime)
-	outputTokens := utils.EstimateTokens(response)
+	outputTokens := utils.CountTokens(model, response)
+	if reporter, ok := a.(agent.UsageReporter); ok && !cached {
+		if usage, ok := reporter.LastUsage(); ok {
+			inputTokens, outputTokens = usage.InputTokens, usage.OutputTokens
+		}
+	}
 	totalTokens := inputTokens + outputTokens
 
-	// Get model from agent
-	model := a.GetModel()
-
-	// Calculate estimated cost
-	cost := utils.EstimateCost(model, inputTokens, outputTokens)
+	// Calculate estimated cost; a cached response costs nothing
+	cost := 0.0
+	if cached {
+		log.WithFields(map[string]interface{}{
+			"agent_name": a.GetName(),
+			"cache_key":  cacheKey,
+		}).Info("agent response served from cache")
+	} else {
+		cost = utils.EstimateCost(model, inputTokens, outputTokens)
+		if o.cache != nil {
+			if err := o.cache.Put(cacheKey, a.GetID(), model, response); err != nil {
+				log.WithField("agent_name", a.GetName()).WithError(err).Warn("failed to cache agent response")
+			}
+		}
+	}
 
 	log.WithFields(map[string]interface{}{
 		"agent_name":    a.GetName(),
@@ -893,8 +1453,11 @@ func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) erro
 		"cost":          cost,
 	}).Info("agent response successful")
 
-	// Record metrics
-	if o.metrics != nil {
+	// Record metrics; cached turns are counted separately and consume no tokens
+	if o.metrics != nil && cached {
+		o.metrics.RecordAgentRequest(a.GetName(), a.GetType(), "cached")
+		o.metrics.RecordConversationTurn(string(o.config.Mode))
+	} else if o.metrics != nil {
 		o.metrics.RecordAgentRequest(a.GetName(), a.GetType(), "success")
 		o.metrics.RecordAgentDuration(a.GetName(), a.GetType(), duration.Seconds())
 		o.metrics.RecordAgentTokens(a.GetName(), a.GetType(), "input", inputTokens)
@@ -905,6 +1468,21 @@ func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) erro
 		o.metrics.RecordConversationTurn(string(o.config.Mode))
 	}
 
+	// The shared history stays in the conversation language
+	response = o.translateOutbound(ctx, a, response)
+
+	// The agent's own filters run first, e.g. to redact secrets it leaked
+	if f, ok := a.(agent.ResponseFilterer); ok {
+		response = f.FilterResponse(response)
+	}
+
+	// Run the response through the content filters before it is stored or shown
+	response, blocked := o.filterResponse(response)
+	if blocked {
+		o.addBlockedNotice(a)
+		return nil
+	}
+
 	// Store the message in history with metrics
 	msg := agent.Message{
 		AgentID:   a.GetID(),
@@ -912,7 +1490,7 @@ func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) erro
 		AgentType: a.GetType(),
 		Content:   response,
 		Timestamp: time.Now().Unix(),
-		Role:      "agent",
+		Role:      agent.RoleAgent,
 		Metrics: &agent.ResponseMetrics{
 			Duration:     duration,
 			InputTokens:  inputTokens,
@@ -920,6 +1498,7 @@ func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) erro
 			TotalTokens:  totalTokens,
 			Model:        model,
 			Cost:         cost,
+			Cached:       cached,
 		},
 	}
 
@@ -953,6 +1532,8 @@ func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) erro
 		}
 	}
 
+	o.recordMemory(a, msg.Content)
+
 	o.mu.Lock()
 	o.messages = append(o.messages, msg)
 	currentTurn := o.currentTurnNumber
@@ -981,6 +1562,7 @@ func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) erro
 	if o.logger != nil {
 		o.logger.LogMessage(msg)
 	}
+	o.notifyMessage(msg)
 	// Always write to writer if available (for TUI)
 	if o.writer != nil {
 		// Include metrics in a special format if available
@@ -999,12 +1581,105 @@ func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) erro
 	return nil
 }
 
+// classifyError maps an agent error to the error type reported in metrics and bridge events:
+// "timeout", "rate_limit", or "unknown".
+func classifyError(err error) string {
+	if err == nil {
+		return "unknown"
+	}
+
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "timeout") || strings.Contains(msg, "deadline"):
+		return "timeout"
+	case strings.Contains(msg, "rate limit") ||
+		strings.Contains(msg, "rate_limit") ||
+		strings.Contains(msg, "ratelimit") ||
+		strings.Contains(msg, "too many requests") ||
+		statusCode429.MatchString(msg):
+		return "rate_limit"
+	default:
+		return "unknown"
+	}
+}
+
+// statusCode429 matches 429 used as an HTTP status ("HTTP 429", "status code:
+// 429", "error 429") rather than any 429 in the text, such as a token count.
+var statusCode429 = regexp.MustCompile(`\b(?:http(?:/[\d.]+)?|status(?: code)?|code|error)\W{0,3}429\b`)
+
+// waitGlobalRateLimit blocks until the conversation-wide limiter admits a's request.
+// A rate limit hit is recorded for a whenever it has to wait.
+func (o *Orchestrator) waitGlobalRateLimit(ctx context.Context, a agent.Agent) error {
+	if o.globalLimiter.Allow() {
+		return nil
+	}
+
+	if o.metrics != nil {
+		o.metrics.RecordRateLimitHit(a.GetName())
+	}
+	log.WithFields(map[string]interface{}{
+		"agent_id":   a.GetID(),
+		"agent_name": a.GetName(),
+	}).Debug("waiting for global rate limit")
+
+	if err := o.globalLimiter.Wait(ctx); err != nil {
+		log.WithFields(map[string]interface{}{
+			"agent_id":   a.GetID(),
+			"agent_name": a.GetName(),
+		}).WithError(err).Error("global rate limit wait failed")
+		return fmt.Errorf("global rate limit wait failed: %w", err)
+	}
+	return nil
+}
+
+// handleRateLimitError records a rate limit hit and halves the agent's effective
+// request rate. The limiter restores the rate gradually after successful turns.
+func (o *Orchestrator) handleRateLimitError(a agent.Agent, limiter *ratelimit.Limiter) {
+	if o.metrics != nil {
+		o.metrics.RecordRateLimitHit(a.GetName())
+	}
+
+	if limiter == nil {
+		return
+	}
+
+	newRate := limiter.Backoff()
+	log.WithFields(map[string]interface{}{
+		"agent_name": a.GetName(),
+		"new_rate":   newRate,
+	}).Warn("agent reported rate limit, reducing request rate")
+}
+
 // calculateBackoffDelay computes the delay for the given retry attempt using exponential backoff.
 // The delay grows exponentially: InitialDelay * (Multiplier ^ attempt), capped at MaxDelay.
+// lockedSource is a rand.Source that is safe for concurrent use, like the
+// global source, so the orchestrator's rng can be drawn from anywhere.
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
+
 func (o *Orchestrator) calculateBackoffDelay(attempt int) time.Duration {
 	// Calculate exponential backoff: initialDelay * multiplier^attempt
 	delay := float64(o.config.RetryInitialDelay) * math.Pow(o.config.RetryMultiplier, float64(attempt))
 
+	// Randomize by ±jitter so agents that failed together spread out their retries
+	if jitter := math.Min(o.config.RetryJitter, 1); jitter > 0 {
+		delay *= 1 + jitter*(2*o.rng.Float64()-1)
+	}
+
 	// Cap at maximum delay
 	if delay > float64(o.config.RetryMaxDelay) {
 		delay = float64(o.config.RetryMaxDelay)
@@ -1013,6 +1688,21 @@ func (o *Orchestrator) calculateBackoffDelay(attempt int) time.Duration {
 	return time.Duration(delay)
 }
 
+// preloadTokenizers loads the tokenizer of every agent's model before the first
+// turn, so no turn stalls on an encoding download, and warns up front about the
+// models whose token counts and costs will be estimated.
+func (o *Orchestrator) preloadTokenizers() {
+	models := make([]string, 0, len(o.agents))
+	for _, a := range o.agents {
+		models = append(models, a.GetModel())
+	}
+
+	if estimated := utils.PreloadTokenizers(models); len(estimated) > 0 {
+		log.WithField("models", strings.Join(estimated, ", ")).
+			Warn("tokenizer encodings could not be loaded, token counts and costs for these models are estimated")
+	}
+}
+
 func (o *Orchestrator) getMessages() []agent.Message {
 	o.mu.RLock()
 	defer o.mu.RUnlock()
@@ -1022,34 +1712,96 @@ func (o *Orchestrator) getMessages() []agent.Message {
 	return messages
 }
 
-func (o *Orchestrator) selectNextAgent(lastSpeaker string) agent.Agent {
-	// Count available agents (excluding last speaker)
-	availableCount := 0
-	for _, a := range o.agents {
-		if a.GetID() != lastSpeaker {
-			availableCount++
+// turnTimeout returns the time a may take to respond: its own timeout when it
+// has one, otherwise the configured TurnTimeout.
+func (o *Orchestrator) turnTimeout(a agent.Agent) time.Duration {
+	if timed, ok := a.(agent.TimeLimited); ok && timed.GetTimeout() > 0 {
+		return timed.GetTimeout()
+	}
+	return o.config.TurnTimeout
+}
+
+// roundRobinOrder returns the speaking order of one round-robin round. An agent
+// with weight N speaks N times per round, interleaved with the others by smooth
+// weighted round-robin: before each turn every agent gains its weight in
+// credit, and the agent with the most credit (the earliest on a tie) speaks
+// and pays the round's total weight. Weights 2/1/1 give A, B, C, A and 3/1/1
+// give A, B, A, C, A.
+func (o *Orchestrator) roundRobinOrder() []agent.Agent {
+	weights := make([]int, len(o.agents))
+	total := 0
+	for i, a := range o.agents {
+		weights[i] = o.agentWeight(a)
+		total += weights[i]
+	}
+
+	credit := make([]int, len(o.agents))
+	order := make([]agent.Agent, 0, total)
+	for len(order) < total {
+		next := 0
+		for i := range o.agents {
+			credit[i] += weights[i]
+			if credit[i] > credit[next] {
+				next = i
+			}
 		}
+		credit[next] -= total
+		order = append(order, o.agents[next])
 	}
+	return order
+}
 
-	if availableCount == 0 {
-		return nil
+// agentWeight returns a's turns per round-robin round. Agents without a weight
+// speak once; negative weights are treated as 1.
+func (o *Orchestrator) agentWeight(a agent.Agent) int {
+	weighted, ok := a.(agent.Weighted)
+	if !ok || weighted.GetWeight() == 0 {
+		return 1
+	}
+	if weight := weighted.GetWeight(); weight > 0 {
+		return weight
 	}
 
-	// Select a random index among available agents
-	targetIndex := rand.Intn(availableCount)
+	log.WithFields(map[string]interface{}{
+		"agent_name": a.GetName(),
+		"weight":     weighted.GetWeight(),
+	}).Warn("invalid agent weight, using 1")
+	return 1
+}
+
+// selectNextAgent picks the next speaker in reactive mode: an agent mentioned
+// as @Name in the last message if there is one, otherwise a random agent other
+// than lastSpeaker. With a ReactiveCooldown, agents that spoke recently are
+// passed over while others are available.
+func (o *Orchestrator) selectNextAgent(lastSpeaker string) agent.Agent {
+	if mentioned := o.mentionedAgent(); mentioned != nil {
+		return mentioned
+	}
 
-	// Find the agent at that index
-	currentIndex := 0
+	// Collect available agents (excluding last speaker and disabled agents)
+	candidates := make([]agent.Agent, 0, len(o.agents))
 	for _, a := range o.agents {
-		if a.GetID() != lastSpeaker {
-			if currentIndex == targetIndex {
+		if a.GetID() != lastSpeaker && !o.AgentDisabled(a) {
+			candidates = append(candidates, a)
+		}
+	}
+
+	if len(candidates) == 0 {
+		// The last speaker carries on alone once every other agent is disabled
+		for _, a := range o.agents {
+			if a.GetID() == lastSpeaker && len(o.agents) > 1 && !o.AgentDisabled(a) {
 				return a
 			}
-			currentIndex++
 		}
+		return nil
 	}
 
-	return nil
+	if o.config.ReactiveCooldown > 0 {
+		return candidates[o.pickWeighted(o.cooldownWeights(candidates, o.config.ReactiveCooldown))]
+	}
+
+	// Select a random agent among the candidates
+	return candidates[o.rng.Intn(len(candidates))]
 }
 
 func shouldRespond(messages []agent.Message, a agent.Agent) bool {
@@ -1068,6 +1820,27 @@ func (o *Orchestrator) GetMessages() []agent.Message {
 	return o.getMessages()
 }
 
+// FailedResponses returns how many agent turns failed after all retries.
+// This method is thread-safe.
+func (o *Orchestrator) FailedResponses() int {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.failedResponses
+}
+
+// CheckProgress returns ErrNoProgress if no agent has responded yet.
+// This method is thread-safe.
+func (o *Orchestrator) CheckProgress() error {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	for _, msg := range o.messages {
+		if msg.Role == agent.RoleAgent {
+			return nil
+		}
+	}
+	return ErrNoProgress
+}
+
 // GetSummary returns the conversation summary if one was generated.
 // Returns nil if summary generation was disabled or hasn't been completed yet.
 // This method is thread-safe.
@@ -5,17 +5,25 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
 	"github.com/kevinelliott/agentpipe/internal/bridge"
 	"github.com/kevinelliott/agentpipe/pkg/agent"
 	"github.com/kevinelliott/agentpipe/pkg/config"
+	"github.com/kevinelliott/agentpipe/pkg/metrics"
+	"github.com/kevinelliott/agentpipe/pkg/ratelimit"
+	"github.com/kevinelliott/agentpipe/pkg/utils"
 )
 
 // MockAgent is a test double for agent.Agent
@@ -24,8 +32,10 @@ type MockAgent struct {
 	name            string
 	agentType       string
 	model           string
+	prompt          string
 	rateLimit       float64
 	rateLimitBurst  int
+	tokensPerMinute int
 	available       bool
 	healthCheckErr  error
 	sendMessageResp string
@@ -37,16 +47,22 @@ type MockAgent struct {
 	failCount  int
 }
 
-func (m *MockAgent) GetID() string          { return m.id }
-func (m *MockAgent) GetName() string        { return m.name }
-func (m *MockAgent) GetType() string        { return m.agentType }
-func (m *MockAgent) GetModel() string       { return m.model }
-func (m *MockAgent) GetRateLimit() float64  { return m.rateLimit }
-func (m *MockAgent) GetRateLimitBurst() int { return m.rateLimitBurst }
-func (m *MockAgent) IsAvailable() bool      { return m.available }
-func (m *MockAgent) Announce() string       { return m.name + " has joined" }
-func (m *MockAgent) GetCLIVersion() string  { return "1.0.0" }
-func (m *MockAgent) GetPrompt() string      { return "You are a helpful assistant" }
+func (m *MockAgent) GetID() string           { return m.id }
+func (m *MockAgent) GetName() string         { return m.name }
+func (m *MockAgent) GetType() string         { return m.agentType }
+func (m *MockAgent) GetModel() string        { return m.model }
+func (m *MockAgent) GetRateLimit() float64   { return m.rateLimit }
+func (m *MockAgent) GetRateLimitBurst() int  { return m.rateLimitBurst }
+func (m *MockAgent) GetTokensPerMinute() int { return m.tokensPerMinute }
+func (m *MockAgent) IsAvailable() bool       { return m.available }
+func (m *MockAgent) Announce() string        { return m.name + " has joined" }
+func (m *MockAgent) GetCLIVersion() string   { return "1.0.0" }
+func (m *MockAgent) GetPrompt() string {
+	if m.prompt != "" {
+		return m.prompt
+	}
+	return "You are a helpful assistant"
+}
 func (m *MockAgent) Initialize(config agent.AgentConfig) error {
 	m.id = config.ID
 	m.name = config.Name
@@ -93,6 +109,7 @@ type MockBridgeEmitter struct {
 	conversationStartedCalled   bool
 	conversationCompletedCalled bool
 	completedStatus             string
+	completedSummary            *bridge.SummaryMetadata
 	messageCreatedCount         int
 	errorCalled                 bool
 }
@@ -112,6 +129,7 @@ func (m *MockBridgeEmitter) EmitMessageCreated(agentID, agentType, agentName, co
 func (m *MockBridgeEmitter) EmitConversationCompleted(status string, totalMessages, totalTurns, totalTokens int, totalCost float64, duration time.Duration, summary *bridge.SummaryMetadata) {
 	m.conversationCompletedCalled = true
 	m.completedStatus = status
+	m.completedSummary = summary
 }
 
 func (m *MockBridgeEmitter) EmitConversationError(errorMessage, errorType, agentType string) {
@@ -244,6 +262,67 @@ func TestRoundRobinMode(t *testing.T) {
 	}
 }
 
+// weightedAgent is a MockAgent with a round-robin weight.
+type weightedAgent struct {
+	MockAgent
+	weight int
+}
+
+func (w *weightedAgent) GetWeight() int { return w.weight }
+
+func TestWeightedRoundRobinMode(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      3,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: time.Millisecond,
+	}, nil)
+
+	lead := &weightedAgent{MockAgent: MockAgent{id: "lead", name: "Lead", agentType: "mock", available: true, sendMessageResp: "lead"}, weight: 2}
+	second := &weightedAgent{MockAgent: MockAgent{id: "second", name: "Second", agentType: "mock", available: true, sendMessageResp: "second"}, weight: 1}
+	third := &weightedAgent{MockAgent: MockAgent{id: "third", name: "Third", agentType: "mock", available: true, sendMessageResp: "third"}, weight: -1}
+	orch.AddAgent(lead)
+	orch.AddAgent(second)
+	orch.AddAgent(third)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Three rounds of lead, second, third, lead; the negative weight counts as 1
+	if lead.callCount != 6 || second.callCount != 3 || third.callCount != 3 {
+		t.Errorf("expected calls 6/3/3, got %d/%d/%d", lead.callCount, second.callCount, third.callCount)
+	}
+	if got := strings.Join(speakingOrder(orch)[:4], ","); got != "lead,second,third,lead" {
+		t.Errorf("expected the first round to be lead,second,third,lead, got %s", got)
+	}
+}
+
+func TestWeightedRoundRobinInterleaves(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      2,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: time.Millisecond,
+	}, nil)
+
+	a := &weightedAgent{MockAgent: MockAgent{id: "a", name: "A", agentType: "mock", available: true, sendMessageResp: "a"}, weight: 3}
+	b := &weightedAgent{MockAgent: MockAgent{id: "b", name: "B", agentType: "mock", available: true, sendMessageResp: "b"}, weight: 1}
+	c := &weightedAgent{MockAgent: MockAgent{id: "c", name: "C", agentType: "mock", available: true, sendMessageResp: "c"}, weight: 1}
+	orch.AddAgent(a)
+	orch.AddAgent(b)
+	orch.AddAgent(c)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The heavy agent's turns are spread over each round instead of bunched
+	if got := strings.Join(speakingOrder(orch), ","); got != "a,b,a,c,a,a,b,a,c,a" {
+		t.Errorf("expected two rounds of a,b,a,c,a, got %s", got)
+	}
+}
+
 func TestReactiveMode(t *testing.T) {
 	config := OrchestratorConfig{
 		Mode:          ModeReactive,
@@ -474,6 +553,174 @@ func TestAgentError(t *testing.T) {
 	}
 }
 
+func TestStopOnError(t *testing.T) {
+	run := func(stopOnError bool) (*MockAgent, error) {
+		config := OrchestratorConfig{
+			Mode:              ModeRoundRobin,
+			MaxTurns:          2,
+			TurnTimeout:       5 * time.Second,
+			ResponseDelay:     time.Millisecond,
+			MaxRetries:        1,
+			RetryInitialDelay: time.Millisecond,
+			StopOnError:       stopOnError,
+		}
+		orch := NewOrchestrator(config, nil)
+
+		failingAgent := &MockAgent{
+			id:             "failing-agent",
+			name:           "FailingAgent",
+			agentType:      "mock",
+			available:      true,
+			sendMessageErr: errors.New("persistent failure"),
+		}
+		workingAgent := &MockAgent{
+			id:              "working-agent",
+			name:            "WorkingAgent",
+			agentType:       "mock",
+			available:       true,
+			sendMessageResp: "I'm working fine",
+		}
+		orch.AddAgent(failingAgent)
+		orch.AddAgent(workingAgent)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return workingAgent, orch.Start(ctx)
+	}
+
+	t.Run("strict mode stops", func(t *testing.T) {
+		workingAgent, err := run(true)
+		if err == nil {
+			t.Fatal("expected Start to return an error in strict mode")
+		}
+		if !strings.Contains(err.Error(), "FailingAgent") || !strings.Contains(err.Error(), "persistent failure") {
+			t.Errorf("error should name the agent and cause, got: %v", err)
+		}
+		if workingAgent.callCount != 0 {
+			t.Errorf("conversation should stop before the next agent, got %d calls", workingAgent.callCount)
+		}
+	})
+
+	t.Run("default mode continues", func(t *testing.T) {
+		workingAgent, err := run(false)
+		if err != nil {
+			t.Fatalf("unexpected error in default mode: %v", err)
+		}
+		if workingAgent.callCount != 2 {
+			t.Errorf("expected working agent to answer every turn, got %d calls", workingAgent.callCount)
+		}
+	})
+}
+
+func TestScriptInjectsPromptsBetweenRounds(t *testing.T) {
+	config := OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: time.Millisecond,
+		Script:        []string{"First question?", "Second question?"},
+	}
+	orch := NewOrchestrator(config, nil)
+
+	agent1 := &MockAgent{id: "agent-1", name: "Agent1", agentType: "mock", available: true, sendMessageResp: "A1"}
+	agent2 := &MockAgent{id: "agent-2", name: "Agent2", agentType: "mock", available: true, sendMessageResp: "A2"}
+	orch.AddAgent(agent1)
+	orch.AddAgent(agent2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := orch.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	var sequence []string
+	for _, msg := range orch.GetMessages() {
+		if msg.Role == "user" || msg.Role == "agent" {
+			sequence = append(sequence, msg.Content)
+		}
+	}
+
+	want := []string{"First question?", "A1", "A2", "Second question?", "A1", "A2"}
+	if strings.Join(sequence, "|") != strings.Join(want, "|") {
+		t.Errorf("unexpected conversation order:\n got: %v\nwant: %v", sequence, want)
+	}
+	if agent1.callCount != 2 || agent2.callCount != 2 {
+		t.Errorf("conversation should end when the script is exhausted, got %d and %d calls", agent1.callCount, agent2.callCount)
+	}
+}
+
+func TestSeededReactiveRunsAreReproducible(t *testing.T) {
+	run := func(seed int64) []string {
+		config := OrchestratorConfig{
+			Mode:          ModeReactive,
+			MaxTurns:      12,
+			TurnTimeout:   5 * time.Second,
+			ResponseDelay: time.Millisecond,
+			InitialPrompt: "Seeded topic",
+			Seed:          seed,
+		}
+		orch := NewOrchestrator(config, nil)
+		for i := 1; i <= 4; i++ {
+			orch.AddAgent(&MockAgent{
+				id:              fmt.Sprintf("agent-%d", i),
+				name:            fmt.Sprintf("Agent%d", i),
+				agentType:       "mock",
+				available:       true,
+				sendMessageResp: fmt.Sprintf("Response from Agent%d", i),
+			})
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := orch.Start(ctx); err != nil {
+			t.Fatalf("Start failed: %v", err)
+		}
+
+		var transcript []string
+		for _, msg := range orch.GetMessages() {
+			transcript = append(transcript, msg.AgentName+": "+msg.Content)
+		}
+		return transcript
+	}
+
+	first := run(42)
+	second := run(42)
+
+	if len(first) != len(second) {
+		t.Fatalf("transcripts differ in length: %d vs %d", len(first), len(second))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("message %d differs with the same seed: %q vs %q", i, first[i], second[i])
+		}
+	}
+}
+
+func TestSeededSelectionSequencesMatch(t *testing.T) {
+	for _, cooldown := range []int{0, 2} {
+		sequence := func(seed int64) []string {
+			orch := NewOrchestrator(OrchestratorConfig{Mode: ModeReactive, Seed: seed, ReactiveCooldown: cooldown}, nil)
+			for i := 1; i <= 5; i++ {
+				orch.AddAgent(&MockAgent{id: fmt.Sprintf("agent-%d", i), name: fmt.Sprintf("Agent%d", i)})
+			}
+
+			var ids []string
+			last := ""
+			for i := 0; i < 50; i++ {
+				next := orch.selectNextAgent(last)
+				last = next.GetID()
+				ids = append(ids, last)
+				orch.messages = append(orch.messages, agent.Message{AgentID: last, Role: "agent"})
+			}
+			return ids
+		}
+
+		first, second := sequence(7), sequence(7)
+		if strings.Join(first, ",") != strings.Join(second, ",") {
+			t.Errorf("cooldown %d: selection differs with the same seed:\n%v\n%v", cooldown, first, second)
+		}
+	}
+}
+
 func TestSelectNextAgent(t *testing.T) {
 	config := OrchestratorConfig{Mode: ModeReactive}
 	orch := NewOrchestrator(config, nil)
@@ -666,6 +913,68 @@ func TestCalculateBackoffDelay(t *testing.T) {
 	}
 }
 
+func TestCalculateBackoffDelayJitter(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		MaxRetries:        6,
+		RetryInitialDelay: 1 * time.Second,
+		RetryMaxDelay:     30 * time.Second,
+		RetryMultiplier:   2.0,
+		RetryJitter:       0.2,
+	}, nil)
+
+	for attempt := 1; attempt <= 6; attempt++ {
+		base := time.Duration(1<<attempt) * time.Second
+		minDelay := time.Duration(float64(base) * 0.8)
+		maxDelay := time.Duration(float64(base) * 1.2)
+		if maxDelay > 30*time.Second {
+			maxDelay = 30 * time.Second
+		}
+		if minDelay > 30*time.Second {
+			minDelay = 30 * time.Second
+		}
+
+		seen := make(map[time.Duration]bool)
+		for i := 0; i < 1000; i++ {
+			delay := orch.calculateBackoffDelay(attempt)
+			if delay < minDelay || delay > maxDelay {
+				t.Fatalf("attempt %d: expected delay between %v and %v, got %v", attempt, minDelay, maxDelay, delay)
+			}
+			seen[delay] = true
+		}
+		if minDelay < maxDelay && len(seen) < 2 {
+			t.Errorf("attempt %d: expected jittered delays to vary", attempt)
+		}
+	}
+}
+
+func TestCalculateBackoffDelayIsReproducibleWithSeed(t *testing.T) {
+	delays := func(seed int64) []time.Duration {
+		orch := NewOrchestrator(OrchestratorConfig{
+			Mode:              ModeRoundRobin,
+			MaxRetries:        5,
+			RetryInitialDelay: 1 * time.Second,
+			RetryMaxDelay:     30 * time.Second,
+			RetryMultiplier:   2.0,
+			RetryJitter:       0.5,
+			Seed:              seed,
+		}, nil)
+		got := make([]time.Duration, 5)
+		for i := range got {
+			got[i] = orch.calculateBackoffDelay(i + 1)
+		}
+		return got
+	}
+
+	first, second := delays(42), delays(42)
+	if fmt.Sprint(first) != fmt.Sprint(second) {
+		t.Errorf("expected the same backoff delays for the same seed, got %v and %v", first, second)
+	}
+	if other := delays(7); fmt.Sprint(first) == fmt.Sprint(other) {
+		t.Errorf("expected different seeds to jitter differently, got %v for both", first)
+	}
+}
+
 func TestRetryWithCustomConfig(t *testing.T) {
 	config := OrchestratorConfig{
 		Mode:              ModeRoundRobin,
@@ -737,6 +1046,9 @@ func TestRetryDefaults(t *testing.T) {
 	if orch.config.RetryMultiplier != 2.0 {
 		t.Errorf("expected default RetryMultiplier=2.0, got %v", orch.config.RetryMultiplier)
 	}
+	if orch.config.RetryJitter != 0.1 {
+		t.Errorf("expected default RetryJitter=0.1, got %v", orch.config.RetryJitter)
+	}
 }
 
 func TestRateLimitingCreation(t *testing.T) {
@@ -775,6 +1087,68 @@ func TestRateLimitingCreation(t *testing.T) {
 	}
 }
 
+func TestTokenRateLimitingCreation(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{Mode: ModeRoundRobin}, nil)
+
+	budgeted := &MockAgent{id: "budgeted", name: "Budgeted", tokensPerMinute: 6000}
+	unbudgeted := &MockAgent{id: "unbudgeted", name: "Unbudgeted"}
+	orch.AddAgent(budgeted)
+	orch.AddAgent(unbudgeted)
+
+	orch.mu.RLock()
+	limiter := orch.tokenLimiters[budgeted.GetID()]
+	_, hasUnbudgeted := orch.tokenLimiters[unbudgeted.GetID()]
+	orch.mu.RUnlock()
+
+	if limiter == nil {
+		t.Fatal("expected token limiter to be created for budgeted agent")
+	}
+	if stats := limiter.GetStats(); stats.Burst != 6000 || stats.Rate != 100.0 {
+		t.Errorf("expected burst 6000 at 100 tokens/s, got burst %d at %.2f", stats.Burst, stats.Rate)
+	}
+	if hasUnbudgeted {
+		t.Error("expected no token limiter for agent without a budget")
+	}
+}
+
+func TestTokenRateLimitingEnforcement(t *testing.T) {
+	config := OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: 10 * time.Millisecond,
+	}
+	orch := NewOrchestrator(config, nil)
+
+	mockAgent := &MockAgent{
+		id:              "budgeted",
+		name:            "Budgeted",
+		agentType:       "mock",
+		available:       true,
+		tokensPerMinute: 600, // 10 tokens/s
+		sendMessageResp: "Response",
+	}
+	orch.AddAgent(mockAgent)
+
+	// Drain the budget so the next turn has to wait for its estimated input tokens
+	orch.mu.RLock()
+	orch.tokenLimiters[mockAgent.GetID()].AllowN(600)
+	orch.mu.RUnlock()
+
+	start := time.Now()
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The announcement alone estimates to a few tokens, each taking 100ms to refill
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("expected token budget to delay the turn, took only %v", elapsed)
+	}
+	if mockAgent.callCount != 1 {
+		t.Errorf("expected 1 call, got %d", mockAgent.callCount)
+	}
+}
+
 func TestRateLimitingEnforcement(t *testing.T) {
 	config := OrchestratorConfig{
 		Mode:          ModeRoundRobin,
@@ -825,6 +1199,232 @@ func TestRateLimitingEnforcement(t *testing.T) {
 	}
 }
 
+func TestMessageCallbackReceivesEveryMessage(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      2,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: time.Millisecond,
+		InitialPrompt: "Plan a trip",
+	}, nil)
+
+	var mu sync.Mutex
+	var received []agent.Message
+	orch.OnMessage(func(msg agent.Message) {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, msg)
+	})
+
+	orch.AddAgent(&MockAgent{id: "a", name: "A", agentType: "mock", available: true, sendMessageResp: "Lisbon"})
+	orch.AddAgent(&MockAgent{id: "b", name: "B", agentType: "mock", available: true, sendMessageResp: "Porto"})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	stored := orch.GetMessages()
+	if len(received) != len(stored) {
+		t.Fatalf("expected the callback for all %d stored messages, got %d", len(stored), len(received))
+	}
+	for i := range stored {
+		if received[i].Content != stored[i].Content || received[i].Role != stored[i].Role {
+			t.Errorf("message %d: callback got %q (%s), stored %q (%s)", i, received[i].Content, received[i].Role, stored[i].Content, stored[i].Role)
+		}
+		if received[i].Role == "agent" && received[i].Metrics == nil {
+			t.Errorf("message %d: expected the response to carry metrics", i)
+		}
+	}
+}
+
+func TestOnMessageCallsEveryCallbackInOrder(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: time.Millisecond,
+		InitialPrompt: "Plan a trip",
+	}, nil)
+
+	var calls []string
+	orch.OnMessage(func(msg agent.Message) { calls = append(calls, "first:"+msg.Content) })
+	orch.OnMessage(func(msg agent.Message) { calls = append(calls, "second:"+msg.Content) })
+
+	a := &MockAgent{id: "a", name: "A", agentType: "mock", available: true, sendMessageResp: "Lisbon"}
+	b := &MockAgent{id: "b", name: "B", agentType: "mock", available: true, sendMessageResp: "Porto"}
+	orch.AddAgent(a)
+	orch.AddAgent(b)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	var want []string
+	for _, content := range []string{a.Announce(), b.Announce(), "Plan a trip", "Lisbon", "Porto"} {
+		want = append(want, "first:"+content, "second:"+content)
+	}
+	if strings.Join(calls, "|") != strings.Join(want, "|") {
+		t.Errorf("unexpected callback calls:\n got: %q\nwant: %q", calls, want)
+	}
+}
+
+func TestGlobalRateLimitBoundsThroughput(t *testing.T) {
+	m := metrics.NewMetrics(prometheus.NewRegistry())
+
+	// 20 req/s with burst 2 across the conversation, while every agent is unlimited
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:                 ModeRoundRobin,
+		MaxTurns:             2,
+		TurnTimeout:          5 * time.Second,
+		ResponseDelay:        time.Millisecond,
+		GlobalRateLimit:      20,
+		GlobalRateLimitBurst: 2,
+	}, nil)
+	orch.SetMetrics(m)
+
+	const numAgents = 8
+	for i := 0; i < numAgents; i++ {
+		orch.AddAgent(&MockAgent{
+			id:              fmt.Sprintf("agent-%d", i),
+			name:            fmt.Sprintf("Agent%d", i),
+			agentType:       "mock",
+			available:       true,
+			sendMessageResp: "Response",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	if err := orch.Start(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	elapsed := time.Since(start)
+
+	responses := len(speakingOrder(orch))
+	if responses != 2*numAgents {
+		t.Fatalf("expected %d responses, got %d", 2*numAgents, responses)
+	}
+
+	// The burst covers 2 requests; each of the other 14 waits 50ms for a token
+	if minimum := time.Duration(responses-2) * 50 * time.Millisecond; elapsed < minimum-50*time.Millisecond {
+		t.Errorf("expected the global limit to take at least %v, took only %v", minimum, elapsed)
+	}
+
+	var hits float64
+	for i := 0; i < numAgents; i++ {
+		hits += testutil.ToFloat64(m.RateLimitHits.WithLabelValues(fmt.Sprintf("Agent%d", i)))
+	}
+	if hits == 0 {
+		t.Error("expected rate limit hits to be recorded while waiting for the global limit")
+	}
+}
+
+func TestGlobalRateLimitCountsRetries(t *testing.T) {
+	m := metrics.NewMetrics(prometheus.NewRegistry())
+
+	// One request per 200ms: the first attempt takes the burst, the retry must wait
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:                 ModeRoundRobin,
+		MaxTurns:             1,
+		TurnTimeout:          5 * time.Second,
+		MaxRetries:           1,
+		RetryInitialDelay:    time.Millisecond,
+		GlobalRateLimit:      5,
+		GlobalRateLimitBurst: 1,
+	}, nil)
+	orch.SetMetrics(m)
+
+	flaky := &MockAgent{id: "flaky", name: "Flaky", agentType: "mock", available: true, sendMessageResp: "Done", failFirstN: 1}
+	orch.AddAgent(flaky)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	if err := orch.Start(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	elapsed := time.Since(start)
+
+	if flaky.callCount != 2 {
+		t.Fatalf("expected 2 attempts, got %d", flaky.callCount)
+	}
+	if elapsed < 150*time.Millisecond {
+		t.Errorf("expected the retry to wait for the global limit, took only %v", elapsed)
+	}
+	if hits := testutil.ToFloat64(m.RateLimitHits.WithLabelValues("Flaky")); hits == 0 {
+		t.Error("expected a rate limit hit to be recorded for the retry")
+	}
+}
+
+func TestAdaptiveRateLimitOnRateLimitError(t *testing.T) {
+	config := OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		TurnTimeout:       5 * time.Second,
+		MaxRetries:        0,
+		RetryInitialDelay: time.Millisecond,
+	}
+	orch := NewOrchestrator(config, nil)
+
+	mockAgent := &MockAgent{
+		id:             "limited",
+		name:           "Limited",
+		agentType:      "mock",
+		rateLimit:      100.0,
+		rateLimitBurst: 10,
+		sendMessageErr: errors.New("API error: 429 Too Many Requests"),
+	}
+	orch.AddAgent(mockAgent)
+
+	orch.mu.RLock()
+	limiter := orch.rateLimiters[mockAgent.GetID()]
+	orch.mu.RUnlock()
+
+	if err := orch.getAgentResponse(context.Background(), mockAgent); err == nil {
+		t.Fatal("expected rate limit error")
+	}
+	if rate := limiter.GetStats().Rate; rate != 50.0 {
+		t.Fatalf("expected rate to be halved to 50, got %.2f", rate)
+	}
+
+	// Successful turns restore the configured rate after the cooldown
+	mockAgent.sendMessageErr = nil
+	mockAgent.sendMessageResp = "ok"
+	for i := 0; i < ratelimit.DefaultRecoveryTurns; i++ {
+		if err := orch.getAgentResponse(context.Background(), mockAgent); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if rate := limiter.GetStats().Rate; rate != 100.0 {
+		t.Errorf("expected rate to recover to 100, got %.2f", rate)
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{errors.New("context deadline exceeded"), "timeout"},
+		{errors.New("request timeout"), "timeout"},
+		{errors.New("Rate limit exceeded"), "rate_limit"},
+		{errors.New("HTTP 429: Too Many Requests"), "rate_limit"},
+		{errors.New("request failed with status code: 429"), "rate_limit"},
+		{errors.New("API error 429"), "rate_limit"},
+		{errors.New("prompt is 14290 tokens, over the 4290 limit"), "unknown"},
+		{errors.New("exit status 1: wrote 429 lines"), "unknown"},
+		{errors.New("exit status 1"), "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := classifyError(tt.err); got != tt.want {
+			t.Errorf("classifyError(%q) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
 func TestRateLimitingUnlimited(t *testing.T) {
 	config := OrchestratorConfig{
 		Mode:          ModeRoundRobin,
@@ -1180,3 +1780,361 @@ func TestGetSummary(t *testing.T) {
 		t.Errorf("summary mismatch: expected %q, got %q", testSummary.Text, retrievedSummary.Text)
 	}
 }
+
+func TestRegisterCustomMode(t *testing.T) {
+	// "last-only" lets only the last agent speak, MaxTurns times
+	var runner *Orchestrator
+	RegisterMode("last-only", func(o *Orchestrator) ModeRunner {
+		runner = o
+		return ModeRunnerFunc(func(ctx context.Context) error {
+			agents := o.Agents()
+			last := agents[len(agents)-1]
+			for i := 0; i < o.Config().MaxTurns; i++ {
+				if _, err := o.TakeTurn(ctx, last); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+	t.Cleanup(func() { UnregisterMode("last-only") })
+
+	found := false
+	for _, name := range RegisteredModes() {
+		if name == "last-only" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected last-only in registered modes, got %v", RegisteredModes())
+	}
+
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          "last-only",
+		MaxTurns:      3,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: time.Millisecond,
+	}, nil)
+	first := &MockAgent{id: "first", name: "First", agentType: "mock", available: true, sendMessageResp: "first"}
+	last := &MockAgent{id: "last", name: "Last", agentType: "mock", available: true, sendMessageResp: "last"}
+	orch.AddAgent(first)
+	orch.AddAgent(last)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	if runner != orch {
+		t.Error("expected the mode factory to receive the orchestrator")
+	}
+	if first.callCount != 0 || last.callCount != 3 {
+		t.Errorf("expected only the last agent to speak 3 times, got first=%d last=%d", first.callCount, last.callCount)
+	}
+
+	// Registered modes are valid in config files too
+	cfg := config.NewDefaultConfig()
+	cfg.Agents = []agent.AgentConfig{{ID: "a1", Type: "mock", Name: "A"}}
+	cfg.Orchestrator.Mode = "last-only"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected registered mode to validate, got %v", err)
+	}
+
+	UnregisterMode("last-only")
+	if HasMode("last-only") {
+		t.Error("expected last-only to be gone after UnregisterMode")
+	}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected an unregistered mode to fail validation")
+	}
+}
+
+func TestStartUnknownMode(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{Mode: "no-such-mode", ResponseDelay: time.Millisecond}, nil)
+	orch.AddAgent(&MockAgent{id: "a", name: "A", agentType: "mock", available: true})
+
+	err := orch.Start(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "unknown conversation mode") {
+		t.Errorf("expected unknown mode error, got %v", err)
+	}
+}
+
+func TestFinalSummary(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		MaxTurns:          1,
+		TurnTimeout:       5 * time.Second,
+		ResponseDelay:     time.Millisecond,
+		RetryInitialDelay: time.Millisecond,
+		FinalSummary:      true,
+		FinalSummaryAgent: "bob",
+	}, nil)
+
+	alice := &memoryAgent{
+		MockAgent: MockAgent{id: "alice", name: "Alice", agentType: "mock", available: true},
+		responses: []string{"Tabs."},
+	}
+	bob := &memoryAgent{
+		MockAgent: MockAgent{id: "bob", name: "Bob", agentType: "mock", available: true},
+		responses: []string{"Spaces.", "SHORT: They disagreed.\nFULL: Alice wants tabs and Bob wants spaces."},
+	}
+	orch.AddAgent(alice)
+	orch.AddAgent(bob)
+	emitter := &MockBridgeEmitter{}
+	orch.SetBridgeEmitter(emitter)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	// The designated summarizer gets one extra turn with the whole transcript
+	if len(alice.prompts) != 1 || len(bob.prompts) != 2 {
+		t.Fatalf("expected alice=1 bob=2 prompts, got alice=%d bob=%d", len(alice.prompts), len(bob.prompts))
+	}
+	summaryPrompt := bob.prompts[1][0].Content
+	if !strings.Contains(summaryPrompt, "Alice: Tabs.") || !strings.Contains(summaryPrompt, "Bob: Spaces.") {
+		t.Errorf("summary prompt should contain the transcript, got %q", summaryPrompt)
+	}
+
+	messages := orch.GetMessages()
+	last := messages[len(messages)-1]
+	if last.Role != "system" || !strings.Contains(last.Content, "Alice wants tabs and Bob wants spaces.") {
+		t.Errorf("expected the summary as the final system message, got %+v", last)
+	}
+
+	summary := orch.GetSummary()
+	if summary == nil || summary.ShortText != "They disagreed." || summary.Text != "Alice wants tabs and Bob wants spaces." {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+	if summary.AgentType != "mock" {
+		t.Errorf("expected summary agent type mock, got %s", summary.AgentType)
+	}
+	if emitter.completedSummary != summary {
+		t.Errorf("expected the summary to be passed to EmitConversationCompleted, got %+v", emitter.completedSummary)
+	}
+}
+
+func TestFinalSummaryUnknownAgent(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		MaxTurns:          1,
+		TurnTimeout:       5 * time.Second,
+		ResponseDelay:     time.Millisecond,
+		RetryInitialDelay: time.Millisecond,
+		FinalSummary:      true,
+		FinalSummaryAgent: "nobody",
+	}, nil)
+	orch.AddAgent(&MockAgent{id: "a", name: "A", agentType: "mock", available: true, sendMessageResp: "Hi"})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if orch.GetSummary() != nil {
+		t.Error("expected no summary when the summary agent is not in the conversation")
+	}
+}
+
+// usageAgent reports exact token usage for its responses, or none when usage is nil.
+type usageAgent struct {
+	MockAgent
+	usage *agent.Usage
+}
+
+func (u *usageAgent) LastUsage() (agent.Usage, bool) {
+	if u.usage == nil {
+		return agent.Usage{}, false
+	}
+	return *u.usage, true
+}
+
+func TestReportedUsageReplacesEstimates(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		MaxTurns:          1,
+		TurnTimeout:       5 * time.Second,
+		ResponseDelay:     time.Millisecond,
+		RetryInitialDelay: time.Millisecond,
+	}, nil)
+	orch.AddAgent(&usageAgent{
+		MockAgent: MockAgent{id: "a", name: "A", agentType: "mock", available: true, sendMessageResp: "Hi"},
+		usage:     &agent.Usage{InputTokens: 1234, OutputTokens: 56},
+	})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	messages := orch.GetMessages()
+	metrics := messages[len(messages)-1].Metrics
+	if metrics == nil {
+		t.Fatal("expected response metrics")
+	}
+	if metrics.InputTokens != 1234 || metrics.OutputTokens != 56 || metrics.TotalTokens != 1290 {
+		t.Errorf("expected reported usage, got input=%d output=%d total=%d", metrics.InputTokens, metrics.OutputTokens, metrics.TotalTokens)
+	}
+}
+
+func TestUnreportedUsageFallsBackToEstimates(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		MaxTurns:          1,
+		TurnTimeout:       5 * time.Second,
+		ResponseDelay:     time.Millisecond,
+		RetryInitialDelay: time.Millisecond,
+		InitialPrompt:     "Compare two approaches to caching",
+	}, nil)
+	response := "Use a write-through cache for consistency."
+	orch.AddAgent(&usageAgent{
+		MockAgent: MockAgent{id: "a", name: "A", agentType: "mock", available: true, sendMessageResp: response},
+	})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	messages := orch.GetMessages()
+	metrics := messages[len(messages)-1].Metrics
+	if metrics == nil {
+		t.Fatal("expected response metrics")
+	}
+	if metrics.InputTokens == 0 || metrics.OutputTokens != utils.CountTokens(metrics.Model, response) {
+		t.Errorf("expected estimated usage, got input=%d output=%d", metrics.InputTokens, metrics.OutputTokens)
+	}
+}
+
+func TestCostEstimateUsesEachAgentsModel(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: time.Millisecond,
+		InitialPrompt: "Compare two approaches to caching",
+	}, nil)
+
+	// Two OpenRouter-style agents routed to differently priced models
+	large := &MockAgent{id: "large", name: "Large", agentType: "openrouter", model: "ai21/jamba-large-1.7", available: true, sendMessageResp: "Use a write-through cache for consistency."}
+	mini := &MockAgent{id: "mini", name: "Mini", agentType: "openrouter", model: "ai21/jamba-mini-1.7", available: true, sendMessageResp: "Use a write-through cache for consistency."}
+	orch.AddAgent(large)
+	orch.AddAgent(mini)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	costs := make(map[string]float64)
+	for _, msg := range orch.GetMessages() {
+		if msg.Role != "agent" || msg.Metrics == nil {
+			continue
+		}
+		want := utils.EstimateCost(msg.Metrics.Model, msg.Metrics.InputTokens, msg.Metrics.OutputTokens)
+		if msg.Metrics.Cost != want {
+			t.Errorf("%s: cost = %v, want %v for model %s", msg.AgentID, msg.Metrics.Cost, want, msg.Metrics.Model)
+		}
+		costs[msg.Metrics.Model] = msg.Metrics.Cost
+	}
+
+	if len(costs) != 2 {
+		t.Fatalf("expected metrics for both models, got %v", costs)
+	}
+	if costs["ai21/jamba-large-1.7"] <= costs["ai21/jamba-mini-1.7"] {
+		t.Errorf("expected the larger model to cost more, got %v", costs)
+	}
+}
+
+// timedAgent is a MockAgent with its own turn timeout.
+type timedAgent struct {
+	MockAgent
+	timeout time.Duration
+}
+
+func (t *timedAgent) GetTimeout() time.Duration { return t.timeout }
+
+func TestPerAgentTurnTimeout(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		MaxTurns:          1,
+		TurnTimeout:       30 * time.Millisecond,
+		ResponseDelay:     time.Millisecond,
+		MaxRetries:        0,
+		RetryInitialDelay: time.Millisecond,
+		InitialPrompt:     "Summarize the design doc",
+	}, nil)
+
+	// Both agents take 100ms; only the one with a longer timeout of its own finishes
+	hasty := &timedAgent{
+		MockAgent: MockAgent{id: "hasty", name: "Hasty", agentType: "mock", available: true, sendMessageResp: "too late", sendDelay: 100 * time.Millisecond},
+		timeout:   20 * time.Millisecond,
+	}
+	patient := &timedAgent{
+		MockAgent: MockAgent{id: "patient", name: "Patient", agentType: "mock", available: true, sendMessageResp: "worth the wait", sendDelay: 100 * time.Millisecond},
+		timeout:   time.Second,
+	}
+	orch.AddAgent(hasty)
+	orch.AddAgent(patient)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	responses := make(map[string]string)
+	for _, msg := range orch.GetMessages() {
+		if msg.Role == "agent" {
+			responses[msg.AgentID] = msg.Content
+		}
+	}
+	if _, ok := responses["hasty"]; ok {
+		t.Error("expected the agent with a 20ms timeout to time out")
+	}
+	if responses["patient"] != "worth the wait" {
+		t.Errorf("expected the agent with a 1s timeout to respond despite the 30ms turn timeout, got %v", responses)
+	}
+	if got := orch.FailedResponses(); got != 1 {
+		t.Errorf("expected 1 failed response, got %d", got)
+	}
+}
+
+// fillNonZero sets every field of the struct v to a non-zero value.
+func fillNonZero(v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		switch f.Kind() {
+		case reflect.String:
+			f.SetString("x")
+		case reflect.Bool:
+			f.SetBool(true)
+		case reflect.Int, reflect.Int64:
+			f.SetInt(1)
+		case reflect.Float64:
+			f.SetFloat(1)
+		case reflect.Struct:
+			fillNonZero(f)
+		}
+	}
+}
+
+func TestConfigFromMapsEveryOrchestratorSetting(t *testing.T) {
+	cfg := &config.Config{}
+	fillNonZero(reflect.ValueOf(&cfg.Orchestrator).Elem())
+
+	oc := ConfigFrom(cfg)
+
+	// Settings that don't come from the orchestrator section of a config file
+	notFromConfig := map[string]bool{
+		"MaxRetries":        true,
+		"RetryInitialDelay": true,
+		"RetryMaxDelay":     true,
+		"RetryMultiplier":   true,
+		"RetryJitter":       true,
+		"Script":            true,
+		"CacheDir":          true,
+	}
+	v := reflect.ValueOf(oc)
+	for i := 0; i < v.NumField(); i++ {
+		name := v.Type().Field(i).Name
+		if !notFromConfig[name] && v.Field(i).IsZero() {
+			t.Errorf("ConfigFrom does not set %s", name)
+		}
+	}
+	if oc.Mode != "x" || oc.ModeratorAgentID != "x" || !oc.Summary.Enabled {
+		t.Errorf("unexpected mapping: %+v", oc)
+	}
+}
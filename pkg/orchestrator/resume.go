@@ -0,0 +1,80 @@
+package orchestrator
+
+import (
+	"fmt"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/log"
+)
+
+// LoadMessages replaces the conversation history with the messages of an
+// earlier run, so Start continues that conversation instead of beginning a new
+// one: the initial prompt is not repeated, and turn counting (including
+// MaxTurns) resumes after the loaded agent responses. Call it after adding the
+// agents and before Start.
+// This method is thread-safe.
+func (o *Orchestrator) LoadMessages(messages []agent.Message) {
+	responses := countResponses(messages)
+
+	o.mu.Lock()
+	o.messages = append(make([]agent.Message, 0, len(messages)), messages...)
+	o.resumed = true
+	o.resumedTurns = responses
+	o.currentTurnNumber = responses
+	o.mu.Unlock()
+
+	log.WithFields(map[string]interface{}{
+		"messages":  len(messages),
+		"responses": responses,
+	}).Info("loaded conversation history")
+}
+
+// ResumedTurns returns the number of agent responses loaded with LoadMessages.
+// Custom modes start their turn count from it.
+func (o *Orchestrator) ResumedTurns() int {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.resumedTurns
+}
+
+// announceResume reports that a loaded conversation is being continued.
+func (o *Orchestrator) announceResume() {
+	msg := fmt.Sprintf("Resuming conversation after %d turns.", o.ResumedTurns())
+	if o.logger != nil {
+		o.logger.LogSystem(msg)
+	}
+	if o.writer != nil {
+		fmt.Fprintln(o.writer, "\n[System] "+msg)
+	}
+}
+
+// resumedRounds returns how many rounds of size responses-per-round the loaded
+// history started, so the next scripted prompt is the one after them.
+func (o *Orchestrator) resumedRounds(perRound int) int {
+	if perRound <= 0 {
+		return 0
+	}
+	return (o.ResumedTurns() + perRound - 1) / perRound
+}
+
+// lastResponder returns the ID of the agent that gave the most recent response.
+func (o *Orchestrator) lastResponder() string {
+	messages := o.getMessages()
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == agent.RoleAgent {
+			return messages[i].AgentID
+		}
+	}
+	return ""
+}
+
+// countResponses counts the agent responses in messages.
+func countResponses(messages []agent.Message) int {
+	responses := 0
+	for _, msg := range messages {
+		if msg.Role == agent.RoleAgent {
+			responses++
+		}
+	}
+	return responses
+}
@@ -0,0 +1,97 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+// savedHistory is a conversation between agents a and b that was interrupted
+// after the given number of responses.
+func savedHistory(responses int) []agent.Message {
+	messages := []agent.Message{
+		{AgentID: "host", AgentName: "HOST", Content: "Brainstorm product names", Timestamp: 1, Role: "system"},
+	}
+	for i := 0; i < responses; i++ {
+		id := []string{"a", "b"}[i%2]
+		messages = append(messages, agent.Message{AgentID: id, AgentName: strings.ToUpper(id), Content: "idea", Timestamp: int64(i + 2), Role: "agent"})
+	}
+	return messages
+}
+
+func newResumeTestOrchestrator(mode ConversationMode, maxTurns int, writer io.Writer) *Orchestrator {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:              mode,
+		MaxTurns:          maxTurns,
+		TurnTimeout:       5 * time.Second,
+		ResponseDelay:     time.Millisecond,
+		RetryInitialDelay: time.Millisecond,
+		InitialPrompt:     "Brainstorm product names",
+		Seed:              1,
+	}, writer)
+	orch.AddAgent(&MockAgent{id: "a", name: "A", agentType: "mock", available: true, sendMessageResp: "more ideas"})
+	orch.AddAgent(&MockAgent{id: "b", name: "B", agentType: "mock", available: true, sendMessageResp: "more ideas"})
+	return orch
+}
+
+func TestResumeRoundRobinContinuesMidRound(t *testing.T) {
+	var out bytes.Buffer
+	orch := newResumeTestOrchestrator(ModeRoundRobin, 2, &out)
+	orch.LoadMessages(savedHistory(1))
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	// a already spoke in round 1, so b finishes it and round 2 follows
+	if got := strings.Join(speakingOrder(orch), ","); got != "a,b,a,b" {
+		t.Errorf("expected speaking order a,b,a,b, got %s", got)
+	}
+
+	prompts := 0
+	for _, msg := range orch.GetMessages() {
+		if msg.AgentID == "host" {
+			prompts++
+		}
+	}
+	if prompts != 1 {
+		t.Errorf("expected the initial prompt not to be repeated, found it %d times", prompts)
+	}
+	if !strings.Contains(out.String(), "Resuming conversation after 1 turns.") {
+		t.Errorf("expected a resume message, got:\n%s", out.String())
+	}
+}
+
+func TestResumeReactiveCountsLoadedTurns(t *testing.T) {
+	orch := newResumeTestOrchestrator(ModeReactive, 4, nil)
+	orch.LoadMessages(savedHistory(3))
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	// Only one turn was left, and a spoke last
+	if got := strings.Join(speakingOrder(orch), ","); got != "a,b,a,b" {
+		t.Errorf("expected speaking order a,b,a,b, got %s", got)
+	}
+}
+
+func TestResumeAtMaxTurnsEndsImmediately(t *testing.T) {
+	orch := newResumeTestOrchestrator(ModeFreeForm, 2, nil)
+	orch.LoadMessages(savedHistory(2))
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if got := len(speakingOrder(orch)); got != 2 {
+		t.Errorf("expected no new responses, got %d in total", got)
+	}
+	if orch.ResumedTurns() != 2 {
+		t.Errorf("expected ResumedTurns() = 2, got %d", orch.ResumedTurns())
+	}
+}
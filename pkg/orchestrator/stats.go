@@ -0,0 +1,128 @@
+package orchestrator
+
+import (
+	"sort"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+// ConversationStats summarizes the messages, tokens, cost, and agent time of a
+// conversation. Tokens and cost count agent responses and moderator queries;
+// duration only counts agent responses.
+type ConversationStats struct {
+	// TotalMessages counts every message, including system and user messages
+	TotalMessages int
+	// AgentMessages counts agent responses
+	AgentMessages int
+	// SystemMessages counts system messages such as the initial prompt
+	SystemMessages int
+	// TotalTokens is the sum of the agent responses' and moderator queries'
+	// input and output tokens
+	TotalTokens int
+	// TotalCost is the estimated cost of the agent responses and moderator
+	// queries in USD
+	TotalCost float64
+	// TotalDuration is the time agents spent generating responses
+	TotalDuration time.Duration
+	// PerAgent breaks the agent totals down by agent, sorted by cost (highest
+	// first), then by name
+	PerAgent []AgentStats
+}
+
+// AgentStats holds one agent's totals within ConversationStats.
+type AgentStats struct {
+	AgentID   string
+	AgentName string
+	Messages  int
+	Tokens    int
+	Cost      float64
+	Duration  time.Duration
+	// AvgDuration is the average response time over the responses with a
+	// measured duration
+	AvgDuration time.Duration
+}
+
+// GetStats returns statistics for the conversation so far.
+// This method is thread-safe and may be called while the conversation is running.
+func (o *Orchestrator) GetStats() ConversationStats {
+	return CalculateStats(o.getMessages())
+}
+
+// CalculateStats computes conversation statistics from a message history.
+// Agents are grouped by ID, or by name for messages without an ID.
+func CalculateStats(messages []agent.Message) ConversationStats {
+	var stats ConversationStats
+	byAgent := make(map[string]int) // index into stats.PerAgent
+	timed := make([]int, 0)         // responses with a duration, per stats.PerAgent entry
+
+	for _, msg := range messages {
+		stats.TotalMessages++
+
+		moderated := false
+		switch msg.Role {
+		case agent.RoleSystem:
+			stats.SystemMessages++
+			continue
+		case agent.RoleAgent:
+			stats.AgentMessages++
+		case agent.RoleModerator:
+			// A moderator query adds to its agent's tokens and cost, not its responses
+			if msg.Metrics == nil {
+				continue
+			}
+			moderated = true
+		default:
+			continue
+		}
+
+		key := msg.AgentID
+		if key == "" {
+			key = msg.AgentName
+		}
+		i, ok := byAgent[key]
+		if !ok {
+			i = len(stats.PerAgent)
+			byAgent[key] = i
+			stats.PerAgent = append(stats.PerAgent, AgentStats{AgentID: msg.AgentID, AgentName: msg.AgentName})
+			timed = append(timed, 0)
+		}
+		s := &stats.PerAgent[i]
+		if moderated {
+			s.Tokens += msg.Metrics.TotalTokens
+			s.Cost += msg.Metrics.Cost
+			stats.TotalTokens += msg.Metrics.TotalTokens
+			stats.TotalCost += msg.Metrics.Cost
+			continue
+		}
+		s.Messages++
+
+		if msg.Metrics == nil {
+			continue
+		}
+		s.Tokens += msg.Metrics.TotalTokens
+		s.Cost += msg.Metrics.Cost
+		s.Duration += msg.Metrics.Duration
+		if msg.Metrics.Duration > 0 {
+			timed[i]++
+		}
+		stats.TotalTokens += msg.Metrics.TotalTokens
+		stats.TotalCost += msg.Metrics.Cost
+		stats.TotalDuration += msg.Metrics.Duration
+	}
+
+	for i := range stats.PerAgent {
+		if timed[i] > 0 {
+			stats.PerAgent[i].AvgDuration = stats.PerAgent[i].Duration / time.Duration(timed[i])
+		}
+	}
+
+	sort.SliceStable(stats.PerAgent, func(i, j int) bool {
+		if stats.PerAgent[i].Cost != stats.PerAgent[j].Cost {
+			return stats.PerAgent[i].Cost > stats.PerAgent[j].Cost
+		}
+		return stats.PerAgent[i].AgentName < stats.PerAgent[j].AgentName
+	})
+
+	return stats
+}
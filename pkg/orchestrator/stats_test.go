@@ -0,0 +1,73 @@
+package orchestrator
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+func TestGetStats(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{}, nil)
+	orch.LoadMessages([]agent.Message{
+		{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Topic"},
+		{AgentID: "a", AgentName: "Alice", Role: "agent", Metrics: &agent.ResponseMetrics{TotalTokens: 100, Cost: 0.01, Duration: time.Second}},
+		{AgentID: "b", AgentName: "Bob", Role: "agent", Metrics: &agent.ResponseMetrics{TotalTokens: 300, Cost: 0.05, Duration: 3 * time.Second}},
+		{AgentID: "c", AgentName: "Carol", Role: "agent"}, // no metrics
+		{AgentID: "a", AgentName: "Alice", Role: "agent", Metrics: &agent.ResponseMetrics{TotalTokens: 150, Cost: 0.02, Duration: 2 * time.Second}},
+		{AgentID: "user", AgentName: "User", Role: "user", Content: "Interjection"},
+		{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Conversation ended"},
+	})
+
+	stats := orch.GetStats()
+
+	if stats.TotalMessages != 7 || stats.AgentMessages != 4 || stats.SystemMessages != 2 {
+		t.Errorf("expected 7 messages (4 agent, 2 system), got %d (%d agent, %d system)",
+			stats.TotalMessages, stats.AgentMessages, stats.SystemMessages)
+	}
+	if stats.TotalTokens != 550 {
+		t.Errorf("expected 550 tokens, got %d", stats.TotalTokens)
+	}
+	if math.Abs(stats.TotalCost-0.08) > 1e-9 {
+		t.Errorf("expected cost 0.08, got %f", stats.TotalCost)
+	}
+	if stats.TotalDuration != 6*time.Second {
+		t.Errorf("expected 6s of agent time, got %v", stats.TotalDuration)
+	}
+
+	want := []AgentStats{
+		{AgentID: "b", AgentName: "Bob", Messages: 1, Tokens: 300, Cost: 0.05, Duration: 3 * time.Second, AvgDuration: 3 * time.Second},
+		{AgentID: "a", AgentName: "Alice", Messages: 2, Tokens: 250, Cost: 0.03, Duration: 3 * time.Second, AvgDuration: 1500 * time.Millisecond},
+		{AgentID: "c", AgentName: "Carol", Messages: 1},
+	}
+	if len(stats.PerAgent) != len(want) {
+		t.Fatalf("expected %d agents, got %+v", len(want), stats.PerAgent)
+	}
+	for i, w := range want {
+		got := stats.PerAgent[i]
+		if got.AgentID != w.AgentID || got.AgentName != w.AgentName || got.Messages != w.Messages ||
+			got.Tokens != w.Tokens || got.Duration != w.Duration || got.AvgDuration != w.AvgDuration || math.Abs(got.Cost-w.Cost) > 1e-9 {
+			t.Errorf("row %d: got %+v, want %+v", i, got, w)
+		}
+	}
+}
+
+func TestCalculateStatsGroupsByNameWithoutID(t *testing.T) {
+	stats := CalculateStats([]agent.Message{
+		{AgentName: "Alice", Role: "agent"},
+		{AgentName: "Alice", Role: "agent"},
+		{AgentName: "Bob", Role: "agent"},
+	})
+
+	if len(stats.PerAgent) != 2 || stats.PerAgent[0].AgentName != "Alice" || stats.PerAgent[0].Messages != 2 {
+		t.Errorf("expected Alice with 2 messages, then Bob, got %+v", stats.PerAgent)
+	}
+}
+
+func TestCalculateStatsEmpty(t *testing.T) {
+	stats := CalculateStats(nil)
+	if stats.TotalMessages != 0 || len(stats.PerAgent) != 0 {
+		t.Errorf("expected empty stats, got %+v", stats)
+	}
+}
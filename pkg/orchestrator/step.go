@@ -0,0 +1,46 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kevinelliott/agentpipe/pkg/log"
+)
+
+// ErrStoppedBeforeTurn is returned by TakeTurn when the BeforeTurn callback
+// ends the conversation. Custom modes can return it from Run; Start treats it
+// as a normal end.
+var ErrStoppedBeforeTurn = errors.New("conversation stopped before the next turn")
+
+// SetBeforeTurn sets a callback run before every agent turn, for example to
+// step through a conversation one turn at a time. When it returns false, the
+// conversation ends instead of taking the turn. It is called from the
+// conversation loop, which waits for it to return.
+// This method is thread-safe.
+func (o *Orchestrator) SetBeforeTurn(fn func() bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.beforeTurn = fn
+}
+
+// continueBeforeTurn runs the BeforeTurn callback and reports whether the
+// conversation should go on. When the callback ends it, the end is reported.
+func (o *Orchestrator) continueBeforeTurn() bool {
+	o.mu.RLock()
+	fn := o.beforeTurn
+	o.mu.RUnlock()
+
+	if fn == nil || fn() {
+		return true
+	}
+
+	endMsg := "Conversation ended before the next turn."
+	log.Info("conversation stopped by the before-turn callback")
+	if o.logger != nil {
+		o.logger.LogSystem(endMsg)
+	}
+	if o.writer != nil {
+		fmt.Fprintln(o.writer, "\n[System] "+endMsg)
+	}
+	return false
+}
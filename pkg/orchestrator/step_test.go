@@ -0,0 +1,82 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBeforeTurnEndsConversation(t *testing.T) {
+	for _, mode := range []ConversationMode{ModeRoundRobin, ModeReactive, ModeFreeForm} {
+		t.Run(string(mode), func(t *testing.T) {
+			var buf bytes.Buffer
+			orch := NewOrchestrator(OrchestratorConfig{
+				Mode:              mode,
+				MaxTurns:          10,
+				TurnTimeout:       5 * time.Second,
+				ResponseDelay:     time.Millisecond,
+				RetryInitialDelay: time.Millisecond,
+			}, &buf)
+			orch.AddAgent(&MockAgent{id: "a1", name: "A1", agentType: "mock", available: true, sendMessageResp: "one"})
+			orch.AddAgent(&MockAgent{id: "a2", name: "A2", agentType: "mock", available: true, sendMessageResp: "two"})
+
+			// A scripted step-through: continue three times, then quit
+			calls := 0
+			orch.SetBeforeTurn(func() bool {
+				calls++
+				return calls <= 3
+			})
+
+			if err := orch.Start(context.Background()); err != nil {
+				t.Fatalf("Start failed: %v", err)
+			}
+
+			responses := 0
+			for _, msg := range orch.GetMessages() {
+				if msg.Role == "agent" {
+					responses++
+				}
+			}
+			if responses != 3 || calls != 4 {
+				t.Errorf("expected 3 responses and 4 callback calls, got %d and %d", responses, calls)
+			}
+			if !strings.Contains(buf.String(), "Conversation ended before the next turn.") {
+				t.Errorf("expected the stop to be reported, got:\n%s", buf.String())
+			}
+		})
+	}
+}
+
+func TestBeforeTurnStopsCustomMode(t *testing.T) {
+	RegisterMode("step-test", func(o *Orchestrator) ModeRunner {
+		return ModeRunnerFunc(func(ctx context.Context) error {
+			for {
+				for _, a := range o.Agents() {
+					if _, err := o.TakeTurn(ctx, a); err != nil {
+						return err
+					}
+				}
+			}
+		})
+	})
+	t.Cleanup(func() { UnregisterMode("step-test") })
+
+	orch := NewOrchestrator(OrchestratorConfig{Mode: "step-test", TurnTimeout: 5 * time.Second, ResponseDelay: time.Millisecond}, nil)
+	a := &MockAgent{id: "a1", name: "A1", agentType: "mock", available: true, sendMessageResp: "one"}
+	orch.AddAgent(a)
+
+	calls := 0
+	orch.SetBeforeTurn(func() bool {
+		calls++
+		return calls <= 2
+	})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("expected a stop before a turn to end the conversation normally, got %v", err)
+	}
+	if a.callCount != 2 {
+		t.Errorf("expected 2 turns, got %d", a.callCount)
+	}
+}
@@ -0,0 +1,196 @@
+package orchestrator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/log"
+)
+
+// StopCondition ends a conversation before MaxTurns. ShouldStop is called with
+// the conversation history after every agent response; when it returns true,
+// the conversation ends and reason is reported (e.g., "Consensus reached").
+type StopCondition interface {
+	ShouldStop(messages []agent.Message) (reason string, stop bool)
+}
+
+// StopConditionFunc adapts a function to the StopCondition interface.
+type StopConditionFunc func(messages []agent.Message) (string, bool)
+
+// ShouldStop calls f(messages).
+func (f StopConditionFunc) ShouldStop(messages []agent.Message) (string, bool) {
+	return f(messages)
+}
+
+// ConsensusStopCondition stops the conversation once the last Consecutive agent
+// responses all contain Phrase. The phrase matches case-insensitively and, where
+// it starts or ends with a letter or digit, only on word boundaries, so
+// "agreed" does not match "disagreed".
+type ConsensusStopCondition struct {
+	pattern *regexp.Regexp
+
+	// Consecutive is how many agent responses in a row must contain the phrase (minimum 1)
+	Consecutive int
+}
+
+// NewConsensusStopCondition creates a ConsensusStopCondition for phrase.
+func NewConsensusStopCondition(phrase string, consecutive int) *ConsensusStopCondition {
+	phrase = strings.TrimSpace(phrase)
+	pattern := regexp.QuoteMeta(phrase)
+	if phrase != "" && isWordByte(phrase[0]) {
+		pattern = `\b` + pattern
+	}
+	if phrase != "" && isWordByte(phrase[len(phrase)-1]) {
+		pattern += `\b`
+	}
+	return &ConsensusStopCondition{
+		pattern:     regexp.MustCompile(`(?i)` + pattern),
+		Consecutive: consecutive,
+	}
+}
+
+// ShouldStop reports whether the most recent agent responses all contain the phrase.
+func (c *ConsensusStopCondition) ShouldStop(messages []agent.Message) (string, bool) {
+	needed := c.Consecutive
+	if needed < 1 {
+		needed = 1
+	}
+
+	for i := len(messages) - 1; i >= 0 && needed > 0; i-- {
+		if messages[i].Role != agent.RoleAgent {
+			continue
+		}
+		if !c.pattern.MatchString(messages[i].Content) {
+			return "", false
+		}
+		needed--
+	}
+	if needed > 0 {
+		return "", false
+	}
+	return "Consensus reached", true
+}
+
+// CostBudgetStopCondition stops the conversation once the agent responses and
+// moderator queries have cost at least MaxCost in USD, as estimated in their metrics.
+type CostBudgetStopCondition struct {
+	MaxCost float64
+}
+
+// ShouldStop reports whether the cost of the conversation has reached MaxCost.
+func (c CostBudgetStopCondition) ShouldStop(messages []agent.Message) (string, bool) {
+	total := 0.0
+	for _, msg := range messages {
+		if billable(msg) {
+			total += msg.Metrics.Cost
+		}
+	}
+	if c.MaxCost <= 0 || total < c.MaxCost {
+		return "", false
+	}
+	return fmt.Sprintf("Cost budget reached ($%.2f)", total), true
+}
+
+// TokenBudgetStopCondition stops the conversation once the agent responses and
+// moderator queries have used at least MaxTokens tokens, input and output combined.
+type TokenBudgetStopCondition struct {
+	MaxTokens int
+}
+
+// ShouldStop reports whether the conversation has used MaxTokens tokens.
+func (c TokenBudgetStopCondition) ShouldStop(messages []agent.Message) (string, bool) {
+	total := 0
+	for _, msg := range messages {
+		if billable(msg) {
+			total += msg.Metrics.TotalTokens
+		}
+	}
+	if c.MaxTokens <= 0 || total < c.MaxTokens {
+		return "", false
+	}
+	return fmt.Sprintf("Token budget reached (%d tokens)", total), true
+}
+
+// billable reports whether msg carries usage that counts toward the budgets:
+// an agent response or a moderator's choice of the next speaker.
+func billable(msg agent.Message) bool {
+	return (msg.Role == agent.RoleAgent || msg.Role == agent.RoleModerator) && msg.Metrics != nil
+}
+
+// isWordByte reports whether b is an ASCII letter, digit, or underscore.
+func isWordByte(b byte) bool {
+	return b == '_' || (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+// AddStopCondition registers a condition checked after every agent response.
+// This method is thread-safe.
+func (o *Orchestrator) AddStopCondition(c StopCondition) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.stopConditions = append(o.stopConditions, c)
+}
+
+// setupStopConditions adds the consensus condition when StopPhrase is set and
+// the cost and token budgets when MaxCost or MaxTokens are set. StopConsecutive
+// defaults to the number of agents, so every agent must agree.
+func (o *Orchestrator) setupStopConditions() {
+	if o.config.MaxCost > 0 {
+		o.AddStopCondition(CostBudgetStopCondition{MaxCost: o.config.MaxCost})
+	}
+	if o.config.MaxTokens > 0 {
+		o.AddStopCondition(TokenBudgetStopCondition{MaxTokens: o.config.MaxTokens})
+	}
+
+	if strings.TrimSpace(o.config.StopPhrase) == "" {
+		return
+	}
+
+	consecutive := o.config.StopConsecutive
+	if consecutive <= 0 {
+		consecutive = len(o.Agents())
+	}
+	o.AddStopCondition(NewConsensusStopCondition(o.config.StopPhrase, consecutive))
+}
+
+// stopConditionMet checks the registered stop conditions against the
+// conversation and reports the first one that is met.
+func (o *Orchestrator) stopConditionMet() bool {
+	o.mu.RLock()
+	conditions := o.stopConditions
+	o.mu.RUnlock()
+
+	if len(conditions) == 0 {
+		return false
+	}
+
+	messages := o.getMessages()
+	for _, c := range conditions {
+		reason, stop := c.ShouldStop(messages)
+		if !stop {
+			continue
+		}
+
+		responses := 0
+		for _, msg := range messages {
+			if msg.Role == agent.RoleAgent {
+				responses++
+			}
+		}
+		endMsg := fmt.Sprintf("%s after %d turns.", reason, responses)
+
+		log.WithFields(map[string]interface{}{
+			"reason": reason,
+			"turns":  responses,
+		}).Info("stop condition met, ending conversation")
+		if o.logger != nil {
+			o.logger.LogSystem(endMsg)
+		}
+		if o.writer != nil {
+			fmt.Fprintln(o.writer, "\n[System] "+endMsg)
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,266 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/utils"
+)
+
+func TestConsensusStopCondition(t *testing.T) {
+	agentMsg := func(id, content string) agent.Message {
+		return agent.Message{AgentID: id, Content: content, Role: "agent"}
+	}
+	system := agent.Message{AgentID: "host", Content: "agreed?", Role: "system"}
+
+	tests := []struct {
+		name        string
+		phrase      string
+		consecutive int
+		messages    []agent.Message
+		want        bool
+	}{
+		{name: "all agree", phrase: "agreed", consecutive: 2, messages: []agent.Message{agentMsg("a", "Agreed."), agentMsg("b", "I AGREED already")}, want: true},
+		{name: "streak too short", phrase: "agreed", consecutive: 3, messages: []agent.Message{agentMsg("a", "agreed"), agentMsg("b", "agreed")}, want: false},
+		{name: "streak broken", phrase: "agreed", consecutive: 2, messages: []agent.Message{agentMsg("a", "agreed"), agentMsg("b", "not yet"), agentMsg("a", "agreed")}, want: false},
+		{name: "non-agent messages skipped", phrase: "agreed", consecutive: 2, messages: []agent.Message{agentMsg("a", "agreed"), system, agentMsg("b", "agreed")}, want: true},
+		{name: "word boundary", phrase: "agreed", consecutive: 1, messages: []agent.Message{agentMsg("a", "I disagreed")}, want: false},
+		{name: "punctuated phrase", phrase: "LGTM!", consecutive: 1, messages: []agent.Message{agentMsg("a", "lgtm! ship it")}, want: true},
+		{name: "no messages", phrase: "agreed", consecutive: 1, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, stop := NewConsensusStopCondition(tt.phrase, tt.consecutive).ShouldStop(tt.messages)
+			if stop != tt.want {
+				t.Errorf("ShouldStop() = %v, want %v", stop, tt.want)
+			}
+			if stop && reason != "Consensus reached" {
+				t.Errorf("unexpected reason %q", reason)
+			}
+		})
+	}
+}
+
+func newStopTestOrchestrator(mode ConversationMode, writer io.Writer) *Orchestrator {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:              mode,
+		MaxTurns:          10,
+		TurnTimeout:       5 * time.Second,
+		ResponseDelay:     time.Millisecond,
+		RetryInitialDelay: time.Millisecond,
+		InitialPrompt:     "Should we adopt Go modules?",
+		Seed:              1,
+		StopPhrase:        "agreed",
+	}, writer)
+	orch.AddAgent(&MockAgent{id: "a", name: "Alice", agentType: "mock", available: true, sendMessageResp: "Agreed, let's do it."})
+	orch.AddAgent(&MockAgent{id: "b", name: "Bob", agentType: "mock", available: true, sendMessageResp: "Agreed."})
+	return orch
+}
+
+func TestConsensusEndsConversationEarly(t *testing.T) {
+	for _, mode := range []ConversationMode{ModeRoundRobin, ModeReactive, ModeFreeForm} {
+		t.Run(string(mode), func(t *testing.T) {
+			var out bytes.Buffer
+			orch := newStopTestOrchestrator(mode, &out)
+
+			if err := orch.Start(context.Background()); err != nil {
+				t.Fatalf("Start failed: %v", err)
+			}
+
+			// StopConsecutive defaults to the number of agents
+			if got := len(speakingOrder(orch)); got != 2 {
+				t.Errorf("expected the conversation to stop after 2 responses, got %d", got)
+			}
+			if !bytes.Contains(out.Bytes(), []byte("Consensus reached after 2 turns.")) {
+				t.Errorf("expected a consensus message, got:\n%s", out.String())
+			}
+		})
+	}
+}
+
+func TestStopPhraseDisabledByDefault(t *testing.T) {
+	orch := newStopTestOrchestrator(ModeRoundRobin, nil)
+	orch.config.StopPhrase = ""
+	orch.config.MaxTurns = 2
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if got := len(speakingOrder(orch)); got != 4 {
+		t.Errorf("expected all 4 responses without a stop phrase, got %d", got)
+	}
+}
+
+func TestAddStopCondition(t *testing.T) {
+	orch := newStopTestOrchestrator(ModeRoundRobin, nil)
+	orch.config.StopPhrase = ""
+	orch.AddStopCondition(StopConditionFunc(func(messages []agent.Message) (string, bool) {
+		for _, msg := range messages {
+			if msg.Role == "agent" && msg.AgentID == "b" {
+				return "Bob spoke", true
+			}
+		}
+		return "", false
+	}))
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if got := len(speakingOrder(orch)); got != 2 {
+		t.Errorf("expected the custom condition to stop after Bob's first response, got %d responses", got)
+	}
+}
+
+func TestCostBudgetStopCondition(t *testing.T) {
+	response := func(cost float64) agent.Message {
+		return agent.Message{Role: "agent", Metrics: &agent.ResponseMetrics{Cost: cost}}
+	}
+	summary := agent.Message{Role: "system", Metrics: &agent.ResponseMetrics{Cost: 1}}
+
+	tests := []struct {
+		name     string
+		maxCost  float64
+		messages []agent.Message
+		want     bool
+	}{
+		{name: "under budget", maxCost: 0.5, messages: []agent.Message{response(0.2), response(0.2)}, want: false},
+		{name: "budget reached", maxCost: 0.5, messages: []agent.Message{response(0.2), response(0.3)}, want: true},
+		{name: "budget crossed", maxCost: 0.5, messages: []agent.Message{response(0.4), response(0.4)}, want: true},
+		{name: "only agent responses count", maxCost: 0.5, messages: []agent.Message{summary, response(0.1)}, want: false},
+		{name: "responses without metrics", maxCost: 0.5, messages: []agent.Message{{Role: "agent"}}, want: false},
+		{name: "no budget", maxCost: 0, messages: []agent.Message{response(10)}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, stop := CostBudgetStopCondition{MaxCost: tt.maxCost}.ShouldStop(tt.messages)
+			if stop != tt.want {
+				t.Errorf("ShouldStop() = %v, want %v", stop, tt.want)
+			}
+			if stop && reason == "" {
+				t.Error("expected a reason when the budget is reached")
+			}
+		})
+	}
+}
+
+// pricedAgent reports a fixed token usage so every response has a known cost.
+type pricedAgent struct {
+	MockAgent
+	usage agent.Usage
+}
+
+func (p *pricedAgent) LastUsage() (agent.Usage, bool) {
+	return p.usage, true
+}
+
+func TestMaxCostEndsConversation(t *testing.T) {
+	// Every response costs 1000 output tokens * $0.20/1K = $0.20
+	utils.SetPricing(&utils.PricingConfig{Models: map[string]utils.ModelRate{
+		"budget-test-model": {OutputPer1K: 0.20},
+	}})
+	defer utils.SetPricing(nil)
+
+	var out bytes.Buffer
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      10,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: time.Millisecond,
+		InitialPrompt: "Plan a product launch",
+		MaxCost:       0.50,
+	}, &out)
+	for _, id := range []string{"a", "b"} {
+		orch.AddAgent(&pricedAgent{
+			MockAgent: MockAgent{id: id, name: id, agentType: "mock", model: "budget-test-model", available: true, sendMessageResp: "Next step"},
+			usage:     agent.Usage{OutputTokens: 1000},
+		})
+	}
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	// $0.20 + $0.20 is under budget; the third response crosses it
+	if got := len(speakingOrder(orch)); got != 3 {
+		t.Errorf("expected the conversation to stop after 3 responses, got %d", got)
+	}
+	if !bytes.Contains(out.Bytes(), []byte("Cost budget reached ($0.60) after 3 turns.")) {
+		t.Errorf("expected a cost budget message, got:\n%s", out.String())
+	}
+}
+
+func TestTokenBudgetStopCondition(t *testing.T) {
+	response := func(tokens int) agent.Message {
+		return agent.Message{Role: "agent", Metrics: &agent.ResponseMetrics{TotalTokens: tokens}}
+	}
+
+	if _, stop := (TokenBudgetStopCondition{MaxTokens: 1000}).ShouldStop([]agent.Message{response(400), response(500)}); stop {
+		t.Error("expected no stop under the budget")
+	}
+	reason, stop := TokenBudgetStopCondition{MaxTokens: 1000}.ShouldStop([]agent.Message{response(400), response(700)})
+	if !stop || reason != "Token budget reached (1100 tokens)" {
+		t.Errorf("expected the budget to be reached, got %q, %v", reason, stop)
+	}
+	if _, stop := (TokenBudgetStopCondition{}).ShouldStop([]agent.Message{response(1 << 20)}); stop {
+		t.Error("expected no stop without a budget")
+	}
+}
+
+func TestConversationLimitsEndRunIndependently(t *testing.T) {
+	// Every response uses 1000 tokens and costs 1000 output tokens * $0.20/1K = $0.20
+	utils.SetPricing(&utils.PricingConfig{Models: map[string]utils.ModelRate{
+		"budget-test-model": {OutputPer1K: 0.20},
+	}})
+	defer utils.SetPricing(nil)
+
+	tests := []struct {
+		name      string
+		maxTurns  int
+		maxCost   float64
+		maxTokens int
+		want      int
+		message   string
+	}{
+		{name: "max turns", maxTurns: 2, want: 2, message: "Maximum turns reached"},
+		{name: "max cost", maxTurns: 10, maxCost: 0.50, want: 3, message: "Cost budget reached ($0.60) after 3 turns."},
+		{name: "max tokens", maxTurns: 10, maxTokens: 2500, want: 3, message: "Token budget reached (3000 tokens) after 3 turns."},
+		{name: "tokens before cost", maxTurns: 10, maxCost: 0.70, maxTokens: 1500, want: 2, message: "Token budget reached (2000 tokens)"},
+		{name: "cost before tokens", maxTurns: 10, maxCost: 0.30, maxTokens: 5000, want: 2, message: "Cost budget reached ($0.40)"},
+		{name: "turns before budgets", maxTurns: 1, maxCost: 0.50, maxTokens: 2500, want: 1, message: "Maximum turns reached"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			orch := NewOrchestrator(OrchestratorConfig{
+				Mode:          ModeRoundRobin,
+				MaxTurns:      tt.maxTurns,
+				TurnTimeout:   5 * time.Second,
+				ResponseDelay: time.Millisecond,
+				InitialPrompt: "Plan a product launch",
+				MaxCost:       tt.maxCost,
+				MaxTokens:     tt.maxTokens,
+			}, &out)
+			orch.AddAgent(&pricedAgent{
+				MockAgent: MockAgent{id: "a", name: "A", agentType: "mock", model: "budget-test-model", available: true, sendMessageResp: "Next step"},
+				usage:     agent.Usage{OutputTokens: 1000},
+			})
+
+			if err := orch.Start(context.Background()); err != nil {
+				t.Fatalf("Start failed: %v", err)
+			}
+			if got := len(speakingOrder(orch)); got != tt.want {
+				t.Errorf("expected %d responses, got %d", tt.want, got)
+			}
+			if !bytes.Contains(out.Bytes(), []byte(tt.message)) {
+				t.Errorf("expected %q, got:\n%s", tt.message, out.String())
+			}
+		})
+	}
+}
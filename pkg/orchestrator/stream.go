@@ -0,0 +1,93 @@
+package orchestrator
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+// StreamHandler receives an agent's response while it is being generated, when
+// Stream is enabled. partial is everything the agent has written so far in the
+// current attempt; an empty partial means the attempt failed and the text shown
+// so far should be discarded. The completed response is still added to the
+// conversation, with its metrics, as usual. Responses that are translated or
+// filtered before they are stored are not streamed, so the handler never sees
+// text the conversation would not keep.
+type StreamHandler func(a agent.Agent, partial string)
+
+// SetStreamHandler sets the handler that receives partial responses.
+// This method is thread-safe.
+func (o *Orchestrator) SetStreamHandler(h StreamHandler) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.streamHandler = h
+}
+
+// sendMessage asks a for its response. With Stream enabled the response is read
+// through the agent's StreamMessage and passed to the stream handler as it arrives.
+func (o *Orchestrator) sendMessage(ctx context.Context, a agent.Agent, messages []agent.Message) (string, error) {
+	if !o.config.Stream {
+		return a.SendMessage(ctx, messages)
+	}
+
+	o.mu.RLock()
+	handler := o.streamHandler
+	o.mu.RUnlock()
+
+	if o.rewritesResponses(a) {
+		handler = nil
+	}
+
+	w := &partialWriter{agent: a, handler: handler}
+	if err := a.StreamMessage(ctx, messages, w); err != nil {
+		if handler != nil {
+			handler(a, "")
+		}
+		return "", err
+	}
+	return strings.TrimSpace(w.String()), nil
+}
+
+// rewritesResponses reports whether a's responses are changed between the agent
+// and the conversation, by translation or the agent's or the orchestrator's
+// response filters.
+func (o *Orchestrator) rewritesResponses(a agent.Agent) bool {
+	if f, ok := a.(agent.ResponseFilterer); ok && f.HasResponseFilters() {
+		return true
+	}
+
+	o.mu.RLock()
+	filtered := len(o.filters) > 0
+	translated := o.translator != nil
+	o.mu.RUnlock()
+
+	return filtered || (translated && o.languageOf(a) != "")
+}
+
+// partialWriter accumulates a streamed response and reports it after every write.
+type partialWriter struct {
+	mu      sync.Mutex
+	buf     strings.Builder
+	agent   agent.Agent
+	handler StreamHandler
+}
+
+func (w *partialWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf.Write(p)
+	if w.handler != nil {
+		w.handler(w.agent, w.buf.String())
+	}
+	return len(p), nil
+}
+
+// String returns everything written so far.
+func (w *partialWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
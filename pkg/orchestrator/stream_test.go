@@ -0,0 +1,215 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+// chunkAgent streams its response in several writes and can fail afterwards.
+type chunkAgent struct {
+	MockAgent
+	chunks    []string
+	streamErr error
+}
+
+func (c *chunkAgent) StreamMessage(ctx context.Context, messages []agent.Message, writer io.Writer) error {
+	for _, chunk := range c.chunks {
+		if _, err := writer.Write([]byte(chunk)); err != nil {
+			return err
+		}
+	}
+	return c.streamErr
+}
+
+func (c *chunkAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	return "not streamed", nil
+}
+
+type partialRecorder struct {
+	mu       sync.Mutex
+	partials []string
+}
+
+func (r *partialRecorder) handle(a agent.Agent, partial string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.partials = append(r.partials, partial)
+}
+
+func newStreamTestOrchestrator(stream bool, a agent.Agent) (*Orchestrator, *partialRecorder) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		MaxTurns:          1,
+		TurnTimeout:       5 * time.Second,
+		ResponseDelay:     time.Millisecond,
+		RetryInitialDelay: time.Millisecond,
+		InitialPrompt:     "Say hello",
+		Stream:            stream,
+	}, nil)
+	rec := &partialRecorder{}
+	orch.SetStreamHandler(rec.handle)
+	orch.AddAgent(a)
+	return orch, rec
+}
+
+func TestStreamReportsPartialResponses(t *testing.T) {
+	a := &chunkAgent{
+		MockAgent: MockAgent{id: "a", name: "A", agentType: "mock", available: true},
+		chunks:    []string{"Hel", "lo ", "there\n"},
+	}
+	orch, rec := newStreamTestOrchestrator(true, a)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	want := []string{"Hel", "Hello ", "Hello there\n"}
+	if len(rec.partials) != len(want) {
+		t.Fatalf("expected partials %q, got %q", want, rec.partials)
+	}
+	for i := range want {
+		if rec.partials[i] != want[i] {
+			t.Errorf("partial %d: expected %q, got %q", i, want[i], rec.partials[i])
+		}
+	}
+
+	var final *agent.Message
+	for _, msg := range orch.GetMessages() {
+		if msg.Role == "agent" {
+			msg := msg
+			final = &msg
+		}
+	}
+	if final == nil {
+		t.Fatal("expected a response to be added to the conversation")
+	}
+	if final.Content != "Hello there" {
+		t.Errorf("expected the trimmed streamed response, got %q", final.Content)
+	}
+	if final.Metrics == nil {
+		t.Error("expected the streamed response to carry metrics")
+	}
+}
+
+func TestStreamDisabledUsesSendMessage(t *testing.T) {
+	a := &chunkAgent{
+		MockAgent: MockAgent{id: "a", name: "A", agentType: "mock", available: true},
+		chunks:    []string{"streamed"},
+	}
+	orch, rec := newStreamTestOrchestrator(false, a)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if len(rec.partials) != 0 {
+		t.Errorf("expected no partials without Stream, got %q", rec.partials)
+	}
+	if got := speakingOrder(orch); len(got) != 1 {
+		t.Fatalf("expected one response, got %d", len(got))
+	}
+}
+
+func TestStreamFailureClearsPartial(t *testing.T) {
+	a := &chunkAgent{
+		MockAgent: MockAgent{id: "a", name: "A", agentType: "mock", available: true},
+		chunks:    []string{"half an ans"},
+		streamErr: errors.New("connection reset"),
+	}
+	orch, rec := newStreamTestOrchestrator(true, a)
+
+	_, err := orch.sendMessage(context.Background(), a, nil)
+	if err == nil {
+		t.Fatal("expected the stream error to be returned")
+	}
+
+	if len(rec.partials) != 2 || rec.partials[0] != "half an ans" || rec.partials[1] != "" {
+		t.Errorf("expected the partial to be cleared after the failure, got %q", rec.partials)
+	}
+}
+
+func TestStreamSkipsPartialsOfFilteredResponses(t *testing.T) {
+	a := &chunkAgent{
+		MockAgent: MockAgent{id: "a", name: "A", agentType: "mock", available: true},
+		chunks:    []string{"The password ", "is hunter2"},
+	}
+	orch, rec := newStreamTestOrchestrator(true, a)
+
+	filter, err := NewPatternFilter([]string{"hunter2"}, nil)
+	if err != nil {
+		t.Fatalf("NewPatternFilter failed: %v", err)
+	}
+	filter.Redact = true
+	orch.AddResponseFilter(filter)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if len(rec.partials) != 0 {
+		t.Errorf("expected no partials for a filtered response, got %q", rec.partials)
+	}
+
+	got := speakingOrder(orch)
+	if len(got) != 1 {
+		t.Fatalf("expected one response, got %d", len(got))
+	}
+	for _, msg := range orch.GetMessages() {
+		if msg.Role == "agent" && msg.Content != "The password is [redacted]" {
+			t.Errorf("expected the filtered response to be stored, got %q", msg.Content)
+		}
+	}
+}
+
+// filteringChunkAgent is a chunkAgent with the response filters of its config.
+type filteringChunkAgent struct {
+	*chunkAgent
+	base agent.BaseAgent
+}
+
+func (f *filteringChunkAgent) FilterResponse(content string) string {
+	return f.base.FilterResponse(content)
+}
+
+func (f *filteringChunkAgent) HasResponseFilters() bool {
+	return f.base.HasResponseFilters()
+}
+
+func TestStreamNeverShowsUnredactedPartials(t *testing.T) {
+	a := &filteringChunkAgent{chunkAgent: &chunkAgent{
+		MockAgent: MockAgent{id: "a", name: "A", agentType: "mock", available: true},
+		chunks:    []string{"Use sk-abcdefghij", "klmnopqrstuvwxyz to log in"},
+	}}
+	if err := a.base.Initialize(agent.AgentConfig{
+		ID:      "a",
+		Name:    "A",
+		Filters: []agent.ResponseFilter{{Pattern: `sk-[A-Za-z0-9]{20,}`, Replacement: "[redacted]"}},
+	}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	orch, rec := newStreamTestOrchestrator(true, a)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	for _, partial := range rec.partials {
+		if strings.Contains(partial, "sk-") {
+			t.Errorf("expected no unredacted partial to reach the handler, got %q", partial)
+		}
+	}
+
+	var final string
+	for _, msg := range orch.GetMessages() {
+		if msg.Role == "agent" {
+			final = msg.Content
+		}
+	}
+	if final != "Use [redacted] to log in" {
+		t.Errorf("expected the redacted response to be stored, got %q", final)
+	}
+}
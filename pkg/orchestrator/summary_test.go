@@ -0,0 +1,122 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/internal/bridge"
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/config"
+)
+
+func init() {
+	// Once any factory is registered, config validation checks agent types
+	// against the registry, so the type of this package's MockAgents is
+	// registered too.
+	agent.RegisterFactory("mock", func() agent.Agent { return &MockAgent{available: true} })
+	agent.RegisterFactory("summary-mock", func() agent.Agent {
+		return &MockAgent{
+			available:       true,
+			sendDelay:       5 * time.Millisecond,
+			sendMessageResp: "SHORT: The agents agreed on Go.\nFULL: Both agents compared languages and agreed on Go for the CLI.",
+		}
+	})
+	agent.RegisterFactory("summary-failing", func() agent.Agent {
+		return &MockAgent{available: true, sendMessageErr: errors.New("summary agent unavailable")}
+	})
+}
+
+// runWithBridgeCapture runs a one-turn conversation that streams to a test
+// server and returns the data of its conversation.completed event.
+func runWithBridgeCapture(t *testing.T, summaryAgent string) map[string]interface{} {
+	t.Helper()
+
+	var mu sync.Mutex
+	var completed map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event bridge.Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if event.Type == bridge.EventConversationCompleted {
+			mu.Lock()
+			completed, _ = event.Data.(map[string]interface{})
+			mu.Unlock()
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: time.Millisecond,
+		InitialPrompt: "Pick a language for the CLI",
+		Summary:       config.SummaryConfig{Enabled: true, Agent: summaryAgent},
+	}, nil)
+	orch.AddAgent(&MockAgent{id: "a", name: "A", agentType: "mock", available: true, sendMessageResp: "Go"})
+	orch.SetBridgeEmitter(bridge.NewEmitter(&bridge.Config{
+		Enabled:   true,
+		URL:       server.URL,
+		APIKey:    "test-key",
+		TimeoutMs: 5000,
+	}, "test"))
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if completed == nil {
+		t.Fatal("expected a conversation.completed event")
+	}
+	return completed
+}
+
+func TestBridgeCompletionIncludesSummary(t *testing.T) {
+	completed := runWithBridgeCapture(t, "summary-mock")
+
+	summary, ok := completed["summary"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected a summary in conversation.completed, got %v", completed)
+	}
+	if summary["short_text"] != "The agents agreed on Go." {
+		t.Errorf("unexpected short_text: %v", summary["short_text"])
+	}
+	if summary["text"] != "Both agents compared languages and agreed on Go for the CLI." {
+		t.Errorf("unexpected text: %v", summary["text"])
+	}
+	if summary["agent_type"] != "summary-mock" {
+		t.Errorf("unexpected agent_type: %v", summary["agent_type"])
+	}
+	for _, field := range []string{"input_tokens", "output_tokens", "total_tokens", "duration_ms"} {
+		if v, _ := summary[field].(float64); v <= 0 {
+			t.Errorf("expected a positive %s, got %v", field, summary[field])
+		}
+	}
+
+	summaryTokens, _ := summary["total_tokens"].(float64)
+	if total, _ := completed["total_tokens"].(float64); total < summaryTokens {
+		t.Errorf("expected total_tokens %v to include the summary's %v", total, summaryTokens)
+	}
+}
+
+func TestBridgeCompletionWithoutSummaryWhenSummaryFails(t *testing.T) {
+	completed := runWithBridgeCapture(t, "summary-failing")
+
+	if completed["status"] != "completed" {
+		t.Errorf("expected status completed, got %v", completed["status"])
+	}
+	if summary, ok := completed["summary"]; ok && summary != nil {
+		t.Errorf("expected no summary after a failed summary, got %v", summary)
+	}
+}
@@ -0,0 +1,195 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/log"
+)
+
+// DefaultLanguage is the language of the shared history when OrchestratorConfig.Language is unset.
+const DefaultLanguage = "English"
+
+// Translator translates text into a target language (e.g., "Japanese" or "ja").
+type Translator interface {
+	Translate(ctx context.Context, text, targetLanguage string) (string, error)
+}
+
+// AgentTranslator is a Translator backed by an agent CLI.
+type AgentTranslator struct {
+	agent agent.Agent
+}
+
+// NewAgentTranslator creates a Translator that asks a to translate.
+func NewAgentTranslator(a agent.Agent) *AgentTranslator {
+	return &AgentTranslator{agent: a}
+}
+
+// Translate sends a translation request to the agent and returns its reply.
+func (t *AgentTranslator) Translate(ctx context.Context, text, targetLanguage string) (string, error) {
+	prompt := fmt.Sprintf("Translate the following text into %s. Keep names, code, and formatting unchanged. Reply with ONLY the translation, without any commentary.\n\n%s", targetLanguage, text)
+
+	response, err := t.agent.SendMessage(ctx, []agent.Message{{
+		AgentID:   "host",
+		AgentName: "HOST",
+		Content:   prompt,
+		Timestamp: time.Now().Unix(),
+		Role:      agent.RoleUser,
+	}})
+	if err != nil {
+		return "", fmt.Errorf("translation failed: %w", err)
+	}
+
+	translated := strings.TrimSpace(response)
+	if translated == "" {
+		return "", fmt.Errorf("translation failed: empty response")
+	}
+	return translated, nil
+}
+
+// SetTranslator enables translation for agents with a language set in their config.
+// Other agents' messages are translated into the agent's language before it is
+// prompted, and its response is translated back into the conversation language.
+// This method is thread-safe.
+func (o *Orchestrator) SetTranslator(t Translator) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.translator = t
+}
+
+// setupTranslator creates the configured translator agent if any agent needs one.
+// Failures are logged and the conversation continues untranslated.
+func (o *Orchestrator) setupTranslator() {
+	o.mu.RLock()
+	needed := o.translator == nil && o.config.TranslatorAgent != ""
+	agents := o.agents
+	o.mu.RUnlock()
+
+	if !needed {
+		return
+	}
+
+	multilingual := false
+	for _, a := range agents {
+		if o.languageOf(a) != "" {
+			multilingual = true
+			break
+		}
+	}
+	if !multilingual {
+		return
+	}
+
+	translatorAgent, err := agent.CreateAgent(agent.AgentConfig{
+		ID:   "translator-agent",
+		Type: o.config.TranslatorAgent,
+		Name: "Translator",
+	})
+	if err != nil {
+		log.WithError(err).WithField("agent_type", o.config.TranslatorAgent).Warn("failed to create translator agent, messages will not be translated")
+		return
+	}
+	o.SetTranslator(NewAgentTranslator(translatorAgent))
+}
+
+// conversationLanguage returns the language of the shared history.
+func (o *Orchestrator) conversationLanguage() string {
+	if o.config.Language != "" {
+		return o.config.Language
+	}
+	return DefaultLanguage
+}
+
+// languageOf returns the language a needs its messages in, or "" when it
+// speaks the conversation language.
+func (o *Orchestrator) languageOf(a agent.Agent) string {
+	m, ok := a.(agent.Multilingual)
+	if !ok {
+		return ""
+	}
+	lang := strings.TrimSpace(m.GetLanguage())
+	if lang == "" || strings.EqualFold(lang, o.conversationLanguage()) {
+		return ""
+	}
+	return lang
+}
+
+// translate translates text for a, reusing earlier translations of the same
+// text. Translation requests count against the global rate limit.
+func (o *Orchestrator) translate(ctx context.Context, t Translator, a agent.Agent, text, lang string) (string, error) {
+	if strings.TrimSpace(text) == "" {
+		return text, nil
+	}
+
+	key := lang + "\x00" + text
+	if cached, ok := o.translations.Load(key); ok {
+		return cached.(string), nil
+	}
+
+	if err := o.waitGlobalRateLimit(ctx, a); err != nil {
+		return "", err
+	}
+
+	translated, err := t.Translate(ctx, text, lang)
+	if err != nil {
+		return "", err
+	}
+	o.translations.Store(key, translated)
+	return translated, nil
+}
+
+// translateInbound translates other agents' messages into a's language.
+// Messages that fail to translate are sent unchanged.
+func (o *Orchestrator) translateInbound(ctx context.Context, a agent.Agent, messages []agent.Message) []agent.Message {
+	o.mu.RLock()
+	t := o.translator
+	o.mu.RUnlock()
+
+	lang := o.languageOf(a)
+	if t == nil || lang == "" {
+		return messages
+	}
+
+	translated := make([]agent.Message, len(messages))
+	for i, msg := range messages {
+		translated[i] = msg
+		if msg.AgentID == a.GetID() {
+			continue
+		}
+		content, err := o.translate(ctx, t, a, msg.Content, lang)
+		if err != nil {
+			log.WithFields(map[string]interface{}{
+				"agent_name": a.GetName(),
+				"language":   lang,
+			}).WithError(err).Warn("failed to translate message, sending original")
+			continue
+		}
+		translated[i].Content = content
+	}
+	return translated
+}
+
+// translateOutbound translates a's response into the conversation language.
+// A response that fails to translate is kept unchanged.
+func (o *Orchestrator) translateOutbound(ctx context.Context, a agent.Agent, response string) string {
+	o.mu.RLock()
+	t := o.translator
+	o.mu.RUnlock()
+
+	if t == nil || o.languageOf(a) == "" {
+		return response
+	}
+
+	translated, err := o.translate(ctx, t, a, response, o.conversationLanguage())
+	if err != nil {
+		log.WithFields(map[string]interface{}{
+			"agent_name": a.GetName(),
+			"language":   o.conversationLanguage(),
+		}).WithError(err).Warn("failed to translate response, keeping original")
+		return response
+	}
+	return translated
+}
@@ -0,0 +1,189 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stubTranslator marks text as translated by wrapping it in the target language.
+type stubTranslator struct {
+	mu    sync.Mutex
+	calls int
+	err   error
+}
+
+func (s *stubTranslator) Translate(ctx context.Context, text, targetLanguage string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	if s.err != nil {
+		return "", s.err
+	}
+	return fmt.Sprintf("[%s] %s", targetLanguage, text), nil
+}
+
+// multilingualAgent is a memoryAgent that converses in its own language.
+type multilingualAgent struct {
+	memoryAgent
+	language string
+}
+
+func (m *multilingualAgent) GetLanguage() string {
+	return m.language
+}
+
+func newTranslationTestOrchestrator() *Orchestrator {
+	return NewOrchestrator(OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		MaxTurns:          1,
+		TurnTimeout:       5 * time.Second,
+		ResponseDelay:     time.Millisecond,
+		RetryInitialDelay: time.Millisecond,
+		InitialPrompt:     "Pick a database",
+	}, nil)
+}
+
+func TestTranslationRoundTrip(t *testing.T) {
+	orch := newTranslationTestOrchestrator()
+	translator := &stubTranslator{}
+	orch.SetTranslator(translator)
+
+	alice := &memoryAgent{
+		MockAgent: MockAgent{id: "alice", name: "Alice", agentType: "mock", available: true},
+		responses: []string{"Use Postgres."},
+	}
+	kenji := &multilingualAgent{
+		memoryAgent: memoryAgent{
+			MockAgent: MockAgent{id: "kenji", name: "Kenji", agentType: "mock", available: true},
+			responses: []string{"賛成です。"},
+		},
+		language: "Japanese",
+	}
+	orch.AddAgent(alice)
+	orch.AddAgent(kenji)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	// Alice speaks the conversation language and sees the originals
+	for _, msg := range alice.prompts[0] {
+		if strings.HasPrefix(msg.Content, "[") {
+			t.Errorf("expected untranslated prompt for Alice, got %q", msg.Content)
+		}
+	}
+
+	// Kenji sees every other message translated into Japanese
+	var sawAlice bool
+	for _, msg := range kenji.prompts[0] {
+		if msg.AgentID == "kenji" {
+			continue
+		}
+		if !strings.HasPrefix(msg.Content, "[Japanese] ") {
+			t.Errorf("expected message translated into Japanese, got %q", msg.Content)
+		}
+		if msg.AgentID == "alice" {
+			sawAlice = msg.Content == "[Japanese] Use Postgres."
+		}
+	}
+	if !sawAlice {
+		t.Errorf("expected Kenji to see Alice's translated response, got %v", kenji.prompts[0])
+	}
+
+	// Kenji's response is stored in the conversation language
+	var stored string
+	for _, msg := range orch.GetMessages() {
+		if msg.Role != "agent" {
+			continue
+		}
+		if msg.AgentID == "kenji" {
+			stored = msg.Content
+		}
+		if msg.AgentID == "alice" && msg.Content != "Use Postgres." {
+			t.Errorf("expected Alice's response unchanged in history, got %q", msg.Content)
+		}
+	}
+	if stored != "[English] 賛成です。" {
+		t.Errorf("expected Kenji's response translated back, got %q", stored)
+	}
+}
+
+func TestTranslationSkippedForConversationLanguage(t *testing.T) {
+	orch := newTranslationTestOrchestrator()
+	translator := &stubTranslator{}
+	orch.SetTranslator(translator)
+
+	a := &multilingualAgent{
+		memoryAgent: memoryAgent{
+			MockAgent: MockAgent{id: "a", name: "A", agentType: "mock", available: true},
+			responses: []string{"Hello."},
+		},
+		language: "english",
+	}
+	orch.AddAgent(a)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	if translator.calls != 0 {
+		t.Errorf("expected no translations, got %d", translator.calls)
+	}
+}
+
+func TestTranslationFailureKeepsOriginal(t *testing.T) {
+	orch := newTranslationTestOrchestrator()
+	orch.SetTranslator(&stubTranslator{err: errors.New("translator unavailable")})
+
+	a := &multilingualAgent{
+		memoryAgent: memoryAgent{
+			MockAgent: MockAgent{id: "a", name: "A", agentType: "mock", available: true},
+			responses: []string{"Bonjour."},
+		},
+		language: "French",
+	}
+	orch.AddAgent(a)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	for _, msg := range a.prompts[0] {
+		if msg.AgentID == "host" && msg.Content != "Pick a database" {
+			t.Errorf("expected the original prompt, got %q", msg.Content)
+		}
+	}
+	messages := orch.GetMessages()
+	if last := messages[len(messages)-1]; last.Content != "Bonjour." {
+		t.Errorf("expected the original response, got %q", last.Content)
+	}
+}
+
+func TestAgentTranslator(t *testing.T) {
+	a := &memoryAgent{
+		MockAgent: MockAgent{id: "t", name: "Translator", agentType: "mock", available: true},
+		responses: []string{"  Hola.\n"},
+	}
+
+	got, err := NewAgentTranslator(a).Translate(context.Background(), "Hello.", "Spanish")
+	if err != nil {
+		t.Fatalf("Translate failed: %v", err)
+	}
+	if got != "Hola." {
+		t.Errorf("expected trimmed translation, got %q", got)
+	}
+	prompt := a.prompts[0][0].Content
+	if !strings.Contains(prompt, "into Spanish") || !strings.HasSuffix(prompt, "Hello.") {
+		t.Errorf("unexpected translation prompt: %q", prompt)
+	}
+
+	a.responses = []string{"  "}
+	if _, err := NewAgentTranslator(a).Translate(context.Background(), "Hello.", "Spanish"); err == nil {
+		t.Error("expected an error for an empty translation")
+	}
+}
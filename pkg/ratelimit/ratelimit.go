@@ -1,6 +1,9 @@
 // Package ratelimit provides token bucket rate limiting for agent requests.
 // It implements a simple but effective rate limiting strategy that allows
 // burst traffic while maintaining an average rate limit.
+//
+// A Limiter can count requests (one bucket token per request) or, when created
+// with NewTokenLimiter, LLM tokens reserved via WaitN (tokens-per-minute quotas).
 package ratelimit
 
 import (
@@ -10,6 +13,14 @@ import (
 	"time"
 )
 
+// DefaultRecoveryTurns is the number of consecutive successful requests
+// required before an adaptive backoff is partially undone.
+const DefaultRecoveryTurns = 3
+
+// maxBackoffSteps bounds how many times Backoff can halve the rate,
+// so the effective rate never drops below 1/16th of the configured rate.
+const maxBackoffSteps = 4
+
 // Limiter implements a token bucket rate limiter.
 // It is safe for concurrent use.
 type Limiter struct {
@@ -19,6 +30,11 @@ type Limiter struct {
 	tokens     float64   // current tokens
 	lastRefill time.Time // last time tokens were refilled
 	disabled   bool      // if true, limiter always allows requests
+
+	baseRate      float64 // configured rate that adaptive recovery returns to
+	backoffSteps  int     // number of halvings currently applied
+	successStreak int     // consecutive successes since the last backoff or recovery step
+	recoveryTurns int     // successes required per recovery step
 }
 
 // NewLimiter creates a new rate limiter with the given rate (requests per second) and burst size.
@@ -36,29 +52,49 @@ func NewLimiter(rate float64, burst int) *Limiter {
 	}
 
 	return &Limiter{
-		rate:       rate,
-		burst:      burst,
-		tokens:     float64(burst), // start with full bucket
-		lastRefill: time.Now(),
-		disabled:   false,
+		rate:          rate,
+		burst:         burst,
+		tokens:        float64(burst), // start with full bucket
+		lastRefill:    time.Now(),
+		disabled:      false,
+		baseRate:      rate,
+		recoveryTurns: DefaultRecoveryTurns,
+	}
+}
+
+// NewTokenLimiter creates a limiter that budgets LLM tokens rather than requests.
+// The bucket refills at tokensPerMinute/60 tokens per second and holds at most one
+// minute's worth of tokens. A tokensPerMinute of 0 or less disables limiting.
+// Use WaitN to reserve the estimated token count of a request.
+func NewTokenLimiter(tokensPerMinute int) *Limiter {
+	if tokensPerMinute <= 0 {
+		return NewLimiter(0, 0)
 	}
+	return NewLimiter(float64(tokensPerMinute)/60.0, tokensPerMinute)
 }
 
 // Wait blocks until the rate limiter allows the request or the context is canceled.
 // It returns an error if the context is canceled before the request can proceed.
 func (l *Limiter) Wait(ctx context.Context) error {
-	if l.disabled {
+	return l.WaitN(ctx, 1)
+}
+
+// WaitN blocks until n tokens are available or the context is canceled.
+// Requests larger than the burst size are clamped to the burst size so that
+// an oversized request waits for a full bucket instead of blocking forever.
+func (l *Limiter) WaitN(ctx context.Context, n int) error {
+	if l.disabled || n <= 0 {
 		return nil
 	}
 
 	for {
-		// Try to take a token
-		if l.tryTake() {
+		// Try to take the tokens
+		if l.tryTakeN(n) {
 			return nil
 		}
 
-		// Calculate how long to wait for next token
-		waitTime := l.calculateWaitTime()
+		// Calculate how long to wait for enough tokens
+		waitTime := l.calculateWaitTimeN(n)
 
 		// Wait or check context
 		select {
@@ -81,38 +117,62 @@ func (l *Limiter) Allow() bool {
 	return l.tryTake()
 }
 
+// AllowN checks if n tokens can be taken immediately without waiting.
+// It returns true and consumes the tokens if they are available, false otherwise.
+func (l *Limiter) AllowN(n int) bool {
+	if l.disabled || n <= 0 {
+		return true
+	}
+
+	return l.tryTakeN(n)
+}
+
 // tryTake attempts to take a token from the bucket.
-// It refills the bucket based on elapsed time before attempting to take.
 func (l *Limiter) tryTake() bool {
+	return l.tryTakeN(1)
+}
+
+// tryTakeN attempts to take n tokens from the bucket.
+// It refills the bucket based on elapsed time before attempting to take.
+func (l *Limiter) tryTakeN(n int) bool {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	now := time.Now()
-	elapsed := now.Sub(l.lastRefill).Seconds()
+	need := l.clampN(n)
 
 	// Refill tokens based on elapsed time
-	l.tokens += elapsed * l.rate
-	if l.tokens > float64(l.burst) {
-		l.tokens = float64(l.burst)
-	}
-	l.lastRefill = now
+	l.refillLocked()
 
-	// Try to take a token
-	if l.tokens >= 1.0 {
-		l.tokens -= 1.0
+	// Try to take the tokens
+	if l.tokens >= need {
+		l.tokens -= need
 		return true
 	}
 
 	return false
 }
 
+// clampN converts a requested token count to a float capped at the burst size.
+// The caller must hold l.mu.
+func (l *Limiter) clampN(n int) float64 {
+	if n > l.burst {
+		n = l.burst
+	}
+	return float64(n)
+}
+
 // calculateWaitTime determines how long to wait for the next token.
 func (l *Limiter) calculateWaitTime() time.Duration {
+	return l.calculateWaitTimeN(1)
+}
+
+// calculateWaitTimeN determines how long to wait until n tokens are available.
+func (l *Limiter) calculateWaitTimeN(n int) time.Duration {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	// Calculate time needed to accumulate 1 token
-	tokensNeeded := 1.0 - l.tokens
+	// Calculate time needed to accumulate the missing tokens
+	tokensNeeded := l.clampN(n) - l.tokens
 	if tokensNeeded <= 0 {
 		return time.Millisecond // minimal wait
 	}
@@ -134,9 +194,85 @@ func (l *Limiter) SetRate(rate float64) {
 
 	l.disabled = false
 	l.rate = rate
+	l.baseRate = rate
+	l.backoffSteps = 0
+	l.successStreak = 0
+	if l.recoveryTurns == 0 {
+		l.recoveryTurns = DefaultRecoveryTurns
+	}
 	l.lastRefill = time.Now()
 }
 
+// Backoff halves the effective rate in response to an upstream rate-limit
+// error (e.g. HTTP 429). The configured rate is remembered and restored
+// gradually by RecordSuccess. Disabled limiters are unaffected.
+// It returns the new effective rate.
+func (l *Limiter) Backoff() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.disabled {
+		return 0
+	}
+
+	l.refillLocked()
+	l.successStreak = 0
+	if l.backoffSteps < maxBackoffSteps {
+		l.backoffSteps++
+		l.rate = l.baseRate / float64(int(1)<<l.backoffSteps)
+	}
+	// Drop any banked burst so the slower rate takes effect immediately
+	if l.tokens > 1.0 {
+		l.tokens = 1.0
+	}
+
+	return l.rate
+}
+
+// RecordSuccess notes a successful request. After RecoveryTurns consecutive
+// successes, one backoff step is undone (the rate doubles, up to the configured rate).
+// It returns the new effective rate.
+func (l *Limiter) RecordSuccess() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.disabled || l.backoffSteps == 0 {
+		return l.rate
+	}
+
+	l.successStreak++
+	if l.successStreak >= l.recoveryTurns {
+		l.refillLocked()
+		l.successStreak = 0
+		l.backoffSteps--
+		l.rate = l.baseRate / float64(int(1)<<l.backoffSteps)
+	}
+
+	return l.rate
+}
+
+// SetRecoveryTurns sets how many consecutive successes are needed per recovery step.
+// Values below 1 are clamped to 1.
+func (l *Limiter) SetRecoveryTurns(turns int) {
+	if turns < 1 {
+		turns = 1
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.recoveryTurns = turns
+}
+
+// refillLocked accrues tokens earned at the current rate. The caller must hold l.mu.
+func (l *Limiter) refillLocked() {
+	now := time.Now()
+	l.tokens += now.Sub(l.lastRefill).Seconds() * l.rate
+	if l.tokens > float64(l.burst) {
+		l.tokens = float64(l.burst)
+	}
+	l.lastRefill = now
+}
+
 // SetBurst updates the burst size. Burst must be at least 1.
 func (l *Limiter) SetBurst(burst int) {
 	if burst < 1 {
@@ -156,9 +292,11 @@ func (l *Limiter) SetBurst(burst int) {
 // Stats returns current rate limiter statistics.
 type Stats struct {
 	Rate            float64
+	BaseRate        float64
 	Burst           int
 	AvailableTokens float64
 	Disabled        bool
+	BackedOff       bool
 }
 
 // GetStats returns current statistics about the rate limiter.
@@ -176,9 +314,11 @@ func (l *Limiter) GetStats() Stats {
 
 	return Stats{
 		Rate:            l.rate,
+		BaseRate:        l.baseRate,
 		Burst:           l.burst,
 		AvailableTokens: tokens,
 		Disabled:        l.disabled,
+		BackedOff:       l.backoffSteps > 0,
 	}
 }
 
@@ -330,6 +330,144 @@ func TestCalculateWaitTime(t *testing.T) {
 	}
 }
 
+func TestNewTokenLimiter(t *testing.T) {
+	limiter := NewTokenLimiter(6000)
+	stats := limiter.GetStats()
+	if stats.Disabled {
+		t.Fatal("expected token limiter to be enabled")
+	}
+	if stats.Rate != 100.0 {
+		t.Errorf("expected rate=100 tokens/s, got %.2f", stats.Rate)
+	}
+	if stats.Burst != 6000 {
+		t.Errorf("expected burst=6000, got %d", stats.Burst)
+	}
+
+	if !NewTokenLimiter(0).GetStats().Disabled {
+		t.Error("expected zero tokens per minute to disable limiting")
+	}
+}
+
+func TestLimiterAllowN(t *testing.T) {
+	limiter := NewTokenLimiter(600) // 10 tokens/s, bucket of 600
+
+	if !limiter.AllowN(500) {
+		t.Fatal("expected 500 tokens to be allowed from a full bucket")
+	}
+	if limiter.AllowN(200) {
+		t.Error("expected 200 tokens to be denied with ~100 remaining")
+	}
+	if !limiter.AllowN(100) {
+		t.Error("expected remaining 100 tokens to be allowed")
+	}
+}
+
+func TestLimiterWaitNLargeContextWaitsLonger(t *testing.T) {
+	const tpm = 6000 // 100 tokens/s
+
+	measure := func(n int) time.Duration {
+		limiter := NewTokenLimiter(tpm)
+		// Drain the bucket so the reservation has to wait for refill
+		limiter.AllowN(tpm)
+
+		start := time.Now()
+		if err := limiter.WaitN(context.Background(), n); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return time.Since(start)
+	}
+
+	small := measure(5)  // ~50ms
+	large := measure(40) // ~400ms
+
+	if large <= small {
+		t.Errorf("expected large context to wait longer: small=%v large=%v", small, large)
+	}
+	if large < 300*time.Millisecond {
+		t.Errorf("expected large context to wait ~400ms, got %v", large)
+	}
+}
+
+func TestLimiterWaitNClampsToBurst(t *testing.T) {
+	limiter := NewLimiter(100.0, 10)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// A request larger than the bucket must not block forever
+	if err := limiter.WaitN(ctx, 1000); err != nil {
+		t.Errorf("expected oversized request to be clamped to burst, got %v", err)
+	}
+}
+
+func TestLimiterBackoffAndRecovery(t *testing.T) {
+	limiter := NewLimiter(8.0, 4)
+	limiter.SetRecoveryTurns(2)
+
+	if rate := limiter.Backoff(); rate != 4.0 {
+		t.Fatalf("expected rate to halve to 4.0, got %.2f", rate)
+	}
+	if stats := limiter.GetStats(); !stats.BackedOff || stats.BaseRate != 8.0 {
+		t.Errorf("expected backed off limiter with base rate 8.0, got %+v", stats)
+	}
+	if rate := limiter.Backoff(); rate != 2.0 {
+		t.Fatalf("expected second backoff to halve to 2.0, got %.2f", rate)
+	}
+
+	// One success is not enough to recover
+	if rate := limiter.RecordSuccess(); rate != 2.0 {
+		t.Errorf("expected rate to stay at 2.0 until cooldown, got %.2f", rate)
+	}
+	if rate := limiter.RecordSuccess(); rate != 4.0 {
+		t.Errorf("expected rate to recover to 4.0, got %.2f", rate)
+	}
+	limiter.RecordSuccess()
+	if rate := limiter.RecordSuccess(); rate != 8.0 {
+		t.Errorf("expected rate to recover to configured 8.0, got %.2f", rate)
+	}
+
+	// Further successes never exceed the configured rate
+	limiter.RecordSuccess()
+	limiter.RecordSuccess()
+	if stats := limiter.GetStats(); stats.Rate != 8.0 || stats.BackedOff {
+		t.Errorf("expected fully recovered limiter at 8.0, got %+v", stats)
+	}
+}
+
+func TestLimiterBackoffFloor(t *testing.T) {
+	limiter := NewLimiter(16.0, 1)
+	for i := 0; i < 10; i++ {
+		limiter.Backoff()
+	}
+	if rate := limiter.GetStats().Rate; rate != 1.0 {
+		t.Errorf("expected rate floor of 1/16th (1.0), got %.2f", rate)
+	}
+}
+
+func TestLimiterBackoffSlowsRequests(t *testing.T) {
+	limiter := NewLimiter(20.0, 5) // 50ms per token
+	limiter.Backoff()              // 10 req/s, 100ms per token
+
+	// Backoff drops banked burst, so two requests need one refill
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("expected backed off limiter to wait ~100ms, took %v", elapsed)
+	}
+}
+
+func TestLimiterBackoffDisabled(t *testing.T) {
+	limiter := NewLimiter(0, 1)
+	limiter.Backoff()
+	if !limiter.Allow() {
+		t.Error("disabled limiter should still allow requests after backoff")
+	}
+}
+
 // Helper function
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
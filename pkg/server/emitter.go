@@ -0,0 +1,172 @@
+package server
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kevinelliott/agentpipe/internal/bridge"
+)
+
+// eventBuffer is the number of events queued for a slow SSE client before
+// the orchestrator blocks.
+const eventBuffer = 64
+
+// sseEmitter implements bridge.BridgeEmitter by queueing events for an SSE stream.
+// Events are dropped once the client detaches so the conversation can keep running.
+type sseEmitter struct {
+	conversationID string
+	sequenceNum    int
+	events         chan bridge.Event
+	detached       chan struct{}
+	detachOnce     sync.Once
+	closed         bool
+	mu             sync.Mutex
+}
+
+// newSSEEmitter creates an emitter with a fresh conversation ID.
+func newSSEEmitter() *sseEmitter {
+	return &sseEmitter{
+		conversationID: uuid.New().String(),
+		events:         make(chan bridge.Event, eventBuffer),
+		detached:       make(chan struct{}),
+	}
+}
+
+// Events returns the channel of emitted events. It is closed when the
+// conversation ends.
+func (e *sseEmitter) Events() <-chan bridge.Event {
+	return e.events
+}
+
+// Detach stops delivery to the SSE client; later events are discarded.
+func (e *sseEmitter) Detach() {
+	e.detachOnce.Do(func() { close(e.detached) })
+}
+
+// GetConversationID returns the conversation ID
+func (e *sseEmitter) GetConversationID() string {
+	return e.conversationID
+}
+
+// emitEvent queues an event unless the emitter is closed or detached
+func (e *sseEmitter) emitEvent(eventType bridge.EventType, data interface{}) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.closed {
+		return
+	}
+
+	event := bridge.Event{
+		Type:      eventType,
+		Timestamp: bridge.UTCTime{Time: time.Now()},
+		Data:      data,
+	}
+
+	select {
+	case e.events <- event:
+	case <-e.detached:
+	}
+}
+
+// EmitConversationStarted emits a conversation.started event
+func (e *sseEmitter) EmitConversationStarted(
+	mode string,
+	initialPrompt string,
+	maxTurns int,
+	participants []bridge.AgentParticipant,
+	commandInfo *bridge.CommandInfo,
+) {
+	e.emitEvent(bridge.EventConversationStarted, bridge.ConversationStartedData{
+		ConversationID: e.conversationID,
+		Mode:           mode,
+		InitialPrompt:  initialPrompt,
+		MaxTurns:       maxTurns,
+		Participants:   participants,
+		Command:        commandInfo,
+	})
+}
+
+// EmitMessageCreated emits a message.created event
+func (e *sseEmitter) EmitMessageCreated(
+	agentID string,
+	agentType string,
+	agentName string,
+	content string,
+	model string,
+	turnNumber int,
+	tokensUsed int,
+	inputTokens int,
+	outputTokens int,
+	cost float64,
+	duration time.Duration,
+) {
+	e.mu.Lock()
+	e.sequenceNum++
+	seqNum := e.sequenceNum
+	e.mu.Unlock()
+
+	e.emitEvent(bridge.EventMessageCreated, bridge.MessageCreatedData{
+		ConversationID: e.conversationID,
+		MessageID:      uuid.New().String(),
+		AgentID:        agentID,
+		AgentType:      agentType,
+		AgentName:      agentName,
+		Content:        content,
+		SequenceNumber: seqNum,
+		TurnNumber:     turnNumber,
+		TokensUsed:     tokensUsed,
+		InputTokens:    inputTokens,
+		OutputTokens:   outputTokens,
+		Cost:           cost,
+		Model:          model,
+		DurationMs:     duration.Milliseconds(),
+	})
+}
+
+// EmitConversationCompleted emits a conversation.completed event
+func (e *sseEmitter) EmitConversationCompleted(
+	status string,
+	totalMessages int,
+	totalTurns int,
+	totalTokens int,
+	totalCost float64,
+	duration time.Duration,
+	summary *bridge.SummaryMetadata,
+) {
+	e.emitEvent(bridge.EventConversationCompleted, bridge.ConversationCompletedData{
+		ConversationID:  e.conversationID,
+		Status:          status,
+		TotalMessages:   totalMessages,
+		TotalTurns:      totalTurns,
+		TotalTokens:     totalTokens,
+		TotalCost:       totalCost,
+		DurationSeconds: duration.Seconds(),
+		Summary:         summary,
+	})
+}
+
+// EmitConversationError emits a conversation.error event
+func (e *sseEmitter) EmitConversationError(errorMessage string, errorType string, agentType string) {
+	e.emitEvent(bridge.EventConversationError, bridge.ConversationErrorData{
+		ConversationID: e.conversationID,
+		ErrorMessage:   errorMessage,
+		ErrorType:      errorType,
+		AgentType:      agentType,
+	})
+}
+
+// Close closes the event channel, ending the SSE stream. It is safe to call
+// more than once.
+func (e *sseEmitter) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.closed {
+		e.closed = true
+		close(e.events)
+	}
+	return nil
+}
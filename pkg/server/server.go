@@ -0,0 +1,328 @@
+// Package server provides an HTTP API for running AgentPipe conversations.
+// Each POST /conversations request runs its own orchestrator and streams the
+// conversation back as Server-Sent Events using the bridge event payloads.
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+	"github.com/kevinelliott/agentpipe/pkg/config"
+	"github.com/kevinelliott/agentpipe/pkg/log"
+	"github.com/kevinelliott/agentpipe/pkg/orchestrator"
+)
+
+// DefaultAddr is the address the server listens on when none is configured.
+// It only accepts local connections.
+const DefaultAddr = "127.0.0.1:8080"
+
+// DefaultRetention is how long a finished conversation stays available when no
+// retention is configured.
+const DefaultRetention = time.Hour
+
+// maxConfigSize limits the size of a POST /conversations request body.
+const maxConfigSize = 1 << 20
+
+// Conversation status values reported by GET /conversations/{id}.
+const (
+	StatusRunning   = orchestrator.ConversationRunning
+	StatusCompleted = orchestrator.ConversationCompleted
+	StatusStopped   = orchestrator.ConversationStopped
+	StatusError     = orchestrator.ConversationFailed
+)
+
+// Server is an HTTP server that runs conversations on request.
+type Server struct {
+	addr      string
+	token     string
+	retention time.Duration
+	server    *http.Server
+
+	// manager runs every conversation; all are stopped by Stop
+	manager *orchestrator.Manager
+}
+
+// ServerConfig contains configuration for the conversation server.
+type ServerConfig struct {
+	// Addr is the address to listen on (default: DefaultAddr)
+	Addr string
+
+	// Token, if set, must be sent as a bearer token ("Authorization: Bearer
+	// <token>") with every request
+	Token string
+
+	// ReadTimeout is the maximum duration for reading the entire request.
+	// There is no write timeout since event streams last as long as the conversation.
+	ReadTimeout time.Duration
+
+	// Retention is how long a finished conversation stays available via
+	// GET /conversations/{id} before it is removed (default: DefaultRetention)
+	Retention time.Duration
+}
+
+// ConversationStatus is the response body of GET /conversations/{id}.
+type ConversationStatus struct {
+	ID            string          `json:"id"`
+	Status        string          `json:"status"`
+	StartedAt     time.Time       `json:"started_at"`
+	CompletedAt   *time.Time      `json:"completed_at,omitempty"`
+	Error         string          `json:"error,omitempty"`
+	TotalMessages int             `json:"total_messages"`
+	Messages      []agent.Message `json:"messages"`
+}
+
+// NewServer creates a new conversation server with the given configuration.
+func NewServer(config ServerConfig) *Server {
+	if config.Addr == "" {
+		config.Addr = DefaultAddr
+	}
+
+	if config.ReadTimeout == 0 {
+		config.ReadTimeout = 10 * time.Second
+	}
+
+	if config.Retention == 0 {
+		config.Retention = DefaultRetention
+	}
+
+	s := &Server{
+		addr:      config.Addr,
+		token:     config.Token,
+		retention: config.Retention,
+		manager:   orchestrator.NewManager(),
+	}
+
+	s.server = &http.Server{
+		Addr:        config.Addr,
+		Handler:     s.Handler(),
+		ReadTimeout: config.ReadTimeout,
+	}
+
+	return s
+}
+
+// Handler returns the HTTP handler serving the conversation API. With a
+// token configured, every request must carry it.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /conversations", s.handleCreateConversation)
+	mux.HandleFunc("GET /conversations/{id}", s.handleGetConversation)
+	if s.token == "" {
+		return mux
+	}
+	return s.requireToken(mux)
+}
+
+// requireToken refuses requests that don't carry the server's bearer token.
+func (s *Server) requireToken(next http.Handler) http.Handler {
+	want := []byte("Bearer " + s.token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), want) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Start starts the conversation server.
+// This method blocks until the server is stopped or encounters an error.
+func (s *Server) Start() error {
+	log.WithField("addr", s.addr).Info("starting conversation server")
+
+	if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.WithError(err).Error("conversation server failed")
+		return fmt.Errorf("conversation server failed: %w", err)
+	}
+
+	return nil
+}
+
+// Stop cancels running conversations and gracefully stops the server.
+func (s *Server) Stop(ctx context.Context) error {
+	log.Info("stopping conversation server")
+
+	s.manager.StopAll()
+
+	if err := s.server.Shutdown(ctx); err != nil {
+		log.WithError(err).Error("conversation server shutdown failed")
+		return fmt.Errorf("conversation server shutdown failed: %w", err)
+	}
+
+	log.Info("conversation server stopped")
+	return nil
+}
+
+// handleCreateConversation starts a conversation from the posted config and
+// streams its events until it ends or the client disconnects.
+func (s *Server) handleCreateConversation(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxConfigSize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("failed to read request: %v", err))
+		return
+	}
+
+	cfg, err := config.ParseConfig(data)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	agents, err := createAgents(cfg)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	emitter := newSSEEmitter()
+	defer emitter.Detach()
+
+	conv, err := s.startConversation(cfg, agents, emitter)
+	if err != nil {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Conversation-ID", conv.ID())
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case event, ok := <-emitter.Events():
+			if !ok {
+				return
+			}
+			payload, err := json.Marshal(event)
+			if err != nil {
+				log.WithError(err).Warn("failed to marshal conversation event")
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, payload); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			// The conversation keeps running; its status stays available via GET
+			return
+		}
+	}
+}
+
+// handleGetConversation reports the status and messages of a conversation.
+func (s *Server) handleGetConversation(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	conv, ok := s.manager.Get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("conversation not found: %s", id))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, snapshot(conv))
+}
+
+// startConversation registers a conversation and runs it in the background.
+func (s *Server) startConversation(cfg *config.Config, agents []agent.Agent, emitter *sseEmitter) (*orchestrator.ManagedConversation, error) {
+	orch := orchestrator.NewOrchestrator(orchestrator.ConfigFrom(cfg), nil)
+	orch.SetBridgeEmitter(emitter)
+	for _, a := range agents {
+		orch.AddAgent(a)
+	}
+
+	conv, err := s.manager.Start(emitter.GetConversationID(), orch)
+	if err != nil {
+		return nil, err
+	}
+
+	log.WithFields(map[string]interface{}{
+		"conversation_id": conv.ID(),
+		"mode":            cfg.Orchestrator.Mode,
+		"agents":          len(agents),
+	}).Info("conversation started via API")
+
+	go func() {
+		<-conv.Done()
+		// Start closes the emitter on return; close again in case it failed early
+		_ = emitter.Close()
+
+		// Forget the conversation once its retention is over, so a long-running
+		// server doesn't keep every conversation's messages
+		time.AfterFunc(s.retention, func() {
+			if err := s.manager.Remove(conv.ID()); err == nil {
+				log.WithField("conversation_id", conv.ID()).Debug("finished conversation removed")
+			}
+		})
+	}()
+
+	return conv, nil
+}
+
+// snapshot returns the current status of the conversation.
+func snapshot(conv *orchestrator.ManagedConversation) ConversationStatus {
+	messages := conv.Orchestrator().GetMessages()
+	if messages == nil {
+		messages = []agent.Message{}
+	}
+
+	info := conv.Info()
+	status := ConversationStatus{
+		ID:            info.ID,
+		Status:        info.State,
+		StartedAt:     info.StartedAt,
+		TotalMessages: len(messages),
+		Messages:      messages,
+	}
+	if !info.CompletedAt.IsZero() {
+		completedAt := info.CompletedAt
+		status.CompletedAt = &completedAt
+	}
+	if info.Err != nil {
+		status.Error = info.Err.Error()
+	}
+	return status
+}
+
+// createAgents creates and checks every agent in the config.
+func createAgents(cfg *config.Config) ([]agent.Agent, error) {
+	agents := make([]agent.Agent, 0, len(cfg.Agents))
+	for _, agentCfg := range cfg.Agents {
+		a, err := agent.CreateAgent(agentCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create agent %s: %w", agentCfg.ID, err)
+		}
+		if !a.IsAvailable() {
+			return nil, fmt.Errorf("agent %s (%s) is not available", agentCfg.Name, agentCfg.Type)
+		}
+		agents = append(agents, a)
+	}
+	return agents, nil
+}
+
+// writeJSON writes v as a JSON response.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a JSON error response.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
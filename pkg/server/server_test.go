@@ -0,0 +1,325 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentpipe/internal/bridge"
+	"github.com/kevinelliott/agentpipe/pkg/agent"
+)
+
+// serverTestAgent is a mock agent that answers every message.
+type serverTestAgent struct {
+	agent.BaseAgent
+}
+
+func (a *serverTestAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	return "hello from " + a.Name, nil
+}
+
+func (a *serverTestAgent) StreamMessage(ctx context.Context, messages []agent.Message, writer io.Writer) error {
+	return nil
+}
+
+func (a *serverTestAgent) IsAvailable() bool                     { return true }
+func (a *serverTestAgent) HealthCheck(ctx context.Context) error { return nil }
+func (a *serverTestAgent) GetCLIVersion() string                 { return "1.0.0" }
+
+func init() {
+	agent.RegisterFactory("server-mock", func() agent.Agent {
+		return &serverTestAgent{}
+	})
+}
+
+const testConfig = `{
+  "agents": [
+    {"id": "a1", "type": "server-mock", "name": "Alice"},
+    {"id": "a2", "type": "server-mock", "name": "Bob"}
+  ],
+  "orchestrator": {
+    "mode": "round-robin",
+    "max_turns": 1,
+    "response_delay": "1ms",
+    "initial_prompt": "Say hello",
+    "summary": {"enabled": false, "agent": "none"}
+  }
+}`
+
+// sseEvent is one parsed Server-Sent Event.
+type sseEvent struct {
+	name string
+	data string
+}
+
+// readEvents reads SSE frames from r until the stream ends.
+func readEvents(t *testing.T, r io.Reader) []sseEvent {
+	t.Helper()
+
+	var events []sseEvent
+	var current sseEvent
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			current.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			current.data = strings.TrimPrefix(line, "data: ")
+		case line == "" && current.name != "":
+			events = append(events, current)
+			current = sseEvent{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("failed to read event stream: %v", err)
+	}
+	return events
+}
+
+func TestCreateConversationStreamsEvents(t *testing.T) {
+	srv := NewServer(ServerConfig{})
+	defer srv.manager.StopAll()
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/conversations", "application/json", strings.NewReader(testConfig))
+	if err != nil {
+		t.Fatalf("POST /conversations failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, body)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("expected text/event-stream, got %q", ct)
+	}
+	id := resp.Header.Get("X-Conversation-ID")
+	if id == "" {
+		t.Fatal("expected X-Conversation-ID header")
+	}
+
+	events := readEvents(t, resp.Body)
+
+	var messages []bridge.MessageCreatedData
+	var completed *bridge.ConversationCompletedData
+	for _, ev := range events {
+		var envelope struct {
+			Type bridge.EventType `json:"type"`
+			Data json.RawMessage  `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(ev.data), &envelope); err != nil {
+			t.Fatalf("invalid event payload %q: %v", ev.data, err)
+		}
+		if string(envelope.Type) != ev.name {
+			t.Errorf("event name %q does not match payload type %q", ev.name, envelope.Type)
+		}
+
+		switch envelope.Type {
+		case bridge.EventMessageCreated:
+			var data bridge.MessageCreatedData
+			if err := json.Unmarshal(envelope.Data, &data); err != nil {
+				t.Fatalf("invalid message.created data: %v", err)
+			}
+			messages = append(messages, data)
+		case bridge.EventConversationCompleted:
+			var data bridge.ConversationCompletedData
+			if err := json.Unmarshal(envelope.Data, &data); err != nil {
+				t.Fatalf("invalid conversation.completed data: %v", err)
+			}
+			completed = &data
+		}
+	}
+
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 message.created events, got %d", len(messages))
+	}
+	if messages[0].AgentName != "Alice" || messages[0].Content != "hello from Alice" {
+		t.Errorf("unexpected first message: %+v", messages[0])
+	}
+	if messages[0].ConversationID != id {
+		t.Errorf("expected conversation ID %s, got %s", id, messages[0].ConversationID)
+	}
+	if completed == nil {
+		t.Fatal("expected a conversation.completed event")
+	}
+	if completed.Status != "completed" {
+		t.Errorf("expected status completed, got %s", completed.Status)
+	}
+	if events[len(events)-1].name != string(bridge.EventConversationCompleted) {
+		t.Errorf("expected conversation.completed to be the last event, got %s", events[len(events)-1].name)
+	}
+
+	// The stream closes before the orchestrator goroutine records the result
+	var status ConversationStatus
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		getResp, err := http.Get(ts.URL + "/conversations/" + id)
+		if err != nil {
+			t.Fatalf("GET /conversations/%s failed: %v", id, err)
+		}
+		err = json.NewDecoder(getResp.Body).Decode(&status)
+		getResp.Body.Close()
+		if err != nil {
+			t.Fatalf("invalid status response: %v", err)
+		}
+		if status.Status != StatusRunning || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if status.Status != StatusCompleted {
+		t.Errorf("expected status %s, got %s (error: %s)", StatusCompleted, status.Status, status.Error)
+	}
+	if status.TotalMessages != len(status.Messages) {
+		t.Errorf("total_messages %d does not match %d messages", status.TotalMessages, len(status.Messages))
+	}
+	responses := 0
+	for _, msg := range status.Messages {
+		if msg.Role == "agent" {
+			responses++
+		}
+	}
+	if responses != 2 {
+		t.Errorf("expected 2 agent messages, got %d", responses)
+	}
+	if status.CompletedAt == nil {
+		t.Error("expected completed_at to be set")
+	}
+}
+
+func TestCreateConversationRejectsInvalidConfig(t *testing.T) {
+	srv := NewServer(ServerConfig{})
+	defer srv.manager.StopAll()
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"malformed", `{"agents": [`},
+		{"no agents", `{"agents": []}`},
+		{"unknown type", `{"agents": [{"id": "a1", "type": "no-such-agent", "name": "X"}]}`},
+		{"exec agent", `{"agents": [{"id": "a1", "type": "exec", "name": "X", "command": "sh"}]}`},
+		{"MCP server command", `{"agents": [{"id": "a1", "type": "server-mock", "name": "X", "mcp_servers": {"fs": {"command": "sh"}}}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Post(ts.URL+"/conversations", "application/json", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatalf("POST failed: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestTokenRequired(t *testing.T) {
+	srv := NewServer(ServerConfig{Token: "s3cret"})
+	defer srv.manager.StopAll()
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	for _, auth := range []string{"", "Bearer wrong", "s3cret"} {
+		req, err := http.NewRequest(http.MethodPost, ts.URL+"/conversations", strings.NewReader(testConfig))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("POST failed: %v", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("Authorization %q: expected 401, got %d", auth, resp.StatusCode)
+		}
+	}
+	if ids := srv.manager.List(); len(ids) != 0 {
+		t.Errorf("expected no conversation to start without the token, got %v", ids)
+	}
+
+	// With the token, requests go through
+	req, err := http.NewRequest(http.MethodGet, ts.URL+"/conversations/missing", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer s3cret")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 with the token, got %d", resp.StatusCode)
+	}
+}
+
+func TestFinishedConversationsAreRemoved(t *testing.T) {
+	srv := NewServer(ServerConfig{Retention: 10 * time.Millisecond})
+	defer srv.manager.StopAll()
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/conversations", "application/json", strings.NewReader(testConfig))
+	if err != nil {
+		t.Fatalf("POST /conversations failed: %v", err)
+	}
+	id := resp.Header.Get("X-Conversation-ID")
+	readEvents(t, resp.Body)
+	resp.Body.Close()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		resp, err := http.Get(ts.URL + "/conversations/" + id)
+		if err != nil {
+			t.Fatalf("GET failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the finished conversation to be removed, still got %d", resp.StatusCode)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := len(srv.manager.List()); got != 0 {
+		t.Errorf("expected no conversations left, got %d", got)
+	}
+}
+
+func TestGetUnknownConversation(t *testing.T) {
+	srv := NewServer(ServerConfig{})
+	defer srv.manager.StopAll()
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/conversations/missing")
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", resp.StatusCode)
+	}
+}
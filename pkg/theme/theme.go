@@ -0,0 +1,153 @@
+// Package theme defines the color palettes the TUI and the console chat logger
+// draw with, so their output stays readable on dark and light terminals.
+package theme
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme names accepted by Resolve.
+const (
+	Auto      = "auto"
+	DarkName  = "dark"
+	LightName = "light"
+)
+
+// Theme is a color palette. Colors are ANSI 256-color codes.
+type Theme struct {
+	// Name is "dark" or "light"
+	Name string
+	// AgentColors are given to agents in turn
+	AgentColors []lipgloss.Color
+
+	Primary        lipgloss.Color // Active panel borders and the input prompt
+	Secondary      lipgloss.Color // Titles, modal borders and host messages
+	Border         lipgloss.Color // Inactive borders, placeholders, idle agents and metrics
+	Muted          lipgloss.Color // System messages, logs and agent types
+	Subtle         lipgloss.Color // Help keys and status text
+	Faint          lipgloss.Color // Timestamps and tool badges
+	Separator      lipgloss.Color // Separator lines between messages
+	Text           lipgloss.Color // Tool output and help descriptions
+	AgentName      lipgloss.Color // Agent names in the simple TUI
+	Surface        lipgloss.Color // Background of modals, selections and badges
+	OnColor        lipgloss.Color // Text on agent colors and highlights
+	BadgeText      lipgloss.Color // Text on the default and moderator badges
+	Error          lipgloss.Color // Errors
+	Info           lipgloss.Color // Informational system messages
+	Success        lipgloss.Color // Active agents and confirmations
+	Warning        lipgloss.Color // Moderator messages
+	WarningSurface lipgloss.Color // Background of the moderator badge
+	Highlight      lipgloss.Color // The user's messages and search matches
+}
+
+// Dark is the palette for terminals with a dark background.
+var Dark = Theme{
+	Name: DarkName,
+	AgentColors: []lipgloss.Color{
+		lipgloss.Color("63"),  // Blue
+		lipgloss.Color("212"), // Pink
+		lipgloss.Color("86"),  // Green
+		lipgloss.Color("214"), // Orange
+		lipgloss.Color("99"),  // Purple
+		lipgloss.Color("51"),  // Cyan
+		lipgloss.Color("226"), // Yellow
+		lipgloss.Color("201"), // Magenta
+	},
+	Primary:        lipgloss.Color("63"),
+	Secondary:      lipgloss.Color("99"),
+	Border:         lipgloss.Color("240"),
+	Muted:          lipgloss.Color("244"),
+	Subtle:         lipgloss.Color("241"),
+	Faint:          lipgloss.Color("238"),
+	Separator:      lipgloss.Color("236"),
+	Text:           lipgloss.Color("248"),
+	AgentName:      lipgloss.Color("86"),
+	Surface:        lipgloss.Color("235"),
+	OnColor:        lipgloss.Color("0"),
+	BadgeText:      lipgloss.Color("255"),
+	Error:          lipgloss.Color("196"),
+	Info:           lipgloss.Color("33"),
+	Success:        lipgloss.Color("82"),
+	Warning:        lipgloss.Color("214"),
+	WarningSurface: lipgloss.Color("130"),
+	Highlight:      lipgloss.Color("226"),
+}
+
+// Light is the palette for terminals with a light background. Its colors are
+// darker so they keep their contrast on white.
+var Light = Theme{
+	Name: LightName,
+	AgentColors: []lipgloss.Color{
+		lipgloss.Color("25"),  // Blue
+		lipgloss.Color("162"), // Pink
+		lipgloss.Color("28"),  // Green
+		lipgloss.Color("166"), // Orange
+		lipgloss.Color("91"),  // Purple
+		lipgloss.Color("30"),  // Cyan
+		lipgloss.Color("136"), // Yellow
+		lipgloss.Color("127"), // Magenta
+	},
+	Primary:        lipgloss.Color("25"),
+	Secondary:      lipgloss.Color("91"),
+	Border:         lipgloss.Color("248"),
+	Muted:          lipgloss.Color("242"),
+	Subtle:         lipgloss.Color("244"),
+	Faint:          lipgloss.Color("246"),
+	Separator:      lipgloss.Color("252"),
+	Text:           lipgloss.Color("238"),
+	AgentName:      lipgloss.Color("28"),
+	Surface:        lipgloss.Color("254"),
+	OnColor:        lipgloss.Color("231"),
+	BadgeText:      lipgloss.Color("235"),
+	Error:          lipgloss.Color("160"),
+	Info:           lipgloss.Color("25"),
+	Success:        lipgloss.Color("28"),
+	Warning:        lipgloss.Color("166"),
+	WarningSurface: lipgloss.Color("223"),
+	Highlight:      lipgloss.Color("136"),
+}
+
+// Valid reports whether name is a theme Resolve accepts. The empty name means
+// auto.
+func Valid(name string) bool {
+	switch name {
+	case "", Auto, DarkName, LightName:
+		return true
+	}
+	return false
+}
+
+// Resolve returns the theme called name. With "auto" (or no name) the theme is
+// picked from the terminal background reported in $COLORFGBG, falling back to
+// dark when the variable is unset or unrecognized.
+func Resolve(name string) (Theme, error) {
+	switch name {
+	case "", Auto:
+		if backgroundIsLight(os.Getenv("COLORFGBG")) {
+			return Light, nil
+		}
+		return Dark, nil
+	case DarkName:
+		return Dark, nil
+	case LightName:
+		return Light, nil
+	}
+	return Theme{}, fmt.Errorf("unknown theme %q (expected %s, %s or %s)", name, Auto, DarkName, LightName)
+}
+
+// backgroundIsLight reports whether a $COLORFGBG value ("fg;bg" or
+// "fg;default;bg", set by rxvt, Konsole and others) has a light background:
+// white (7) or one of the bright colors other than bright black (9-15).
+func backgroundIsLight(colorfgbg string) bool {
+	parts := strings.Split(colorfgbg, ";")
+	bg, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return false
+	}
+	return bg == 7 || (bg >= 9 && bg <= 15)
+}
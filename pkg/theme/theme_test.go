@@ -0,0 +1,81 @@
+package theme
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name          string
+		theme         string
+		colorfgbg     string
+		wantName      string
+		wantPrimary   lipgloss.Color
+		wantHighlight lipgloss.Color
+	}{
+		{"dark", "dark", "0;15", DarkName, "63", "226"},
+		{"light", "light", "15;0", LightName, "25", "136"},
+		{"auto light background", "auto", "0;15", LightName, "25", "136"},
+		{"auto white background", "auto", "0;7", LightName, "25", "136"},
+		{"auto dark background", "auto", "15;0", DarkName, "63", "226"},
+		{"auto bright black background", "auto", "15;8", DarkName, "63", "226"},
+		{"auto three fields", "auto", "0;default;15", LightName, "25", "136"},
+		{"auto default background", "auto", "15;default", DarkName, "63", "226"},
+		{"auto unset", "auto", "", DarkName, "63", "226"},
+		{"empty is auto", "", "0;15", LightName, "25", "136"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("COLORFGBG", tt.colorfgbg)
+
+			got, err := Resolve(tt.theme)
+			if err != nil {
+				t.Fatalf("Resolve(%q) failed: %v", tt.theme, err)
+			}
+			if got.Name != tt.wantName {
+				t.Errorf("Resolve(%q) = %s theme, want %s", tt.theme, got.Name, tt.wantName)
+			}
+			if got.Primary != tt.wantPrimary {
+				t.Errorf("Primary = %q, want %q", got.Primary, tt.wantPrimary)
+			}
+			if got.Highlight != tt.wantHighlight {
+				t.Errorf("Highlight = %q, want %q", got.Highlight, tt.wantHighlight)
+			}
+		})
+	}
+}
+
+func TestResolveUnknownTheme(t *testing.T) {
+	if _, err := Resolve("solarized"); err == nil {
+		t.Error("Expected an error for an unknown theme")
+	}
+	if Valid("solarized") {
+		t.Error("Expected solarized not to be valid")
+	}
+	for _, name := range []string{"", Auto, DarkName, LightName} {
+		if !Valid(name) {
+			t.Errorf("Expected %q to be valid", name)
+		}
+	}
+}
+
+// TestThemesSetEveryColor checks that no color of a theme is left empty, which
+// would render in the terminal's default color.
+func TestThemesSetEveryColor(t *testing.T) {
+	for _, theme := range []Theme{Dark, Light} {
+		if len(theme.AgentColors) != len(Dark.AgentColors) {
+			t.Errorf("%s theme has %d agent colors, want %d", theme.Name, len(theme.AgentColors), len(Dark.AgentColors))
+		}
+
+		v := reflect.ValueOf(theme)
+		for i := 0; i < v.NumField(); i++ {
+			if c, ok := v.Field(i).Interface().(lipgloss.Color); ok && c == "" {
+				t.Errorf("%s theme has no %s color", theme.Name, v.Type().Field(i).Name)
+			}
+		}
+	}
+}
@@ -4,15 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/rivo/uniseg"
 	"github.com/rs/zerolog"
 
 	"github.com/kevinelliott/agentpipe/internal/branding"
@@ -43,6 +47,7 @@ type EnhancedModel struct {
 	conversation viewport.Model
 	logPanel     viewport.Model
 	userInput    textarea.Model
+	searchInput  textinput.Model
 
 	// State
 	messages      []agent.Message
@@ -64,9 +69,16 @@ type EnhancedModel struct {
 	initialized   bool
 	initializing  bool
 	activeAgent   string             // Track which agent is currently responding
+	streaming     *agent.Message     // Partial response of the active agent when streaming
 	chatLogger    *logger.ChatLogger // For logging conversations
-	totalCost     float64            // Track total cost of conversation
-	totalTime     time.Duration      // Track total time of agent requests
+	statusMessage string             // Brief notice shown in the status bar
+
+	// Search state
+	searchMode         bool
+	searchPattern      *regexp.Regexp // Last search, highlighted in the conversation
+	searchResults      []int          // Message indices that match search
+	currentSearchIndex int            // Current position in searchResults
+	messageLines       map[int]int    // Line of the conversation each message starts on
 
 	// Initialization params
 	skipHealthCheck    bool
@@ -77,83 +89,6 @@ type EnhancedModel struct {
 	agentColors map[string]lipgloss.Color
 }
 
-// Styles
-var (
-	// Panel styles
-	activePanelStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color("63")).
-				Padding(0, 1)
-
-	inactivePanelStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color("240")).
-				Padding(0, 1)
-
-	// Input panel styles (no padding)
-	activeInputPanelStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color("63"))
-
-	inactiveInputPanelStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color("240"))
-
-	// Log panel styles
-	logPanelStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("240")).
-			Padding(0, 1)
-
-	// Title styles
-	enhancedTitleStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(lipgloss.Color("99"))
-
-	// Modal styles
-	modalStyle = lipgloss.NewStyle().
-			Border(lipgloss.DoubleBorder()).
-			BorderForeground(lipgloss.Color("99")).
-			Padding(1, 2).
-			Background(lipgloss.Color("235"))
-
-	// Status bar styles
-	statusBarStyle = lipgloss.NewStyle().
-			Padding(0, 1)
-
-	// Help styles
-	helpKeyStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241"))
-
-	helpDescStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("248"))
-
-	// Logo panel styles
-	logoPanelStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("240")).
-			Align(lipgloss.Center)
-
-	_ = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("99")).
-		Bold(true)
-
-	logoInfoStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("244")).
-			Align(lipgloss.Center)
-)
-
-var agentColors = []lipgloss.Color{
-	lipgloss.Color("63"),  // Blue
-	lipgloss.Color("212"), // Pink
-	lipgloss.Color("86"),  // Green
-	lipgloss.Color("214"), // Orange
-	lipgloss.Color("99"),  // Purple
-	lipgloss.Color("51"),  // Cyan
-	lipgloss.Color("226"), // Yellow
-	lipgloss.Color("201"), // Magenta
-}
-
 type agentItem struct {
 	agent agent.Agent
 	color lipgloss.Color
@@ -240,7 +175,9 @@ func (w *logWriter) formatLogLine(line string) string {
 	return formatted
 }
 
-func RunEnhanced(ctx context.Context, cfg *config.Config, agents []agent.Agent, skipHealthCheck bool, healthCheckTimeout int, configPath string) error {
+// RunEnhanced runs the enhanced TUI until the user quits. It returns the
+// orchestrator that ran the conversation so callers can report on it.
+func RunEnhanced(ctx context.Context, cfg *config.Config, agents []agent.Agent, skipHealthCheck bool, healthCheckTimeout int, configPath string) (*orchestrator.Orchestrator, error) {
 	// Create agent items for the list
 	var items []list.Item
 	agentColorMap := make(map[string]lipgloss.Color)
@@ -279,26 +216,27 @@ func RunEnhanced(ctx context.Context, cfg *config.Config, agents []agent.Agent,
 	// Remove all backgrounds from textarea
 	ta.FocusedStyle.Base = lipgloss.NewStyle()
 	ta.FocusedStyle.CursorLine = lipgloss.NewStyle()
-	ta.FocusedStyle.Placeholder = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
-	ta.FocusedStyle.Prompt = lipgloss.NewStyle().Foreground(lipgloss.Color("63"))
+	ta.FocusedStyle.Placeholder = lipgloss.NewStyle().Foreground(palette.Border)
+	ta.FocusedStyle.Prompt = lipgloss.NewStyle().Foreground(palette.Primary)
 	ta.FocusedStyle.Text = lipgloss.NewStyle()
 
 	ta.BlurredStyle.Base = lipgloss.NewStyle()
 	ta.BlurredStyle.CursorLine = lipgloss.NewStyle()
-	ta.BlurredStyle.Placeholder = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
-	ta.BlurredStyle.Prompt = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	ta.BlurredStyle.Placeholder = lipgloss.NewStyle().Foreground(palette.Border)
+	ta.BlurredStyle.Prompt = lipgloss.NewStyle().Foreground(palette.Border)
 	ta.BlurredStyle.Text = lipgloss.NewStyle()
 
 	ta.Focus()
 
+	// Create the search input
+	searchInput := textinput.New()
+	searchInput.Placeholder = "Search messages..."
+	searchInput.CharLimit = 100
+
 	// Create orchestrator configuration
-	orchConfig := orchestrator.OrchestratorConfig{
-		Mode:          orchestrator.ConversationMode(cfg.Orchestrator.Mode),
-		TurnTimeout:   cfg.Orchestrator.TurnTimeout,
-		MaxTurns:      cfg.Orchestrator.MaxTurns,
-		ResponseDelay: cfg.Orchestrator.ResponseDelay,
-		InitialPrompt: cfg.Orchestrator.InitialPrompt,
-	}
+	orchConfig := orchestrator.ConfigFrom(cfg)
+	// The TUI has nowhere to show a conversation summary
+	orchConfig.Summary.Enabled = false
 
 	// Only set a default timeout if none was configured
 	if orchConfig.TurnTimeout == 0 {
@@ -328,6 +266,35 @@ func RunEnhanced(ctx context.Context, cfg *config.Config, agents []agent.Agent,
 		currentContent: strings.Builder{},
 	})
 
+	// Show responses in the conversation panel as they are generated
+	if orchConfig.Stream {
+		orch.SetStreamHandler(func(a agent.Agent, partial string) {
+			msg := agent.Message{
+				AgentID:   a.GetID(),
+				AgentName: a.GetName(),
+				AgentType: a.GetType(),
+				Content:   partial,
+				Timestamp: time.Now().Unix(),
+				Role:      "partial",
+			}
+
+			// The clear after a failed attempt must arrive, or the discarded text stays on screen
+			if partial == "" {
+				select {
+				case msgChan <- msg:
+				case <-ctx.Done():
+				}
+				return
+			}
+
+			select {
+			case msgChan <- msg:
+			default:
+				// Channel full; a later partial or the final message replaces this one
+			}
+		})
+	}
+
 	// Set up logging if enabled
 	var chatLogger *logger.ChatLogger
 	if cfg.Logging.Enabled {
@@ -348,6 +315,9 @@ func RunEnhanced(ctx context.Context, cfg *config.Config, agents []agent.Agent,
 		orch:               orch,
 		agentList:          agentList,
 		userInput:          ta,
+		searchInput:        searchInput,
+		searchResults:      make([]int, 0),
+		currentSearchIndex: -1,
 		messages:           make([]agent.Message, 0),
 		logMessages:        make([]string, 0),
 		activePanel:        conversationPanel,
@@ -376,7 +346,7 @@ func RunEnhanced(ctx context.Context, cfg *config.Config, agents []agent.Agent,
 		chatLogger.Close()
 	}
 
-	return err
+	return orch, err
 }
 
 func (m EnhancedModel) Init() tea.Cmd {
@@ -496,6 +466,22 @@ type logUpdate struct {
 	message string
 }
 
+// clipboardCopied reports the result of copying the conversation
+type clipboardCopied struct {
+	err error
+}
+
+// statusExpired clears the status bar notice if it is still status
+type statusExpired struct {
+	status string
+}
+
+// statusDuration is how long a status bar notice is shown
+const statusDuration = 3 * time.Second
+
+// writeClipboard copies text to the system clipboard (replaced in tests)
+var writeClipboard = clipboard.WriteAll
+
 func (m EnhancedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
@@ -509,10 +495,27 @@ func (m EnhancedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		if m.searchMode {
+			return m.updateSearch(msg)
+		}
+
 		switch msg.String() {
 		case "ctrl+c", "q":
 			return m, tea.Quit
 
+		case "y":
+			// Copy the conversation, unless typing a message
+			if m.activePanel != inputPanel {
+				return m, m.copyTranscript()
+			}
+
+		case "ctrl+f":
+			// Search the conversation
+			if m.ready {
+				m.searchMode = true
+				return m, m.searchInput.Focus()
+			}
+
 		case "tab":
 			// Cycle through panels
 			m.activePanel = (m.activePanel + 1) % 3
@@ -635,7 +638,7 @@ func (m EnhancedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			AgentName: "System",
 			Content:   msg.message,
 			Timestamp: time.Now().Unix(),
-			Role:      "system",
+			Role:      agent.RoleSystem,
 		}
 		m.messages = append(m.messages, initMsg)
 		m.conversation.SetContent(m.renderConversation())
@@ -649,7 +652,7 @@ func (m EnhancedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				AgentName: "System",
 				Content:   fmt.Sprintf("Failed to initialize agents: %v", msg.err),
 				Timestamp: time.Now().Unix(),
-				Role:      "system",
+				Role:      agent.RoleSystem,
 			}
 			m.messages = append(m.messages, errMsg)
 			m.conversation.SetContent(m.renderConversation())
@@ -681,7 +684,7 @@ func (m EnhancedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			AgentName: "System",
 			Content:   fmt.Sprintf("✅ All %d agents initialized successfully", len(m.agents)),
 			Timestamp: time.Now().Unix(),
-			Role:      "system",
+			Role:      agent.RoleSystem,
 		}
 		m.messages = append(m.messages, successMsg)
 		m.conversation.SetContent(m.renderConversation())
@@ -697,6 +700,17 @@ func (m EnhancedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.message.Role == "active" {
 			// This is just an indicator that an agent is actively typing
 			m.activeAgent = msg.message.AgentName
+		} else if msg.message.Role == "partial" {
+			// A streamed response in progress replaces the previous partial
+			if msg.message.Content == "" {
+				m.streaming = nil
+			} else {
+				partial := msg.message
+				m.streaming = &partial
+				m.activeAgent = partial.AgentName
+			}
+			m.conversation.SetContent(m.renderConversation())
+			m.conversation.GotoBottom()
 		} else {
 			// Regular message
 			m.messages = append(m.messages, msg.message)
@@ -707,20 +721,15 @@ func (m EnhancedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 			// Track turn count and cost for agent messages (not system/error messages)
-			if msg.message.Role == "agent" {
+			if msg.message.Role == agent.RoleAgent {
 				m.turnCount++
 				// Clear active agent when message is complete
 				if msg.message.AgentName == m.activeAgent {
 					m.activeAgent = ""
 				}
-				// Accumulate cost and time if metrics are available
-				if msg.message.Metrics != nil {
-					if msg.message.Metrics.Cost > 0 {
-						m.totalCost += msg.message.Metrics.Cost
-					}
-					if msg.message.Metrics.Duration > 0 {
-						m.totalTime += msg.message.Metrics.Duration
-					}
+				// The completed message, with its metrics, replaces the partial
+				if m.streaming != nil && m.streaming.AgentName == msg.message.AgentName {
+					m.streaming = nil
 				}
 			}
 			// If this is the "Starting AgentPipe conversation" message, mark as running
@@ -765,6 +774,22 @@ func (m EnhancedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Continue polling for logs
 		cmds = append(cmds, m.waitForLog())
 
+	case clipboardCopied:
+		if msg.err != nil {
+			m.statusMessage = fmt.Sprintf("⚠️  Could not copy to clipboard: %v", msg.err)
+		} else {
+			m.statusMessage = "📋 Conversation copied to clipboard"
+		}
+		status := m.statusMessage
+		cmds = append(cmds, tea.Tick(statusDuration, func(time.Time) tea.Msg {
+			return statusExpired{status: status}
+		}))
+
+	case statusExpired:
+		if m.statusMessage == msg.status {
+			m.statusMessage = ""
+		}
+
 	case conversationDone:
 		m.running = false
 
@@ -957,19 +982,19 @@ func (m *EnhancedModel) renderAgentList() string {
 
 		// Type style in gray
 		typeStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("244"))
+			Foreground(palette.Muted)
 
 		// Selection indicator
 		indicator := ""
 		if m.activePanel == agentsPanel && i == m.selectedAgent {
 			indicator = "▶ "
-			nameStyle = nameStyle.Background(lipgloss.Color("235"))
+			nameStyle = nameStyle.Background(palette.Surface)
 		}
 
 		// Active indicator (green dot when agent is responding, grey when inactive)
-		activeColor := lipgloss.Color("240") // Grey color for inactive
+		activeColor := palette.Border // Grey color for inactive
 		if m.activeAgent == a.GetName() {
-			activeColor = lipgloss.Color("82") // Green color for active
+			activeColor = palette.Success // Green color for active
 		}
 		statusDot := lipgloss.NewStyle().Foreground(activeColor).Render("●")
 
@@ -978,8 +1003,8 @@ func (m *EnhancedModel) renderAgentList() string {
 		agentType := typeStyle.Render(a.GetType())
 
 		// Calculate spacing
-		nameLen := len(a.GetName()) + len(indicator) + 2 // +2 for status dot and space
-		typeLen := len(a.GetType())
+		nameLen := displayWidth(a.GetName()) + displayWidth(indicator) + 2 // +2 for status dot and space
+		typeLen := displayWidth(a.GetType())
 		spaces := availableWidth - nameLen - typeLen
 		if spaces < 1 {
 			spaces = 1
@@ -1009,8 +1034,8 @@ func (m *EnhancedModel) renderConfig() string {
 	if m.configPath != "" {
 		// Truncate long paths
 		path := m.configPath
-		if len(path) > 28 {
-			path = "..." + path[len(path)-25:]
+		if displayWidth(path) > 28 {
+			path = "..." + truncateLeft(path, 25)
 		}
 		b.WriteString(fmt.Sprintf("File: %s\n\n", path))
 	}
@@ -1027,7 +1052,7 @@ func (m *EnhancedModel) renderConfig() string {
 	}
 
 	for _, item := range items {
-		spaces := availableWidth - len(item.label) - len(item.value)
+		spaces := availableWidth - displayWidth(item.label) - displayWidth(item.value)
 		if spaces < 1 {
 			spaces = 1
 		}
@@ -1052,7 +1077,7 @@ func (m *EnhancedModel) renderLogPanel() string {
 	// Add title
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("244"))
+		Foreground(palette.Muted)
 	b.WriteString(titleStyle.Render("📋 System Logs"))
 	b.WriteString("\n")
 
@@ -1060,7 +1085,7 @@ func (m *EnhancedModel) renderLogPanel() string {
 	// The log panel will auto-scroll to the bottom
 	for _, logMsg := range m.logMessages {
 		// Use a dim style for log messages
-		logStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+		logStyle := lipgloss.NewStyle().Foreground(palette.Muted)
 		b.WriteString(logStyle.Render(logMsg))
 		b.WriteString("\n")
 	}
@@ -1087,15 +1112,19 @@ func (m *EnhancedModel) renderStats() string {
 		turnsDisplay = fmt.Sprintf("%d/∞", m.turnCount)
 	}
 
+	// Cost and time come from the agent responses received so far
+	stats := orchestrator.CalculateStats(m.messages)
+
 	// Format time display
 	timeDisplay := ""
-	if m.totalTime < time.Second {
-		timeDisplay = fmt.Sprintf("%dms", m.totalTime.Milliseconds())
-	} else if m.totalTime < time.Minute {
-		timeDisplay = fmt.Sprintf("%.1fs", m.totalTime.Seconds())
+	totalTime := stats.TotalDuration
+	if totalTime < time.Second {
+		timeDisplay = fmt.Sprintf("%dms", totalTime.Milliseconds())
+	} else if totalTime < time.Minute {
+		timeDisplay = fmt.Sprintf("%.1fs", totalTime.Seconds())
 	} else {
-		minutes := int(m.totalTime.Minutes())
-		seconds := int(m.totalTime.Seconds()) % 60
+		minutes := int(totalTime.Minutes())
+		seconds := int(totalTime.Seconds()) % 60
 		timeDisplay = fmt.Sprintf("%dm%ds", minutes, seconds)
 	}
 
@@ -1114,12 +1143,12 @@ func (m *EnhancedModel) renderStats() string {
 		{"Agents:", fmt.Sprintf("%d/%d", connectedAgents, configuredAgents)},
 		{"Turns:", turnsDisplay},
 		{"Total Time:", timeDisplay},
-		{"Total Cost:", fmt.Sprintf("$%.4f", m.totalCost)},
+		{"Total Cost:", fmt.Sprintf("$%.4f", stats.TotalCost)},
 		{"Status:", status},
 	}
 
 	for _, item := range items {
-		spaces := availableWidth - len(item.label) - len(item.value)
+		spaces := availableWidth - displayWidth(item.label) - displayWidth(item.value)
 		if spaces < 1 {
 			spaces = 1
 		}
@@ -1144,28 +1173,26 @@ func (m *EnhancedModel) renderConversation() string {
 
 	lastSpeaker := ""
 
-	for i, msg := range m.messages {
-		// Don't show the initial prompt in the conversation since we have a Topic panel
-		if msg.Role == "system" && m.config.Orchestrator.InitialPrompt != "" &&
-			strings.Contains(msg.Content, m.config.Orchestrator.InitialPrompt) {
-			continue // Skip showing the initial prompt in the conversation
-		}
+	// A response still being streamed is shown after the completed messages
+	messages := m.messages
+	if m.streaming != nil {
+		partial := *m.streaming
+		partial.Role = agent.RoleAgent
+		partial.Content += " ▌"
+		messages = append(messages[:len(messages):len(messages)], partial)
+	}
 
-		// Determine the display name for this message
-		displayName := ""
-		if msg.Role == "system" {
-			if msg.AgentID == "error" {
-				displayName = "System Error"
-			} else if msg.AgentID == "info" {
-				displayName = "System Info"
-			} else {
-				displayName = "System Info" // Changed from "System" to "System Info"
-			}
-		} else if msg.AgentName == "User" {
-			displayName = "User"
-		} else {
-			displayName = msg.AgentName
+	// Byte offset in the output at which each shown message starts
+	offsets := make([]int, len(messages))
+
+	for i, msg := range messages {
+		offsets[i] = -1
+		if m.hiddenInConversation(msg) {
+			continue
 		}
+		offsets[i] = b.Len()
+
+		displayName := conversationName(msg)
 
 		// Only show header if speaker changed
 		if displayName != lastSpeaker {
@@ -1176,31 +1203,37 @@ func (m *EnhancedModel) renderConversation() string {
 			timestamp := time.Unix(msg.Timestamp, 0).Format("15:04:05")
 
 			// Get color for agent
-			color := lipgloss.Color("244")
+			color := palette.Muted
 			if c, ok := m.agentColors[msg.AgentName]; ok {
 				color = c
 			}
 
-			if msg.Role == "system" {
+			if msg.Role == agent.RoleSystem {
 				if msg.AgentID == "error" {
-					errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196")) // Red
+					errorStyle := lipgloss.NewStyle().Foreground(palette.Error)
 					b.WriteString(fmt.Sprintf("[%s] ", timestamp))
 					b.WriteString(errorStyle.Render(displayName))
 				} else if msg.AgentID == "info" {
-					infoStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("33")) // Blue
+					infoStyle := lipgloss.NewStyle().Foreground(palette.Info)
 					b.WriteString(fmt.Sprintf("[%s] ", timestamp))
 					b.WriteString(infoStyle.Render(displayName))
 				} else {
-					systemStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244")) // Grey
+					systemStyle := lipgloss.NewStyle().Foreground(palette.Muted)
 					b.WriteString(fmt.Sprintf("[%s] ", timestamp))
 					b.WriteString(systemStyle.Render(displayName))
 				}
 			} else if msg.AgentName == "User" {
 				userStyle := lipgloss.NewStyle().
-					Foreground(lipgloss.Color("226")).
+					Foreground(palette.Highlight).
 					Bold(true)
 				b.WriteString(fmt.Sprintf("[%s] ", timestamp))
 				b.WriteString(userStyle.Render("👤 " + displayName))
+			} else if msg.Role == agent.RoleTool {
+				b.WriteString(fmt.Sprintf("[%s] ", timestamp))
+				b.WriteString(toolStyle.Render(displayName))
+			} else if msg.Role == agent.RoleModerator {
+				b.WriteString(fmt.Sprintf("[%s] ", timestamp))
+				b.WriteString(moderatorStyle.Bold(true).Render(displayName))
 			} else {
 				// Agent messages
 				style := lipgloss.NewStyle().Foreground(color).Bold(true)
@@ -1209,13 +1242,13 @@ func (m *EnhancedModel) renderConversation() string {
 			}
 
 			// Add metrics if available and enabled (only for agents, not system messages)
-			if msg.Role != "system" && m.config.Logging.ShowMetrics && msg.Metrics != nil {
+			if msg.Role != agent.RoleSystem && m.config.Logging.ShowMetrics && msg.Metrics != nil {
 				seconds := msg.Metrics.Duration.Seconds()
 				metricsStr := fmt.Sprintf(" (%.1fs, %d tokens, $%.4f)",
 					seconds,
 					msg.Metrics.TotalTokens,
 					msg.Metrics.Cost)
-				b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render(metricsStr))
+				b.WriteString(lipgloss.NewStyle().Foreground(palette.Muted).Render(metricsStr))
 			}
 			b.WriteString("\n")
 
@@ -1226,31 +1259,75 @@ func (m *EnhancedModel) renderConversation() string {
 		wrappedContent := wrapText(msg.Content, textWidth)
 
 		// Apply color to content for system messages
-		if msg.Role == "system" {
+		if msg.Role == agent.RoleSystem {
 			if msg.AgentID == "error" {
-				errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
-				b.WriteString(errorStyle.Render(wrappedContent))
+				errorStyle := lipgloss.NewStyle().Foreground(palette.Error)
+				b.WriteString(m.highlightSearch(wrappedContent, errorStyle.Render))
 			} else if msg.AgentID == "info" {
-				infoStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("33"))
-				b.WriteString(infoStyle.Render(wrappedContent))
+				infoStyle := lipgloss.NewStyle().Foreground(palette.Info)
+				b.WriteString(m.highlightSearch(wrappedContent, infoStyle.Render))
 			} else {
-				b.WriteString(wrappedContent)
+				b.WriteString(m.highlightSearch(wrappedContent, plainText))
 			}
+		} else if msg.Role == agent.RoleTool {
+			b.WriteString(m.highlightSearch(wrappedContent, toolStyle.Render))
+		} else if msg.Role == agent.RoleModerator {
+			b.WriteString(m.highlightSearch(wrappedContent, moderatorStyle.Render))
 		} else {
-			b.WriteString(wrappedContent)
+			b.WriteString(m.highlightSearch(wrappedContent, plainText))
 		}
 
 		// Add single newline after content (for same speaker continuation)
 		// The spacing for different speakers is handled by the header
-		if i < len(m.messages)-1 {
+		if i < len(messages)-1 {
 			b.WriteString("\n")
 		}
 	}
 
-	return b.String()
+	// Record the line each message starts on, so search can scroll to it
+	content := b.String()
+	m.messageLines = make(map[int]int, len(messages))
+	line, pos := 0, 0
+	for i, offset := range offsets {
+		if offset < 0 {
+			continue
+		}
+		line += strings.Count(content[pos:offset], "\n")
+		pos = offset
+		m.messageLines[i] = line
+	}
+
+	return content
+}
+
+// conversationName returns the name shown in the header of msg in the
+// conversation panel
+func conversationName(msg agent.Message) string {
+	switch {
+	case msg.Role == agent.RoleSystem && msg.AgentID == "error":
+		return "System Error"
+	case msg.Role == agent.RoleSystem:
+		return "System Info"
+	case msg.AgentName == "User":
+		return "User"
+	case msg.Role == agent.RoleTool:
+		return "🔧 " + msg.AgentName
+	case msg.Role == agent.RoleModerator:
+		return "⚖️ " + msg.AgentName
+	default:
+		return msg.AgentName
+	}
+}
+
+// hiddenInConversation reports whether msg is left out of the conversation
+// panel. The initial prompt is not shown there since it has the Topic panel.
+func (m *EnhancedModel) hiddenInConversation(msg agent.Message) bool {
+	return msg.Role == agent.RoleSystem && m.config.Orchestrator.InitialPrompt != "" &&
+		strings.Contains(msg.Content, m.config.Orchestrator.InitialPrompt)
 }
 
-// wrapText wraps text to fit within the specified width
+// wrapText wraps text to fit within the specified width in terminal columns,
+// breaking at spaces where possible and never inside a character
 func wrapText(text string, width int) string {
 	if width <= 0 {
 		return text
@@ -1260,33 +1337,100 @@ func wrapText(text string, width int) string {
 	lines := strings.Split(text, "\n")
 
 	for _, line := range lines {
-		if len(line) <= width {
+		if displayWidth(line) <= width {
 			result = append(result, line)
 			continue
 		}
 
 		// Wrap long lines
-		for len(line) > width {
-			// Find last space before width
-			cutPoint := width
-			for i := width - 1; i > 0; i-- {
-				if line[i] == ' ' {
+		clusters := splitGraphemes(line)
+		for len(clusters) > 0 {
+			// Take as many clusters as fit in the width, at least one
+			fit, lineWidth := 0, 0
+			for fit < len(clusters) && lineWidth+clusters[fit].width <= width {
+				lineWidth += clusters[fit].width
+				fit++
+			}
+			if fit == len(clusters) {
+				result = append(result, joinGraphemes(clusters))
+				break
+			}
+			if fit == 0 {
+				fit = 1
+			}
+
+			// Find last space at or before the cut
+			cutPoint := fit
+			for i := fit; i > 0; i-- {
+				if clusters[i].text == " " {
 					cutPoint = i
 					break
 				}
 			}
 
-			result = append(result, line[:cutPoint])
-			line = strings.TrimSpace(line[cutPoint:])
-		}
-		if len(line) > 0 {
-			result = append(result, line)
+			result = append(result, joinGraphemes(clusters[:cutPoint]))
+			clusters = trimSpaceGraphemes(clusters[cutPoint:])
 		}
 	}
 
 	return strings.Join(result, "\n")
 }
 
+// displayWidth returns the number of terminal columns s takes, counting wide
+// (e.g. CJK) characters and emoji as two and combining marks as zero.
+func displayWidth(s string) int {
+	return uniseg.StringWidth(s)
+}
+
+// truncateLeft keeps the end of s that fits in width columns.
+func truncateLeft(s string, width int) string {
+	clusters := splitGraphemes(s)
+	start, used := len(clusters), 0
+	for start > 0 && used+clusters[start-1].width <= width {
+		start--
+		used += clusters[start].width
+	}
+	return joinGraphemes(clusters[start:])
+}
+
+// grapheme is one user-perceived character and the terminal columns it takes.
+type grapheme struct {
+	text  string
+	width int
+}
+
+// splitGraphemes splits s into grapheme clusters, so emoji sequences and
+// combining marks are never split and wide (e.g. CJK) characters count as two
+// columns.
+func splitGraphemes(s string) []grapheme {
+	var clusters []grapheme
+	g := uniseg.NewGraphemes(s)
+	for g.Next() {
+		clusters = append(clusters, grapheme{text: g.Str(), width: g.Width()})
+	}
+	return clusters
+}
+
+// joinGraphemes concatenates clusters back into a string.
+func joinGraphemes(clusters []grapheme) string {
+	var b strings.Builder
+	for _, c := range clusters {
+		b.WriteString(c.text)
+	}
+	return b.String()
+}
+
+// trimSpaceGraphemes drops leading and trailing whitespace clusters.
+func trimSpaceGraphemes(clusters []grapheme) []grapheme {
+	for len(clusters) > 0 && strings.TrimSpace(clusters[0].text) == "" {
+		clusters = clusters[1:]
+	}
+	for len(clusters) > 0 && strings.TrimSpace(clusters[len(clusters)-1].text) == "" {
+		clusters = clusters[:len(clusters)-1]
+	}
+	return clusters
+}
+
 func (m *EnhancedModel) renderLogo() string {
 	// Use the colored ASCII logo from branding package
 	logo := branding.ASCIILogo
@@ -1306,11 +1450,31 @@ func (m *EnhancedModel) renderLogo() string {
 }
 
 func (m *EnhancedModel) renderStatusBar() string {
+	if m.searchMode {
+		searchBar := searchStyle.Render("Search:") + " " + m.searchInput.View()
+		if len(m.searchResults) > 0 {
+			searchBar += fmt.Sprintf(" (%d/%d matches, n/N to navigate, Esc to close)", m.currentSearchIndex+1, len(m.searchResults))
+		} else if m.searchPattern != nil {
+			searchBar += " (no matches)"
+		}
+		return statusBarStyle.
+			Width(m.width).
+			Render(searchBar)
+	}
+
+	if m.statusMessage != "" {
+		return statusBarStyle.
+			Width(m.width).
+			Render(m.statusMessage)
+	}
+
 	help := []string{
 		helpKeyStyle.Render("Tab") + helpDescStyle.Render(" Switch panel"),
 		helpKeyStyle.Render("↑↓") + helpDescStyle.Render(" Navigate"),
 		helpKeyStyle.Render("Enter") + helpDescStyle.Render(" Select/Send"),
 		helpKeyStyle.Render("Ctrl+U") + helpDescStyle.Render(" User mode"),
+		helpKeyStyle.Render("Ctrl+F") + helpDescStyle.Render(" Search"),
+		helpKeyStyle.Render("Y") + helpDescStyle.Render(" Copy"),
 		helpKeyStyle.Render("Q") + helpDescStyle.Render(" Quit"),
 	}
 
@@ -1369,7 +1533,7 @@ func (m *EnhancedModel) sendUserMessage() tea.Cmd {
 			AgentName: "User",
 			Content:   text,
 			Timestamp: time.Now().Unix(),
-			Role:      "user",
+			Role:      agent.RoleUser,
 		}
 
 		return messageUpdate{message: msg}
@@ -1445,7 +1609,7 @@ func (w *messageWriter) Write(p []byte) (n int, err error) {
 					agentName = agentInfo
 				}
 
-				if agentName == "System" || agentName == "Error" || agentName == "Info" {
+				if agentName == "System" || agentName == "Error" || agentName == "Info" || agentName == "Moderator" {
 					// Handle system messages immediately
 					var msg agent.Message
 					msg.Timestamp = time.Now().Unix()
@@ -1454,7 +1618,7 @@ func (w *messageWriter) Write(p []byte) (n int, err error) {
 						msg.AgentID = "system"
 						msg.AgentName = "System"
 						msg.Content = messageContent
-						msg.Role = "system"
+						msg.Role = agent.RoleSystem
 					} else if agentName == "Error" {
 						msg.AgentID = "error"
 						msg.AgentName = "Error"
@@ -1473,12 +1637,17 @@ func (w *messageWriter) Write(p []byte) (n int, err error) {
 						} else {
 							msg.Content = "❌ Error: " + messageContent
 						}
-						msg.Role = "system"
+						msg.Role = agent.RoleSystem
 					} else if agentName == "Info" {
 						msg.AgentID = "info"
 						msg.AgentName = "Info"
 						msg.Content = "ℹ️ " + messageContent
-						msg.Role = "system"
+						msg.Role = agent.RoleSystem
+					} else if agentName == "Moderator" {
+						msg.AgentID = "moderator"
+						msg.AgentName = "Moderator"
+						msg.Content = messageContent
+						msg.Role = agent.RoleModerator
 					}
 
 					if msg.Content != "" {
@@ -1545,7 +1714,7 @@ func (w *messageWriter) flushCurrentMessage() {
 			AgentName: w.currentAgent,
 			Content:   strings.TrimSpace(w.currentContent.String()),
 			Timestamp: time.Now().Unix(),
-			Role:      "agent",
+			Role:      agent.RoleAgent,
 			Metrics:   w.currentMetrics,
 		}
 
@@ -1570,7 +1739,7 @@ func (m *EnhancedModel) startConversation() tea.Cmd {
 			AgentName: "System",
 			Content:   fmt.Sprintf("🚀 Starting AgentPipe conversation in %s mode...", m.config.Orchestrator.Mode),
 			Timestamp: time.Now().Unix(),
-			Role:      "system",
+			Role:      agent.RoleSystem,
 		}
 
 		// Add agents to orchestrator and announce them
@@ -1593,7 +1762,7 @@ func (m *EnhancedModel) startConversation() tea.Cmd {
 				AgentName: "System",
 				Content:   "✅ Conversation ended. Press 'q' to quit or Ctrl+C to exit.",
 				Timestamp: time.Now().Unix(),
-				Role:      "system",
+				Role:      agent.RoleSystem,
 			}
 
 			if convErr != nil {
@@ -1615,3 +1784,157 @@ func (m *EnhancedModel) startConversation() tea.Cmd {
 		return messageUpdate{message: startMsg}
 	}
 }
+
+// transcriptText returns the conversation shown in the conversation panel as
+// plain text, without styling or line wrapping
+func (m *EnhancedModel) transcriptText() string {
+	var b strings.Builder
+	lastSpeaker := ""
+	for _, msg := range m.messages {
+		if m.hiddenInConversation(msg) {
+			continue
+		}
+
+		// Like the panel, only show a header when the speaker changes
+		if name := conversationName(msg); name != lastSpeaker {
+			if b.Len() > 0 {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(&b, "[%s] %s\n", time.Unix(msg.Timestamp, 0).Format("15:04:05"), name)
+			lastSpeaker = name
+		}
+		b.WriteString(msg.Content)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// copyTranscript copies the conversation to the system clipboard. Where there
+// is no clipboard (e.g. over SSH without xclip), the error is shown in the
+// status bar.
+func (m *EnhancedModel) copyTranscript() tea.Cmd {
+	text := m.transcriptText()
+	return func() tea.Msg {
+		return clipboardCopied{err: writeClipboard(text)}
+	}
+}
+
+// plainText renders text unstyled
+func plainText(text ...string) string {
+	return strings.Join(text, " ")
+}
+
+// highlightSearch renders content with render, highlighting the search
+// matches. The text around the matches is rendered separately, line by line,
+// since a match rendered inside styled text would end its style early.
+func (m *EnhancedModel) highlightSearch(content string, render func(...string) string) string {
+	if m.searchPattern == nil {
+		return render(content)
+	}
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		var b strings.Builder
+		last := 0
+		for _, match := range m.searchPattern.FindAllStringIndex(line, -1) {
+			if match[0] > last {
+				b.WriteString(render(line[last:match[0]]))
+			}
+			b.WriteString(searchMatchStyle.Render(line[match[0]:match[1]]))
+			last = match[1]
+		}
+		if last < len(line) {
+			b.WriteString(render(line[last:]))
+		}
+		lines[i] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// updateSearch handles keys while the search bar is open. Enter searches for
+// the typed text, n/N move between the matches, Esc closes the search and
+// Ctrl+C quits.
+func (m EnhancedModel) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.searchMode = false
+		m.searchInput.Blur()
+		m.searchInput.SetValue("")
+		m.searchPattern = nil
+		m.searchResults = make([]int, 0)
+		m.currentSearchIndex = -1
+		m.conversation.SetContent(m.renderConversation())
+		return m, nil
+	case tea.KeyEnter:
+		m.performSearch()
+		return m, nil
+	}
+
+	// n/N navigate once a search has matches; otherwise they are typed
+	if len(m.searchResults) > 0 {
+		switch msg.String() {
+		case "n":
+			m.currentSearchIndex = (m.currentSearchIndex + 1) % len(m.searchResults)
+			m.scrollToSearchResult()
+			return m, nil
+		case "N":
+			m.currentSearchIndex--
+			if m.currentSearchIndex < 0 {
+				m.currentSearchIndex = len(m.searchResults) - 1
+			}
+			m.scrollToSearchResult()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+	return m, cmd
+}
+
+// performSearch finds the messages whose content or agent name contains the
+// search text, ignoring case, highlights the matches and scrolls to the first
+func (m *EnhancedModel) performSearch() {
+	m.searchResults = make([]int, 0)
+	m.currentSearchIndex = -1
+	m.searchPattern = nil
+
+	term := m.searchInput.Value()
+	if term != "" {
+		m.searchPattern = regexp.MustCompile("(?i)" + regexp.QuoteMeta(term))
+		for i, msg := range m.messages {
+			if m.hiddenInConversation(msg) {
+				continue
+			}
+			if m.searchPattern.MatchString(msg.Content) || m.searchPattern.MatchString(msg.AgentName) {
+				m.searchResults = append(m.searchResults, i)
+			}
+		}
+	}
+
+	m.conversation.SetContent(m.renderConversation())
+	if len(m.searchResults) > 0 {
+		m.currentSearchIndex = 0
+		m.scrollToSearchResult()
+	}
+}
+
+// scrollToSearchResult scrolls the conversation so the current search result
+// is in the middle of the panel
+func (m *EnhancedModel) scrollToSearchResult() {
+	if m.currentSearchIndex < 0 || m.currentSearchIndex >= len(m.searchResults) {
+		return
+	}
+
+	line, ok := m.messageLines[m.searchResults[m.currentSearchIndex]]
+	if !ok {
+		return
+	}
+	target := line - m.conversation.Height/2
+	if target < 0 {
+		target = 0
+	}
+	m.conversation.SetYOffset(target)
+}
@@ -2,18 +2,25 @@ package tui
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/rivo/uniseg"
 
 	"github.com/kevinelliott/agentpipe/pkg/agent"
 	"github.com/kevinelliott/agentpipe/pkg/config"
+	"github.com/kevinelliott/agentpipe/pkg/orchestrator"
+	"github.com/kevinelliott/agentpipe/pkg/theme"
 )
 
 // MockAgent for testing
@@ -238,8 +245,6 @@ func TestEnhancedModel_Update_MessageUpdate(t *testing.T) {
 		ready:       false,
 		agentColors: make(map[string]lipgloss.Color),
 		turnCount:   0,
-		totalCost:   0,
-		totalTime:   0,
 		agentList:   list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0),
 		userInput:   textarea.New(),
 	}
@@ -309,8 +314,8 @@ func TestEnhancedModel_Update_MessageUpdate(t *testing.T) {
 			if m.turnCount != tt.wantTurns {
 				t.Errorf("Expected %d turns, got %d", tt.wantTurns, m.turnCount)
 			}
-			if m.totalCost != tt.wantCost {
-				t.Errorf("Expected cost %.4f, got %.4f", tt.wantCost, m.totalCost)
+			if cost := orchestrator.CalculateStats(m.messages).TotalCost; cost != tt.wantCost {
+				t.Errorf("Expected cost %.4f, got %.4f", tt.wantCost, cost)
 			}
 		})
 	}
@@ -320,6 +325,55 @@ func TestEnhancedModel_Update_MessageUpdate(t *testing.T) {
 	}
 }
 
+// TestEnhancedModel_Update_StreamingMessage tests partial responses while streaming
+func TestEnhancedModel_Update_StreamingMessage(t *testing.T) {
+	cfg := &config.Config{
+		Orchestrator: config.OrchestratorConfig{Mode: "round-robin", Stream: true},
+		Logging: config.LoggingConfig{
+			ShowMetrics: true,
+		},
+	}
+
+	m := createTestEnhancedModel(cfg, agentsPanel, false)
+	updatedModel, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	m = updatedModel.(EnhancedModel)
+
+	partial := agent.Message{AgentID: "agent-1", AgentName: "TestAgent", Content: "Half of the ans", Role: "partial"}
+	updatedModel, _ = m.Update(messageUpdate{message: partial})
+	m = updatedModel.(EnhancedModel)
+
+	if m.streaming == nil {
+		t.Fatal("Expected a partial response to be shown")
+	}
+	if len(m.messages) != 0 {
+		t.Errorf("Expected partial responses not to be added to the conversation, got %d messages", len(m.messages))
+	}
+	if !strings.Contains(m.renderConversation(), "Half of the ans") {
+		t.Error("Expected the conversation to show the partial response")
+	}
+
+	final := agent.Message{
+		AgentID:   "agent-1",
+		AgentName: "TestAgent",
+		Content:   "Half of the answer, and the rest",
+		Timestamp: time.Now().Unix(),
+		Role:      "agent",
+		Metrics:   &agent.ResponseMetrics{Duration: time.Second, TotalTokens: 12, Cost: 0.002},
+	}
+	updatedModel, _ = m.Update(messageUpdate{message: final})
+	m = updatedModel.(EnhancedModel)
+
+	if m.streaming != nil {
+		t.Error("Expected the final response to replace the partial one")
+	}
+	if cost := orchestrator.CalculateStats(m.messages).TotalCost; m.turnCount != 1 || cost != 0.002 {
+		t.Errorf("Expected 1 turn costing 0.002, got %d turns costing %.4f", m.turnCount, cost)
+	}
+	if strings.Count(m.renderConversation(), "Half of the ans") != 1 {
+		t.Error("Expected the response to be shown once")
+	}
+}
+
 // TestEnhancedModel_Update_AgentInit tests agent initialization
 func TestEnhancedModel_Update_AgentInit(t *testing.T) {
 	cfg := &config.Config{
@@ -485,6 +539,70 @@ func TestWrapText(t *testing.T) {
 	}
 }
 
+func TestWrapTextMultibyte(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  []string
+	}{
+		{
+			name:  "CJK without spaces wraps by column",
+			text:  "日本語のテキストです",
+			width: 8,
+			want:  []string{"日本語の", "テキスト", "です"},
+		},
+		{
+			name:  "Odd width leaves a column free rather than splitting",
+			text:  "你好世界",
+			width: 5,
+			want:  []string{"你好", "世界"},
+		},
+		{
+			name:  "Emoji count as two columns",
+			text:  "🎉🎉🎉🎉",
+			width: 4,
+			want:  []string{"🎉🎉", "🎉🎉"},
+		},
+		{
+			name:  "ZWJ emoji sequence is kept whole",
+			text:  "ab👨‍👩‍👧cd",
+			width: 3,
+			want:  []string{"ab", "👨‍👩‍👧c", "d"},
+		},
+		{
+			name:  "Accented words wrap at spaces",
+			text:  "café résumé naïve",
+			width: 12,
+			want:  []string{"café résumé", "naïve"},
+		},
+		{
+			name:  "Mixed CJK and English words",
+			text:  "Hello 世界 again",
+			width: 10,
+			want:  []string{"Hello 世界", "again"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := wrapText(tt.text, tt.width)
+			if !utf8.ValidString(result) {
+				t.Fatalf("wrapText produced invalid UTF-8: %q", result)
+			}
+			lines := strings.Split(result, "\n")
+			if strings.Join(lines, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("wrapText(%q, %d) = %q, want %q", tt.text, tt.width, lines, tt.want)
+			}
+			for _, line := range lines {
+				if w := uniseg.StringWidth(line); w > tt.width {
+					t.Errorf("line %q is %d columns wide, more than %d", line, w, tt.width)
+				}
+			}
+		})
+	}
+}
+
 // TestEnhancedModel_RenderMethods tests various render methods
 func TestEnhancedModel_RenderAgentList(t *testing.T) {
 	cfg := &config.Config{
@@ -521,6 +639,76 @@ func TestEnhancedModel_RenderAgentList(t *testing.T) {
 	}
 }
 
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"", 0},
+		{"Claude", 6},
+		{"Zoë", 3},
+		{"Zoe\u0308", 3}, // e + combining diaeresis
+		{"Renée Müller", 12},
+		{"太郎", 4},
+		{"クロード", 8},
+		{"AI 助手", 7},
+		{"🤖 Bot", 6},
+		{"▶ ", 2},
+	}
+
+	for _, tt := range tests {
+		if got := displayWidth(tt.text); got != tt.want {
+			t.Errorf("displayWidth(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestTruncateLeft(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		want  string
+	}{
+		{"/home/user/config.yaml", 11, "config.yaml"},
+		{"/設定/会話.yaml", 9, "会話.yaml"},
+		{"/設定/会話.yaml", 10, "/会話.yaml"},
+		{"/設定/会話.yaml", 8, "話.yaml"}, // a wide character that doesn't fit is dropped whole
+		{"short", 10, "short"},
+	}
+
+	for _, tt := range tests {
+		if got := truncateLeft(tt.text, tt.width); got != tt.want {
+			t.Errorf("truncateLeft(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestEnhancedModel_RenderAgentListAlignsWideNames(t *testing.T) {
+	agents := []agent.Agent{
+		&MockAgent{id: "1", name: "Alice", agentType: "claude", available: true},
+		&MockAgent{id: "2", name: "Zoë", agentType: "gemini", available: true},
+		&MockAgent{id: "3", name: "太郎", agentType: "qwen", available: true},
+		&MockAgent{id: "4", name: "🤖 Bot", agentType: "ollama", available: true},
+	}
+
+	m := EnhancedModel{
+		ctx:           context.Background(),
+		config:        &config.Config{},
+		agents:        agents,
+		agentColors:   make(map[string]lipgloss.Color),
+		activePanel:   agentsPanel,
+		selectedAgent: 2,
+	}
+
+	lines := strings.Split(strings.TrimRight(m.renderAgentList(), "\n"), "\n")
+	lines = lines[len(lines)-len(agents):] // skip the title
+	for _, line := range lines {
+		if w := lipgloss.Width(line); w != 30 {
+			t.Errorf("expected every row to be 30 columns wide, got %d for %q", w, line)
+		}
+	}
+}
+
 // TestEnhancedModel_RenderConfig tests config rendering
 func TestEnhancedModel_RenderConfig(t *testing.T) {
 	cfg := &config.Config{
@@ -573,12 +761,16 @@ func TestEnhancedModel_RenderStats(t *testing.T) {
 	}
 
 	m := EnhancedModel{
-		config:      cfg,
-		agents:      []agent.Agent{&MockAgent{}, &MockAgent{}},
-		messages:    make([]agent.Message, 5),
+		config: cfg,
+		agents: []agent.Agent{&MockAgent{}, &MockAgent{}},
+		messages: []agent.Message{
+			{Role: "system", Content: "Topic"},
+			{AgentID: "a", Role: "agent", Metrics: &agent.ResponseMetrics{Cost: 0.0015, Duration: 500 * time.Millisecond}},
+			{AgentID: "b", Role: "agent", Metrics: &agent.ResponseMetrics{Cost: 0.0030, Duration: time.Second}},
+			{AgentID: "a", Role: "agent"},
+			{Role: "user", Content: "Interjection"},
+		},
 		turnCount:   3,
-		totalCost:   0.0045,
-		totalTime:   1500 * time.Millisecond,
 		running:     true,
 		agentColors: make(map[string]lipgloss.Color),
 	}
@@ -662,6 +854,301 @@ func TestEnhancedModel_RenderConversation(t *testing.T) {
 	if !strings.Contains(rendered, "0.0010") {
 		t.Error("Expected conversation to show cost metrics")
 	}
+
+	m.messages = append(m.messages,
+		agent.Message{AgentID: "lint", AgentName: "Linter", Content: "2 warnings", Timestamp: now + 2, Role: agent.RoleTool},
+		agent.Message{AgentID: "moderator", AgentName: "Moderator", Content: "Chair calls on Bob", Timestamp: now + 3, Role: agent.RoleModerator},
+	)
+	rendered = m.renderConversation()
+	for _, want := range []string{"🔧 Linter", "2 warnings", "⚖️ Moderator", "Chair calls on Bob"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("Expected conversation to contain %q", want)
+		}
+	}
+}
+
+// newSearchTestModel returns a sized enhanced model showing messages
+func newSearchTestModel(t *testing.T, messages []agent.Message) EnhancedModel {
+	t.Helper()
+
+	cfg := &config.Config{Orchestrator: config.OrchestratorConfig{Mode: "round-robin"}}
+	m := createTestEnhancedModel(cfg, conversationPanel, false)
+	m.ready = false
+	m.searchInput = textinput.New()
+	m.currentSearchIndex = -1
+	m.messages = messages
+
+	updatedModel, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	return updatedModel.(EnhancedModel)
+}
+
+// TestEnhancedModel_PerformSearch tests finding messages by content and agent name
+func TestEnhancedModel_PerformSearch(t *testing.T) {
+	now := time.Now().Unix()
+	m := newSearchTestModel(t, []agent.Message{
+		{AgentName: "Agent1", Content: "Hello world", Role: "agent", Timestamp: now},
+		{AgentName: "Agent2", Content: "Testing search", Role: "agent", Timestamp: now},
+		{AgentName: "Agent3", Content: "Another message", Role: "agent", Timestamp: now},
+		{AgentName: "Agent1", Content: "HELLO again", Role: "agent", Timestamp: now},
+	})
+
+	tests := []struct {
+		term string
+		want []int
+	}{
+		{"hello", []int{0, 3}},
+		{"search", []int{1}},
+		{"agent3", []int{2}},
+		{"a.", []int{}}, // regexp characters are matched literally
+		{"xyz123", []int{}},
+		{"", []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.term, func(t *testing.T) {
+			m.searchInput.SetValue(tt.term)
+			m.performSearch()
+
+			if len(m.searchResults) != len(tt.want) {
+				t.Fatalf("Expected results %v, got %v", tt.want, m.searchResults)
+			}
+			for i := range tt.want {
+				if m.searchResults[i] != tt.want[i] {
+					t.Errorf("Expected results %v, got %v", tt.want, m.searchResults)
+				}
+			}
+
+			wantIndex := -1
+			if len(tt.want) > 0 {
+				wantIndex = 0
+			}
+			if m.currentSearchIndex != wantIndex {
+				t.Errorf("Expected currentSearchIndex %d, got %d", wantIndex, m.currentSearchIndex)
+			}
+		})
+	}
+}
+
+// TestEnhancedModel_SearchNavigation tests opening the search, moving between
+// matches with n/N and closing it with Esc
+func TestEnhancedModel_SearchNavigation(t *testing.T) {
+	now := time.Now().Unix()
+	messages := make([]agent.Message, 0, 60)
+	for i := 0; i < 60; i++ {
+		content := fmt.Sprintf("filler message %d", i)
+		if i == 5 || i == 30 || i == 55 {
+			content = fmt.Sprintf("needle message %d", i)
+		}
+		messages = append(messages, agent.Message{AgentName: fmt.Sprintf("Agent%d", i%2), Content: content, Role: "agent", Timestamp: now})
+	}
+	m := newSearchTestModel(t, messages)
+
+	update := func(msg tea.KeyMsg) {
+		t.Helper()
+		updatedModel, _ := m.Update(msg)
+		m = updatedModel.(EnhancedModel)
+	}
+
+	update(tea.KeyMsg{Type: tea.KeyCtrlF})
+	if !m.searchMode {
+		t.Fatal("Expected Ctrl+F to open the search")
+	}
+
+	// n is typed into the search until there are matches
+	for _, r := range "needle" {
+		update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if m.searchInput.Value() != "needle" {
+		t.Fatalf("Expected search text %q, got %q", "needle", m.searchInput.Value())
+	}
+	update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if len(m.searchResults) != 3 {
+		t.Fatalf("Expected 3 search results, got %v", m.searchResults)
+	}
+
+	next := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}}
+	prev := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}}
+	steps := []struct {
+		key  tea.KeyMsg
+		want int
+	}{
+		{next, 1},
+		{next, 2},
+		{next, 0}, // wraps around
+		{prev, 2}, // wraps backwards
+		{prev, 1},
+	}
+
+	offsets := make(map[int]int)
+	for _, step := range steps {
+		update(step.key)
+		if m.currentSearchIndex != step.want {
+			t.Fatalf("Expected index %d after %q, got %d", step.want, step.key.String(), m.currentSearchIndex)
+		}
+		offsets[m.currentSearchIndex] = m.conversation.YOffset
+	}
+	if !(offsets[0] < offsets[1] && offsets[1] < offsets[2]) {
+		t.Errorf("Expected later matches to scroll further down, got offsets %v", offsets)
+	}
+	if m.searchInput.Value() != "needle" {
+		t.Errorf("Expected n/N not to change the search text, got %q", m.searchInput.Value())
+	}
+	if !strings.Contains(m.renderStatusBar(), "2/3 matches") {
+		t.Errorf("Expected status bar to show the match position, got %q", m.renderStatusBar())
+	}
+
+	update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.searchMode || m.searchPattern != nil || len(m.searchResults) != 0 || m.currentSearchIndex != -1 {
+		t.Error("Expected Esc to close and clear the search")
+	}
+	if m.searchInput.Value() != "" {
+		t.Errorf("Expected search text to be cleared, got %q", m.searchInput.Value())
+	}
+}
+
+// TestEnhancedModel_HighlightSearch tests that the text around search matches
+// keeps the message style
+func TestEnhancedModel_HighlightSearch(t *testing.T) {
+	m := newSearchTestModel(t, nil)
+	m.searchInput.SetValue("full")
+	m.performSearch()
+
+	render := func(text ...string) string { return "<" + strings.Join(text, " ") + ">" }
+	got := m.highlightSearch("disk full, retrying\nstill full", render)
+
+	match := searchMatchStyle.Render("full")
+	want := "<disk >" + match + "<, retrying>\n<still >" + match
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+// TestEnhancedModel_SearchCtrlC tests that Ctrl+C quits while the search bar is open
+func TestEnhancedModel_SearchCtrlC(t *testing.T) {
+	m := newSearchTestModel(t, nil)
+	updatedModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlF})
+
+	_, cmd := updatedModel.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	if cmd == nil {
+		t.Fatal("Expected Ctrl+C to quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("Expected Ctrl+C to quit")
+	}
+}
+
+// TestEnhancedModel_TranscriptText tests the plain text copied to the clipboard
+func TestEnhancedModel_TranscriptText(t *testing.T) {
+	cfg := &config.Config{
+		Orchestrator: config.OrchestratorConfig{InitialPrompt: "Discuss testing"},
+	}
+	m := createTestEnhancedModel(cfg, conversationPanel, false)
+
+	now := time.Now().Unix()
+	m.messages = []agent.Message{
+		{AgentID: "host", AgentName: "System", Content: "Discuss testing", Timestamp: now, Role: "system"},
+		{AgentID: "agent-1", AgentName: "Alice", Content: "First point, which is long enough that the panel would wrap it", Timestamp: now, Role: "agent"},
+		{AgentID: "agent-1", AgentName: "Alice", Content: "Second point", Timestamp: now + 1, Role: "agent"},
+		{AgentID: "lint", AgentName: "Linter", Content: "2 warnings", Timestamp: now + 2, Role: agent.RoleTool},
+		{AgentID: "error", AgentName: "System", Content: "Agent Bob failed", Timestamp: now + 3, Role: "system"},
+	}
+
+	stamp := func(offset int64) string { return time.Unix(now+offset, 0).Format("15:04:05") }
+	want := "[" + stamp(0) + "] Alice\n" +
+		"First point, which is long enough that the panel would wrap it\n" +
+		"Second point\n" +
+		"\n[" + stamp(2) + "] 🔧 Linter\n" +
+		"2 warnings\n" +
+		"\n[" + stamp(3) + "] System Error\n" +
+		"Agent Bob failed\n"
+
+	if got := m.transcriptText(); got != want {
+		t.Errorf("transcriptText() =\n%s\nwant\n%s", got, want)
+	}
+	if strings.Contains(m.transcriptText(), "\x1b[") {
+		t.Error("Expected transcript to be plain text without styling")
+	}
+}
+
+// TestEnhancedModel_CopyTranscript tests the status shown after copying,
+// including when there is no clipboard
+func TestEnhancedModel_CopyTranscript(t *testing.T) {
+	orig := writeClipboard
+	t.Cleanup(func() { writeClipboard = orig })
+
+	cfg := &config.Config{Orchestrator: config.OrchestratorConfig{Mode: "round-robin"}}
+	m := createTestEnhancedModel(cfg, conversationPanel, false)
+	m.messages = []agent.Message{
+		{AgentName: "Alice", Content: "Hello", Timestamp: time.Now().Unix(), Role: "agent"},
+	}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus string
+	}{
+		{"copied", nil, "copied to clipboard"},
+		{"no clipboard", errors.New("no clipboard utilities available"), "Could not copy to clipboard: no clipboard utilities available"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var copied string
+			writeClipboard = func(text string) error {
+				copied = text
+				return tt.err
+			}
+
+			_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+			if cmd == nil {
+				t.Fatal("Expected y to return a copy command")
+			}
+			updatedModel, _ := m.Update(cmd())
+			got := updatedModel.(EnhancedModel)
+
+			if !strings.Contains(copied, "Hello") {
+				t.Errorf("Expected the transcript to be copied, got %q", copied)
+			}
+			if !strings.Contains(got.statusMessage, tt.wantStatus) {
+				t.Errorf("Expected status containing %q, got %q", tt.wantStatus, got.statusMessage)
+			}
+
+			updatedModel, _ = got.Update(statusExpired{status: got.statusMessage})
+			if status := updatedModel.(EnhancedModel).statusMessage; status != "" {
+				t.Errorf("Expected status to clear, got %q", status)
+			}
+		})
+	}
+}
+
+// TestSetTheme tests that the TUI styles follow the theme
+func TestSetTheme(t *testing.T) {
+	SetTheme(theme.Light)
+	t.Cleanup(func() { SetTheme(theme.Dark) })
+
+	if got := activePanelStyle.GetBorderTopForeground(); got != theme.Light.Primary {
+		t.Errorf("Expected active border %v, got %v", theme.Light.Primary, got)
+	}
+	if got := searchMatchStyle.GetBackground(); got != theme.Light.Highlight {
+		t.Errorf("Expected search match background %v, got %v", theme.Light.Highlight, got)
+	}
+	if agentColors[0] != theme.Light.AgentColors[0] {
+		t.Errorf("Expected first agent color %v, got %v", theme.Light.AgentColors[0], agentColors[0])
+	}
+}
+
+// TestDarkThemeKeepsClassicColors tests that the dark theme draws with the
+// colors the TUI used before themes existed
+func TestDarkThemeKeepsClassicColors(t *testing.T) {
+	SetTheme(theme.Dark)
+
+	if got := helpDescStyle.GetForeground(); got != lipgloss.Color("248") {
+		t.Errorf("Expected help description color 248, got %v", got)
+	}
+	if got := agentStyle.GetForeground(); got != lipgloss.Color("86") {
+		t.Errorf("Expected agent name color 86, got %v", got)
+	}
 }
 
 // TestMessageWriter tests the messageWriter implementation
@@ -733,6 +1220,19 @@ func TestMessageWriter_Write(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:     "Moderator message",
+			input:    "[Moderator] Chair calls on Bob\n",
+			wantMsgs: 1,
+			checkFunc: func(t *testing.T, msg agent.Message) {
+				if msg.Role != agent.RoleModerator {
+					t.Errorf("Expected moderator role, got %s", msg.Role)
+				}
+				if msg.Content != "Chair calls on Bob" {
+					t.Errorf("Expected the moderator's decision, got %s", msg.Content)
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kevinelliott/agentpipe/pkg/theme"
+)
+
+// palette is the color theme of the TUI (see SetTheme)
+var palette theme.Theme
+
+// agentColors are given to agents in turn
+var agentColors []lipgloss.Color
+
+// Styles of the simple TUI
+var (
+	titleStyle       lipgloss.Style
+	agentStyle       lipgloss.Style
+	systemStyle      lipgloss.Style
+	toolStyle        lipgloss.Style
+	moderatorStyle   lipgloss.Style
+	messageStyle     lipgloss.Style
+	statusStyle      lipgloss.Style
+	helpStyle        lipgloss.Style
+	searchStyle      lipgloss.Style
+	searchMatchStyle lipgloss.Style
+)
+
+// Styles of the enhanced TUI
+var (
+	activePanelStyle        lipgloss.Style
+	inactivePanelStyle      lipgloss.Style
+	activeInputPanelStyle   lipgloss.Style
+	inactiveInputPanelStyle lipgloss.Style
+	logPanelStyle           lipgloss.Style
+	enhancedTitleStyle      lipgloss.Style
+	modalStyle              lipgloss.Style
+	statusBarStyle          lipgloss.Style
+	helpKeyStyle            lipgloss.Style
+	helpDescStyle           lipgloss.Style
+	logoPanelStyle          lipgloss.Style
+	logoInfoStyle           lipgloss.Style
+)
+
+func init() {
+	SetTheme(theme.Dark)
+}
+
+// SetTheme sets the colors of the TUI. Call it before starting the TUI.
+func SetTheme(t theme.Theme) {
+	palette = t
+	agentColors = t.AgentColors
+
+	titleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(t.Secondary).
+		Background(t.Primary).
+		Padding(0, 1)
+
+	agentStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(t.AgentName)
+
+	systemStyle = lipgloss.NewStyle().
+		Italic(true).
+		Foreground(t.Muted)
+
+	toolStyle = lipgloss.NewStyle().
+		Foreground(t.Text)
+
+	moderatorStyle = lipgloss.NewStyle().
+		Italic(true).
+		Foreground(t.Warning)
+
+	messageStyle = lipgloss.NewStyle().
+		PaddingLeft(2)
+
+	statusStyle = lipgloss.NewStyle().
+		Foreground(t.Subtle)
+
+	helpStyle = lipgloss.NewStyle().
+		Foreground(t.Subtle)
+
+	searchStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(t.Highlight).
+		Background(t.Surface).
+		Padding(0, 1)
+
+	searchMatchStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(t.OnColor).
+		Background(t.Highlight)
+
+	// Panel styles
+	activePanelStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(0, 1)
+
+	inactivePanelStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border).
+		Padding(0, 1)
+
+	// Input panel styles (no padding)
+	activeInputPanelStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary)
+
+	inactiveInputPanelStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border)
+
+	// Log panel styles
+	logPanelStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border).
+		Padding(0, 1)
+
+	// Title styles
+	enhancedTitleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(t.Secondary)
+
+	// Modal styles
+	modalStyle = lipgloss.NewStyle().
+		Border(lipgloss.DoubleBorder()).
+		BorderForeground(t.Secondary).
+		Padding(1, 2).
+		Background(t.Surface)
+
+	// Status bar styles
+	statusBarStyle = lipgloss.NewStyle().
+		Padding(0, 1)
+
+	// Help styles
+	helpKeyStyle = lipgloss.NewStyle().
+		Foreground(t.Subtle)
+
+	helpDescStyle = lipgloss.NewStyle().
+		Foreground(t.Text)
+
+	// Logo panel styles
+	logoPanelStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border).
+		Align(lipgloss.Center)
+
+	logoInfoStyle = lipgloss.NewStyle().
+		Foreground(t.Muted).
+		Align(lipgloss.Center)
+}
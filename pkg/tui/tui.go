@@ -17,42 +17,6 @@ import (
 	"github.com/kevinelliott/agentpipe/pkg/orchestrator"
 )
 
-var (
-	titleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("99")).
-			Background(lipgloss.Color("63")).
-			Padding(0, 1)
-
-	agentStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("86"))
-
-	systemStyle = lipgloss.NewStyle().
-			Italic(true).
-			Foreground(lipgloss.Color("244"))
-
-	messageStyle = lipgloss.NewStyle().
-			PaddingLeft(2)
-
-	statusStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241"))
-
-	helpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241"))
-
-	searchStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("226")).
-			Background(lipgloss.Color("235")).
-			Padding(0, 1)
-
-	_ = lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("0")).
-		Background(lipgloss.Color("226"))
-)
-
 type Model struct {
 	ctx                context.Context
 	config             *config.Config
@@ -345,7 +309,7 @@ func (m Model) View() string {
 	// Show status message if present
 	if m.statusMessage != "" {
 		b.WriteString("\n")
-		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("green")).Render(m.statusMessage))
+		b.WriteString(lipgloss.NewStyle().Foreground(palette.Success).Render(m.statusMessage))
 	}
 
 	// Show command bar when in command mode
@@ -369,7 +333,7 @@ func (m Model) View() string {
 
 	if m.err != nil {
 		b.WriteString("\n")
-		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(fmt.Sprintf("Error: %v", m.err)))
+		b.WriteString(lipgloss.NewStyle().Foreground(palette.Error).Render(fmt.Sprintf("Error: %v", m.err)))
 	}
 
 	return b.String()
@@ -380,7 +344,7 @@ func (m Model) renderMessages() string {
 
 	for _, msg := range m.messages {
 		// Apply filter if active
-		if m.filterAgent != "" && msg.AgentName != m.filterAgent && msg.Role != "system" {
+		if m.filterAgent != "" && msg.AgentName != m.filterAgent && msg.Role != agent.RoleSystem {
 			continue
 		}
 
@@ -389,10 +353,17 @@ func (m Model) renderMessages() string {
 		var prefix string
 		var style lipgloss.Style
 
-		if msg.Role == "system" {
+		switch msg.Role {
+		case agent.RoleSystem:
 			prefix = fmt.Sprintf("[%s] System", timestamp)
 			style = systemStyle
-		} else {
+		case agent.RoleTool:
+			prefix = fmt.Sprintf("[%s] 🔧 %s", timestamp, msg.AgentName)
+			style = toolStyle
+		case agent.RoleModerator:
+			prefix = fmt.Sprintf("[%s] ⚖️ %s", timestamp, msg.AgentName)
+			style = moderatorStyle
+		default:
 			prefix = fmt.Sprintf("[%s] %s", timestamp, msg.AgentName)
 			style = agentStyle
 		}
@@ -612,13 +583,9 @@ func (m *Model) scrollToSearchResult() {
 
 func (m Model) startConversation() tea.Cmd {
 	return func() tea.Msg {
-		orchConfig := orchestrator.OrchestratorConfig{
-			Mode:          orchestrator.ConversationMode(m.config.Orchestrator.Mode),
-			TurnTimeout:   m.config.Orchestrator.TurnTimeout,
-			MaxTurns:      m.config.Orchestrator.MaxTurns,
-			ResponseDelay: m.config.Orchestrator.ResponseDelay,
-			InitialPrompt: m.config.Orchestrator.InitialPrompt,
-		}
+		orchConfig := orchestrator.ConfigFrom(m.config)
+		// The TUI has nowhere to show a conversation summary
+		orchConfig.Summary.Enabled = false
 
 		writer := &tuiWriter{
 			messageChan: make(chan agent.Message, 100),
@@ -296,6 +296,32 @@ func TestModel_RenderMessages(t *testing.T) {
 			},
 			want: []string{"TestAgent", "Agent response"},
 		},
+		{
+			name: "Tool message",
+			messages: []agent.Message{
+				{
+					AgentID:   "lint",
+					AgentName: "Linter",
+					Content:   "2 warnings",
+					Timestamp: now,
+					Role:      agent.RoleTool,
+				},
+			},
+			want: []string{"🔧 Linter", "2 warnings"},
+		},
+		{
+			name: "Moderator message",
+			messages: []agent.Message{
+				{
+					AgentID:   "chair",
+					AgentName: "Chair",
+					Content:   "Calls on Bob",
+					Timestamp: now,
+					Role:      agent.RoleModerator,
+				},
+			},
+			want: []string{"⚖️ Chair", "Calls on Bob"},
+		},
 		{
 			name: "Multiple messages",
 			messages: []agent.Message{
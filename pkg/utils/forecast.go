@@ -0,0 +1,124 @@
+package utils
+
+// Default assumptions used by ForecastCost when none are provided.
+const (
+	// DefaultForecastMinMessageTokens is the low-end assumption for an agent response
+	DefaultForecastMinMessageTokens = 150
+	// DefaultForecastMaxMessageTokens is the high-end assumption for an agent response
+	DefaultForecastMaxMessageTokens = 600
+)
+
+// ForecastAgent describes one participant in a forecast.
+type ForecastAgent struct {
+	// Name is the agent's display name
+	Name string `json:"name"`
+	// Model is the model used for pricing and token counting
+	Model string `json:"model"`
+	// PromptTokens is the size of the agent's system prompt in tokens
+	PromptTokens int `json:"prompt_tokens"`
+}
+
+// ForecastAssumptions are the inputs that turn a configuration into a projection.
+type ForecastAssumptions struct {
+	// Responses is the total number of agent responses expected in the conversation
+	Responses int `json:"responses"`
+	// InitialPromptTokens is the size of the conversation's initial prompt in tokens
+	InitialPromptTokens int `json:"initial_prompt_tokens"`
+	// MinMessageTokens is the low-end size of an agent response
+	MinMessageTokens int `json:"min_message_tokens"`
+	// MaxMessageTokens is the high-end size of an agent response
+	MaxMessageTokens int `json:"max_message_tokens"`
+}
+
+// TokenRange is a low/high pair for a projected value.
+type TokenRange struct {
+	Low  int `json:"low"`
+	High int `json:"high"`
+}
+
+// CostRange is a low/high pair for a projected cost in USD.
+type CostRange struct {
+	Low  float64 `json:"low"`
+	High float64 `json:"high"`
+}
+
+// AgentForecast is the projected usage for a single agent.
+type AgentForecast struct {
+	Name         string     `json:"name"`
+	Model        string     `json:"model"`
+	Responses    int        `json:"responses"`
+	InputTokens  TokenRange `json:"input_tokens"`
+	OutputTokens TokenRange `json:"output_tokens"`
+	Cost         CostRange  `json:"cost"`
+}
+
+// CostForecast is the projected usage for a whole conversation.
+type CostForecast struct {
+	Assumptions ForecastAssumptions `json:"assumptions"`
+	Agents      []AgentForecast     `json:"agents"`
+	TotalTokens TokenRange          `json:"total_tokens"`
+	TotalCost   CostRange           `json:"total_cost"`
+}
+
+// ForecastCost projects token usage and cost for a conversation before it runs.
+//
+// Responses are assigned to agents in round-robin order. Every response sees the
+// full history so far, so the input for the k-th response (0-based) is the agent's
+// prompt, the initial prompt, and k previous messages. The low and high bounds use
+// MinMessageTokens and MaxMessageTokens as the size of every message. Costs come
+// from EstimateCost, so pricing overrides and the provider registry both apply.
+// This is a planning aid, not an exact prediction.
+func ForecastCost(agents []ForecastAgent, a ForecastAssumptions) CostForecast {
+	if a.MinMessageTokens <= 0 {
+		a.MinMessageTokens = DefaultForecastMinMessageTokens
+	}
+	if a.MaxMessageTokens <= 0 {
+		a.MaxMessageTokens = DefaultForecastMaxMessageTokens
+	}
+	if a.MaxMessageTokens < a.MinMessageTokens {
+		a.MinMessageTokens, a.MaxMessageTokens = a.MaxMessageTokens, a.MinMessageTokens
+	}
+	if a.Responses < 0 {
+		a.Responses = 0
+	}
+
+	forecast := CostForecast{
+		Assumptions: a,
+		Agents:      make([]AgentForecast, len(agents)),
+	}
+	if len(agents) == 0 {
+		return forecast
+	}
+
+	for i, ag := range agents {
+		forecast.Agents[i] = AgentForecast{Name: ag.Name, Model: ag.Model}
+	}
+
+	for k := 0; k < a.Responses; k++ {
+		i := k % len(agents)
+		base := agents[i].PromptTokens + a.InitialPromptTokens
+
+		af := &forecast.Agents[i]
+		af.Responses++
+		af.InputTokens.Low += base + k*a.MinMessageTokens
+		af.InputTokens.High += base + k*a.MaxMessageTokens
+		af.OutputTokens.Low += a.MinMessageTokens
+		af.OutputTokens.High += a.MaxMessageTokens
+	}
+
+	for i := range forecast.Agents {
+		af := &forecast.Agents[i]
+		if af.Responses > 0 {
+			// Pricing is linear, so one lookup per bound covers all of the agent's responses
+			af.Cost.Low = EstimateCost(af.Model, af.InputTokens.Low, af.OutputTokens.Low)
+			af.Cost.High = EstimateCost(af.Model, af.InputTokens.High, af.OutputTokens.High)
+		}
+
+		forecast.TotalTokens.Low += af.InputTokens.Low + af.OutputTokens.Low
+		forecast.TotalTokens.High += af.InputTokens.High + af.OutputTokens.High
+		forecast.TotalCost.Low += af.Cost.Low
+		forecast.TotalCost.High += af.Cost.High
+	}
+
+	return forecast
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestForecastCost(t *testing.T) {
+	SetPricing(&PricingConfig{Models: map[string]ModelRate{
+		"model-a": {InputPer1K: 1.0, OutputPer1K: 2.0},
+	}})
+	defer SetPricing(nil)
+
+	agents := []ForecastAgent{
+		{Name: "A", Model: "model-a", PromptTokens: 10},
+		{Name: "B", Model: "model-a", PromptTokens: 10},
+	}
+	f := ForecastCost(agents, ForecastAssumptions{
+		Responses:           4,
+		InitialPromptTokens: 20,
+		MinMessageTokens:    100,
+		MaxMessageTokens:    200,
+	})
+
+	// Agent A answers responses 0 and 2, agent B answers 1 and 3.
+	// Input for response k = 10 (prompt) + 20 (initial) + k * message size.
+	want := []AgentForecast{
+		{Name: "A", Responses: 2, InputTokens: TokenRange{260, 460}, OutputTokens: TokenRange{200, 400}, Cost: CostRange{0.66, 1.26}},
+		{Name: "B", Responses: 2, InputTokens: TokenRange{460, 860}, OutputTokens: TokenRange{200, 400}, Cost: CostRange{0.86, 1.66}},
+	}
+
+	for i, w := range want {
+		got := f.Agents[i]
+		if got.Responses != w.Responses || got.InputTokens != w.InputTokens || got.OutputTokens != w.OutputTokens {
+			t.Errorf("agent %s: got %+v, want %+v", w.Name, got, w)
+		}
+		if math.Abs(got.Cost.Low-w.Cost.Low) > 1e-9 || math.Abs(got.Cost.High-w.Cost.High) > 1e-9 {
+			t.Errorf("agent %s: got cost %+v, want %+v", w.Name, got.Cost, w.Cost)
+		}
+	}
+
+	if f.TotalTokens != (TokenRange{1120, 2120}) {
+		t.Errorf("unexpected total tokens: %+v", f.TotalTokens)
+	}
+	if math.Abs(f.TotalCost.Low-1.52) > 1e-9 || math.Abs(f.TotalCost.High-2.92) > 1e-9 {
+		t.Errorf("unexpected total cost: %+v", f.TotalCost)
+	}
+	if f.TotalCost.Low > f.TotalCost.High {
+		t.Error("low bound must not exceed high bound")
+	}
+}
+
+func TestForecastCostDefaultsAndEdgeCases(t *testing.T) {
+	isolatePricing(t)
+
+	f := ForecastCost(nil, ForecastAssumptions{Responses: 5})
+	if len(f.Agents) != 0 || f.TotalTokens.High != 0 {
+		t.Errorf("expected empty forecast without agents, got %+v", f)
+	}
+	if f.Assumptions.MinMessageTokens != DefaultForecastMinMessageTokens ||
+		f.Assumptions.MaxMessageTokens != DefaultForecastMaxMessageTokens {
+		t.Errorf("expected default message sizes, got %+v", f.Assumptions)
+	}
+
+	// Swapped bounds are normalized
+	f = ForecastCost([]ForecastAgent{{Name: "A", Model: "model-a"}}, ForecastAssumptions{
+		Responses:        1,
+		MinMessageTokens: 500,
+		MaxMessageTokens: 100,
+	})
+	if f.Agents[0].OutputTokens != (TokenRange{100, 500}) {
+		t.Errorf("expected normalized bounds, got %+v", f.Agents[0].OutputTokens)
+	}
+}
@@ -0,0 +1,203 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kevinelliott/agentpipe/internal/providers"
+	"github.com/kevinelliott/agentpipe/pkg/log"
+)
+
+// ModelRate is the price of a model in USD per 1,000 tokens.
+type ModelRate struct {
+	// InputPer1K is the cost per 1K input tokens in USD
+	InputPer1K float64 `yaml:"input_per_1k" json:"input_per_1k"`
+	// OutputPer1K is the cost per 1K output tokens in USD
+	OutputPer1K float64 `yaml:"output_per_1k" json:"output_per_1k"`
+}
+
+// Cost returns the USD cost of the given token counts at this rate.
+func (r ModelRate) Cost(inputTokens, outputTokens int) float64 {
+	return (float64(inputTokens)/1000)*r.InputPer1K + (float64(outputTokens)/1000)*r.OutputPer1K
+}
+
+// PricingConfig holds user-defined model prices loaded from pricing.yaml.
+// Entries take precedence over the built-in provider registry in EstimateCost.
+//
+// Example:
+//
+//	models:
+//	  claude-sonnet-4-5:
+//	    input_per_1k: 0.003
+//	    output_per_1k: 0.015
+type PricingConfig struct {
+	// Models maps model names (matched case-insensitively) to their rates
+	Models map[string]ModelRate `yaml:"models"`
+}
+
+var (
+	pricingMu     sync.RWMutex
+	pricing       *PricingConfig
+	pricingLoaded bool
+)
+
+// DefaultPricingPath returns the default pricing override file: ~/.agentpipe/pricing.yaml.
+func DefaultPricingPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".agentpipe", "pricing.yaml"), nil
+}
+
+// LoadPricingFile reads and validates a pricing file.
+func LoadPricingFile(path string) (*PricingConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pricing file: %w", err)
+	}
+
+	var cfg PricingConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse pricing file: %w", err)
+	}
+
+	normalized := make(map[string]ModelRate, len(cfg.Models))
+	for model, rate := range cfg.Models {
+		if rate.InputPer1K < 0 || rate.OutputPer1K < 0 {
+			return nil, fmt.Errorf("invalid pricing for model %s: rates cannot be negative", model)
+		}
+		normalized[strings.ToLower(strings.TrimSpace(model))] = rate
+	}
+	cfg.Models = normalized
+
+	return &cfg, nil
+}
+
+// SetPricing replaces the active pricing overrides. Passing nil clears them.
+func SetPricing(cfg *PricingConfig) {
+	pricingMu.Lock()
+	defer pricingMu.Unlock()
+	pricing = cfg
+	pricingLoaded = true
+}
+
+// GetPricing returns the active pricing overrides, loading ~/.agentpipe/pricing.yaml
+// on first use. Returns nil if no pricing file exists or it cannot be parsed.
+func GetPricing() *PricingConfig {
+	pricingMu.RLock()
+	if pricingLoaded {
+		defer pricingMu.RUnlock()
+		return pricing
+	}
+	pricingMu.RUnlock()
+
+	pricingMu.Lock()
+	defer pricingMu.Unlock()
+	if pricingLoaded {
+		return pricing
+	}
+	pricingLoaded = true
+
+	path, err := DefaultPricingPath()
+	if err != nil {
+		return nil
+	}
+	if _, statErr := os.Stat(path); os.IsNotExist(statErr) {
+		return nil
+	}
+
+	cfg, err := LoadPricingFile(path)
+	if err != nil {
+		log.WithError(err).WithField("path", path).Warn("failed to load pricing file, using built-in pricing")
+		return nil
+	}
+
+	log.WithFields(map[string]interface{}{
+		"path":   path,
+		"models": len(cfg.Models),
+	}).Info("loaded pricing overrides")
+
+	pricing = cfg
+	return pricing
+}
+
+// Lookup returns the override rate for model, if one is configured.
+func (p *PricingConfig) Lookup(model string) (ModelRate, bool) {
+	if p == nil {
+		return ModelRate{}, false
+	}
+	rate, ok := p.Models[strings.ToLower(strings.TrimSpace(model))]
+	return rate, ok
+}
+
+// Pricing sources reported by ListPricing.
+const (
+	PricingSourceBuiltIn  = "built-in"
+	PricingSourceOverride = "override"
+)
+
+// PricingEntry is one row of the effective model pricing table.
+type PricingEntry struct {
+	// Model is the model ID
+	Model string `json:"model"`
+	// Provider is the provider the built-in price comes from (empty for override-only models)
+	Provider string `json:"provider,omitempty"`
+	// Source is "built-in" or "override"
+	Source string `json:"source"`
+	ModelRate
+}
+
+// ListPricing returns the effective pricing table used by EstimateCost: every model
+// in the provider registry merged with the user's pricing overrides, sorted by model.
+// When several providers list the same model ID, the first one wins, matching the
+// registry's exact-match lookup.
+func ListPricing() []PricingEntry {
+	entries := make(map[string]PricingEntry)
+	order := make([]string, 0)
+
+	for _, p := range providers.GetRegistry().ListProviders() {
+		for _, m := range p.Models {
+			key := strings.ToLower(m.ID)
+			if _, exists := entries[key]; exists {
+				continue
+			}
+			entries[key] = PricingEntry{
+				Model:    m.ID,
+				Provider: p.Name,
+				Source:   PricingSourceBuiltIn,
+				ModelRate: ModelRate{
+					InputPer1K:  m.CostPer1MIn / 1000,
+					OutputPer1K: m.CostPer1MOut / 1000,
+				},
+			}
+			order = append(order, key)
+		}
+	}
+
+	if overrides := GetPricing(); overrides != nil {
+		for key, rate := range overrides.Models {
+			entry, exists := entries[key]
+			if !exists {
+				entry = PricingEntry{Model: key}
+				order = append(order, key)
+			}
+			entry.Source = PricingSourceOverride
+			entry.ModelRate = rate
+			entries[key] = entry
+		}
+	}
+
+	sort.Strings(order)
+	result := make([]PricingEntry, 0, len(order))
+	for _, key := range order {
+		result = append(result, entries[key])
+	}
+	return result
+}
@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writePricingFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pricing.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write pricing file: %v", err)
+	}
+	return path
+}
+
+// isolatePricing points HOME at an empty directory and forgets any loaded
+// pricing, so GetPricing never reads the developer's ~/.agentpipe/pricing.yaml.
+// It returns the temporary home directory.
+func isolatePricing(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	resetPricing()
+	t.Cleanup(resetPricing)
+	return home
+}
+
+// resetPricing makes the next GetPricing call load the pricing file again.
+func resetPricing() {
+	pricingMu.Lock()
+	pricing, pricingLoaded = nil, false
+	pricingMu.Unlock()
+}
+
+func TestGetPricingLoadsHomeFile(t *testing.T) {
+	home := isolatePricing(t)
+	if p := GetPricing(); p != nil {
+		t.Fatalf("expected no pricing without a pricing file, got %+v", p)
+	}
+
+	dir := filepath.Join(home, ".agentpipe")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "models:\n  my-model:\n    input_per_1k: 0.5\n    output_per_1k: 1.5\n"
+	if err := os.WriteFile(filepath.Join(dir, "pricing.yaml"), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	resetPricing()
+	rate, ok := GetPricing().Lookup("My-Model")
+	if !ok || rate.InputPer1K != 0.5 || rate.OutputPer1K != 1.5 {
+		t.Errorf("expected the home pricing file to be loaded, got %+v (found %v)", rate, ok)
+	}
+}
+
+func TestLoadPricingFile(t *testing.T) {
+	path := writePricingFile(t, `models:
+  My-Custom-Model:
+    input_per_1k: 0.5
+    output_per_1k: 1.5
+`)
+
+	cfg, err := LoadPricingFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rate, ok := cfg.Lookup("my-custom-model")
+	if !ok {
+		t.Fatal("expected case-insensitive lookup to find model")
+	}
+	if rate.InputPer1K != 0.5 || rate.OutputPer1K != 1.5 {
+		t.Errorf("unexpected rate: %+v", rate)
+	}
+
+	if _, ok := cfg.Lookup("other-model"); ok {
+		t.Error("expected unknown model to be absent")
+	}
+}
+
+func TestLoadPricingFileErrors(t *testing.T) {
+	if _, err := LoadPricingFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected error for missing file")
+	}
+
+	path := writePricingFile(t, "models: [not, a, map]")
+	if _, err := LoadPricingFile(path); err == nil {
+		t.Error("expected error for malformed file")
+	}
+
+	path = writePricingFile(t, "models:\n  m:\n    input_per_1k: -1\n")
+	if _, err := LoadPricingFile(path); err == nil {
+		t.Error("expected error for negative rate")
+	}
+}
+
+func TestEstimateCostUsesPricingFile(t *testing.T) {
+	isolatePricing(t)
+
+	const model = "claude-sonnet-4-5-20250929"
+	builtin := EstimateCost(model, 1000, 500)
+
+	path := writePricingFile(t, `models:
+  claude-sonnet-4-5-20250929:
+    input_per_1k: 1.0
+    output_per_1k: 2.0
+  brand-new-model:
+    input_per_1k: 0.01
+    output_per_1k: 0.02
+`)
+	cfg, err := LoadPricingFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	SetPricing(cfg)
+
+	// 1000 input * $1/1K + 500 output * $2/1K = $2.00
+	if got := EstimateCost(model, 1000, 500); math.Abs(got-2.0) > 1e-9 {
+		t.Errorf("expected override cost 2.00, got %f", got)
+	}
+	if got := EstimateCost(model, 1000, 500); got == builtin {
+		t.Errorf("expected override to change built-in cost %f", builtin)
+	}
+
+	// Models missing from the registry can be priced via the file
+	if got := EstimateCost("brand-new-model", 2000, 1000); math.Abs(got-0.04) > 1e-9 {
+		t.Errorf("expected cost 0.04 for new model, got %f", got)
+	}
+
+	// Unlisted models still fall back to built-in pricing
+	SetPricing(&PricingConfig{Models: map[string]ModelRate{}})
+	if got := EstimateCost(model, 1000, 500); got != builtin {
+		t.Errorf("expected fallback to built-in cost %f, got %f", builtin, got)
+	}
+}
@@ -0,0 +1,385 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkoukk/tiktoken-go"
+
+	"github.com/kevinelliott/agentpipe/pkg/log"
+)
+
+// Tokenizer counts the tokens in a piece of text for a particular model family.
+type Tokenizer interface {
+	// CountTokens returns the number of tokens in text
+	CountTokens(text string) int
+	// Name identifies the tokenizer (e.g., "cl100k_base" or "estimate")
+	Name() string
+}
+
+// EstimateTokenizer is the fallback Tokenizer backed by EstimateTokens.
+type EstimateTokenizer struct{}
+
+// CountTokens returns the character/word based estimate for text.
+func (EstimateTokenizer) CountTokens(text string) int {
+	return EstimateTokens(text)
+}
+
+// Name returns "estimate".
+func (EstimateTokenizer) Name() string {
+	return "estimate"
+}
+
+// TiktokenTokenizer counts tokens using a tiktoken BPE encoding.
+type TiktokenTokenizer struct {
+	name string
+	enc  *tiktoken.Tiktoken
+}
+
+// NewTiktokenTokenizer wraps a tiktoken encoding as a Tokenizer.
+func NewTiktokenTokenizer(name string, enc *tiktoken.Tiktoken) *TiktokenTokenizer {
+	return &TiktokenTokenizer{name: name, enc: enc}
+}
+
+// CountTokens returns the exact number of BPE tokens in text.
+// Special tokens such as <|endoftext|> are counted as ordinary text.
+func (t *TiktokenTokenizer) CountTokens(text string) int {
+	if text == "" {
+		return 0
+	}
+	return len(t.enc.EncodeOrdinary(text))
+}
+
+// Name returns the encoding name (e.g., "cl100k_base").
+func (t *TiktokenTokenizer) Name() string {
+	return t.name
+}
+
+// modelPrefixEncodings maps model families that tiktoken-go does not know about
+// to their encodings. Checked after tiktoken's own model table.
+var modelPrefixEncodings = []struct {
+	prefix   string
+	encoding string
+}{
+	{"gpt-5", tiktoken.MODEL_O200K_BASE},
+	{"gpt-4.1", tiktoken.MODEL_O200K_BASE},
+	{"gpt-4o", tiktoken.MODEL_O200K_BASE},
+	{"o1", tiktoken.MODEL_O200K_BASE},
+	{"o3", tiktoken.MODEL_O200K_BASE},
+	{"o4", tiktoken.MODEL_O200K_BASE},
+	{"gpt-4", tiktoken.MODEL_CL100K_BASE},
+	{"gpt-3.5", tiktoken.MODEL_CL100K_BASE},
+}
+
+var (
+	tokenizerMu    sync.Mutex
+	tokenizerCache = make(map[string]*cachedTokenizer) // keyed by encoding name
+	loaderOnce     sync.Once
+	bpeLoader      *cachedBpeLoader
+)
+
+// cachedTokenizer is the Tokenizer for one encoding, loaded on first use.
+type cachedTokenizer struct {
+	once sync.Once
+	tok  Tokenizer
+}
+
+// bpeFile is the rank file of a tiktoken encoding and its SHA-256.
+type bpeFile struct {
+	name   string
+	sha256 string
+}
+
+// bpeBaseURL is where tiktoken publishes its rank files.
+const bpeBaseURL = "https://openaipublic.blob.core.windows.net/encodings/"
+
+// bpeFiles maps each tiktoken encoding to its rank file. The hashes are the
+// ones tiktoken pins; a download or cached file that doesn't match is rejected.
+var bpeFiles = map[string]bpeFile{
+	tiktoken.MODEL_O200K_BASE:  {"o200k_base.tiktoken", "446a9538cb6c348e3516120d7c08b09f57c36495e2acfffe59a5bf8b0cfb1a2d"},
+	tiktoken.MODEL_CL100K_BASE: {"cl100k_base.tiktoken", "223921b76ee99bde995b7ff738513eef100fb51d18c93597a113bcffe865b2a7"},
+	tiktoken.MODEL_P50K_BASE:   {"p50k_base.tiktoken", "94b5ca7dff4d00767bc256fdd1b27e5b17361d7b8a5f968547f9f23eb70d2069"},
+	tiktoken.MODEL_P50K_EDIT:   {"p50k_base.tiktoken", "94b5ca7dff4d00767bc256fdd1b27e5b17361d7b8a5f968547f9f23eb70d2069"},
+	tiktoken.MODEL_R50K_BASE:   {"r50k_base.tiktoken", "306cd27f03c1a714eca7108e03d66b7dc042abe8c258b44c199a7ed9838dd930"},
+}
+
+// EncodingForModel returns the tiktoken encoding name for a model, or "" if the
+// model has no known tiktoken encoding (e.g., Claude or Gemini models).
+// Provider prefixes such as "openai/" (OpenRouter model IDs) are ignored.
+func EncodingForModel(model string) string {
+	name := strings.ToLower(strings.TrimSpace(model))
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	if name == "" {
+		return ""
+	}
+
+	if enc, ok := tiktoken.MODEL_TO_ENCODING[name]; ok {
+		return enc
+	}
+	for _, m := range modelPrefixEncodings {
+		if strings.HasPrefix(name, m.prefix) {
+			return m.encoding
+		}
+	}
+	return ""
+}
+
+// TokenizerForModel returns the most accurate Tokenizer available for model.
+// Models with a known tiktoken encoding get a TiktokenTokenizer; everything else,
+// or any model whose encoding cannot be loaded, falls back to EstimateTokenizer.
+// Encodings are loaded once and cached for the life of the process.
+func TokenizerForModel(model string) Tokenizer {
+	encodingName := EncodingForModel(model)
+	if encodingName == "" {
+		return EstimateTokenizer{}
+	}
+
+	tokenizerMu.Lock()
+	cached, ok := tokenizerCache[encodingName]
+	if !ok {
+		cached = &cachedTokenizer{}
+		tokenizerCache[encodingName] = cached
+	}
+	tokenizerMu.Unlock()
+
+	// Load outside tokenizerMu so a slow download never blocks counting with
+	// other encodings; only callers waiting for this encoding wait for it.
+	// The fallback is cached too so a failing download isn't retried every turn.
+	cached.once.Do(func() {
+		cached.tok = loadTokenizer(model, encodingName)
+	})
+	return cached.tok
+}
+
+// loadTokenizer loads a tiktoken encoding, or returns EstimateTokenizer if it
+// cannot be loaded. The rank file is fetched and verified before tiktoken is
+// asked for the encoding, because tiktoken holds a global lock while it loads.
+func loadTokenizer(model, encodingName string) Tokenizer {
+	loaderOnce.Do(func() {
+		bpeLoader = newCachedBpeLoader()
+		tiktoken.SetBpeLoader(bpeLoader)
+	})
+
+	enc, err := fetchEncoding(encodingName)
+	if err != nil {
+		log.WithFields(map[string]interface{}{
+			"model":    model,
+			"encoding": encodingName,
+		}).WithError(err).Warn("failed to load tokenizer encoding, token counts for this model will be estimated")
+		return EstimateTokenizer{}
+	}
+	return NewTiktokenTokenizer(encodingName, enc)
+}
+
+// fetchEncoding makes sure the encoding's rank file is cached and verified,
+// then has tiktoken build the encoding from the cached copy.
+func fetchEncoding(encodingName string) (*tiktoken.Tiktoken, error) {
+	file, ok := bpeFiles[encodingName]
+	if !ok {
+		return nil, fmt.Errorf("no rank file known for encoding %s", encodingName)
+	}
+	if _, err := bpeLoader.load(bpeBaseURL + file.name); err != nil {
+		return nil, err
+	}
+	return tiktoken.GetEncoding(encodingName)
+}
+
+// PreloadTokenizers loads the tokenizers of models in parallel, so that no
+// turn has to wait for an encoding to download. It returns the models with a
+// tiktoken encoding that could not be loaded, whose token counts (and costs)
+// will be estimated.
+func PreloadTokenizers(models []string) []string {
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		estimated []string
+	)
+	seen := make(map[string]bool)
+	for _, model := range models {
+		if seen[model] || EncodingForModel(model) == "" {
+			continue
+		}
+		seen[model] = true
+
+		wg.Add(1)
+		go func(model string) {
+			defer wg.Done()
+			if _, ok := TokenizerForModel(model).(EstimateTokenizer); ok {
+				mu.Lock()
+				estimated = append(estimated, model)
+				mu.Unlock()
+			}
+		}(model)
+	}
+	wg.Wait()
+
+	sort.Strings(estimated)
+	return estimated
+}
+
+// CountTokens counts the tokens in text using the best tokenizer for model.
+func CountTokens(model, text string) int {
+	return TokenizerForModel(model).CountTokens(text)
+}
+
+// cachedBpeLoader loads tiktoken BPE rank files from ~/.agentpipe/tiktoken,
+// downloading them on first use with a bounded timeout. Files are checked
+// against their pinned SHA-256 whether downloaded or read from the cache.
+type cachedBpeLoader struct {
+	cacheDir string
+	client   *http.Client
+	hashes   map[string]string // SHA-256 by file name
+}
+
+func newCachedBpeLoader() *cachedBpeLoader {
+	cacheDir := os.Getenv("TIKTOKEN_CACHE_DIR")
+	if cacheDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			homeDir = "."
+		}
+		cacheDir = filepath.Join(homeDir, ".agentpipe", "tiktoken")
+	}
+
+	hashes := make(map[string]string, len(bpeFiles))
+	for _, file := range bpeFiles {
+		hashes[file.name] = file.sha256
+	}
+
+	return &cachedBpeLoader{
+		cacheDir: cacheDir,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		hashes:   hashes,
+	}
+}
+
+// LoadTiktokenBpe implements tiktoken.BpeLoader.
+func (l *cachedBpeLoader) LoadTiktokenBpe(url string) (map[string]int, error) {
+	data, err := l.load(url)
+	if err != nil {
+		return nil, err
+	}
+	return parseBpeRanks(bytes.NewReader(data))
+}
+
+// load returns the verified contents of the rank file at url, from the cache
+// if it holds a good copy, otherwise downloaded and then cached.
+func (l *cachedBpeLoader) load(url string) ([]byte, error) {
+	name := path.Base(url)
+	want, ok := l.hashes[name]
+	if !ok {
+		return nil, fmt.Errorf("no pinned hash for %s", name)
+	}
+
+	cachePath := filepath.Join(l.cacheDir, name)
+	if data, err := os.ReadFile(cachePath); err == nil && sha256Hex(data) == want {
+		return data, nil
+	}
+
+	data, err := l.download(url)
+	if err != nil {
+		return nil, err
+	}
+	if got := sha256Hex(data); got != want {
+		return nil, fmt.Errorf("downloaded %s has SHA-256 %s, want %s", name, got, want)
+	}
+	if err := writeFileAtomic(cachePath, data); err != nil {
+		log.WithError(err).WithField("path", cachePath).Debug("failed to cache tokenizer encoding")
+	}
+	return data, nil
+}
+
+// sha256Hex returns the hex-encoded SHA-256 of data.
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// writeFileAtomic writes data to path through a temporary file in the same
+// directory, so concurrent readers never see a partial file.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+func (l *cachedBpeLoader) download(url string) ([]byte, error) {
+	resp, err := l.client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: HTTP %d", url, resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// parseBpeRanks parses the tiktoken rank format: one "<base64 token> <rank>" per line.
+func parseBpeRanks(r io.Reader) (map[string]int, error) {
+	ranks := make(map[string]int)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		parts := strings.Fields(line)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid BPE rank line: %q", line)
+		}
+		token, err := base64.StdEncoding.DecodeString(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid BPE token %q: %w", parts[0], err)
+		}
+		rank, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid BPE rank %q: %w", parts[1], err)
+		}
+		ranks[string(token)] = rank
+	}
+
+	return ranks, scanner.Err()
+}
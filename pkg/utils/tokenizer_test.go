@@ -0,0 +1,240 @@
+package utils
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkoukk/tiktoken-go"
+)
+
+// cl100kPattern is the pre-tokenization regex used by cl100k_base.
+const cl100kPattern = `(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+(?!\S)|\s+`
+
+// fixtureRanks is a tiny BPE vocabulary: every single byte plus merges that
+// build "hello" and " world", so the expected counts are known exactly.
+func fixtureRanks() map[string]int {
+	ranks := make(map[string]int, 264)
+	for i := 0; i < 256; i++ {
+		ranks[string([]byte{byte(i)})] = i
+	}
+	for i, merge := range []string{"he", "ll", "hell", "hello", " w", "or", " wor", " worl", " world"} {
+		ranks[merge] = 256 + i
+	}
+	return ranks
+}
+
+func newFixtureTokenizer(t *testing.T) *TiktokenTokenizer {
+	t.Helper()
+	bpe, err := tiktoken.NewCoreBPE(fixtureRanks(), map[string]int{}, cl100kPattern)
+	if err != nil {
+		t.Fatalf("failed to build fixture BPE: %v", err)
+	}
+	enc := tiktoken.NewTiktoken(bpe, &tiktoken.Encoding{Name: "fixture"}, map[string]any{})
+	return NewTiktokenTokenizer("fixture", enc)
+}
+
+func TestTiktokenTokenizerFixtures(t *testing.T) {
+	tok := newFixtureTokenizer(t)
+
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"", 0},
+		{"hello", 1},
+		{"hello world", 2},
+		{"hello world hello", 4}, // " hello" has no merge for the leading space
+		{"help", 3},              // "he" + "l" + "p"
+		{"hello!", 2},            // punctuation is its own pre-token
+	}
+
+	for _, tt := range tests {
+		if got := tok.CountTokens(tt.text); got != tt.want {
+			t.Errorf("CountTokens(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+
+	if tok.Name() != "fixture" {
+		t.Errorf("expected name 'fixture', got %q", tok.Name())
+	}
+}
+
+func TestTiktokenTokenizerRealEncoding(t *testing.T) {
+	// Uses the real cl100k_base vocabulary; skipped when it cannot be downloaded.
+	// The download is cached under a temporary HOME, not the developer's.
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("TIKTOKEN_CACHE_DIR", "")
+	tok := TokenizerForModel("gpt-4")
+	if tok.Name() != tiktoken.MODEL_CL100K_BASE {
+		t.Skip("cl100k_base encoding not available (offline?)")
+	}
+
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"hello world", 2},
+		{"tiktoken is great!", 6},
+	}
+
+	for _, tt := range tests {
+		if got := tok.CountTokens(tt.text); got != tt.want {
+			t.Errorf("CountTokens(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestEncodingForModel(t *testing.T) {
+	tests := []struct {
+		model string
+		want  string
+	}{
+		{"gpt-4", tiktoken.MODEL_CL100K_BASE},
+		{"gpt-4-turbo", tiktoken.MODEL_CL100K_BASE},
+		{"gpt-3.5-turbo", tiktoken.MODEL_CL100K_BASE},
+		{"gpt-4o", tiktoken.MODEL_O200K_BASE},
+		{"GPT-4o-mini", tiktoken.MODEL_O200K_BASE},
+		{"openai/gpt-4o", tiktoken.MODEL_O200K_BASE},
+		{"gpt-5", tiktoken.MODEL_O200K_BASE},
+		{"o3-mini", tiktoken.MODEL_O200K_BASE},
+		{"claude-sonnet-4-5", ""},
+		{"gemini-2.5-pro", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := EncodingForModel(tt.model); got != tt.want {
+			t.Errorf("EncodingForModel(%q) = %q, want %q", tt.model, got, tt.want)
+		}
+	}
+}
+
+func TestTokenizerForModelFallback(t *testing.T) {
+	tok := TokenizerForModel("claude-sonnet-4-5")
+	if _, ok := tok.(EstimateTokenizer); !ok {
+		t.Fatalf("expected EstimateTokenizer for unknown model, got %T", tok)
+	}
+
+	text := "The quick brown fox jumps over the lazy dog"
+	if got, want := CountTokens("claude", text), EstimateTokens(text); got != want {
+		t.Errorf("expected fallback count %d, got %d", want, got)
+	}
+}
+
+// testRankFile is a two-token rank file served by the loader tests.
+var testRankFile = fmt.Sprintf("%s 0\n%s 1\n",
+	base64.StdEncoding.EncodeToString([]byte("a")),
+	base64.StdEncoding.EncodeToString([]byte("ab")))
+
+// newTestBpeLoader returns a loader with an empty cache that trusts only
+// test.tiktoken with the hash of testRankFile.
+func newTestBpeLoader(t *testing.T) *cachedBpeLoader {
+	t.Helper()
+	loader := newCachedBpeLoader()
+	loader.cacheDir = t.TempDir()
+	loader.hashes = map[string]string{"test.tiktoken": sha256Hex([]byte(testRankFile))}
+	return loader
+}
+
+func TestCachedBpeLoader(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, testRankFile)
+	}))
+	defer server.Close()
+
+	loader := newTestBpeLoader(t)
+
+	for i := 0; i < 2; i++ {
+		ranks, err := loader.LoadTiktokenBpe(server.URL + "/encodings/test.tiktoken")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ranks["a"] != 0 || ranks["ab"] != 1 {
+			t.Errorf("unexpected ranks: %v", ranks)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("expected second load to come from cache, got %d requests", requests)
+	}
+	if _, err := os.Stat(filepath.Join(loader.cacheDir, "test.tiktoken")); err != nil {
+		t.Errorf("expected cache file to be written: %v", err)
+	}
+}
+
+func TestCachedBpeLoaderRejectsBadHash(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testRankFile+"tampered 2\n")
+	}))
+	defer server.Close()
+
+	loader := newTestBpeLoader(t)
+	if _, err := loader.LoadTiktokenBpe(server.URL + "/encodings/test.tiktoken"); err == nil || !strings.Contains(err.Error(), "SHA-256") {
+		t.Fatalf("expected a hash mismatch error, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(loader.cacheDir, "test.tiktoken")); !os.IsNotExist(err) {
+		t.Errorf("expected a rejected download not to be cached, got %v", err)
+	}
+
+	// A corrupted cache file is replaced by a good download
+	good := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testRankFile)
+	}))
+	defer good.Close()
+	if err := os.WriteFile(filepath.Join(loader.cacheDir, "test.tiktoken"), []byte("garbage"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if ranks, err := loader.LoadTiktokenBpe(good.URL + "/encodings/test.tiktoken"); err != nil || ranks["ab"] != 1 {
+		t.Errorf("expected the corrupted cache to be replaced, got %v, %v", ranks, err)
+	}
+
+	if _, err := loader.LoadTiktokenBpe(good.URL + "/encodings/unknown.tiktoken"); err == nil {
+		t.Error("expected an error for a file without a pinned hash")
+	}
+}
+
+func TestParseBpeRanksInvalid(t *testing.T) {
+	if _, err := parseBpeRanks(strings.NewReader("not-a-valid-line\n")); err == nil {
+		t.Error("expected error for malformed line")
+	}
+	if _, err := parseBpeRanks(strings.NewReader("YQ== notanumber\n")); err == nil {
+		t.Error("expected error for non-numeric rank")
+	}
+}
+
+func TestPreloadTokenizers(t *testing.T) {
+	// Pretend cl100k_base failed to load, without touching the network
+	failed := &cachedTokenizer{}
+	failed.once.Do(func() { failed.tok = EstimateTokenizer{} })
+
+	tokenizerMu.Lock()
+	previous, hadPrevious := tokenizerCache[tiktoken.MODEL_CL100K_BASE]
+	tokenizerCache[tiktoken.MODEL_CL100K_BASE] = failed
+	tokenizerMu.Unlock()
+	t.Cleanup(func() {
+		tokenizerMu.Lock()
+		defer tokenizerMu.Unlock()
+		if hadPrevious {
+			tokenizerCache[tiktoken.MODEL_CL100K_BASE] = previous
+		} else {
+			delete(tokenizerCache, tiktoken.MODEL_CL100K_BASE)
+		}
+	})
+
+	got := PreloadTokenizers([]string{"gpt-4", "claude-sonnet-4-5", "gpt-3.5-turbo", "", "gpt-4"})
+	if want := []string{"gpt-3.5-turbo", "gpt-4"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("PreloadTokenizers() = %v, want %v", got, want)
+	}
+
+	if got := PreloadTokenizers([]string{"claude-sonnet-4-5", "gemini-2.5-pro"}); len(got) != 0 {
+		t.Errorf("expected models without an encoding not to be reported, got %v", got)
+	}
+}
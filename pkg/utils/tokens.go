@@ -25,9 +25,22 @@ func EstimateTokens(text string) int {
 }
 
 // EstimateCost calculates estimated cost based on model and token count.
-// It uses the provider registry to lookup accurate pricing from Catwalk's provider configs.
-// Falls back to zero cost if the model is not found in the registry.
+// Prices from the user's pricing file (~/.agentpipe/pricing.yaml) take precedence;
+// otherwise it uses the provider registry to lookup pricing from Catwalk's provider configs.
+// Falls back to zero cost if the model is not found in either.
 func EstimateCost(model string, inputTokens, outputTokens int) float64 {
+	if rate, ok := GetPricing().Lookup(model); ok {
+		totalCost := rate.Cost(inputTokens, outputTokens)
+		log.WithFields(map[string]interface{}{
+			"model":         model,
+			"source":        "pricing_file",
+			"input_tokens":  inputTokens,
+			"output_tokens": outputTokens,
+			"total_cost":    totalCost,
+		}).Debug("calculated cost estimate")
+		return totalCost
+	}
+
 	registry := providers.GetRegistry()
 
 	// Try to find the model in the registry
@@ -58,6 +58,8 @@ func TestEstimateTokens(t *testing.T) {
 }
 
 func TestEstimateCost(t *testing.T) {
+	isolatePricing(t)
+
 	tests := []struct {
 		name         string
 		model        string